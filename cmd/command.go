@@ -76,7 +76,7 @@ func init() {
 
 	i18nCmd.Flags().StringVarP(&i18nTargetPath, "target", "t", "", "i18n target path, eg: -t ./i18n/target")
 
-	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd} {
+	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd, recountCmd} {
 		rootCmd.AddCommand(cmd)
 	}
 }
@@ -285,6 +285,26 @@ To run answer, use:
 		},
 	}
 
+	// recountCmd recount the content counts of tags
+	recountCmd = &cobra.Command{
+		Use:   "recount",
+		Short: "recount the content counts of tags",
+		Long:  `Recount the question, article, quote, author and piece counts of every tag from tag relations`,
+		Run: func(_ *cobra.Command, _ []string) {
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			if err = cli.RecountTagContentCount(c.Data.Database); err != nil {
+				fmt.Println("recount tag content count failed: ", err.Error())
+				return
+			}
+			fmt.Println("recount tag content count successfully")
+		},
+	}
+
 	// i18nCmd used to merge i18n files
 	i18nCmd = &cobra.Command{
 		Use:   "i18n",
@@ -35,6 +35,9 @@ import (
 	"github.com/segmentfault/pacman/contrib/log/zap"
 	"github.com/segmentfault/pacman/contrib/server/http"
 	"github.com/segmentfault/pacman/log"
+
+	// built-in plugins
+	_ "github.com/apache/incubator-answer/internal/plugin/pow_captcha"
 )
 
 // go build -ldflags "-X github.com/apache/incubator-answer/cmd.Version=x.y.z"
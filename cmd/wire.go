@@ -60,6 +60,7 @@ func initApplication(
 	logConf log.Logger) (*pacman.Application, func(), error) {
 	panic(wire.Build(
 		server.ProviderSetServer,
+		wire.FieldsOf(new(*conf.Server), "HTTP"),
 		router.ProviderSetRouter,
 		controller.ProviderSetController,
 		controller_admin.ProviderSetController,
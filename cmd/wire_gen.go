@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/activity_common"
 	"github.com/apache/incubator-answer/internal/repo/answer"
 	"github.com/apache/incubator-answer/internal/repo/article"
+	"github.com/apache/incubator-answer/internal/repo/audit_log"
 	"github.com/apache/incubator-answer/internal/repo/auth"
 	"github.com/apache/incubator-answer/internal/repo/badge"
 	"github.com/apache/incubator-answer/internal/repo/badge_award"
@@ -30,10 +31,12 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/collection"
 	"github.com/apache/incubator-answer/internal/repo/comment"
 	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/content_filter"
 	"github.com/apache/incubator-answer/internal/repo/export"
 	"github.com/apache/incubator-answer/internal/repo/limit"
 	"github.com/apache/incubator-answer/internal/repo/meta"
 	notification2 "github.com/apache/incubator-answer/internal/repo/notification"
+	"github.com/apache/incubator-answer/internal/repo/personal_access_token"
 	"github.com/apache/incubator-answer/internal/repo/plugin_config"
 	"github.com/apache/incubator-answer/internal/repo/question"
 	"github.com/apache/incubator-answer/internal/repo/quote"
@@ -45,14 +48,19 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/review"
 	"github.com/apache/incubator-answer/internal/repo/revision"
 	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/apache/incubator-answer/internal/repo/saved_search"
 	"github.com/apache/incubator-answer/internal/repo/search_common"
 	"github.com/apache/incubator-answer/internal/repo/site_info"
 	"github.com/apache/incubator-answer/internal/repo/tag"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
 	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/repo/user_data_export"
 	"github.com/apache/incubator-answer/internal/repo/user_external_login"
+	"github.com/apache/incubator-answer/internal/repo/user_login_security"
 	"github.com/apache/incubator-answer/internal/repo/user_notification_config"
+	"github.com/apache/incubator-answer/internal/repo/user_relation"
+	"github.com/apache/incubator-answer/internal/repo/user_two_factor"
 	"github.com/apache/incubator-answer/internal/router"
 	"github.com/apache/incubator-answer/internal/service/action"
 	activity2 "github.com/apache/incubator-answer/internal/service/activity"
@@ -60,6 +68,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	"github.com/apache/incubator-answer/internal/service/answer_common"
 	"github.com/apache/incubator-answer/internal/service/article_common"
+	audit_log2 "github.com/apache/incubator-answer/internal/service/audit_log"
 	auth2 "github.com/apache/incubator-answer/internal/service/auth"
 	badge2 "github.com/apache/incubator-answer/internal/service/badge"
 	collection2 "github.com/apache/incubator-answer/internal/service/collection"
@@ -68,16 +77,19 @@ import (
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	config2 "github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/content"
+	content_filter2 "github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/dashboard"
 	"github.com/apache/incubator-answer/internal/service/event_queue"
 	export2 "github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/follow"
+	"github.com/apache/incubator-answer/internal/service/mention_common"
 	meta2 "github.com/apache/incubator-answer/internal/service/meta"
 	"github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
 	"github.com/apache/incubator-answer/internal/service/notification_common"
 	"github.com/apache/incubator-answer/internal/service/object_info"
+	personal_access_token2 "github.com/apache/incubator-answer/internal/service/personal_access_token"
 	"github.com/apache/incubator-answer/internal/service/plugin_common"
 	"github.com/apache/incubator-answer/internal/service/question_common"
 	rank2 "github.com/apache/incubator-answer/internal/service/rank"
@@ -87,6 +99,7 @@ import (
 	review2 "github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	role2 "github.com/apache/incubator-answer/internal/service/role"
+	saved_search2 "github.com/apache/incubator-answer/internal/service/saved_search"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
 	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
@@ -96,8 +109,12 @@ import (
 	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
 	"github.com/apache/incubator-answer/internal/service/user_common"
+	user_data_export2 "github.com/apache/incubator-answer/internal/service/user_data_export"
 	user_external_login2 "github.com/apache/incubator-answer/internal/service/user_external_login"
+	user_login_security2 "github.com/apache/incubator-answer/internal/service/user_login_security"
 	user_notification_config2 "github.com/apache/incubator-answer/internal/service/user_notification_config"
+	user_relation2 "github.com/apache/incubator-answer/internal/service/user_relation"
+	user_two_factor2 "github.com/apache/incubator-answer/internal/service/user_two_factor"
 	"github.com/apache/incubator-answer/internal/service_article"
 	"github.com/apache/incubator-answer/internal/service_quote"
 	"github.com/apache/incubator-answer/internal/service_quote/quote_common"
@@ -143,13 +160,24 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	emailService := export2.NewEmailService(configService, emailRepo, siteInfoCommonService)
 	userRoleRelRepo := role.NewUserRoleRelRepo(dataData)
 	roleRepo := role.NewRoleRepo(dataData)
-	roleService := role2.NewRoleService(roleRepo)
+	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	auditLogRepo := audit_log.NewAuditLogRepo(dataData)
+	auditLogService := audit_log2.NewAuditLogService(auditLogRepo, userRepo, siteInfoCommonService)
+	roleService := role2.NewRoleService(roleRepo, rolePowerRelRepo, userRoleRelRepo, auditLogService)
 	userRoleRelService := role2.NewUserRoleRelService(userRoleRelRepo, roleService)
 	userCommon := usercommon.NewUserCommon(userRepo, userRoleRelService, authService, siteInfoCommonService)
+	userRelationRepo := user_relation.NewUserRelationRepo(dataData)
+	userRelationService := user_relation2.NewUserRelationService(userRelationRepo, userCommon)
 	userExternalLoginRepo := user_external_login.NewUserExternalLoginRepo(dataData)
 	userNotificationConfigRepo := user_notification_config.NewUserNotificationConfigRepo(dataData)
 	userNotificationConfigService := user_notification_config2.NewUserNotificationConfigService(userRepo, userNotificationConfigRepo)
-	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService)
+	userTwoFactorRepo := user_two_factor.NewUserTwoFactorRepo(dataData)
+	userTwoFactorService := user_two_factor2.NewUserTwoFactorService(userTwoFactorRepo, userRepo, userRoleRelService, siteInfoCommonService, auditLogService)
+	userLoginSecurityRepo := user_login_security.NewUserLoginSecurityRepo(dataData)
+	userLoginSecurityService := user_login_security2.NewUserLoginSecurityService(userLoginSecurityRepo, userRepo, emailService, auditLogService)
+	contentFilterRepo := content_filter.NewContentFilterRepo(dataData)
+	contentFilterService := content_filter2.NewContentFilterService(contentFilterRepo, userRepo, auditLogService)
+	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService, userTwoFactorService)
 	questionRepo := question.NewQuestionRepo(dataData, uniqueIDRepo)
 	answerRepo := answer.NewAnswerRepo(dataData, uniqueIDRepo, userRankRepo, activityRepo)
 	voteRepo := activity_common.NewVoteRepo(dataData, activityRepo)
@@ -160,49 +188,61 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	revisionRepo := revision.NewRevisionRepo(dataData, uniqueIDRepo)
 	revisionService := revision_common.NewRevisionService(revisionRepo, userRepo)
 	activityQueueService := activity_queue.NewActivityQueueService()
-	tagCommonService := tag_common2.NewTagCommonService(tagCommonRepo, tagRelRepo, tagRepo, revisionService, siteInfoCommonService, activityQueueService)
+	tagCommonService := tag_common2.NewTagCommonService(tagCommonRepo, tagRelRepo, tagRepo, revisionService, siteInfoCommonService, activityQueueService, contentFilterService)
 	collectionRepo := collection.NewCollectionRepo(dataData, uniqueIDRepo)
 	collectionCommon := collectioncommon.NewCollectionCommon(collectionRepo)
 	answerCommon := answercommon.NewAnswerCommon(answerRepo)
 	metaRepo := meta.NewMetaRepo(dataData)
 	metaCommonService := metacommon.NewMetaCommonService(metaRepo)
-	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData)
+	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData, userRelationService)
 	eventQueueService := event_queue.NewEventQueueService()
-	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventQueueService)
+	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventQueueService, userTwoFactorService, userLoginSecurityService, contentFilterService)
 	captchaRepo := captcha.NewCaptchaRepo(dataData)
-	captchaService := action.NewCaptchaService(captchaRepo)
-	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService)
+	captchaService := action.NewCaptchaService(captchaRepo, siteInfoCommonService, userRepo)
+	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService, userTwoFactorService)
 	commentRepo := comment.NewCommentRepo(dataData, uniqueIDRepo)
 	commentCommonRepo := comment.NewCommentCommonRepo(dataData, uniqueIDRepo)
 	articleRepo := article.NewArticleRepo(dataData, uniqueIDRepo)
-	objService := object_info.NewObjService(answerRepo, questionRepo, commentCommonRepo, tagCommonRepo, tagCommonService, articleRepo)
+	quoteRepo := quote.NewQuoteRepo(dataData, uniqueIDRepo)
+	quoteAuthorRepo := quote_author.NewQuoteAuthorRepo(dataData, uniqueIDRepo)
+	quotePieceRepo := quote_piece.NewQuotePieceRepo(dataData, uniqueIDRepo)
+	objService := object_info.NewObjService(answerRepo, questionRepo, commentCommonRepo, tagCommonRepo, tagCommonService, articleRepo, quoteRepo, quoteAuthorRepo, quotePieceRepo)
 	notificationQueueService := notice_queue.NewNotificationQueueService()
 	externalNotificationQueueService := notice_queue.NewNewQuestionNotificationQueueService()
-	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService, eventQueueService)
-	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	mentionCommon := mention_common.NewMentionCommon(userCommon, userRepo, siteInfoCommonService, notificationQueueService, externalNotificationQueueService, userRelationService)
+	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService, eventQueueService, userRelationService, mentionCommon, contentFilterService)
 	rolePowerRelService := role2.NewRolePowerRelService(rolePowerRelRepo, userRoleRelService)
 	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService)
 	limitRepo := limit.NewRateLimitRepo(dataData)
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo, siteInfoCommonService, userRepo)
 	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware)
 	reportRepo := report.NewReportRepo(dataData, uniqueIDRepo)
-	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService)
+	tagRelatedRepo := tag.NewTagRelatedRepo(dataData)
+	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService, activityRepo, tagRelatedRepo, auditLogService)
 	answerActivityRepo := activity.NewAnswerActivityRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
 	answerActivityService := activity2.NewAnswerActivityService(answerActivityRepo, configService)
 	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService)
 	reviewRepo := review.NewReviewRepo(dataData)
-	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService)
-	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService)
-	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService, eventQueueService)
-	reportHandle := report_handle.NewReportHandle(questionService, answerService, commentService)
-	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventQueueService)
+	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService, contentFilterService, articleRepo, quoteRepo, quoteAuthorRepo, quotePieceRepo, auditLogService, revisionRepo, mentionCommon)
+	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, userRelationService, mentionCommon, contentFilterService, auditLogService)
+	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService, eventQueueService, userRelationService, mentionCommon, contentFilterService, auditLogService)
+	articleCommon := articlecommon.NewArticleCommon(articleRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData, userRelationService)
+	articleService := service_article.NewArticleService(activityRepo, articleRepo, answerRepo, tagCommonService, tagService, articleCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, mentionCommon, contentFilterService, auditLogService)
+	quoteCommon := quote_common.NewQuoteCommon(quoteRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData, quoteAuthorRepo, quotePieceRepo, userRelationService)
+	quoteAuthorCommon := quote_common.NewQuoteAuthorCommon(quoteAuthorRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData, userRelationService)
+	quoteAuthorService := service_quote.NewQuoteAuthorService(activityRepo, quoteAuthorRepo, answerRepo, tagCommonService, tagService, quoteAuthorCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, contentFilterService, auditLogService)
+	quotePieceCommon := quote_common.NewQuotePieceCommon(quotePieceRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData, userRelationService)
+	quotePieceService := service_quote.NewQuotePieceService(activityRepo, quotePieceRepo, answerRepo, tagCommonService, tagService, quotePieceCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, contentFilterService, auditLogService)
+	quoteService := service_quote.NewQuoteService(activityRepo, quoteRepo, answerRepo, tagCommonService, tagService, quoteCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, quoteAuthorService, quotePieceService, quoteAuthorRepo, quotePieceRepo, quoteAuthorCommon, quotePieceCommon, mentionCommon, contentFilterService, auditLogService)
+	reportHandle := report_handle.NewReportHandle(questionService, answerService, commentService, articleService, quoteService, quoteAuthorService, quotePieceService, notificationQueueService)
+	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventQueueService, auditLogService)
 	reportController := controller.NewReportController(reportService, rankService, captchaService)
 	contentVoteRepo := activity.NewVoteRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
-	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService, eventQueueService)
+	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService, eventQueueService, userRelationService)
 	voteController := controller.NewVoteController(voteService, rankService, captchaService)
 	tagController := controller.NewTagController(tagService, tagCommonService, rankService)
 	followFollowRepo := activity.NewFollowRepo(dataData, uniqueIDRepo, activityRepo)
-	followService := follow.NewFollowService(followFollowRepo, followRepo, tagCommonRepo)
+	followService := follow.NewFollowService(followFollowRepo, followRepo, tagCommonRepo, tagCommonService)
 	followController := controller.NewFollowController(followService)
 	collectionGroupRepo := collection.NewCollectionGroupRepo(dataData)
 	collectionService := collection2.NewCollectionService(collectionRepo, collectionGroupRepo, questionCommon)
@@ -212,24 +252,25 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	searchParser := search_parser.NewSearchParser(tagCommonService, userCommon)
 	searchRepo := search_common.NewSearchRepo(dataData, uniqueIDRepo, userCommon, tagCommonService)
 	searchService := content.NewSearchService(searchParser, searchRepo)
-	searchController := controller.NewSearchController(searchService, captchaService)
+	savedSearchRepo := saved_search.NewSavedSearchRepo(dataData)
+	savedSearchService := saved_search2.NewSavedSearchService(dataData, savedSearchRepo, searchService, userRepo, notificationQueueService, externalNotificationQueueService)
+	searchController := controller.NewSearchController(searchService, savedSearchService, captchaService, rateLimitMiddleware)
 	reviewActivityRepo := activity.NewReviewActivityRepo(dataData, activityRepo, userRankRepo, configService)
-	articleCommon := articlecommon.NewArticleCommon(articleRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData)
-	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo, articleCommon)
+	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo, articleCommon, mentionCommon)
 	revisionController := controller.NewRevisionController(contentRevisionService, rankService)
 	rankController := controller.NewRankController(rankService)
 	userAdminRepo := user.NewUserAdminRepo(dataData, authRepo)
-	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo)
-	userAdminController := controller_admin.NewUserAdminController(userAdminService)
+	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo, auditLogService)
+	userAdminController := controller_admin.NewUserAdminController(userAdminService, userLoginSecurityService)
 	reasonRepo := reason.NewReasonRepo(configService)
 	reasonService := reason2.NewReasonService(reasonRepo)
 	reasonController := controller.NewReasonController(reasonService)
 	themeController := controller_admin.NewThemeController()
-	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon)
-	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService)
+	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon, auditLogService)
+	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService, rateLimitMiddleware)
 	controllerSiteInfoController := controller.NewSiteInfoController(siteInfoCommonService)
 	notificationRepo := notification2.NewNotificationRepo(dataData)
-	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, notificationQueueService, userExternalLoginRepo, siteInfoCommonService)
+	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, notificationQueueService, userExternalLoginRepo, siteInfoCommonService, userRelationService)
 	badgeRepo := badge.NewBadgeRepo(dataData, uniqueIDRepo)
 	notificationService := notification.NewNotificationService(dataData, notificationRepo, notificationCommon, revisionService, userRepo, reportRepo, reviewService, badgeRepo)
 	notificationController := controller.NewNotificationController(notificationService, rankService)
@@ -245,7 +286,7 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	roleController := controller_admin.NewRoleController(roleService)
 	pluginConfigRepo := plugin_config.NewPluginConfigRepo(dataData)
 	pluginUserConfigRepo := plugin_config.NewPluginUserConfigRepo(dataData)
-	pluginCommonService := plugin_common.NewPluginCommonService(pluginConfigRepo, pluginUserConfigRepo, configService, dataData)
+	pluginCommonService := plugin_common.NewPluginCommonService(pluginConfigRepo, pluginUserConfigRepo, configService, dataData, auditLogService)
 	pluginController := controller_admin.NewPluginController(pluginCommonService)
 	permissionController := controller.NewPermissionController(rankService)
 	userPluginController := controller.NewUserPluginController(pluginCommonService)
@@ -257,30 +298,30 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	eventRuleRepo := badge.NewEventRuleRepo(dataData)
 	badgeAwardService := badge2.NewBadgeAwardService(badgeAwardRepo, badgeRepo, userCommon, objService, notificationQueueService)
 	badgeEventService := badge2.NewBadgeEventService(dataData, eventQueueService, badgeRepo, eventRuleRepo, badgeAwardService)
-	badgeService := badge2.NewBadgeService(badgeRepo, badgeGroupRepo, badgeAwardRepo, badgeEventService, siteInfoCommonService)
+	badgeService := badge2.NewBadgeService(badgeRepo, badgeGroupRepo, badgeAwardRepo, badgeEventService, siteInfoCommonService, auditLogService)
 	badgeController := controller.NewBadgeController(badgeService, badgeAwardService)
 	controller_adminBadgeController := controller_admin.NewBadgeController(badgeService)
-	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController)
+	contentFilterController := controller_admin.NewContentFilterController(contentFilterService)
+	auditLogController := controller_admin.NewAuditLogController(auditLogService)
+	personalAccessTokenRepo := personal_access_token.NewPersonalAccessTokenRepo(dataData)
+	personalAccessTokenService := personal_access_token2.NewPersonalAccessTokenService(personalAccessTokenRepo, userRepo, userCommon, userRoleRelService, auditLogService)
+	personalAccessTokenController := controller.NewPersonalAccessTokenController(personalAccessTokenService)
+	userTwoFactorController := controller.NewUserTwoFactorController(userTwoFactorService)
+	userDataExportRepo := user_data_export.NewUserDataExportRepo(dataData)
+	userDataExportService := user_data_export2.NewUserDataExportService(userDataExportRepo, userRepo, configService, emailService, siteInfoCommonService, serviceConf)
+	userDataExportController := controller.NewUserDataExportController(userDataExportService)
+	userRelationController := controller.NewUserRelationController(userRelationService)
+	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController, personalAccessTokenController, userTwoFactorController, userDataExportController, userRelationController, contentFilterController, auditLogController)
 	swaggerRouter := router.NewSwaggerRouter(swaggerConf)
 	uiRouter := router.NewUIRouter(controllerSiteInfoController, siteInfoCommonService)
-	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService)
+	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService, personalAccessTokenService)
 	avatarMiddleware := middleware.NewAvatarMiddleware(serviceConf, uploaderService)
 	shortIDMiddleware := middleware.NewShortIDMiddleware(siteInfoCommonService)
-	articleService := service_article.NewArticleService(activityRepo, articleRepo, answerRepo, tagCommonService, tagService, articleCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService)
-	quoteRepo := quote.NewQuoteRepo(dataData, uniqueIDRepo)
-	quoteAuthorRepo := quote_author.NewQuoteAuthorRepo(dataData, uniqueIDRepo)
-	quotePieceRepo := quote_piece.NewQuotePieceRepo(dataData, uniqueIDRepo)
-	quoteCommon := quote_common.NewQuoteCommon(quoteRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData, quoteAuthorRepo, quotePieceRepo)
-	quoteAuthorCommon := quote_common.NewQuoteAuthorCommon(quoteAuthorRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData)
-	quoteAuthorService := service_quote.NewQuoteAuthorService(activityRepo, quoteAuthorRepo, answerRepo, tagCommonService, tagService, quoteAuthorCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService)
-	quotePieceCommon := quote_common.NewQuotePieceCommon(quotePieceRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData)
-	quotePieceService := service_quote.NewQuotePieceService(activityRepo, quotePieceRepo, answerRepo, tagCommonService, tagService, quotePieceCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService)
-	quoteService := service_quote.NewQuoteService(activityRepo, quoteRepo, answerRepo, tagCommonService, tagService, quoteCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, quoteAuthorService, quotePieceService, quoteAuthorRepo, quotePieceRepo, quoteAuthorCommon, quotePieceCommon)
 	templateRenderController := templaterender.NewTemplateRenderController(questionService, userService, tagService, answerService, commentService, siteInfoCommonService, questionRepo, articleRepo, articleService, quoteRepo, quoteService)
 	templateController := controller.NewTemplateController(templateRenderController, siteInfoCommonService, eventQueueService, userService)
 	templateRouter := router.NewTemplateRouter(templateController, templateRenderController, siteInfoController, authUserMiddleware)
 	connectorController := controller.NewConnectorController(siteInfoCommonService, emailService, userExternalLoginService)
-	userCenterLoginService := user_external_login2.NewUserCenterLoginService(userRepo, userCommon, userExternalLoginRepo, userActiveActivityRepo, siteInfoCommonService)
+	userCenterLoginService := user_external_login2.NewUserCenterLoginService(userRepo, userCommon, userExternalLoginRepo, userActiveActivityRepo, siteInfoCommonService, userTwoFactorService)
 	userCenterController := controller.NewUserCenterController(userCenterLoginService, siteInfoCommonService)
 	captchaController := controller.NewCaptchaController()
 	embedController := controller.NewEmbedController()
@@ -292,8 +333,9 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	quoteAuthorController := controller_quote.NewQuoteAuthorController(quoteAuthorService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware)
 	quotePieceController := controller_quote.NewQuotePieceController(quotePieceService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware)
 	quoteAPIRouter := router.NewQuoteAPIRouter(quoteController, quoteAuthorController, quotePieceController)
-	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, templateRouter, pluginAPIRouter, uiConf, articleAPIRouter, quoteAPIRouter)
-	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, articleService)
+	http := serverConf.HTTP
+	ginEngine := server.NewHTTPServer(debug, http, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, rateLimitMiddleware, avatarMiddleware, shortIDMiddleware, templateRouter, pluginAPIRouter, uiConf, articleAPIRouter, quoteAPIRouter)
+	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, articleService, savedSearchService, tagService, userDataExportService, auditLogService)
 	application := newApplication(serverConf, ginEngine, scheduledTaskManager)
 	return application, func() {
 		cleanup2()
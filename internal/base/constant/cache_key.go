@@ -32,6 +32,7 @@ const (
 	AdminTokenCacheKey                         = "answer:admin:token:"
 	AdminTokenCacheTime                        = 7 * 24 * time.Hour
 	UserTokenMappingCacheKey                   = "answer:user-token:mapping:"
+	UserSessionCacheKey                        = "answer:user:session:"
 	UserEmailCodeCacheKey                      = "answer:user:email-code:"
 	UserEmailCodeCacheTime                     = 10 * time.Minute
 	UserLatestEmailCodeCacheKey                = "answer:user-id:email-code:"
@@ -48,10 +49,31 @@ const (
 	NewQuestionNotificationLimitCacheKeyPrefix = "answer:new-question-notification-limit:"
 	NewQuestionNotificationLimitCacheTime      = 7 * 24 * time.Hour
 	NewQuestionNotificationLimitMax            = 50
+	SavedSearchAlertLimitCacheKeyPrefix        = "answer:saved-search-alert-limit:"
+	SavedSearchAlertLimitCacheTime             = 24 * time.Hour
+	SavedSearchAlertLimitMax                   = 10
 	RateLimitCacheKeyPrefix                    = "answer:rate-limit:"
 	RateLimitCacheTime                         = 5 * time.Minute
+	RateLimitBucketCacheKeyPrefix              = "answer:rate-limit:bucket:"
+	RateLimitCounterCacheKeyPrefix             = "answer:rate-limit:counter:"
+	RateLimitCounterCacheTime                  = 48 * time.Hour
+	RateLimitUserRankCacheKeyPrefix            = "answer:rate-limit:rank:"
+	RateLimitUserRankCacheTime                 = time.Minute
 	RedDotCacheKey                             = "answer:red-dot:%s:%s"
 	RedDotCacheTime                            = 30 * 24 * time.Hour
+	SearchSuggestCacheKeyPrefix                = "answer:search:suggest:"
+	SearchSuggestCacheTime                     = time.Minute
+	SearchSuggestRateLimitMax                  = 60
+	SearchSuggestRateLimitWindow               = time.Minute
+	UserTwoFactorPendingLoginCacheKeyPrefix    = "answer:user:two-factor:pending:"
+	UserTwoFactorPendingAttemptsCacheKeyPrefix = "answer:user:two-factor:pending-attempts:"
+	UserTwoFactorPendingLoginCacheTime         = 5 * time.Minute
+	RolePowerCacheKeyPrefix                    = "answer:role:powers:"
+	RolePowerCacheTime                         = 1 * time.Hour
+	LoginFailedIPCacheKeyPrefix                = "answer:login:failed:ip:"
+	LoginLockedIPCacheKeyPrefix                = "answer:login:locked:ip:"
+	LoginLockoutEmailCacheKeyPrefix            = "answer:login:lockout:email:"
+	LoginLockoutEmailCacheTime                 = 24 * time.Hour
 
 	//@ms:
 	SiteMapArticleCacheKeyPrefix = "answer:sitemap:article:%d" //@cws，要改成aritcle "answer:sitemap:question:%d"
@@ -22,4 +22,6 @@ package constant
 const (
 	AcceptLanguageFlag = "Accept-Language"
 	ShortIDFlag        = "Short-ID-Enabled"
+	ClientIPFlag       = "Client-IP"
+	LoginUserIDFlag    = "Login-User-ID"
 )
@@ -43,4 +43,16 @@ const (
 
 	EmailTplKeyNewQuestionTitle = "email_tpl.new_question.title"
 	EmailTplKeyNewQuestionBody  = "email_tpl.new_question.body"
+
+	EmailTplKeySavedSearchAlertTitle = "email_tpl.saved_search_alert.title"
+	EmailTplKeySavedSearchAlertBody  = "email_tpl.saved_search_alert.body"
+
+	EmailTplKeyMentionTitle = "email_tpl.mention_you.title"
+	EmailTplKeyMentionBody  = "email_tpl.mention_you.body"
+
+	EmailTplKeyDataExportReadyTitle = "email_tpl.data_export_ready.title"
+	EmailTplKeyDataExportReadyBody  = "email_tpl.data_export_ready.body"
+
+	EmailTplKeyLoginLockoutTitle = "email_tpl.login_lockout.title"
+	EmailTplKeyLoginLockoutBody  = "email_tpl.login_lockout.body"
 )
@@ -58,6 +58,12 @@ const (
 	NotificationInvitedYouToAnswer = "notification.action.invited_you_to_answer"
 	// NotificationEarnedBadge earned badge
 	NotificationEarnedBadge = "notification.action.earned_badge"
+	// NotificationSavedSearchNewResult new contents match the saved search
+	NotificationSavedSearchNewResult = "notification.action.saved_search_new_result"
+	// NotificationYourPostWasApproved your queued post was approved by the reviewer
+	NotificationYourPostWasApproved = "notification.action.your_post_was_approved"
+	// NotificationYourPostWasRejected your queued post was rejected by the reviewer
+	NotificationYourPostWasRejected = "notification.action.your_post_was_rejected"
 
 	NotificationYourArticleIsClosed = "notification.action.your_article_is_closed"
 	// NotificationYourArticleWasDeleted your Article was deleted
@@ -115,5 +121,6 @@ var (
 		NotificationYourAnswerWasDeleted:   1,
 		NotificationYourCommentWasDeleted:  1,
 		NotificationInvitedYouToAnswer:     3,
+		NotificationSavedSearchNewResult:   1,
 	}
 )
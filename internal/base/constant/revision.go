@@ -41,4 +41,14 @@ const (
 	ReviewQueuedPostLabel        = "review.queued_post"
 	ReviewFlaggedPostLabel       = "review.flagged_post"
 	ReviewSuggestedPostEditLabel = "review.suggested_post_edit"
+	ReviewContentFilterLabel     = "review.content_filter"
+	// ReviewContentFilterReason the reason of the review submitted by the built-in content filter
+	ReviewContentFilterReason = "review.content_filter_reason"
+)
+
+const (
+	// ReviewSubmitterContentFilter the submitter of the review which is created by the built-in content filter
+	ReviewSubmitterContentFilter = "content_filter"
+	// ReviewSubmitterSuggestedEdit the submitter of the review which is created by the pending edit of the post
+	ReviewSubmitterSuggestedEdit = "suggested_edit"
 )
@@ -31,6 +31,10 @@ const (
 	SiteTypeTheme         = "theme"
 	SiteTypePrivileges    = "privileges"
 	SiteTypeUsers         = "users"
+	SiteTypeActionPolicy  = "action_policy"
+	SiteTypeRateLimit     = "rate_limit"
+	SiteTypeHTMLSanitizer = "html_sanitizer"
+	SiteTypeAuditLog      = "audit_log"
 
 	//@关于我们
 	SiteType_about    = "site_about_info"
@@ -24,17 +24,25 @@ import (
 	"fmt"
 	"github.com/apache/incubator-answer/internal/service_article"
 
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/saved_search"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/tag"
+	"github.com/apache/incubator-answer/internal/service/user_data_export"
 	"github.com/robfig/cron/v3"
 	"github.com/segmentfault/pacman/log"
 )
 
 // ScheduledTaskManager scheduled task manager
 type ScheduledTaskManager struct {
-	siteInfoService siteinfo_common.SiteInfoCommonService
-	questionService *content.QuestionService
-	articleService  *service_article.ArticleService
+	siteInfoService       siteinfo_common.SiteInfoCommonService
+	questionService       *content.QuestionService
+	articleService        *service_article.ArticleService
+	savedSearchService    *saved_search.SavedSearchService
+	tagService            *tag.TagService
+	userDataExportService *user_data_export.UserDataExportService
+	auditLogService       *audit_log.AuditLogService
 }
 
 // NewScheduledTaskManager new scheduled task manager
@@ -42,11 +50,19 @@ func NewScheduledTaskManager(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	questionService *content.QuestionService,
 	articleService *service_article.ArticleService,
+	savedSearchService *saved_search.SavedSearchService,
+	tagService *tag.TagService,
+	userDataExportService *user_data_export.UserDataExportService,
+	auditLogService *audit_log.AuditLogService,
 ) *ScheduledTaskManager {
 	manager := &ScheduledTaskManager{
-		siteInfoService: siteInfoService,
-		questionService: questionService,
-		articleService:  articleService,
+		siteInfoService:       siteInfoService,
+		questionService:       questionService,
+		articleService:        articleService,
+		savedSearchService:    savedSearchService,
+		tagService:            tagService,
+		userDataExportService: userDataExportService,
+		auditLogService:       auditLogService,
 	}
 	return manager
 }
@@ -54,6 +70,7 @@ func NewScheduledTaskManager(
 func (s *ScheduledTaskManager) Run() {
 	fmt.Println("start cron")
 	s.questionService.SitemapCron(context.Background())
+	go s.tagService.RefreshRelatedTagsCron(context.Background())
 	c := cron.New()
 	_, err := c.AddFunc("0 */1 * * *", func() {
 		ctx := context.Background()
@@ -75,5 +92,41 @@ func (s *ScheduledTaskManager) Run() {
 		log.Error(err)
 	}
 
+	_, err = c.AddFunc("30 */1 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("saved search alert cron execution")
+		s.savedSearchService.AlertCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("15 3 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("refresh related tags cron execution")
+		s.tagService.RefreshRelatedTagsCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("45 */1 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("clean user data export cron execution")
+		s.userDataExportService.CleanUserDataExportCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("30 4 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("clean audit log cron execution")
+		s.auditLogService.CleanAuditLogCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
 	c.Start()
 }
@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package handler
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+)
+
+// GetClientIPByCtx get the client ip from context
+func GetClientIPByCtx(ctx context.Context) string {
+	ip, ok := ctx.Value(constant.ClientIPFlag).(string)
+	if ok {
+		return ip
+	}
+	return ""
+}
+
+// GetLoginUserIDByCtx get the login user id from context, it is empty if the user is not login
+func GetLoginUserIDByCtx(ctx context.Context) string {
+	userID, ok := ctx.Value(constant.LoginUserIDFlag).(string)
+	if ok {
+		return userID
+	}
+	return ""
+}
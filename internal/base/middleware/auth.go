@@ -29,10 +29,12 @@ import (
 	"github.com/apache/incubator-answer/ui"
 	"github.com/gin-gonic/gin"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/service/auth"
+	pat "github.com/apache/incubator-answer/internal/service/personal_access_token"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -42,20 +44,51 @@ var ctxUUIDKey = "ctxUuidKey"
 
 // AuthUserMiddleware auth user middleware
 type AuthUserMiddleware struct {
-	authService           *auth.AuthService
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	authService                *auth.AuthService
+	siteInfoCommonService      siteinfo_common.SiteInfoCommonService
+	personalAccessTokenService *pat.PersonalAccessTokenService
 }
 
 // NewAuthUserMiddleware new auth user middleware
 func NewAuthUserMiddleware(
 	authService *auth.AuthService,
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService) *AuthUserMiddleware {
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	personalAccessTokenService *pat.PersonalAccessTokenService) *AuthUserMiddleware {
 	return &AuthUserMiddleware{
-		authService:           authService,
-		siteInfoCommonService: siteInfoCommonService,
+		authService:                authService,
+		siteInfoCommonService:      siteInfoCommonService,
+		personalAccessTokenService: personalAccessTokenService,
 	}
 }
 
+// getUserCacheInfo get user info by the session token or the personal access token,
+// a personal access token without the scope of the request is treated as no token
+func (am *AuthUserMiddleware) getUserCacheInfo(ctx *gin.Context, token string, isAdminAPI bool) (
+	userInfo *entity.UserCacheInfo, scopeDenied bool, err error) {
+	if !pat.IsPersonalAccessToken(token) {
+		if isAdminAPI {
+			userInfo, err = am.authService.GetAdminUserCacheInfo(ctx, token)
+		} else {
+			userInfo, err = am.authService.GetUserCacheInfo(ctx, token)
+		}
+		if err == nil && userInfo != nil {
+			am.authService.TouchUserSession(ctx, token, ctx.ClientIP(), ctx.GetHeader("User-Agent"))
+		}
+		return userInfo, false, err
+	}
+	userInfo, scopes, err := am.personalAccessTokenService.GetUserCacheInfoByToken(ctx, token)
+	if err != nil || userInfo == nil {
+		return nil, false, err
+	}
+	if !pat.CheckScope(scopes, ctx.Request.Method, isAdminAPI) {
+		return nil, true, nil
+	}
+	if isAdminAPI && userInfo.RoleID != role.RoleAdminID {
+		return nil, false, nil
+	}
+	return userInfo, false, nil
+}
+
 // Auth get token and auth user, set user info to context if user is already login
 func (am *AuthUserMiddleware) Auth() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -64,13 +97,13 @@ func (am *AuthUserMiddleware) Auth() gin.HandlerFunc {
 			ctx.Next()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, _, err := am.getUserCacheInfo(ctx, token, false)
 		if err != nil {
 			ctx.Next()
 			return
 		}
 		if userInfo != nil {
-			ctx.Set(ctxUUIDKey, userInfo)
+			setLoginUserToContext(ctx, userInfo)
 		}
 		ctx.Next()
 	}
@@ -116,7 +149,12 @@ func (am *AuthUserMiddleware) MustAuthWithoutAccountAvailable() gin.HandlerFunc
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, scopeDenied, err := am.getUserCacheInfo(ctx, token, false)
+		if scopeDenied {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.PersonalAccessTokenScopeDenied), nil)
+			ctx.Abort()
+			return
+		}
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -127,7 +165,7 @@ func (am *AuthUserMiddleware) MustAuthWithoutAccountAvailable() gin.HandlerFunc
 			ctx.Abort()
 			return
 		}
-		ctx.Set(ctxUUIDKey, userInfo)
+		setLoginUserToContext(ctx, userInfo)
 		ctx.Next()
 	}
 }
@@ -141,7 +179,12 @@ func (am *AuthUserMiddleware) MustAuthAndAccountAvailable() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, scopeDenied, err := am.getUserCacheInfo(ctx, token, false)
+		if scopeDenied {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.PersonalAccessTokenScopeDenied), nil)
+			ctx.Abort()
+			return
+		}
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -164,7 +207,7 @@ func (am *AuthUserMiddleware) MustAuthAndAccountAvailable() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		ctx.Set(ctxUUIDKey, userInfo)
+		setLoginUserToContext(ctx, userInfo)
 		ctx.Next()
 	}
 }
@@ -177,7 +220,12 @@ func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetAdminUserCacheInfo(ctx, token)
+		userInfo, scopeDenied, err := am.getUserCacheInfo(ctx, token, true)
+		if scopeDenied {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.PersonalAccessTokenScopeDenied), nil)
+			ctx.Abort()
+			return
+		}
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Forbidden(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -189,7 +237,7 @@ func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 				ctx.Abort()
 				return
 			}
-			ctx.Set(ctxUUIDKey, userInfo)
+			setLoginUserToContext(ctx, userInfo)
 		}
 		ctx.Next()
 	}
@@ -223,6 +271,12 @@ func ShowIndexPage(ctx *gin.Context) {
 	ctx.String(http.StatusOK, string(file))
 }
 
+// setLoginUserToContext set the login user to context, the user id is also set as a flag for the services
+func setLoginUserToContext(ctx *gin.Context, userInfo *entity.UserCacheInfo) {
+	ctx.Set(ctxUUIDKey, userInfo)
+	ctx.Set(constant.LoginUserIDFlag, userInfo.UserID)
+}
+
 // GetLoginUserIDFromContext get user id from context
 func GetLoginUserIDFromContext(ctx *gin.Context) (userID string) {
 	userInfo := GetUserInfoFromContext(ctx)
@@ -0,0 +1,31 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/gin-gonic/gin"
+)
+
+// ExtractAndSetClientIP extract the client ip and set to context, so that the services can get it.
+// The forwarded headers are only used if the request is from the trusted proxies, see server.HTTP.
+func ExtractAndSetClientIP(ctx *gin.Context) {
+	ctx.Set(constant.ClientIPFlag, ctx.ClientIP())
+}
@@ -20,25 +20,53 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/repo/limit"
+	"github.com/apache/incubator-answer/internal/schema"
+	pat "github.com/apache/incubator-answer/internal/service/personal_access_token"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/encryption"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
 
+// rateLimitConfigCacheTime the rate limit config is checked on every request, so it is kept in memory for a while
+const rateLimitConfigCacheTime = 30 * time.Second
+
 type RateLimitMiddleware struct {
-	limitRepo *limit.LimitRepo
+	limitRepo             *limit.LimitRepo
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	userRepo              usercommon.UserRepo
+
+	configLock     sync.Mutex
+	config         *schema.SiteRateLimitResp
+	configExpireAt time.Time
 }
 
 // NewRateLimitMiddleware new rate limit middleware
-func NewRateLimitMiddleware(limitRepo *limit.LimitRepo) *RateLimitMiddleware {
+func NewRateLimitMiddleware(
+	limitRepo *limit.LimitRepo,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	userRepo usercommon.UserRepo,
+) *RateLimitMiddleware {
 	return &RateLimitMiddleware{
-		limitRepo: limitRepo,
+		limitRepo:             limitRepo,
+		siteInfoCommonService: siteInfoCommonService,
+		userRepo:              userRepo,
 	}
 }
 
@@ -70,3 +98,166 @@ func (rm *RateLimitMiddleware) DuplicateRequestClear(ctx *gin.Context, key strin
 		log.Errorf("clear rate limit error: %s", err.Error())
 	}
 }
+
+// IPRequestLimitation limits the request count of the client ip in the window
+// It works for the requests that may be sent frequently without login, such as search suggestion.
+func (rm *RateLimitMiddleware) IPRequestLimitation(ctx *gin.Context, max int64, window time.Duration) (reject bool) {
+	key := encryption.MD5(fmt.Sprintf("%s:%s", ctx.FullPath(), ctx.ClientIP()))
+	reject, err := rm.limitRepo.CheckAndIncrease(ctx, key, max, window)
+	if err != nil {
+		log.Errorf("check and increase rate limit error: %s", err.Error())
+		return false
+	}
+	if !reject {
+		return false
+	}
+	log.Debugf("too many requests: [%s] %s", ctx.FullPath(), ctx.ClientIP())
+	handler.HandleResponse(ctx, errors.New(http.StatusTooManyRequests, reason.TooManyRequestsError), nil)
+	return true
+}
+
+// RateLimit limits the requests by the token bucket of the login user or the client ip.
+// The group of the route is decided by the request method and path if it is RateLimitGroupAuto.
+func (rm *RateLimitMiddleware) RateLimit(group string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if rm.takeRateLimitToken(ctx, group) {
+			ctx.Next()
+			return
+		}
+		ctx.Abort()
+	}
+}
+
+func (rm *RateLimitMiddleware) takeRateLimitToken(ctx *gin.Context, group string) (allowed bool) {
+	config := rm.getRateLimitConfig(ctx)
+	if !config.Enabled {
+		return true
+	}
+	if group == schema.RateLimitGroupAuto {
+		group = getRateLimitGroup(ctx)
+	}
+
+	scope, unit, reputation := schema.RateLimitScopeIP, ctx.ClientIP(), 0
+	if userInfo := GetUserInfoFromContext(ctx); userInfo != nil {
+		if userInfo.RoleID == role.RoleAdminID || userInfo.RoleID == role.RoleModeratorID {
+			return true
+		}
+		// the trusted bots must use the personal access token
+		if config.IsTrustedUser(userInfo.UserID) && pat.IsPersonalAccessToken(ExtractToken(ctx)) {
+			return true
+		}
+		scope, unit, reputation = schema.RateLimitScopeUser, userInfo.UserID, rm.getUserRank(ctx, userInfo.UserID)
+	}
+	rule := config.GetRule(group, scope, reputation)
+	if rule == nil {
+		return true
+	}
+
+	key := fmt.Sprintf("%s:%s:%s", group, scope, unit)
+	result, err := rm.limitRepo.TakeToken(ctx, key, rule.Capacity, float64(rule.RefillPerMinute)/60)
+	if err != nil {
+		log.Errorf("take rate limit token error: %s", err.Error())
+		return true
+	}
+	rm.increaseRateLimitCounter(ctx, group, result.Allowed)
+
+	ctx.Header("RateLimit-Limit", strconv.FormatInt(rule.Capacity, 10))
+	ctx.Header("RateLimit-Remaining", strconv.FormatInt(result.Remaining, 10))
+	ctx.Header("RateLimit-Reset", strconv.FormatInt(durationToSeconds(result.ResetAfter), 10))
+	if result.Allowed {
+		return true
+	}
+	ctx.Header("Retry-After", strconv.FormatInt(durationToSeconds(result.RetryAfter), 10))
+	log.Debugf("rate limit exceeded: [%s] %s %s", group, scope, unit)
+	handler.HandleResponse(ctx, errors.New(http.StatusTooManyRequests, reason.TooManyRequestsError), nil)
+	return false
+}
+
+// GetRateLimitStats get the requests allowed and rejected by the rate limit today
+func (rm *RateLimitMiddleware) GetRateLimitStats(ctx context.Context) (resp []*schema.GetRateLimitStatsResp) {
+	resp = make([]*schema.GetRateLimitStatsResp, 0, len(schema.RateLimitGroupList))
+	for _, group := range schema.RateLimitGroupList {
+		stats := &schema.GetRateLimitStatsResp{Group: group}
+		var err error
+		if stats.Allowed, err = rm.limitRepo.GetCounter(ctx, rateLimitCounterKey(group, true)); err != nil {
+			log.Error(err)
+		}
+		if stats.Rejected, err = rm.limitRepo.GetCounter(ctx, rateLimitCounterKey(group, false)); err != nil {
+			log.Error(err)
+		}
+		resp = append(resp, stats)
+	}
+	return resp
+}
+
+func (rm *RateLimitMiddleware) increaseRateLimitCounter(ctx context.Context, group string, allowed bool) {
+	if err := rm.limitRepo.IncreaseCounter(ctx, rateLimitCounterKey(group, allowed)); err != nil {
+		log.Error(err)
+	}
+}
+
+func (rm *RateLimitMiddleware) getRateLimitConfig(ctx context.Context) *schema.SiteRateLimitResp {
+	rm.configLock.Lock()
+	defer rm.configLock.Unlock()
+	if rm.config != nil && time.Now().Before(rm.configExpireAt) {
+		return rm.config
+	}
+	config, err := rm.siteInfoCommonService.GetSiteRateLimit(ctx)
+	if err != nil {
+		log.Error(err)
+		// keep the last config until the next check
+		if rm.config != nil {
+			return rm.config
+		}
+		return schema.DefaultSiteRateLimit
+	}
+	rm.config, rm.configExpireAt = config, time.Now().Add(rateLimitConfigCacheTime)
+	return rm.config
+}
+
+func (rm *RateLimitMiddleware) getUserRank(ctx context.Context, userID string) int {
+	rank, exist, err := rm.limitRepo.GetUserRank(ctx, userID)
+	if err != nil {
+		log.Error(err)
+	}
+	if exist {
+		return int(rank)
+	}
+	userInfo, exist, err := rm.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return 0
+	}
+	if !exist {
+		return 0
+	}
+	if err = rm.limitRepo.SetUserRank(ctx, userID, int64(userInfo.Rank)); err != nil {
+		log.Error(err)
+	}
+	return userInfo.Rank
+}
+
+// getRateLimitGroup the search requests are limited separately, the others are limited by reading or writing
+func getRateLimitGroup(ctx *gin.Context) string {
+	if strings.Contains(ctx.FullPath(), "/search") {
+		return schema.RateLimitGroupSearch
+	}
+	switch ctx.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return schema.RateLimitGroupRead
+	}
+	return schema.RateLimitGroupWrite
+}
+
+// rateLimitCounterKey the counters are kept by day
+func rateLimitCounterKey(group string, allowed bool) string {
+	result := "rejected"
+	if allowed {
+		result = "allowed"
+	}
+	return fmt.Sprintf("%s:%s:%s", time.Now().Format("2006-01-02"), group, result)
+}
+
+func durationToSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
@@ -34,6 +34,8 @@ const (
 	ForbiddenError = "base.forbidden_error"
 	// DuplicateRequestError duplicate request error
 	DuplicateRequestError = "base.duplicate_request_error"
+	// TooManyRequestsError too many requests error
+	TooManyRequestsError = "base.too_many_requests_error"
 )
 
 const (
@@ -74,6 +76,8 @@ const (
 	TagCannotUpdate                  = "error.tag.cannot_update"
 	TagIsUsedCannotDelete            = "error.tag.is_used_cannot_delete"
 	TagAlreadyExist                  = "error.tag.already_exist"
+	TagParentCannotBeDescendant      = "error.tag.parent_cannot_be_descendant"
+	TagNotSibling                    = "error.tag.not_sibling"
 	RankFailToMeetTheCondition       = "error.rank.fail_to_meet_the_condition"
 	VoteRankFailToMeetTheCondition   = "error.rank.vote_fail_to_meet_the_condition"
 	NoEnoughRankToOperate            = "error.rank.no_enough_rank_to_operate"
@@ -94,6 +98,8 @@ const (
 	RevisionNoPermission             = "error.revision.no_permission"
 	UserCannotUpdateYourRole         = "error.user.cannot_update_your_role"
 	TagCannotSetSynonymAsItself      = "error.tag.cannot_set_synonym_as_itself"
+	TagCannotMergeIntoItself         = "error.tag.cannot_merge_into_itself"
+	TagCannotMergeSynonym            = "error.tag.cannot_merge_synonym"
 	NotAllowedRegistration           = "error.user.not_allowed_registration"
 	NotAllowedLoginViaPassword       = "error.user.not_allowed_login_via_password"
 	SMTPConfigFromNameCannotBeEmail  = "error.smtp.config_from_name_cannot_be_email"
@@ -107,6 +113,36 @@ const (
 	InvalidURLError                  = "error.common.invalid_url"
 	MetaObjectNotFound               = "error.meta.object_not_found"
 	BadgeObjectNotFound              = "error.badge.object_not_found"
+	SavedSearchNotFound              = "error.saved_search.not_found"
+	SavedSearchExceedLimit           = "error.saved_search.exceed_limit"
+	PersonalAccessTokenNotFound      = "error.personal_access_token.not_found"
+	PersonalAccessTokenExceedLimit   = "error.personal_access_token.exceed_limit"
+	PersonalAccessTokenExpiredAt     = "error.personal_access_token.expired_at_invalid"
+	PersonalAccessTokenAdminDenied   = "error.personal_access_token.admin_scope_denied"
+	PersonalAccessTokenScopeDenied   = "error.personal_access_token.scope_denied"
+	TwoFactorAlreadyEnabled          = "error.two_factor.already_enabled"
+	TwoFactorNotEnabled              = "error.two_factor.not_enabled"
+	TwoFactorCodeInvalid             = "error.two_factor.code_invalid"
+	TwoFactorTokenInvalid            = "error.two_factor.token_invalid"
+	TwoFactorRequired                = "error.two_factor.required"
+	UserSessionNotFound              = "error.user.session_not_found"
+	UserDataExportTooFrequent        = "error.data_export.too_frequent"
+	UserDataExportLinkInvalid        = "error.data_export.link_invalid"
+	UserRelationCannotSelf           = "error.user_relation.cannot_self"
+	UserBlocked                      = "error.user_relation.blocked"
+	RoleNotFound                     = "error.role.not_found"
+	RoleNameDuplicate                = "error.role.name_duplicate"
+	RoleCannotDeleteBuiltIn          = "error.role.cannot_delete_built_in"
+	RoleCannotUpdateAdmin            = "error.role.cannot_update_admin"
+	RoleInUse                        = "error.role.in_use"
+	RolePowerInvalid                 = "error.role.power_invalid"
+	ActionBlocked                    = "error.action.blocked"
+	ActionTooFrequent                = "error.action.too_frequent"
+	LoginLocked                      = "error.login.locked"
+	LoginTooFrequent                 = "error.login.too_frequent"
+	ContentFilterRejected            = "error.content_filter.rejected"
+	ContentFilterRegexInvalid        = "error.content_filter.regex_invalid"
+	ContentFilterRuleNotFound        = "error.content_filter.rule_not_found"
 	StatusInvalid                    = "error.common.status_invalid"
 
 	//@ms:
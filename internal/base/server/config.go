@@ -22,6 +22,9 @@ package server
 // HTTP http config
 type HTTP struct {
 	Addr string `json:"addr" mapstructure:"addr"`
+	// TrustedProxies the ips or cidrs of the reverse proxies whose forwarded headers are used to get the client ip,
+	// all the proxies are trusted if empty, so the forwarded headers can be spoofed when the site is not behind a proxy
+	TrustedProxies []string `json:"trusted_proxies" mapstructure:"trusted_proxies" yaml:"trusted_proxies"`
 }
 
 // UI ui config
@@ -23,20 +23,24 @@ import (
 	brotli "github.com/anargu/gin-brotli"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/router"
+	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/apache/incubator-answer/ui"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
 	"html/template"
 	"io/fs"
 )
 
 // NewHTTPServer new http server.
 func NewHTTPServer(debug bool,
+	httpConf *HTTP,
 	staticRouter *router.StaticRouter,
 	answerRouter *router.AnswerAPIRouter,
 	swaggerRouter *router.SwaggerRouter,
 	viewRouter *router.UIRouter,
 	authUserMiddleware *middleware.AuthUserMiddleware,
+	rateLimitMiddleware *middleware.RateLimitMiddleware,
 	avatarMiddleware *middleware.AvatarMiddleware,
 	shortIDMiddleware *middleware.ShortIDMiddleware,
 	templateRouter *router.TemplateRouter,
@@ -52,6 +56,14 @@ func NewHTTPServer(debug bool,
 		gin.SetMode(gin.ReleaseMode)
 	}
 	r := gin.New()
+	// the forwarded headers can be set by anyone, only the ones set by the trusted proxies are used to get the client ip.
+	// If not configured, all the proxies are trusted as before, so the sites behind a proxy still get the client ip.
+	if len(httpConf.TrustedProxies) == 0 {
+		log.Warn("server.http.trusted_proxies is not configured, the forwarded headers of all requests are trusted")
+	} else if err := r.SetTrustedProxies(httpConf.TrustedProxies); err != nil {
+		log.Errorf("set trusted proxies failed, none of them is trusted: %s", err)
+		_ = r.SetTrustedProxies(nil)
+	}
 
 	//Gin框架，body参数只能读取一次 https://blog.csdn.net/impressionw/article/details/84194783
 
@@ -72,7 +84,7 @@ func NewHTTPServer(debug bool,
 	//}
 	r.Use(middleware.LoggerWithConfig()) //@cws
 
-	r.Use(brotli.Brotli(brotli.DefaultCompression), middleware.ExtractAndSetAcceptLanguage, shortIDMiddleware.SetShortIDFlag())
+	r.Use(brotli.Brotli(brotli.DefaultCompression), middleware.ExtractAndSetAcceptLanguage, middleware.ExtractAndSetClientIP, shortIDMiddleware.SetShortIDFlag())
 	r.GET("/healthz", func(ctx *gin.Context) { ctx.String(200, "OK") })
 
 	html, _ := fs.Sub(ui.Template, "template")
@@ -89,21 +101,24 @@ func NewHTTPServer(debug bool,
 
 	// The route must be available without logging in
 	mustUnAuthV1 := r.Group("/answer/api/v1")
+	mustUnAuthV1.Use(rateLimitMiddleware.RateLimit(schema.RateLimitGroupAuth))
 	answerRouter.RegisterMustUnAuthAnswerAPIRouter(authUserMiddleware, mustUnAuthV1)
 
 	// register api that no need to login
 	unAuthV1 := r.Group("/answer/api/v1")
-	unAuthV1.Use(authUserMiddleware.Auth(), authUserMiddleware.EjectUserBySiteInfo())
+	unAuthV1.Use(authUserMiddleware.Auth(), authUserMiddleware.EjectUserBySiteInfo(),
+		rateLimitMiddleware.RateLimit(schema.RateLimitGroupAuto))
 	answerRouter.RegisterUnAuthAnswerAPIRouter(unAuthV1)
 
 	// register api that must be authenticated but no need to check account status
 	authWithoutStatusV1 := r.Group("/answer/api/v1")
-	authWithoutStatusV1.Use(authUserMiddleware.MustAuthWithoutAccountAvailable())
+	authWithoutStatusV1.Use(authUserMiddleware.MustAuthWithoutAccountAvailable(),
+		rateLimitMiddleware.RateLimit(schema.RateLimitGroupAuto))
 	answerRouter.RegisterAuthUserWithAnyStatusAnswerAPIRouter(authWithoutStatusV1)
 
 	// register api that must be authenticated
 	authV1 := r.Group("/answer/api/v1")
-	authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable())
+	authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable(), rateLimitMiddleware.RateLimit(schema.RateLimitGroupAuto))
 	answerRouter.RegisterAnswerAPIRouter(authV1)
 
 	adminauthV1 := r.Group("/answer/admin/api")
@@ -126,22 +141,24 @@ func NewHTTPServer(debug bool,
 	//@csw
 	// register api that no need to login
 	article_unAuthV1 := r.Group("/answer/api/v1")
-	article_unAuthV1.Use(authUserMiddleware.Auth(), authUserMiddleware.EjectUserBySiteInfo())
+	article_unAuthV1.Use(authUserMiddleware.Auth(), authUserMiddleware.EjectUserBySiteInfo(),
+		rateLimitMiddleware.RateLimit(schema.RateLimitGroupAuto))
 	articleRouter.RegisterUnAuthArticleAPIRouter(article_unAuthV1)
 
 	// register api that must be authenticated
 	article_authV1 := r.Group("/answer/api/v1")
-	article_authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable())
+	article_authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable(), rateLimitMiddleware.RateLimit(schema.RateLimitGroupAuto))
 	articleRouter.RegisterArticleAPIRouter(article_authV1)
 
 	// register api that no need to login
 	quote_unAuthV1 := r.Group("/answer/api/v1")
-	quote_unAuthV1.Use(authUserMiddleware.Auth(), authUserMiddleware.EjectUserBySiteInfo())
+	quote_unAuthV1.Use(authUserMiddleware.Auth(), authUserMiddleware.EjectUserBySiteInfo(),
+		rateLimitMiddleware.RateLimit(schema.RateLimitGroupAuto))
 	quoteRouter.RegisterUnAuthQuoteAPIRouter(quote_unAuthV1)
 
 	// register api that must be authenticated
 	quote_authV1 := r.Group("/answer/api/v1")
-	quote_authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable())
+	quote_authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable(), rateLimitMiddleware.RateLimit(schema.RateLimitGroupAuto))
 	quoteRouter.RegisterQuoteAPIRouter(quote_authV1)
 
 	return r
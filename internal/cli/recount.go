@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/pkg/obj"
+)
+
+// RecountTagContentCount recount the content count of every type for all tags from tag relations
+func RecountTagContentCount(dataConf *data.Database) error {
+	db, err := data.NewDB(false, dataConf)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err = db.Ping(); err != nil {
+		return err
+	}
+
+	objectTypes := make([]string, 0, len(entity.TagContentCountColumnMapping))
+	for objectType := range entity.TagContentCountColumnMapping {
+		objectTypes = append(objectTypes, objectType)
+	}
+	sort.Strings(objectTypes)
+
+	for _, objectType := range objectTypes {
+		column := entity.TagContentCountColumnMapping[objectType]
+		minID, maxID, err := obj.GetObjectIDRangeByObjectType(objectType)
+		if err != nil {
+			return err
+		}
+		res, err := db.Exec(fmt.Sprintf("UPDATE tag SET %s = (SELECT COUNT(*) FROM tag_rel "+
+			"WHERE tag_rel.tag_id = tag.id AND tag_rel.status = ? AND tag_rel.object_id BETWEEN ? AND ?)", column),
+			entity.TagRelStatusAvailable, minID, maxID)
+		if err != nil {
+			return fmt.Errorf("recount tag %s failed: %w", column, err)
+		}
+		affected, _ := res.RowsAffected()
+		fmt.Printf("recount tag %s done, %d tags updated\n", column, affected)
+	}
+	return nil
+}
@@ -151,7 +151,10 @@ func (cc *ConnectorController) ConnectorRedirect(connector plugin.Connector) (fn
 			ctx.Redirect(http.StatusFound, fmt.Sprintf("/50x?title=%s&msg=%s", resp.ErrTitle, resp.ErrMsg))
 			return
 		}
-		if len(resp.AccessToken) > 0 {
+		if len(resp.TwoFactorToken) > 0 {
+			ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/two-factor?token=%s&enroll=%t",
+				siteGeneral.SiteUrl, resp.TwoFactorToken, resp.TwoFactorEnrollRequired))
+		} else if len(resp.AccessToken) > 0 {
 			ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/auth-landing?access_token=%s",
 				siteGeneral.SiteUrl, resp.AccessToken))
 		} else {
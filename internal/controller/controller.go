@@ -55,4 +55,8 @@ var ProviderSetController = wire.NewSet(
 	NewEmbedController,
 	NewBadgeController,
 	NewRenderController,
+	NewPersonalAccessTokenController,
+	NewUserTwoFactorController,
+	NewUserDataExportController,
+	NewUserRelationController,
 )
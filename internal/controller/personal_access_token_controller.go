@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/personal_access_token"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// PersonalAccessTokenController personal access token controller
+type PersonalAccessTokenController struct {
+	personalAccessTokenService *personal_access_token.PersonalAccessTokenService
+}
+
+// NewPersonalAccessTokenController new controller
+func NewPersonalAccessTokenController(
+	personalAccessTokenService *personal_access_token.PersonalAccessTokenService,
+) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{
+		personalAccessTokenService: personalAccessTokenService,
+	}
+}
+
+// GetPersonalAccessTokenList get personal access token list
+// @Summary get personal access token list
+// @Description get the available personal access tokens of the current user
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.PersonalAccessTokenResp}
+// @Router /answer/api/v1/user/personal-access-tokens [get]
+func (pc *PersonalAccessTokenController) GetPersonalAccessTokenList(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := pc.personalAccessTokenService.GetPersonalAccessTokenList(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddPersonalAccessToken add personal access token
+// @Summary add personal access token
+// @Description add personal access token, the token is only returned once
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddPersonalAccessTokenReq true "personal access token"
+// @Success 200 {object} handler.RespBody{data=schema.AddPersonalAccessTokenResp}
+// @Router /answer/api/v1/user/personal-access-token [post]
+func (pc *PersonalAccessTokenController) AddPersonalAccessToken(ctx *gin.Context) {
+	req := &schema.AddPersonalAccessTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	// a personal access token can not be used to create another one, otherwise the scopes can be escalated
+	if personal_access_token.IsPersonalAccessToken(middleware.ExtractToken(ctx)) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.PersonalAccessTokenScopeDenied), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetIsAdminFromContext(ctx)
+
+	resp, err := pc.personalAccessTokenService.AddPersonalAccessToken(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokePersonalAccessToken revoke personal access token
+// @Summary revoke personal access token
+// @Description revoke personal access token of the current user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevokePersonalAccessTokenReq true "personal access token"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/personal-access-token [delete]
+func (pc *PersonalAccessTokenController) RevokePersonalAccessToken(ctx *gin.Context) {
+	req := &schema.RevokePersonalAccessTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := pc.personalAccessTokenService.RevokePersonalAccessToken(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AdminGetPersonalAccessTokenPage get personal access token page
+// @Summary get personal access token page
+// @Description get the personal access tokens of all users
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page size"
+// @Param page_size query int false "page size"
+// @Param user_id query string false "user id"
+// @Param status query string false "status" Enums(available, revoked)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetPersonalAccessTokenPageResp}}
+// @Router /answer/admin/api/personal-access-tokens/page [get]
+func (pc *PersonalAccessTokenController) AdminGetPersonalAccessTokenPage(ctx *gin.Context) {
+	req := &schema.GetPersonalAccessTokenPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := pc.personalAccessTokenService.AdminGetPersonalAccessTokenPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AdminRevokePersonalAccessToken revoke personal access token
+// @Summary revoke personal access token
+// @Description force revoke the personal access token of any user
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AdminRevokePersonalAccessTokenReq true "personal access token"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/personal-access-token/revoke [put]
+func (pc *PersonalAccessTokenController) AdminRevokePersonalAccessToken(ctx *gin.Context) {
+	req := &schema.AdminRevokePersonalAccessTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := pc.personalAccessTokenService.AdminRevokePersonalAccessToken(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -161,6 +161,11 @@ func (uc *UserCenterController) UserCenterLoginCallback(ctx *gin.Context) {
 		ctx.Redirect(http.StatusFound, fmt.Sprintf("/50x?title=%s&msg=%s", resp.ErrTitle, resp.ErrMsg))
 		return
 	}
+	if len(resp.TwoFactorToken) > 0 {
+		ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/two-factor?token=%s&enroll=%t",
+			siteGeneral.SiteUrl, resp.TwoFactorToken, resp.TwoFactorEnrollRequired))
+		return
+	}
 	userCenter.AfterLogin(userInfo.ExternalID, resp.AccessToken)
 	ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/auth-landing?access_token=%s",
 		siteGeneral.SiteUrl, resp.AccessToken))
@@ -196,6 +201,11 @@ func (uc *UserCenterController) UserCenterSignUpCallback(ctx *gin.Context) {
 		ctx.Redirect(http.StatusFound, fmt.Sprintf("/50x?title=%s&msg=%s", resp.ErrTitle, resp.ErrMsg))
 		return
 	}
+	if len(resp.TwoFactorToken) > 0 {
+		ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/two-factor?token=%s&enroll=%t",
+			siteGeneral.SiteUrl, resp.TwoFactorToken, resp.TwoFactorEnrollRequired))
+		return
+	}
 	userCenter.AfterLogin(userInfo.ExternalID, resp.AccessToken)
 	ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/auth-landing?access_token=%s",
 		siteGeneral.SiteUrl, resp.AccessToken))
@@ -20,9 +20,11 @@
 package controller
 
 import (
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/rank"
@@ -73,6 +75,10 @@ func (rc *ReviewController) GetUnreviewedPostPage(ctx *gin.Context) {
 	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
 
 	req.ReviewerMapping = make(map[string]string)
+	req.ReviewerMapping[constant.ReviewSubmitterContentFilter] = translator.Tr(handler.GetLangByCtx(ctx),
+		constant.ReviewContentFilterLabel)
+	req.ReviewerMapping[constant.ReviewSubmitterSuggestedEdit] = translator.Tr(handler.GetLangByCtx(ctx),
+		constant.ReviewSuggestedPostEditLabel)
 	_ = plugin.CallReviewer(func(base plugin.Reviewer) error {
 		info := base.Info()
 		req.ReviewerMapping[info.SlugName] = info.Name.Translate(ctx)
@@ -20,6 +20,7 @@
 package controller
 
 import (
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/base/reason"
@@ -29,6 +30,7 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/saved_search"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -36,18 +38,24 @@ import (
 
 // SearchController tag controller
 type SearchController struct {
-	searchService *content.SearchService
-	actionService *action.CaptchaService
+	searchService       *content.SearchService
+	savedSearchService  *saved_search.SavedSearchService
+	actionService       *action.CaptchaService
+	rateLimitMiddleware *middleware.RateLimitMiddleware
 }
 
 // NewSearchController new controller
 func NewSearchController(
 	searchService *content.SearchService,
+	savedSearchService *saved_search.SavedSearchService,
 	actionService *action.CaptchaService,
+	rateLimitMiddleware *middleware.RateLimitMiddleware,
 ) *SearchController {
 	return &SearchController{
-		searchService: searchService,
-		actionService: actionService,
+		searchService:       searchService,
+		savedSearchService:  savedSearchService,
+		actionService:       actionService,
+		rateLimitMiddleware: rateLimitMiddleware,
 	}
 }
 
@@ -59,6 +67,7 @@ func NewSearchController(
 // @Security ApiKeyAuth
 // @Param q query string true "query string"
 // @Param order query string true "order" Enums(newest,active,score,relevance)
+// @Param facets query bool false "whether to return the facets of the search result"
 // @Success 200 {object} handler.RespBody{data=schema.SearchResp}
 // @Router /answer/api/v1/search [get]
 func (sc *SearchController) Search(ctx *gin.Context) {
@@ -74,7 +83,11 @@ func (sc *SearchController) Search(ctx *gin.Context) {
 	}
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
-		captchaPass := sc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionSearch, unit, dto.CaptchaID, dto.CaptchaCode)
+		captchaPass, err := sc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionSearch, unit, dto.CaptchaID, dto.CaptchaCode)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
 		if !captchaPass {
 			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 				ErrorField: "captcha_code",
@@ -92,6 +105,28 @@ func (sc *SearchController) Search(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// SearchSuggest get search suggestions as the user types
+// @Summary get search suggestions
+// @Description get the matched titles of questions, articles and quotes, tags and users by prefix
+// @Tags Search
+// @Produce json
+// @Param q query string true "query prefix"
+// @Param size query int false "size of each suggestion type" default(5)
+// @Success 200 {object} handler.RespBody{data=schema.SearchSuggestResp}
+// @Router /answer/api/v1/search/suggest [get]
+func (sc *SearchController) SearchSuggest(ctx *gin.Context) {
+	req := &schema.SearchSuggestReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if sc.rateLimitMiddleware.IPRequestLimitation(ctx, constant.SearchSuggestRateLimitMax, constant.SearchSuggestRateLimitWindow) {
+		return
+	}
+
+	resp, err := sc.searchService.Suggest(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // SearchDesc get search description
 // @Summary get search description
 // @Description get search description
@@ -113,3 +148,80 @@ func (sc *SearchController) SearchDesc(ctx *gin.Context) {
 	}
 	handler.HandleResponse(ctx, nil, resp)
 }
+
+// GetSavedSearchList get saved searches of the login user
+// @Summary get saved searches
+// @Description get saved searches of the login user
+// @Tags Search
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.SavedSearchResp}
+// @Router /answer/api/v1/search/saved [get]
+func (sc *SearchController) GetSavedSearchList(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := sc.savedSearchService.GetSavedSearchList(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddSavedSearch add saved search
+// @Summary add saved search
+// @Description add saved search, the new contents matching the query will be alerted if alert is enabled
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddSavedSearchReq true "saved search"
+// @Success 200 {object} handler.RespBody{data=schema.SavedSearchResp}
+// @Router /answer/api/v1/search/saved [post]
+func (sc *SearchController) AddSavedSearch(ctx *gin.Context) {
+	req := &schema.AddSavedSearchReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := sc.savedSearchService.AddSavedSearch(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSavedSearch update saved search
+// @Summary update saved search
+// @Description update saved search
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateSavedSearchReq true "saved search"
+// @Success 200 {object} handler.RespBody{data=schema.SavedSearchResp}
+// @Router /answer/api/v1/search/saved [put]
+func (sc *SearchController) UpdateSavedSearch(ctx *gin.Context) {
+	req := &schema.UpdateSavedSearchReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := sc.savedSearchService.UpdateSavedSearch(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveSavedSearch remove saved search
+// @Summary remove saved search
+// @Description remove saved search
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveSavedSearchReq true "saved search"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/search/saved [delete]
+func (sc *SearchController) RemoveSavedSearch(ctx *gin.Context) {
+	req := &schema.RemoveSavedSearchReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := sc.savedSearchService.RemoveSavedSearch(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -343,6 +343,160 @@ func (tc *TagController) UpdateTagSynonym(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// GetTagTree get tag tree
+// @Summary get tag tree
+// @Description get the hierarchical tag tree, if tag_id is set, only return the subtree of the tag
+// @Tags Tag
+// @Produce json
+// @Param tag_id query string false "root tag id"
+// @Success 200 {object} handler.RespBody{data=[]schema.TagTreeNode}
+// @Router /answer/api/v1/tags/tree [get]
+func (tc *TagController) GetTagTree(ctx *gin.Context) {
+	req := &schema.GetTagTreeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := tc.tagCommonService.GetTagTree(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetRelatedTags get related tags
+// @Summary get related tags
+// @Description get the tags which are often used together with the picked tags, used to suggest tags in the editor
+// @Tags Tag
+// @Produce json
+// @Param tags query []string true "picked tag slug names" collectionFormat(csv)
+// @Param limit query int false "max number of related tags"
+// @Success 200 {object} handler.RespBody{data=[]schema.RelatedTag}
+// @Router /answer/api/v1/tags/related [get]
+func (tc *TagController) GetRelatedTags(ctx *gin.Context) {
+	req := &schema.GetRelatedTagsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := tc.tagService.GetRelatedTags(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// MoveTag move tag to another parent tag
+// @Summary move tag to another parent tag
+// @Description move the tag and its subtree to another parent tag
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.MoveTagReq true "tag"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/tag/parent [put]
+func (tc *TagController) MoveTag(ctx *gin.Context) {
+	req := &schema.MoveTagReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := tc.rankService.CheckOperationPermission(ctx, req.UserID, permission.TagEditWithoutReview, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	err = tc.tagCommonService.MoveTag(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ReorderTags reorder sibling tags
+// @Summary reorder sibling tags
+// @Description reorder the tags which have the same parent tag
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ReorderTagsReq true "tags"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/tag/sort [put]
+func (tc *TagController) ReorderTags(ctx *gin.Context) {
+	req := &schema.ReorderTagsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := tc.rankService.CheckOperationPermission(ctx, req.UserID, permission.TagEditWithoutReview, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	err = tc.tagCommonService.ReorderTags(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AdminMergeTag merge tag
+// @Summary merge the source tag into the target tag
+// @Description move all content and followers of the source tag to the target tag, the source tag becomes a synonym
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.MergeTagReq true "tag"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/merge [post]
+func (tc *TagController) AdminMergeTag(ctx *gin.Context) {
+	req := &schema.MergeTagReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := tc.tagService.MergeTag(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AdminGetTagSlugProposals get tag slug proposals
+// @Summary propose pinyin slug names for the tags whose slug name is chinese
+// @Description propose pinyin slug names for the tags whose slug name is chinese
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.TagSlugProposal}
+// @Router /answer/admin/api/tag/slug/proposals [get]
+func (tc *TagController) AdminGetTagSlugProposals(ctx *gin.Context) {
+	resp, err := tc.tagService.GetTagSlugProposals(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AdminApplyTagSlugProposals apply tag slug proposals
+// @Summary update the slug names of the tags to the proposed ones
+// @Description update the slug names of the tags to the proposed ones, apply all proposals if tag_ids is empty
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ApplyTagSlugProposalsReq true "tag ids"
+// @Success 200 {object} handler.RespBody{data=[]schema.TagSlugProposal}
+// @Router /answer/admin/api/tag/slug/proposals [put]
+func (tc *TagController) AdminApplyTagSlugProposals(ctx *gin.Context) {
+	req := &schema.ApplyTagSlugProposalsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := tc.tagService.ApplyTagSlugProposals(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // @cws
 func (tc *TagController) GetTagByType(ctx *gin.Context) {
 	req := &schema.GetTagWithPageReq{}
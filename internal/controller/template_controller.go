@@ -808,9 +808,15 @@ func (tc *TemplateController) TagInfo(ctx *gin.Context) {
 		tc.Page404(ctx)
 		return
 	}
+	siteInfo := tc.SiteInfo(ctx)
+	// synonym tags (including the merged tags) are permanently moved to their main tag
+	if len(tagInfo.MainTagSlugName) > 0 && tagInfo.MainTagSlugName != tag {
+		ctx.Redirect(http.StatusMovedPermanently,
+			fmt.Sprintf("%s/tags/%s", siteInfo.General.SiteUrl, url.PathEscape(tagInfo.MainTagSlugName)))
+		return
+	}
 	page := templaterender.Paginator(nowPage, req.PageSize, questionCount)
 
-	siteInfo := tc.SiteInfo(ctx)
 	siteInfo.Canonical = fmt.Sprintf("%s/tags/%s", siteInfo.General.SiteUrl, tag)
 	if req.Page > 1 {
 		siteInfo.Canonical = fmt.Sprintf("%s/tags/%s?page=%d", siteInfo.General.SiteUrl, tag, req.Page)
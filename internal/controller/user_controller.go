@@ -20,6 +20,7 @@
 package controller
 
 import (
+	stdErrors "errors"
 	"net/url"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
@@ -36,6 +37,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/internal/service/user_two_factor"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -50,6 +52,7 @@ type UserController struct {
 	emailService                  *export.EmailService
 	siteInfoCommonService         siteinfo_common.SiteInfoCommonService
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	userTwoFactorService          *user_two_factor.UserTwoFactorService
 }
 
 // NewUserController new controller
@@ -60,6 +63,7 @@ func NewUserController(
 	emailService *export.EmailService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	userTwoFactorService *user_two_factor.UserTwoFactorService,
 ) *UserController {
 	return &UserController{
 		authService:                   authService,
@@ -68,6 +72,7 @@ func NewUserController(
 		emailService:                  emailService,
 		siteInfoCommonService:         siteInfoCommonService,
 		userNotificationConfigService: userNotificationConfigService,
+		userTwoFactorService:          userTwoFactorService,
 	}
 }
 
@@ -138,7 +143,11 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 	}
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
-		captchaPass := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionPassword, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
+		captchaPass, err := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionPassword, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
 		if !captchaPass {
 			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 				ErrorField: "captcha_code",
@@ -149,9 +158,18 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 		}
 	}
 
+	req.IP = ctx.ClientIP()
+	req.UserAgent = ctx.GetHeader("User-Agent")
 	resp, err := uc.userService.EmailLogin(ctx, req)
 	if err != nil {
 		_, _ = uc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionPassword, ctx.ClientIP())
+		// the lockout error tells the user when to try again, and it does not reveal whether the password is right
+		var loginErr *errors.Error
+		if stdErrors.As(err, &loginErr) &&
+			(loginErr.Reason == reason.LoginLocked || loginErr.Reason == reason.LoginTooFrequent) {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
 		errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 			ErrorField: "e_mail",
 			ErrorMsg:   translator.Tr(handler.GetLang(ctx), reason.EmailOrPasswordWrong),
@@ -162,10 +180,56 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 	if !isAdmin {
 		uc.actionService.ActionRecordDel(ctx, entity.CaptchaActionPassword, ctx.ClientIP())
 	}
+	// the visit cookies are set after the second factor is passed
+	if len(resp.TwoFactorToken) == 0 {
+		uc.setVisitCookies(ctx, resp.VisitToken, true)
+	}
+	handler.HandleResponse(ctx, nil, resp)
+}
+
+// UserTwoFactorLogin complete the login by the second factor
+// @Summary complete the login by the second factor
+// @Description complete the login by totp code or recovery code with the two-factor token returned by login
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.UserTwoFactorLoginReq true "two-factor login"
+// @Success 200 {object} handler.RespBody{data=schema.UserTwoFactorLoginResp}
+// @Router /answer/api/v1/user/login/two-factor [post]
+func (uc *UserController) UserTwoFactorLogin(ctx *gin.Context) {
+	req := &schema.UserTwoFactorLoginReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := uc.userService.TwoFactorLogin(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 	uc.setVisitCookies(ctx, resp.VisitToken, true)
 	handler.HandleResponse(ctx, nil, resp)
 }
 
+// UserTwoFactorLoginEnroll enroll two-factor authentication during login
+// @Summary enroll two-factor authentication during login
+// @Description enroll two-factor authentication when it is required by site policy but not enabled by user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.UserTwoFactorLoginEnrollReq true "two-factor token"
+// @Success 200 {object} handler.RespBody{data=schema.UserTwoFactorEnrollResp}
+// @Router /answer/api/v1/user/login/two-factor/enroll [post]
+func (uc *UserController) UserTwoFactorLoginEnroll(ctx *gin.Context) {
+	req := &schema.UserTwoFactorLoginEnrollReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := uc.userTwoFactorService.EnrollPendingLogin(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // RetrievePassWord godoc
 // @Summary RetrievePassWord
 // @Description RetrievePassWord
@@ -182,7 +246,11 @@ func (uc *UserController) RetrievePassWord(ctx *gin.Context) {
 	}
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
-		captchaPass := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEmail, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
+		captchaPass, err := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEmail, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
 		if !captchaPass {
 			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 				ErrorField: "captcha_code",
@@ -244,6 +312,41 @@ func (uc *UserController) UserLogout(ctx *gin.Context) {
 	handler.HandleResponse(ctx, nil, nil)
 }
 
+// GetUserSessionList get user login sessions
+// @Summary get user login sessions
+// @Description get the active login sessions of the current user
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.UserSessionResp}
+// @Router /answer/api/v1/user/sessions [get]
+func (uc *UserController) GetUserSessionList(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.authService.GetUserSessionList(ctx, userID, middleware.ExtractToken(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokeUserSession revoke user login session
+// @Summary revoke user login session
+// @Description log out one of the login sessions of the current user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevokeUserSessionReq true "session"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/session [delete]
+func (uc *UserController) RevokeUserSession(ctx *gin.Context) {
+	req := &schema.RevokeUserSessionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.authService.RevokeUserSession(ctx, req.UserID, req.SessionID)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // UserRegisterByEmail godoc
 // @Summary UserRegisterByEmail
 // @Description UserRegisterByEmail
@@ -276,7 +379,11 @@ func (uc *UserController) UserRegisterByEmail(ctx *gin.Context) {
 	req.IP = ctx.ClientIP()
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
-		captchaPass := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEmail, req.IP, req.CaptchaID, req.CaptchaCode)
+		captchaPass, err := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEmail, req.IP, req.CaptchaID, req.CaptchaCode)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
 		if !captchaPass {
 			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 				ErrorField: "captcha_code",
@@ -354,7 +461,11 @@ func (uc *UserController) UserVerifyEmailSend(ctx *gin.Context) {
 	}
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
-		captchaPass := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEmail, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
+		captchaPass, err := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEmail, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
 		if !captchaPass {
 			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 				ErrorField: "captcha_code",
@@ -388,8 +499,12 @@ func (uc *UserController) UserModifyPassWord(ctx *gin.Context) {
 	req.AccessToken = middleware.ExtractToken(ctx)
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
-		captchaPass := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEditUserinfo, req.UserID,
+		captchaPass, err := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEditUserinfo, req.UserID,
 			req.CaptchaID, req.CaptchaCode)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
 		if !captchaPass {
 			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 				ErrorField: "captcha_code",
@@ -398,7 +513,7 @@ func (uc *UserController) UserModifyPassWord(ctx *gin.Context) {
 			handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), errFields)
 			return
 		}
-		_, err := uc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionEditUserinfo, req.UserID)
+		_, err = uc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionEditUserinfo, req.UserID)
 		if err != nil {
 			log.Error(err)
 		}
@@ -509,6 +624,23 @@ func (uc *UserController) ActionRecord(ctx *gin.Context) {
 
 }
 
+// GetActionPolicy godoc
+// @Summary get the measures currently applied to the actions
+// @Description get which actions currently need a captcha, are delayed or blocked for the user
+// @Tags User
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetActionPolicyResp}
+// @Router /answer/api/v1/user/action/policy [get]
+func (uc *UserController) GetActionPolicy(ctx *gin.Context) {
+	req := &schema.GetActionPolicyReq{
+		UserID:  middleware.GetLoginUserIDFromContext(ctx),
+		IP:      ctx.ClientIP(),
+		IsAdmin: middleware.GetUserIsAdminModerator(ctx),
+	}
+	resp := uc.actionService.GetActionPolicy(ctx, req)
+	handler.HandleResponse(ctx, nil, resp)
+}
+
 // GetUserNotificationConfig get user's notification config
 // @Summary get user's notification config
 // @Description get user's notification config
@@ -579,8 +711,12 @@ func (uc *UserController) UserChangeEmailSendCode(ctx *gin.Context) {
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 
 	if !isAdmin {
-		captchaPass := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEditUserinfo, req.UserID, req.CaptchaID, req.CaptchaCode)
+		captchaPass, err := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEditUserinfo, req.UserID, req.CaptchaID, req.CaptchaCode)
 		uc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionEditUserinfo, req.UserID)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
 		if !captchaPass {
 			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 				ErrorField: "captcha_code",
@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/user_data_export"
+	"github.com/gin-gonic/gin"
+)
+
+// UserDataExportController user personal data export controller
+type UserDataExportController struct {
+	userDataExportService *user_data_export.UserDataExportService
+}
+
+// NewUserDataExportController new controller
+func NewUserDataExportController(
+	userDataExportService *user_data_export.UserDataExportService,
+) *UserDataExportController {
+	return &UserDataExportController{
+		userDataExportService: userDataExportService,
+	}
+}
+
+// GetUserDataExport get the latest personal data export
+// @Summary get the latest personal data export
+// @Description get the status and progress of the latest personal data export of the current user
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.UserDataExportResp}
+// @Router /answer/api/v1/user/data-export [get]
+func (uc *UserDataExportController) GetUserDataExport(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userDataExportService.GetUserDataExport(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RequestUserDataExport request personal data export
+// @Summary request personal data export
+// @Description the archive is generated asynchronously and the download link is sent by email, at most once per day
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.UserDataExportResp}
+// @Router /answer/api/v1/user/data-export [post]
+func (uc *UserDataExportController) RequestUserDataExport(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userDataExportService.RequestUserDataExport(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// DownloadUserDataExport download personal data export
+// @Summary download personal data export
+// @Description download the archive by the signed link
+// @Tags User
+// @Produce application/zip
+// @Param id query string true "export id"
+// @Param expires query int true "the unix time when the link expires"
+// @Param sign query string true "signature"
+// @Success 200 {file} file
+// @Router /answer/api/v1/user/data-export/download [get]
+func (uc *UserDataExportController) DownloadUserDataExport(ctx *gin.Context) {
+	req := &schema.DownloadUserDataExportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	filePath, fileName, err := uc.userDataExportService.DownloadUserDataExport(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.FileAttachment(filePath, fileName)
+}
@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
+	"github.com/gin-gonic/gin"
+)
+
+// UserRelationController user block and mute controller
+type UserRelationController struct {
+	userRelationService *user_relation.UserRelationService
+}
+
+// NewUserRelationController new controller
+func NewUserRelationController(
+	userRelationService *user_relation.UserRelationService,
+) *UserRelationController {
+	return &UserRelationController{
+		userRelationService: userRelationService,
+	}
+}
+
+// GetUserRelationPage get blocked or muted users
+// @Summary get blocked or muted users
+// @Description get the users blocked or muted by the current user
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type query string true "relation type" Enums(block, mute)
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.UserRelationResp}}
+// @Router /answer/api/v1/user/relation/page [get]
+func (uc *UserRelationController) GetUserRelationPage(ctx *gin.Context) {
+	req := &schema.GetUserRelationPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := uc.userRelationService.GetUserRelationPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddUserRelation block or mute a user
+// @Summary block or mute a user
+// @Description the blocked user can not comment on, mention, invite, vote on or notify the current user,
+// @Description the content of the muted user is collapsed for the current user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddUserRelationReq true "user relation"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/relation [post]
+func (uc *UserRelationController) AddUserRelation(ctx *gin.Context) {
+	req := &schema.AddUserRelationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userRelationService.AddUserRelation(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveUserRelation unblock or unmute a user
+// @Summary unblock or unmute a user
+// @Description unblock or unmute a user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveUserRelationReq true "user relation"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/relation [delete]
+func (uc *UserRelationController) RemoveUserRelation(ctx *gin.Context) {
+	req := &schema.RemoveUserRelationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userRelationService.RemoveUserRelation(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/user_two_factor"
+	"github.com/gin-gonic/gin"
+)
+
+// UserTwoFactorController user two-factor authentication controller
+type UserTwoFactorController struct {
+	userTwoFactorService *user_two_factor.UserTwoFactorService
+}
+
+// NewUserTwoFactorController new controller
+func NewUserTwoFactorController(
+	userTwoFactorService *user_two_factor.UserTwoFactorService,
+) *UserTwoFactorController {
+	return &UserTwoFactorController{
+		userTwoFactorService: userTwoFactorService,
+	}
+}
+
+// GetUserTwoFactor get two-factor authentication status
+// @Summary get two-factor authentication status
+// @Description get two-factor authentication status of the current user
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.GetUserTwoFactorResp}
+// @Router /answer/api/v1/user/two-factor [get]
+func (uc *UserTwoFactorController) GetUserTwoFactor(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userTwoFactorService.GetUserTwoFactor(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// EnrollUserTwoFactor enroll two-factor authentication
+// @Summary enroll two-factor authentication
+// @Description generate a new secret, it takes effect after confirmed by a code
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.UserTwoFactorEnrollResp}
+// @Router /answer/api/v1/user/two-factor/enroll [post]
+func (uc *UserTwoFactorController) EnrollUserTwoFactor(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userTwoFactorService.EnrollUserTwoFactor(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// EnableUserTwoFactor enable two-factor authentication
+// @Summary enable two-factor authentication
+// @Description confirm the enrolled secret by a totp code, the recovery codes are only returned once
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UserTwoFactorCodeReq true "code"
+// @Success 200 {object} handler.RespBody{data=schema.UserTwoFactorRecoveryCodesResp}
+// @Router /answer/api/v1/user/two-factor [post]
+func (uc *UserTwoFactorController) EnableUserTwoFactor(ctx *gin.Context) {
+	req := &schema.UserTwoFactorCodeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := uc.userTwoFactorService.EnableUserTwoFactor(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// DisableUserTwoFactor disable two-factor authentication
+// @Summary disable two-factor authentication
+// @Description disable two-factor authentication, confirmed by a totp code or recovery code
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UserTwoFactorCodeReq true "code"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/two-factor [delete]
+func (uc *UserTwoFactorController) DisableUserTwoFactor(ctx *gin.Context) {
+	req := &schema.UserTwoFactorCodeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userTwoFactorService.DisableUserTwoFactor(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RegenerateRecoveryCodes regenerate recovery codes
+// @Summary regenerate recovery codes
+// @Description replace all recovery codes, confirmed by a totp code or recovery code
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UserTwoFactorCodeReq true "code"
+// @Success 200 {object} handler.RespBody{data=schema.UserTwoFactorRecoveryCodesResp}
+// @Router /answer/api/v1/user/two-factor/recovery-codes [put]
+func (uc *UserTwoFactorController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	req := &schema.UserTwoFactorCodeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := uc.userTwoFactorService.RegenerateRecoveryCodes(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AdminResetUserTwoFactor reset user two-factor authentication
+// @Summary reset user two-factor authentication
+// @Description remove the two-factor authentication of the user who is locked out
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AdminResetUserTwoFactorReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/two-factor/reset [put]
+func (uc *UserTwoFactorController) AdminResetUserTwoFactor(ctx *gin.Context) {
+	req := &schema.AdminResetUserTwoFactorReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.OperatorID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userTwoFactorService.AdminResetUserTwoFactor(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
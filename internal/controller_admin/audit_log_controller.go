@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogController audit log controller
+type AuditLogController struct {
+	auditLogService *audit_log.AuditLogService
+}
+
+// NewAuditLogController new audit log controller
+func NewAuditLogController(auditLogService *audit_log.AuditLogService) *AuditLogController {
+	return &AuditLogController{
+		auditLogService: auditLogService,
+	}
+}
+
+// GetAuditLogPage get audit logs
+// @Summary get audit logs
+// @Description get the audit log of the admin and moderator actions by page
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param user_id query string false "the actor user id"
+// @Param action query string false "action, such as user.update_status"
+// @Param object_type query string false "object type"
+// @Param object_id query string false "object id"
+// @Param start_time query int false "start unix time in seconds"
+// @Param end_time query int false "end unix time in seconds"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.AuditLogResp}}
+// @Router /answer/admin/api/audit-logs [get]
+func (ac *AuditLogController) GetAuditLogPage(ctx *gin.Context) {
+	req := &schema.GetAuditLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := ac.auditLogService.GetAuditLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ExportAuditLog export audit logs
+// @Summary export audit logs
+// @Description export the filtered audit log as csv
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce text/csv
+// @Param user_id query string false "the actor user id"
+// @Param action query string false "action, such as user.update_status"
+// @Param object_type query string false "object type"
+// @Param object_id query string false "object id"
+// @Param start_time query int false "start unix time in seconds"
+// @Param end_time query int false "end unix time in seconds"
+// @Success 200 {file} file
+// @Router /answer/admin/api/audit-logs/export [get]
+func (ac *AuditLogController) ExportAuditLog(ctx *gin.Context) {
+	req := &schema.ExportAuditLogReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	content, err := ac.auditLogService.ExportAuditLog(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	fileName := fmt.Sprintf("audit_log_%s.csv", time.Now().Format("20060102150405"))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", content)
+}
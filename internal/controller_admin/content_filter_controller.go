@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentFilterController content filter controller
+type ContentFilterController struct {
+	contentFilterService *content_filter.ContentFilterService
+}
+
+// NewContentFilterController new content filter controller
+func NewContentFilterController(contentFilterService *content_filter.ContentFilterService) *ContentFilterController {
+	return &ContentFilterController{
+		contentFilterService: contentFilterService,
+	}
+}
+
+// GetContentFilterRulePage get content filter rules
+// @Summary get content filter rules
+// @Description get the banned words, regexes and domains by page
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param rule_type query string false "rule type" Enums(word, regex, domain)
+// @Param severity query string false "severity" Enums(mask, review, reject)
+// @Param query query string false "search the pattern"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.ContentFilterRuleResp}}
+// @Router /answer/admin/api/content-filter/rules [get]
+func (cc *ContentFilterController) GetContentFilterRulePage(ctx *gin.Context) {
+	req := &schema.GetContentFilterRulePageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := cc.contentFilterService.GetContentFilterRulePage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddContentFilterRules add content filter rules
+// @Summary add content filter rules
+// @Description add the banned words, regexes or domains with the same severity
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddContentFilterRuleReq true "rules"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/content-filter/rules [post]
+func (cc *ContentFilterController) AddContentFilterRules(ctx *gin.Context) {
+	req := &schema.AddContentFilterRuleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := cc.contentFilterService.AddContentFilterRules(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateContentFilterRule update content filter rule
+// @Summary update content filter rule
+// @Description update content filter rule
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateContentFilterRuleReq true "rule"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/content-filter/rule [put]
+func (cc *ContentFilterController) UpdateContentFilterRule(ctx *gin.Context) {
+	req := &schema.UpdateContentFilterRuleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := cc.contentFilterService.UpdateContentFilterRule(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveContentFilterRule remove content filter rule
+// @Summary remove content filter rule
+// @Description remove content filter rule
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveContentFilterRuleReq true "rule"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/content-filter/rule [delete]
+func (cc *ContentFilterController) RemoveContentFilterRule(ctx *gin.Context) {
+	req := &schema.RemoveContentFilterRuleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := cc.contentFilterService.RemoveContentFilterRule(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetContentFilterLogPage get content filter logs
+// @Summary get content filter logs
+// @Description get the content matched by the content filter for moderator review
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param user_id query string false "user id"
+// @Param object_type query string false "object type"
+// @Param severity query string false "severity" Enums(mask, review, reject)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.ContentFilterLogResp}}
+// @Router /answer/admin/api/content-filter/logs [get]
+func (cc *ContentFilterController) GetContentFilterLogPage(ctx *gin.Context) {
+	req := &schema.GetContentFilterLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := cc.contentFilterService.GetContentFilterLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
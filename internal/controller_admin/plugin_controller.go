@@ -149,8 +149,7 @@ func (pc *PluginController) UpdatePluginStatus(ctx *gin.Context) {
 		return
 	}
 
-	plugin.StatusManager.Enable(req.PluginSlugName, req.Enabled)
-	err := pc.pluginCommonService.UpdatePluginStatus(ctx)
+	err := pc.pluginCommonService.UpdatePluginStatus(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -51,3 +51,91 @@ func (rc *RoleController) GetRoleList(ctx *gin.Context) {
 	resp, err := rc.roleService.GetRoleList(ctx)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// GetRoleInfo get role info with its powers
+// @Summary get role info with its powers
+// @Description get role info with its powers
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query int true "role id"
+// @Success 200 {object} handler.RespBody{data=schema.GetRoleInfoResp}
+// @Router /answer/admin/api/role/info [get]
+func (rc *RoleController) GetRoleInfo(ctx *gin.Context) {
+	req := &schema.GetRoleInfoReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := rc.roleService.GetRoleInfo(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetPowerList get all the powers that can be assigned to a role
+// @Summary get all the powers that can be assigned to a role
+// @Description get all the powers that can be assigned to a role
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetPowerResp}
+// @Router /answer/admin/api/powers [get]
+func (rc *RoleController) GetPowerList(ctx *gin.Context) {
+	resp := rc.roleService.GetPowerList(ctx)
+	handler.HandleResponse(ctx, nil, resp)
+}
+
+// AddRole add role
+// @Summary add role
+// @Description add role with powers
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddRoleReq true "role"
+// @Success 200 {object} handler.RespBody{data=schema.GetRoleInfoResp}
+// @Router /answer/admin/api/role [post]
+func (rc *RoleController) AddRole(ctx *gin.Context) {
+	req := &schema.AddRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := rc.roleService.AddRole(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateRole update role
+// @Summary update role
+// @Description update role and replace its powers
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [put]
+func (rc *RoleController) UpdateRole(ctx *gin.Context) {
+	req := &schema.UpdateRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := rc.roleService.UpdateRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveRole remove role
+// @Summary remove role
+// @Description remove the role that is not built-in and not assigned to any user
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [delete]
+func (rc *RoleController) RemoveRole(ctx *gin.Context) {
+	req := &schema.RemoveRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := rc.roleService.RemoveRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -32,13 +32,18 @@ import (
 
 // SiteInfoController site info controller
 type SiteInfoController struct {
-	siteInfoService *siteinfo.SiteInfoService
+	siteInfoService     *siteinfo.SiteInfoService
+	rateLimitMiddleware *middleware.RateLimitMiddleware
 }
 
 // NewSiteInfoController new site info controller
-func NewSiteInfoController(siteInfoService *siteinfo.SiteInfoService) *SiteInfoController {
+func NewSiteInfoController(
+	siteInfoService *siteinfo.SiteInfoService,
+	rateLimitMiddleware *middleware.RateLimitMiddleware,
+) *SiteInfoController {
 	return &SiteInfoController{
-		siteInfoService: siteInfoService,
+		siteInfoService:     siteInfoService,
+		rateLimitMiddleware: rateLimitMiddleware,
 	}
 }
 
@@ -449,3 +454,140 @@ func (sc *SiteInfoController) UpdatePrivilegesConfig(ctx *gin.Context) {
 	err := sc.siteInfoService.UpdatePrivilegesConfig(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// GetActionPolicy get action policy
+// @Summary get action policy
+// @Description get the captcha and action limit policy of each action
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteActionPolicyResp}
+// @Router /answer/admin/api/setting/action-policy [get]
+func (sc *SiteInfoController) GetActionPolicy(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteActionPolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateActionPolicy update action policy
+// @Summary update action policy
+// @Description update the captcha and action limit policy of each action
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteActionPolicyReq true "policy"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/action-policy [put]
+func (sc *SiteInfoController) UpdateActionPolicy(ctx *gin.Context) {
+	req := &schema.SiteActionPolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteActionPolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetRateLimit get rate limit config
+// @Summary get rate limit config
+// @Description get the token bucket rules of the route groups and the trusted users
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteRateLimitResp}
+// @Router /answer/admin/api/setting/rate-limit [get]
+func (sc *SiteInfoController) GetRateLimit(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteRateLimit(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateRateLimit update rate limit config
+// @Summary update rate limit config
+// @Description update the token bucket rules of the route groups and the trusted users
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteRateLimitReq true "config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/rate-limit [put]
+func (sc *SiteInfoController) UpdateRateLimit(ctx *gin.Context) {
+	req := &schema.SiteRateLimitReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteRateLimit(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetRateLimitStats get rate limit stats
+// @Summary get rate limit stats
+// @Description get the requests allowed and rejected by the rate limit today
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetRateLimitStatsResp}
+// @Router /answer/admin/api/setting/rate-limit/stats [get]
+func (sc *SiteInfoController) GetRateLimitStats(ctx *gin.Context) {
+	resp := sc.rateLimitMiddleware.GetRateLimitStats(ctx)
+	handler.HandleResponse(ctx, nil, resp)
+}
+
+// GetHTMLSanitizer get html sanitizer allowlist
+// @Summary get html sanitizer allowlist
+// @Description get the elements, attributes, url schemes and iframe hosts allowed in html-format content
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteHTMLSanitizerResp}
+// @Router /answer/admin/api/setting/html-sanitizer [get]
+func (sc *SiteInfoController) GetHTMLSanitizer(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteHTMLSanitizer(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateHTMLSanitizer update html sanitizer allowlist
+// @Summary update html sanitizer allowlist
+// @Description update the elements, attributes, url schemes and iframe hosts allowed in html-format content
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteHTMLSanitizerReq true "allowlist"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/html-sanitizer [put]
+func (sc *SiteInfoController) UpdateHTMLSanitizer(ctx *gin.Context) {
+	req := &schema.SiteHTMLSanitizerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteHTMLSanitizer(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetAuditLog get audit log config
+// @Summary get audit log config
+// @Description get the retention days of the audit log
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteAuditLogResp}
+// @Router /answer/admin/api/setting/audit-log [get]
+func (sc *SiteInfoController) GetAuditLog(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteAuditLog(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateAuditLog update audit log config
+// @Summary update audit log config
+// @Description update the retention days of the audit log, 0 means keep forever
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteAuditLogReq true "config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/audit-log [put]
+func (sc *SiteInfoController) UpdateAuditLog(ctx *gin.Context) {
+	req := &schema.SiteAuditLogReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteAuditLog(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
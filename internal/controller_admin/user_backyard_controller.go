@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
+	"github.com/apache/incubator-answer/internal/service/user_login_security"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -33,12 +34,19 @@ import (
 
 // UserAdminController user controller
 type UserAdminController struct {
-	userService *user_admin.UserAdminService
+	userService              *user_admin.UserAdminService
+	userLoginSecurityService *user_login_security.UserLoginSecurityService
 }
 
 // NewUserAdminController new controller
-func NewUserAdminController(userService *user_admin.UserAdminService) *UserAdminController {
-	return &UserAdminController{userService: userService}
+func NewUserAdminController(
+	userService *user_admin.UserAdminService,
+	userLoginSecurityService *user_login_security.UserLoginSecurityService,
+) *UserAdminController {
+	return &UserAdminController{
+		userService:              userService,
+		userLoginSecurityService: userLoginSecurityService,
+	}
 }
 
 // UpdateUserStatus update user
@@ -242,3 +250,105 @@ func (uc *UserAdminController) SendUserActivation(ctx *gin.Context) {
 	err := uc.userService.SendUserActivation(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// GetUserSessionList get user login sessions
+// @Summary get user login sessions
+// @Description get the active login sessions of the user
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param user_id query string true "user id"
+// @Success 200 {object} handler.RespBody{data=[]schema.UserSessionResp}
+// @Router /answer/admin/api/user/sessions [get]
+func (uc *UserAdminController) GetUserSessionList(ctx *gin.Context) {
+	req := &schema.AdminGetUserSessionListReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := uc.userService.GetUserSessionList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokeUserSession revoke user login session
+// @Summary revoke user login session
+// @Description log out one of the login sessions of the user
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AdminRevokeUserSessionReq true "session"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/session [delete]
+func (uc *UserAdminController) RevokeUserSession(ctx *gin.Context) {
+	req := &schema.AdminRevokeUserSessionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := uc.userService.RevokeUserSession(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetLockedUserPage get locked users
+// @Summary get locked users
+// @Description get the users which are locked after too many failed login attempts
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page size"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.LockedUserResp}}
+// @Router /answer/admin/api/users/locked [get]
+func (uc *UserAdminController) GetLockedUserPage(ctx *gin.Context) {
+	req := &schema.GetLockedUserPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := uc.userLoginSecurityService.GetLockedUserPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UnlockUser unlock user
+// @Summary unlock user
+// @Description unlock the user and reset the failed login attempts
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UnlockUserReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/unlock [put]
+func (uc *UserAdminController) UnlockUser(ctx *gin.Context) {
+	req := &schema.UnlockUserReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := uc.userLoginSecurityService.UnlockUser(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetUserLoginLogPage get user login logs
+// @Summary get user login logs
+// @Description get the audit log of password logins
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page size"
+// @Param page_size query int false "page size"
+// @Param user_id query string false "user id"
+// @Param ip query string false "ip"
+// @Param status query string false "status" Enums(success, failed)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.UserLoginLogResp}}
+// @Router /answer/admin/api/user/login-logs [get]
+func (uc *UserAdminController) GetUserLoginLogPage(ctx *gin.Context) {
+	req := &schema.GetUserLoginLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := uc.userLoginSecurityService.GetUserLoginLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -17,7 +17,7 @@ type Article struct {
 	ParsedText   string `xorm:"not null MEDIUMTEXT parsed_text"`
 
 	//Content         string    `json:"content" xorm:"content"`
-	Title           string    `json:"title" xorm:"title"`
+	Title           string    `json:"title" xorm:"VARCHAR(255) INDEX title"`
 	Excerpt         string    `json:"excerpt" xorm:"excerpt"` // 摘录
 	Status          int       `json:"status" xorm:"status"`
 	CommentStatus   int8      `json:"comment_status" xorm:"comment_status"` // 评论状态（open/closed）
@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	AuditLogActionUserUpdateStatus    = "user.update_status"
+	AuditLogActionUserUpdateRole      = "user.update_role"
+	AuditLogActionUserAdd             = "user.add"
+	AuditLogActionUserBulkAdd         = "user.bulk_add"
+	AuditLogActionUserUpdatePassword  = "user.update_password"
+	AuditLogActionUserEditProfile     = "user.edit_profile"
+	AuditLogActionUserRevokeSession   = "user.revoke_session"
+	AuditLogActionUserUnlock          = "user.unlock"
+	AuditLogActionSiteInfoUpdate      = "site_info.update"
+	AuditLogActionPluginUpdateStatus  = "plugin.update_status"
+	AuditLogActionPluginUpdateConfig  = "plugin.update_config"
+	AuditLogActionReportHandle        = "report.handle"
+	AuditLogActionReviewHandle        = "review.handle"
+	AuditLogActionContentUpdateStatus = "content.update_status"
+	AuditLogActionContentFilterAdd    = "content_filter.add"
+	AuditLogActionContentFilterUpdate = "content_filter.update"
+	AuditLogActionContentFilterRemove = "content_filter.delete"
+	AuditLogActionBadgeUpdateStatus   = "badge.update_status"
+	AuditLogActionRoleAdd             = "role.add"
+	AuditLogActionRoleUpdate          = "role.update"
+	AuditLogActionRoleRemove          = "role.delete"
+	AuditLogActionTagMerge            = "tag.merge"
+	AuditLogActionTagUpdateSlugName   = "tag.update_slug_name"
+	AuditLogActionTokenRevoke         = "personal_access_token.revoke"
+	AuditLogActionUserResetTwoFactor  = "user.reset_two_factor"
+	AuditLogActionUserSendActivation  = "user.send_activation"
+	AuditLogActionContentClose        = "content.close"
+	AuditLogActionContentOperate      = "content.operate"
+	AuditLogActionContentRemove       = "content.delete"
+)
+
+// the object types of the audit log target besides the ones in constant.ObjectTypeStrMapping
+const (
+	AuditLogObjectTypeSiteInfo          = "site_info"
+	AuditLogObjectTypePlugin            = "plugin"
+	AuditLogObjectTypeReview            = "review"
+	AuditLogObjectTypeContentFilterRule = "content_filter_rule"
+	AuditLogObjectTypeRole              = "role"
+	AuditLogObjectTypeToken             = "personal_access_token"
+)
+
+// AuditLog the append-only log of the admin and moderator actions
+type AuditLog struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP INDEX created_at"`
+	// UserID the actor, 0 if the action is not done by a login user
+	UserID     string `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	Action     string `xorm:"not null default '' VARCHAR(64) INDEX action"`
+	ObjectType string `xorm:"not null default '' VARCHAR(64) object_type"`
+	ObjectID   string `xorm:"not null default '' VARCHAR(128) INDEX object_id"`
+	// BeforeValue and AfterValue are the json of the target before and after the action
+	BeforeValue string `xorm:"MEDIUMTEXT before_value"`
+	AfterValue  string `xorm:"MEDIUMTEXT after_value"`
+	IP          string `xorm:"not null default '' VARCHAR(64) ip"`
+}
+
+// TableName audit log table name
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	ContentFilterRuleTypeWord   = 1
+	ContentFilterRuleTypeRegex  = 2
+	ContentFilterRuleTypeDomain = 3
+)
+
+// ContentFilterRuleTypeMapping content filter rule type mapping
+var ContentFilterRuleTypeMapping = map[string]int{
+	"word":   ContentFilterRuleTypeWord,
+	"regex":  ContentFilterRuleTypeRegex,
+	"domain": ContentFilterRuleTypeDomain,
+}
+
+// ContentFilterRuleTypeIntToString content filter rule type reverse mapping
+var ContentFilterRuleTypeIntToString = map[int]string{
+	ContentFilterRuleTypeWord:   "word",
+	ContentFilterRuleTypeRegex:  "regex",
+	ContentFilterRuleTypeDomain: "domain",
+}
+
+// The severity of content filter rule, the higher one wins when several rules are matched
+const (
+	// ContentFilterSeverityMask the matched text is replaced by asterisks
+	ContentFilterSeverityMask = 1
+	// ContentFilterSeverityReview the content is sent to the review queue
+	ContentFilterSeverityReview = 2
+	// ContentFilterSeverityReject the content is rejected
+	ContentFilterSeverityReject = 3
+)
+
+// ContentFilterSeverityMapping content filter severity mapping
+var ContentFilterSeverityMapping = map[string]int{
+	"mask":   ContentFilterSeverityMask,
+	"review": ContentFilterSeverityReview,
+	"reject": ContentFilterSeverityReject,
+}
+
+// ContentFilterSeverityIntToString content filter severity reverse mapping
+var ContentFilterSeverityIntToString = map[int]string{
+	ContentFilterSeverityMask:   "mask",
+	ContentFilterSeverityReview: "review",
+	ContentFilterSeverityReject: "reject",
+}
+
+// ContentFilterRule banned word, regex or domain managed by admin
+type ContentFilterRule struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	RuleType  int       `xorm:"not null default 1 INT(11) INDEX rule_type"`
+	Pattern   string    `xorm:"not null default '' VARCHAR(255) pattern"`
+	Severity  int       `xorm:"not null default 1 INT(11) severity"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) user_id"`
+}
+
+// TableName content filter rule table name
+func (ContentFilterRule) TableName() string {
+	return "content_filter_rule"
+}
+
+// ContentFilterLog the record of content matched by the filter rule
+type ContentFilterLog struct {
+	ID         string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP INDEX created_at"`
+	UserID     string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(32) object_type"`
+	// ObjectID is 0 when the content is rejected or not created yet
+	ObjectID string `xorm:"not null default 0 BIGINT(20) object_id"`
+	Field    string `xorm:"not null default '' VARCHAR(32) field"`
+	RuleID   string `xorm:"not null default 0 BIGINT(20) rule_id"`
+	RuleType int    `xorm:"not null default 1 INT(11) rule_type"`
+	// Pattern is kept because the rule may be removed later
+	Pattern  string `xorm:"not null default '' VARCHAR(255) pattern"`
+	Severity int    `xorm:"not null default 1 INT(11) INDEX severity"`
+	Matched  string `xorm:"not null default '' VARCHAR(255) matched"`
+	Excerpt  string `xorm:"not null default '' VARCHAR(1024) excerpt"`
+}
+
+// TableName content filter log table name
+func (ContentFilterLog) TableName() string {
+	return "content_filter_log"
+}
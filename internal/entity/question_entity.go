@@ -56,7 +56,7 @@ type Question struct {
 	UserID           string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
 	InviteUserID     string    `xorm:"TEXT invite_user_id"`
 	LastEditUserID   string    `xorm:"not null default 0 BIGINT(20) last_edit_user_id"`
-	Title            string    `xorm:"not null default '' VARCHAR(150) title"`
+	Title            string    `xorm:"not null default '' VARCHAR(150) INDEX title"`
 	OriginalText     string    `xorm:"not null MEDIUMTEXT original_text"`
 	ParsedText       string    `xorm:"not null MEDIUMTEXT parsed_text"`
 	Pin              int       `xorm:"not null default 1 INT(11) pin"`
@@ -39,6 +39,20 @@ type Review struct {
 	Submitter      string    `xorm:"not null default '' VARCHAR(100) submitter"`
 	Reason         string    `xorm:"not null TEXT reason"`
 	Status         int       `xorm:"not null default 0 INT(11) status"`
+	// SubmittedTitle and SubmittedContent keep what was sent to review, so that later edits can be diffed
+	SubmittedTitle   string `xorm:"not null default '' VARCHAR(255) submitted_title"`
+	SubmittedContent string `xorm:"MEDIUMTEXT submitted_content"`
+	// RevisionID the unreviewed revision of the pending edit, 0 for the new post
+	RevisionID string `xorm:"not null default 0 BIGINT(20) revision_id"`
+	// BaseTitle and BaseContent keep the content before the pending edit, empty for new posts
+	BaseTitle    string `xorm:"not null default '' VARCHAR(255) base_title"`
+	BaseContent  string `xorm:"MEDIUMTEXT base_content"`
+	RejectReason string `xorm:"not null default '' VARCHAR(500) reject_reason"`
+}
+
+// IsEdit whether the review is for the pending edit of the post
+func (r *Review) IsEdit() bool {
+	return len(r.RevisionID) > 0 && r.RevisionID != "0"
 }
 
 // TableName review table name
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	SavedSearchAlertHourly = "hourly"
+	SavedSearchAlertDaily  = "daily"
+	SavedSearchAlertWeekly = "weekly"
+)
+
+// SavedSearchAlertIntervalMapping the minimum interval between two alerts of each frequency
+var SavedSearchAlertIntervalMapping = map[string]time.Duration{
+	SavedSearchAlertHourly: time.Hour,
+	SavedSearchAlertDaily:  24 * time.Hour,
+	SavedSearchAlertWeekly: 7 * 24 * time.Hour,
+}
+
+// SavedSearch saved search
+type SavedSearch struct {
+	ID             string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt      time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	UserID         string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	Name           string    `xorm:"not null default '' VARCHAR(100) name"`
+	Query          string    `xorm:"not null default '' VARCHAR(255) query"`
+	Alert          bool      `xorm:"not null default false BOOL INDEX alert"`
+	AlertFrequency string    `xorm:"not null default '' VARCHAR(16) alert_frequency"`
+	// LastSeenAt the created time of the newest content that has been alerted, it is the high-water mark of alert
+	LastSeenAt    time.Time `xorm:"TIMESTAMP last_seen_at"`
+	LastCheckedAt time.Time `xorm:"TIMESTAMP last_checked_at"`
+}
+
+// TableName saved search table name
+func (SavedSearch) TableName() string {
+	return "saved_search"
+}
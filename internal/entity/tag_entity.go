@@ -19,18 +19,34 @@
 
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+)
 
 const (
 	TagStatusAvailable = 1
 	TagStatusDeleted   = 10
 )
 
+// TagSlugNameMaxLength the max length of the tag slug name
+const TagSlugNameMaxLength = 35
+
 var TagStatusDisplayMapping = map[int]string{
 	TagStatusAvailable: "available",
 	TagStatusDeleted:   "deleted",
 }
 
+// TagContentCountColumnMapping the count column of each content type in tag table
+var TagContentCountColumnMapping = map[string]string{
+	constant.QuestionObjectType:    "question_count",
+	constant.ArticleObjectType:     "article_count",
+	constant.QuoteObjectType:       "quote_count",
+	constant.QuoteAuthorObjectType: "author_count",
+	constant.QuotePieceObjectType:  "piece_count",
+}
+
 // Tag tag
 type Tag struct {
 	ID              string    `xorm:"not null pk comment('tag_id') BIGINT(20) id"`
@@ -44,6 +60,10 @@ type Tag struct {
 	ParsedText      string    `xorm:"not null MEDIUMTEXT parsed_text"`
 	FollowCount     int       `xorm:"not null default 0 INT(11) follow_count"`
 	QuestionCount   int       `xorm:"not null default 0 INT(11) question_count"`
+	ArticleCount    int       `xorm:"not null default 0 INT(11) article_count"`
+	QuoteCount      int       `xorm:"not null default 0 INT(11) quote_count"`
+	AuthorCount     int       `xorm:"not null default 0 INT(11) author_count"`
+	PieceCount      int       `xorm:"not null default 0 INT(11) piece_count"`
 	Status          int       `xorm:"not null default 1 INT(11) status"`
 	Recommend       bool      `xorm:"not null default false BOOL recommend"`
 	Reserved        bool      `xorm:"not null default false BOOL reserved"`
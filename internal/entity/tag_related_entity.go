@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// TagRelated the co-occurrence statistics of two tags, it is refreshed by the scheduled task
+type TagRelated struct {
+	ID           int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt    time.Time `xorm:"created TIMESTAMP created_at"`
+	TagID        string    `xorm:"not null default 0 BIGINT(20) INDEX tag_id"`
+	RelatedTagID string    `xorm:"not null default 0 BIGINT(20) related_tag_id"`
+	// CoCount the number of contents which are tagged with both tags
+	CoCount int `xorm:"not null default 0 INT(11) co_count"`
+	// Strength the jaccard similarity of the contents of two tags, between 0 and 1
+	Strength float64 `xorm:"not null default 0 DOUBLE strength"`
+}
+
+// TableName tag related table name
+func (TagRelated) TableName() string {
+	return "tag_related"
+}
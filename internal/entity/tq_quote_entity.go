@@ -56,7 +56,7 @@ type Quote struct {
 	QuoteAuthorId string `json:"quote_author_id" xorm:"quote_author_id"` // 发布者ID
 	QuotePieceId  string `json:"quote_piece_id" xorm:"quote_piece_id"`   // 作品（来源出处)
 
-	Title string `json:"title" xorm:"title"`
+	Title string `json:"title" xorm:"VARCHAR(255) INDEX title"`
 
 	OriginalText    string    `xorm:"not null MEDIUMTEXT original_text"`
 	ParsedText      string    `xorm:"not null MEDIUMTEXT parsed_text"`
@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	UserDataExportStatusPending    = 1
+	UserDataExportStatusProcessing = 2
+	UserDataExportStatusCompleted  = 3
+	UserDataExportStatusFailed     = 4
+	UserDataExportStatusExpired    = 5
+)
+
+// UserDataExportStatusMapping user data export status mapping
+var UserDataExportStatusMapping = map[int]string{
+	UserDataExportStatusPending:    "pending",
+	UserDataExportStatusProcessing: "processing",
+	UserDataExportStatusCompleted:  "completed",
+	UserDataExportStatusFailed:     "failed",
+	UserDataExportStatusExpired:    "expired",
+}
+
+// UserDataExport the personal data export job of user
+type UserDataExport struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	Status    int       `xorm:"not null default 1 INT(11) INDEX status"`
+	// Progress the percentage of the export job, from 0 to 100
+	Progress int `xorm:"not null default 0 INT(11) progress"`
+	// FileName the name of the archive file in the export directory
+	FileName string `xorm:"not null default '' VARCHAR(255) file_name"`
+	FileSize int64  `xorm:"not null default 0 BIGINT(20) file_size"`
+	// SignKey the random key used to sign the download link
+	SignKey   string    `xorm:"not null default '' VARCHAR(64) sign_key"`
+	ExpiredAt time.Time `xorm:"TIMESTAMP expired_at"`
+	ErrMsg    string    `xorm:"not null default '' VARCHAR(255) err_msg"`
+}
+
+// TableName user data export table name
+func (UserDataExport) TableName() string {
+	return "user_data_export"
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	UserLoginLogStatusSuccess = 1
+	UserLoginLogStatusFailed  = 2
+)
+
+const (
+	// UserLoginLogReasonWrongPassword the password is wrong
+	UserLoginLogReasonWrongPassword = "wrong_password"
+	// UserLoginLogReasonUserNotFound no available user with the email
+	UserLoginLogReasonUserNotFound = "user_not_found"
+	// UserLoginLogReasonAccountLocked the account is locked after too many failed attempts
+	UserLoginLogReasonAccountLocked = "account_locked"
+	// UserLoginLogReasonIPLocked the ip is locked after too many failed attempts
+	UserLoginLogReasonIPLocked = "ip_locked"
+	// UserLoginLogReasonTooFrequent the attempt is rejected by the exponential backoff
+	UserLoginLogReasonTooFrequent = "too_frequent"
+)
+
+// UserLoginLockout the failed password login state of user
+type UserLoginLockout struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) UNIQUE user_id"`
+	// FailedCount the consecutive failed attempts since the last successful login or lockout
+	FailedCount int `xorm:"not null default 0 INT(11) failed_count"`
+	// LockoutCount the lockouts since the last successful login, the lockout duration doubles each time
+	LockoutCount int       `xorm:"not null default 0 INT(11) lockout_count"`
+	LastFailedAt time.Time `xorm:"TIMESTAMP last_failed_at"`
+	LastFailedIP string    `xorm:"not null default '' VARCHAR(64) last_failed_ip"`
+	LockedUntil  time.Time `xorm:"TIMESTAMP INDEX locked_until"`
+}
+
+// TableName user login lockout table name
+func (UserLoginLockout) TableName() string {
+	return "user_login_lockout"
+}
+
+// IsLocked whether the account is locked at the time
+func (u *UserLoginLockout) IsLocked(now time.Time) bool {
+	return u.LockedUntil.After(now)
+}
+
+// UserLoginLog the audit log of password login
+type UserLoginLog struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP INDEX created_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	Email     string    `xorm:"not null default '' VARCHAR(100) email"`
+	IP        string    `xorm:"not null default '' VARCHAR(64) INDEX ip"`
+	UserAgent string    `xorm:"not null default '' VARCHAR(255) user_agent"`
+	Status    int       `xorm:"not null default 1 INT(11) status"`
+	// Reason why the login is failed, empty if success
+	Reason string `xorm:"not null default '' VARCHAR(32) reason"`
+}
+
+// TableName user login log table name
+func (UserLoginLog) TableName() string {
+	return "user_login_log"
+}
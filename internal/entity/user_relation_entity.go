@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	// UserRelationTypeBlock the target user can not interact with the user
+	UserRelationTypeBlock = 1
+	// UserRelationTypeMute the content of the target user is collapsed for the user
+	UserRelationTypeMute = 2
+)
+
+// UserRelationTypeMapping user relation type mapping
+var UserRelationTypeMapping = map[string]int{
+	"block": UserRelationTypeBlock,
+	"mute":  UserRelationTypeMute,
+}
+
+// UserRelation the block or mute relation from user to target user
+type UserRelation struct {
+	ID           string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt    time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UserID       string    `xorm:"not null default 0 BIGINT(20) UNIQUE(user_target_type) user_id"`
+	TargetUserID string    `xorm:"not null default 0 BIGINT(20) UNIQUE(user_target_type) INDEX target_user_id"`
+	RelationType int       `xorm:"not null default 0 INT(11) UNIQUE(user_target_type) relation_type"`
+}
+
+// TableName user relation table name
+func (UserRelation) TableName() string {
+	return "user_relation"
+}
@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	// UserTwoFactorStatusPending the secret is generated but not confirmed by a code yet
+	UserTwoFactorStatusPending = 1
+	// UserTwoFactorStatusEnabled the second factor is required when login
+	UserTwoFactorStatusEnabled = 2
+)
+
+// UserTwoFactor the totp two-factor authentication of user
+type UserTwoFactor struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) UNIQUE user_id"`
+	// Secret base32 encoded totp secret
+	Secret string `xorm:"not null default '' VARCHAR(64) secret"`
+	Status int    `xorm:"not null default 1 INT(11) status"`
+	// RecoveryCodes sha256 hashes of the unused recovery codes split by ','
+	RecoveryCodes string `xorm:"TEXT recovery_codes"`
+	// LastUsedStep the time step of the last accepted code, a code can not be used twice
+	LastUsedStep int64 `xorm:"not null default 0 BIGINT(20) last_used_step"`
+}
+
+// TableName user two factor table name
+func (UserTwoFactor) TableName() string {
+	return "user_two_factor"
+}
@@ -184,11 +184,16 @@ func (m *Mentor) initSiteInfoGeneralData() {
 }
 
 func (m *Mentor) initSiteInfoLoginConfig() {
-	loginConfig := map[string]bool{
-		"allow_new_registrations":   true,
-		"allow_email_registrations": true,
-		"allow_password_login":      true,
-		"login_required":            m.userData.LoginRequired,
+	loginConfig := map[string]interface{}{
+		"allow_new_registrations":    true,
+		"allow_email_registrations":  true,
+		"allow_password_login":       true,
+		"login_required":             m.userData.LoginRequired,
+		"login_backoff_threshold":    schema.DefaultLoginBackoffThreshold,
+		"login_backoff_base_seconds": schema.DefaultLoginBackoffBaseSeconds,
+		"login_lockout_threshold":    schema.DefaultLoginLockoutThreshold,
+		"login_ip_lockout_threshold": schema.DefaultLoginIPLockoutThreshold,
+		"login_lockout_minutes":      schema.DefaultLoginLockoutMinutes,
 	}
 	loginConfigDataBytes, _ := json.Marshal(loginConfig)
 	_, m.err = m.engine.Context(m.ctx).Insert(&entity.SiteInfo{
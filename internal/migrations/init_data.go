@@ -72,6 +72,17 @@ var (
 		&entity.Badge{},
 		&entity.BadgeGroup{},
 		&entity.BadgeAward{},
+		&entity.SavedSearch{},
+		&entity.TagRelated{},
+		&entity.PersonalAccessToken{},
+		&entity.UserTwoFactor{},
+		&entity.UserDataExport{},
+		&entity.UserRelation{},
+		&entity.UserLoginLockout{},
+		&entity.UserLoginLog{},
+		&entity.ContentFilterRule{},
+		&entity.ContentFilterLog{},
+		&entity.AuditLog{},
 	}
 
 	roles = []*entity.Role{
@@ -346,6 +357,18 @@ var (
 		{ID: 128, Key: "rank.answer.undeleted", Value: `-1`},
 		{ID: 129, Key: "rank.question.undeleted", Value: `-1`},
 		{ID: 130, Key: "rank.tag.undeleted", Value: `-1`},
+		{ID: 131, Key: "reason.plagiarism", Value: `{"name":"plagiarism","description":"This post copies someone else's work without proper attribution."}`},
+		{ID: 132, Key: "reason.misattributed", Value: `{"name":"misattributed","description":"This quote is credited to the wrong author or source."}`},
+		{ID: 133, Key: "reason.inaccurate_info", Value: `{"name":"inaccurate information","description":"The information in this entry is wrong or misleading."}`},
+		{ID: 134, Key: "reason.duplicate_entry", Value: `{"name":"duplicate entry","description":"The same entry already exists on this site."}`},
+		{ID: 135, Key: "article.flag.reasons", Value: `["reason.spam","reason.rude_or_abusive","reason.plagiarism","reason.something"]`},
+		{ID: 136, Key: "tq_quote.flag.reasons", Value: `["reason.spam","reason.rude_or_abusive","reason.misattributed","reason.duplicate_entry","reason.something"]`},
+		{ID: 137, Key: "tq_quote_author.flag.reasons", Value: `["reason.spam","reason.inaccurate_info","reason.duplicate_entry","reason.something"]`},
+		{ID: 138, Key: "tq_quote_piece.flag.reasons", Value: `["reason.spam","reason.plagiarism","reason.inaccurate_info","reason.duplicate_entry","reason.something"]`},
+		{ID: 139, Key: "article.close.reasons", Value: `["reason.plagiarism","reason.community_specific","reason.something"]`},
+		{ID: 140, Key: "tq_quote.close.reasons", Value: `["reason.misattributed","reason.duplicate_entry","reason.community_specific","reason.something"]`},
+		{ID: 141, Key: "tq_quote_author.close.reasons", Value: `["reason.inaccurate_info","reason.duplicate_entry","reason.something"]`},
+		{ID: 142, Key: "tq_quote_piece.close.reasons", Value: `["reason.inaccurate_info","reason.duplicate_entry","reason.something"]`},
 	}
 
 	defaultBadgeGroupTable = []*entity.BadgeGroup{
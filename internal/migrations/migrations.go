@@ -98,6 +98,22 @@ var migrations = []Migration{
 	NewMigration("v1.3.0", "add review", addReview, false),
 	NewMigration("v1.3.6", "add hot score to question table", addQuestionHotScore, true),
 	NewMigration("v1.4.0", "add badge/badge_group/badge_award table", addBadges, true),
+	NewMigration("v1.4.1", "add title index for search suggestion", addSearchSuggestIndex, false),
+	NewMigration("v1.4.2", "add saved search table", addSavedSearch, false),
+	NewMigration("v1.4.2", "add tag content type count", addTagContentCount, false),
+	NewMigration("v1.4.2", "add tag related table", addTagRelated, false),
+	NewMigration("v1.4.2", "add personal access token table", addPersonalAccessToken, false),
+	NewMigration("v1.4.2", "add user two factor table", addUserTwoFactor, false),
+	NewMigration("v1.4.2", "add user data export table", addUserDataExport, false),
+	NewMigration("v1.4.2", "add user relation table", addUserRelation, false),
+	NewMigration("v1.4.2", "add user login security table", addUserLoginSecurity, false),
+	NewMigration("v1.4.2", "re-sanitize the parsed text of html content", resanitizeParsedText, false),
+	NewMigration("v1.4.2", "add content filter table", addContentFilter, false),
+	NewMigration("v1.4.2", "add article and quote report reasons", addArticleAndQuoteReportReasons, true),
+	NewMigration("v1.4.2", "add submitted content and reject reason to review", addReviewSubmittedContent, false),
+	NewMigration("v1.4.2", "add audit log table", addAuditLog, false),
+	NewMigration("v1.4.2", "add pending edit revision and base content to review", addReviewBaseContent, false),
+	NewMigration("v1.4.2", "add article title index for search suggestion", addArticleTitleIndex, false),
 }
 
 func GetMigrations() []Migration {
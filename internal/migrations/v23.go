@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"xorm.io/xorm"
+)
+
+type questionTitleIndexV23 struct {
+	Title string `xorm:"not null default '' VARCHAR(150) INDEX title"`
+}
+
+func (questionTitleIndexV23) TableName() string {
+	return "question"
+}
+
+type quoteTitleIndexV23 struct {
+	Title string `xorm:"not null default '' VARCHAR(256) INDEX title"`
+}
+
+func (quoteTitleIndexV23) TableName() string {
+	return "tq_quote"
+}
+
+// addSearchSuggestIndex add title index for search suggestion prefix matching.
+func addSearchSuggestIndex(ctx context.Context, x *xorm.Engine) error {
+	return addTitleIndexes(ctx, x, new(questionTitleIndexV23), new(quoteTitleIndexV23))
+}
+
+// addTitleIndexes add the title index to the tables of the beans if the table exists and has no such index.
+// The sync is not used here, because it will drop the indexes which are not defined in the struct.
+func addTitleIndexes(ctx context.Context, x *xorm.Engine, beans ...interface{}) error {
+	for _, bean := range beans {
+		table, err := x.TableInfo(bean)
+		if err != nil {
+			return err
+		}
+		exist, err := x.Dialect().IsTableExist(x.DB(), ctx, table.Name)
+		if err != nil {
+			return fmt.Errorf("check table %s exist failed: %w", table.Name, err)
+		}
+		if !exist {
+			continue
+		}
+		indexes, err := x.Dialect().GetIndexes(x.DB(), ctx, table.Name)
+		if err != nil {
+			return fmt.Errorf("get table %s indexes failed: %w", table.Name, err)
+		}
+		if _, ok := indexes["title"]; ok {
+			continue
+		}
+		if err = x.Context(ctx).CreateIndexes(bean); err != nil {
+			return fmt.Errorf("create table %s title index failed: %w", table.Name, err)
+		}
+	}
+	return nil
+}
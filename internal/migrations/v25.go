@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"xorm.io/xorm"
+)
+
+func addTagContentCount(ctx context.Context, x *xorm.Engine) error {
+	type Tag struct {
+		ID            string `xorm:"not null pk comment('tag_id') BIGINT(20) id"`
+		QuestionCount int    `xorm:"not null default 0 INT(11) question_count"`
+		ArticleCount  int    `xorm:"not null default 0 INT(11) article_count"`
+		QuoteCount    int    `xorm:"not null default 0 INT(11) quote_count"`
+		AuthorCount   int    `xorm:"not null default 0 INT(11) author_count"`
+		PieceCount    int    `xorm:"not null default 0 INT(11) piece_count"`
+	}
+	if err := x.Context(ctx).Sync(new(Tag)); err != nil {
+		return fmt.Errorf("sync tag table failed: %w", err)
+	}
+
+	// question_count was shared by all content types before, so recount all of them from tag_rel
+	for objectType, column := range entity.TagContentCountColumnMapping {
+		minID, maxID, err := obj.GetObjectIDRangeByObjectType(objectType)
+		if err != nil {
+			return err
+		}
+		_, err = x.Context(ctx).Exec(fmt.Sprintf("UPDATE tag SET %s = (SELECT COUNT(*) FROM tag_rel "+
+			"WHERE tag_rel.tag_id = tag.id AND tag_rel.status = ? AND tag_rel.object_id BETWEEN ? AND ?)", column),
+			entity.TagRelStatusAvailable, minID, maxID)
+		if err != nil {
+			return fmt.Errorf("recount tag %s failed: %w", column, err)
+		}
+	}
+	return nil
+}
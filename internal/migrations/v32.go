@@ -0,0 +1,117 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/microcosm-cc/bluemonday"
+	"xorm.io/xorm"
+)
+
+// resanitizeBatchSize the number of rows sanitized in each batch
+const resanitizeBatchSize = 100
+
+// htmlTagRegexp matches the html tags and comments, the text like `a < b` or `I <3 x` is not a tag
+var htmlTagRegexp = regexp.MustCompile(`<!--[\s\S]*?-->|</?[a-zA-Z][^<>]*>`)
+
+// resanitizeParsedText sanitize the existing parsed text and quote author bio which may be posted in html format
+func resanitizeParsedText(ctx context.Context, x *xorm.Engine) error {
+	htmlSanitizer := schema.NewDefaultSiteHTMLSanitizer()
+	siteInfo := &entity.SiteInfo{Type: constant.SiteTypeHTMLSanitizer}
+	exist, err := x.Context(ctx).Get(siteInfo)
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		_ = json.Unmarshal([]byte(siteInfo.Content), htmlSanitizer)
+	}
+	sanitizer := converter.NewHTMLSanitizer(htmlSanitizer.HTMLAllowlist())
+
+	tableColumns := []struct {
+		tableName, column string
+		markdown          bool
+	}{
+		{entity.Article{}.TableName(), "parsed_text", false},
+		{entity.Quote{}.TableName(), "parsed_text", false},
+		{entity.QuotePiece{}.TableName(), "parsed_text", false},
+		{entity.QuoteAuthor{}.TableName(), "bio", true},
+	}
+	for _, tc := range tableColumns {
+		if err = resanitizeTableColumn(ctx, x, sanitizer, tc.tableName, tc.column, tc.markdown); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resanitizeTableColumn sanitize the column of all rows, the values without any tag are left as they are.
+// The markdown column only has its tags sanitized, so that the rest of the markdown is not escaped.
+func resanitizeTableColumn(ctx context.Context, x *xorm.Engine, sanitizer *bluemonday.Policy,
+	tableName, column string, markdown bool) error {
+	exist, err := x.Context(ctx).IsTableExist(tableName)
+	if err != nil {
+		return fmt.Errorf("check table %s failed: %w", tableName, err)
+	}
+	if !exist {
+		return nil
+	}
+
+	var lastID int64
+	for {
+		rows, err := x.Context(ctx).Table(tableName).Cols("id", column).
+			Where("id > ?", lastID).Asc("id").Limit(resanitizeBatchSize).QueryString()
+		if err != nil {
+			return fmt.Errorf("get %s failed: %w", tableName, err)
+		}
+		for _, row := range rows {
+			lastID, _ = strconv.ParseInt(row["id"], 10, 64)
+			value := row[column]
+			if !htmlTagRegexp.MatchString(value) {
+				continue
+			}
+			var sanitized string
+			if markdown {
+				sanitized = htmlTagRegexp.ReplaceAllStringFunc(value, sanitizer.Sanitize)
+			} else {
+				sanitized = sanitizer.Sanitize(value)
+			}
+			if sanitized == value {
+				continue
+			}
+			_, err = x.Context(ctx).Table(tableName).Where("id = ?", row["id"]).
+				Update(map[string]interface{}{column: sanitized})
+			if err != nil {
+				return fmt.Errorf("update %s %s failed: %w", tableName, row["id"], err)
+			}
+		}
+		if len(rows) < resanitizeBatchSize {
+			return nil
+		}
+	}
+}
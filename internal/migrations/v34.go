@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
+)
+
+func addArticleAndQuoteReportReasons(ctx context.Context, x *xorm.Engine) error {
+	defaultConfigTable := []*entity.Config{
+		{ID: 131, Key: "reason.plagiarism", Value: `{"name":"plagiarism","description":"This post copies someone else's work without proper attribution."}`},
+		{ID: 132, Key: "reason.misattributed", Value: `{"name":"misattributed","description":"This quote is credited to the wrong author or source."}`},
+		{ID: 133, Key: "reason.inaccurate_info", Value: `{"name":"inaccurate information","description":"The information in this entry is wrong or misleading."}`},
+		{ID: 134, Key: "reason.duplicate_entry", Value: `{"name":"duplicate entry","description":"The same entry already exists on this site."}`},
+		{ID: 135, Key: "article.flag.reasons", Value: `["reason.spam","reason.rude_or_abusive","reason.plagiarism","reason.something"]`},
+		{ID: 136, Key: "tq_quote.flag.reasons", Value: `["reason.spam","reason.rude_or_abusive","reason.misattributed","reason.duplicate_entry","reason.something"]`},
+		{ID: 137, Key: "tq_quote_author.flag.reasons", Value: `["reason.spam","reason.inaccurate_info","reason.duplicate_entry","reason.something"]`},
+		{ID: 138, Key: "tq_quote_piece.flag.reasons", Value: `["reason.spam","reason.plagiarism","reason.inaccurate_info","reason.duplicate_entry","reason.something"]`},
+		{ID: 139, Key: "article.close.reasons", Value: `["reason.plagiarism","reason.community_specific","reason.something"]`},
+		{ID: 140, Key: "tq_quote.close.reasons", Value: `["reason.misattributed","reason.duplicate_entry","reason.community_specific","reason.something"]`},
+		{ID: 141, Key: "tq_quote_author.close.reasons", Value: `["reason.inaccurate_info","reason.duplicate_entry","reason.something"]`},
+		{ID: 142, Key: "tq_quote_piece.close.reasons", Value: `["reason.inaccurate_info","reason.duplicate_entry","reason.something"]`},
+	}
+	for _, c := range defaultConfigTable {
+		exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			if _, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID}); err != nil {
+				log.Errorf("update %+v config failed: %s", c, err)
+				return fmt.Errorf("update config failed: %w", err)
+			}
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(&entity.Config{ID: c.ID, Key: c.Key, Value: c.Value}); err != nil {
+			log.Errorf("insert %+v config failed: %s", c, err)
+			return fmt.Errorf("add config failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+
+	"xorm.io/xorm"
+)
+
+type articleTitleIndexV38 struct {
+	Title string `xorm:"VARCHAR(255) INDEX title"`
+}
+
+func (articleTitleIndexV38) TableName() string {
+	return "ta_article"
+}
+
+// addArticleTitleIndex add title index for article search suggestion, v23 only covers question and quote.
+func addArticleTitleIndex(ctx context.Context, x *xorm.Engine) error {
+	return addTitleIndexes(ctx, x, new(articleTitleIndexV38))
+}
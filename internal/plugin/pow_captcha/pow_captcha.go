@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package pow_captcha is the built-in captcha plugin that asks the client to solve a proof-of-work challenge.
+// It works without any third-party service, and the challenge gets harder when the action is more suspicious.
+package pow_captcha
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/pkg/pow"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	slugName = "pow_captcha"
+
+	defaultDifficulty    = 16
+	defaultMaxDifficulty = 22
+	defaultExpiration    = 300
+	// difficultyPerLevel the zero bits added for each level of the anti-abuse signal, each bit doubles the work
+	difficultyPerLevel = 2
+)
+
+// Captcha proof-of-work captcha plugin
+type Captcha struct {
+	Config *CaptchaConfig
+}
+
+// CaptchaConfig proof-of-work captcha config
+type CaptchaConfig struct {
+	// Difficulty the leading zero bits of the normal challenge
+	Difficulty int `json:"difficulty"`
+	// MaxDifficulty the difficulty never exceeds it however suspicious the action is
+	MaxDifficulty int `json:"max_difficulty"`
+	// Expiration the seconds the challenge can be solved in
+	Expiration int `json:"expiration"`
+}
+
+// challengeResp the captcha sent to the client
+type challengeResp struct {
+	Algorithm  string `json:"algorithm"`
+	Challenge  string `json:"challenge"`
+	Difficulty int    `json:"difficulty"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
+
+func init() {
+	plugin.Register(&Captcha{
+		Config: &CaptchaConfig{
+			Difficulty:    defaultDifficulty,
+			MaxDifficulty: defaultMaxDifficulty,
+			Expiration:    defaultExpiration,
+		},
+	})
+}
+
+func (c *Captcha) Info() plugin.Info {
+	return plugin.Info{
+		Name:        plugin.MakeTranslator("backend.pow_captcha.name"),
+		SlugName:    slugName,
+		Description: plugin.MakeTranslator("backend.pow_captcha.description"),
+		Author:      "answerdev",
+		Version:     "1.0.0",
+		Link:        "https://github.com/apache/incubator-answer",
+	}
+}
+
+// GetConfig tells the frontend to solve the challenge instead of displaying an image
+func (c *Captcha) GetConfig() (configJsonStr string) {
+	config, _ := json.Marshal(map[string]string{"type": "proof_of_work", "algorithm": "SHA-256"})
+	return string(config)
+}
+
+// Create create the challenge with the normal difficulty
+func (c *Captcha) Create() (captcha, code string) {
+	return c.CreateWithLevel(0)
+}
+
+// CreateWithLevel create the challenge, the difficulty grows with the level of the anti-abuse signal.
+// The code is the challenge that is kept by the CaptchaRepo until it is verified,
+// so the client can not pick an easier one and every instance can verify it.
+func (c *Captcha) CreateWithLevel(level int) (captcha, code string) {
+	difficulty := c.Config.Difficulty + level*difficultyPerLevel
+	if difficulty > c.Config.MaxDifficulty {
+		difficulty = c.Config.MaxDifficulty
+	}
+	challenge := pow.NewChallenge(difficulty, time.Duration(c.Config.Expiration)*time.Second)
+	content, _ := json.Marshal(&challengeResp{
+		Algorithm:  "SHA-256",
+		Challenge:  challenge.Payload(),
+		Difficulty: challenge.Difficulty,
+		ExpiresAt:  challenge.ExpiresAt,
+	})
+	return string(content), challenge.Encode()
+}
+
+// Verify the user input is the nonce that solves the challenge
+func (c *Captcha) Verify(captchaCode, userInput string) (pass bool) {
+	challenge, err := pow.Decode(captchaCode)
+	if err != nil {
+		log.Debugf("decode proof-of-work challenge failed: %v", err)
+		return false
+	}
+	return challenge.Verify(userInput, time.Now())
+}
+
+func (c *Captcha) ConfigFields() []plugin.ConfigField {
+	return []plugin.ConfigField{
+		{
+			Name:        "difficulty",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.pow_captcha.config.difficulty.title"),
+			Description: plugin.MakeTranslator("backend.pow_captcha.config.difficulty.description"),
+			Required:    true,
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypeNumber},
+			Value:       c.Config.Difficulty,
+		},
+		{
+			Name:        "max_difficulty",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.pow_captcha.config.max_difficulty.title"),
+			Description: plugin.MakeTranslator("backend.pow_captcha.config.max_difficulty.description"),
+			Required:    true,
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypeNumber},
+			Value:       c.Config.MaxDifficulty,
+		},
+		{
+			Name:        "expiration",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.pow_captcha.config.expiration.title"),
+			Description: plugin.MakeTranslator("backend.pow_captcha.config.expiration.description"),
+			Required:    true,
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypeNumber},
+			Value:       c.Config.Expiration,
+		},
+	}
+}
+
+func (c *Captcha) ConfigReceiver(config []byte) error {
+	// the number input may be submitted as string
+	raw := struct {
+		Difficulty    json.Number `json:"difficulty"`
+		MaxDifficulty json.Number `json:"max_difficulty"`
+		Expiration    json.Number `json:"expiration"`
+	}{}
+	if err := json.Unmarshal(config, &raw); err != nil {
+		return err
+	}
+	conf := &CaptchaConfig{
+		Difficulty:    numberOrDefault(raw.Difficulty, defaultDifficulty),
+		MaxDifficulty: numberOrDefault(raw.MaxDifficulty, defaultMaxDifficulty),
+		Expiration:    numberOrDefault(raw.Expiration, defaultExpiration),
+	}
+	if conf.MaxDifficulty > pow.MaxDifficulty {
+		conf.MaxDifficulty = pow.MaxDifficulty
+	}
+	if conf.Difficulty > conf.MaxDifficulty {
+		conf.Difficulty = conf.MaxDifficulty
+	}
+	c.Config = conf
+	return nil
+}
+
+func numberOrDefault(n json.Number, defaultValue int) int {
+	v, err := n.Int64()
+	if err != nil || v <= 0 {
+		return defaultValue
+	}
+	return int(v)
+}
@@ -356,9 +356,9 @@ func (qr *articleRepo) GetArticlePage(ctx context.Context, page, pageSize int,
 	}
 	session.In("article.status", status)
 	if len(tagIDs) > 0 {
-		session.Join("LEFT", "tag_rel", "article.id = tag_rel.object_id")
-		session.In("tag_rel.tag_id", tagIDs)
-		session.And("tag_rel.status = ?", entity.TagRelStatusAvailable)
+		// use sub query to avoid duplicate rows when the article has more than one of the tags
+		session.In("article.id", builder.Select("object_id").From("tag_rel").
+			Where(builder.In("tag_id", tagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable})))
 	}
 	if len(userID) > 0 {
 		session.And("article.user_id = ?", userID)
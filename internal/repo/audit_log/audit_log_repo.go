@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package audit_log
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// auditLogRepo audit log repository
+type auditLogRepo struct {
+	data *data.Data
+}
+
+// NewAuditLogRepo new repository
+func NewAuditLogRepo(data *data.Data) audit_log.AuditLogRepo {
+	return &auditLogRepo{
+		data: data,
+	}
+}
+
+// AddAuditLog add audit log, the audit log is append-only and never be updated
+func (ar *auditLogRepo) AddAuditLog(ctx context.Context, auditLog *entity.AuditLog) (err error) {
+	_, err = ar.data.DB.Context(ctx).Insert(auditLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAuditLogPage get the audit log filtered by the non-empty fields of cond and the time range,
+// the zero time means no limit
+func (ar *auditLogRepo) GetAuditLogPage(ctx context.Context, page, pageSize int, cond *entity.AuditLog,
+	startTime, endTime time.Time) (auditLogList []*entity.AuditLog, total int64, err error) {
+	auditLogList = make([]*entity.AuditLog, 0)
+	session := ar.data.DB.Context(ctx).Desc("id")
+	if !startTime.IsZero() {
+		session.Where(builder.Gte{"created_at": startTime})
+	}
+	if !endTime.IsZero() {
+		session.Where(builder.Lt{"created_at": endTime})
+	}
+	total, err = pager.Help(page, pageSize, &auditLogList, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAuditLogListBeforeID get at most limit audit log whose id is less than beforeID, the latest ones come first.
+// The beforeID 0 means no limit. It is used to read all the filtered audit log batch by batch.
+func (ar *auditLogRepo) GetAuditLogListBeforeID(ctx context.Context, cond *entity.AuditLog,
+	startTime, endTime time.Time, beforeID int64, limit int) (auditLogList []*entity.AuditLog, err error) {
+	auditLogList = make([]*entity.AuditLog, 0)
+	session := ar.data.DB.Context(ctx).Desc("id").Limit(limit)
+	if beforeID > 0 {
+		session.Where(builder.Lt{"id": beforeID})
+	}
+	if !startTime.IsZero() {
+		session.Where(builder.Gte{"created_at": startTime})
+	}
+	if !endTime.IsZero() {
+		session.Where(builder.Lt{"created_at": endTime})
+	}
+	err = session.Find(&auditLogList, cond)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveAuditLogBefore remove the audit log created before the time, it is only used by the retention policy
+func (ar *auditLogRepo) RemoveAuditLogBefore(ctx context.Context, before time.Time) (affected int64, err error) {
+	affected, err = ar.data.DB.Context(ctx).Where(builder.Lt{"created_at": before}).Delete(&entity.AuditLog{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
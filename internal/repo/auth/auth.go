@@ -102,6 +102,9 @@ func (ar *authRepo) RemoveUserCacheInfo(ctx context.Context, accessToken string)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	if err := ar.data.Cache.Del(ctx, constant.UserSessionCacheKey+accessToken); err != nil {
+		log.Error(err)
+	}
 	return nil
 }
 
@@ -228,6 +231,9 @@ func (ar *authRepo) RemoveUserTokens(ctx context.Context, userID string, remainT
 		} else {
 			log.Debugf("del user %s token success")
 		}
+		if err := ar.RemoveAdminUserCacheInfo(ctx, token); err != nil {
+			log.Error(err)
+		}
 	}
 	if err := ar.RemoveUserStatus(ctx, userID); err != nil {
 		log.Error(err)
@@ -236,3 +242,82 @@ func (ar *authRepo) RemoveUserTokens(ctx context.Context, userID string, remainT
 		log.Error(err)
 	}
 }
+
+// GetUserTokens get all access tokens of the user, including the expired ones
+func (ar *authRepo) GetUserTokens(ctx context.Context, userID string) (tokens []string, err error) {
+	resp, _, err := ar.data.Cache.GetString(ctx, constant.UserTokenMappingCacheKey+userID)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	mapping := make(map[string]bool, 0)
+	if len(resp) > 0 {
+		_ = json.Unmarshal([]byte(resp), &mapping)
+	}
+	for token := range mapping {
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// RemoveUserToken log out one access token of the user
+func (ar *authRepo) RemoveUserToken(ctx context.Context, userID, accessToken string) (err error) {
+	userInfo, err := ar.GetUserCacheInfo(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	if userInfo != nil && len(userInfo.VisitToken) > 0 {
+		if err := ar.RemoveUserVisitCacheInfo(ctx, userInfo.VisitToken); err != nil {
+			log.Error(err)
+		}
+	}
+	if err = ar.RemoveUserCacheInfo(ctx, accessToken); err != nil {
+		return err
+	}
+	if err = ar.RemoveAdminUserCacheInfo(ctx, accessToken); err != nil {
+		return err
+	}
+
+	key := constant.UserTokenMappingCacheKey + userID
+	resp, _, err := ar.data.Cache.GetString(ctx, key)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	mapping := make(map[string]bool, 0)
+	if len(resp) > 0 {
+		_ = json.Unmarshal([]byte(resp), &mapping)
+	}
+	delete(mapping, accessToken)
+	content, _ := json.Marshal(mapping)
+	err = ar.data.Cache.SetString(ctx, key, string(content), constant.UserTokenCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetUserSessionInfo get the session metadata of the access token
+func (ar *authRepo) GetUserSessionInfo(ctx context.Context, accessToken string) (
+	sessionInfo *entity.UserSessionInfo, err error) {
+	sessionCache, exist, err := ar.data.Cache.GetString(ctx, constant.UserSessionCacheKey+accessToken)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, nil
+	}
+	sessionInfo = &entity.UserSessionInfo{}
+	_ = json.Unmarshal([]byte(sessionCache), sessionInfo)
+	return sessionInfo, nil
+}
+
+// SetUserSessionInfo set the session metadata of the access token
+func (ar *authRepo) SetUserSessionInfo(ctx context.Context, accessToken string,
+	sessionInfo *entity.UserSessionInfo) (err error) {
+	sessionCache, _ := json.Marshal(sessionInfo)
+	err = ar.data.Cache.SetString(ctx, constant.UserSessionCacheKey+accessToken,
+		string(sessionCache), constant.UserTokenCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
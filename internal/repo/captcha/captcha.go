@@ -55,7 +55,8 @@ func (cr *captchaRepo) SetActionType(ctx context.Context, unit, actionType, conf
 	if err != nil {
 		return nil
 	}
-	err = cr.data.Cache.SetString(ctx, cacheKey, string(valueStr), 6*time.Minute)
+	// the record is kept for the whole day, the action policy decides when the count is reset
+	err = cr.data.Cache.SetString(ctx, cacheKey, string(valueStr), 24*time.Hour)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
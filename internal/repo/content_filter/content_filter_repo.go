@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_filter
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// contentFilterRepo content filter repository
+type contentFilterRepo struct {
+	data *data.Data
+}
+
+// NewContentFilterRepo new repository
+func NewContentFilterRepo(data *data.Data) content_filter.ContentFilterRepo {
+	return &contentFilterRepo{
+		data: data,
+	}
+}
+
+// AddContentFilterRules add content filter rules
+func (cr *contentFilterRepo) AddContentFilterRules(ctx context.Context, rules []*entity.ContentFilterRule) (err error) {
+	_, err = cr.data.DB.Context(ctx).Insert(rules)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateContentFilterRule update content filter rule
+func (cr *contentFilterRepo) UpdateContentFilterRule(ctx context.Context, rule *entity.ContentFilterRule) (err error) {
+	_, err = cr.data.DB.Context(ctx).ID(rule.ID).Cols("rule_type", "pattern", "severity").Update(rule)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveContentFilterRule remove content filter rule
+func (cr *contentFilterRepo) RemoveContentFilterRule(ctx context.Context, id string) (err error) {
+	_, err = cr.data.DB.Context(ctx).ID(id).Delete(&entity.ContentFilterRule{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetContentFilterRule get content filter rule by id
+func (cr *contentFilterRepo) GetContentFilterRule(ctx context.Context, id string) (
+	rule *entity.ContentFilterRule, exist bool, err error) {
+	rule = &entity.ContentFilterRule{}
+	exist, err = cr.data.DB.Context(ctx).ID(id).Get(rule)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetContentFilterRulePage get content filter rule page filtered by the non-empty fields of cond and the pattern query
+func (cr *contentFilterRepo) GetContentFilterRulePage(ctx context.Context, page, pageSize int,
+	cond *entity.ContentFilterRule, query string) (rules []*entity.ContentFilterRule, total int64, err error) {
+	rules = make([]*entity.ContentFilterRule, 0)
+	session := cr.data.DB.Context(ctx).Desc("id")
+	if len(query) > 0 {
+		session.Where(builder.Like{"pattern", query})
+	}
+	total, err = pager.Help(page, pageSize, &rules, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAllContentFilterRules get all content filter rules
+func (cr *contentFilterRepo) GetAllContentFilterRules(ctx context.Context) (rules []*entity.ContentFilterRule, err error) {
+	rules = make([]*entity.ContentFilterRule, 0)
+	err = cr.data.DB.Context(ctx).Asc("id").Find(&rules)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddContentFilterLogs add the log of the content matched by the filter
+func (cr *contentFilterRepo) AddContentFilterLogs(ctx context.Context, logs []*entity.ContentFilterLog) (err error) {
+	_, err = cr.data.DB.Context(ctx).Insert(logs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetContentFilterLogPage get the log page filtered by the non-empty fields of cond
+func (cr *contentFilterRepo) GetContentFilterLogPage(ctx context.Context, page, pageSize int,
+	cond *entity.ContentFilterLog) (logs []*entity.ContentFilterLog, total int64, err error) {
+	logs = make([]*entity.ContentFilterLog, 0)
+	session := cr.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &logs, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
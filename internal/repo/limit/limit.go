@@ -21,17 +21,28 @@ package limit
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/segmentfault/pacman/errors"
-	"time"
 )
 
+// bucketLockCount the number of locks shared by the token buckets
+const bucketLockCount = 64
+
 // LimitRepo auth repository
 type LimitRepo struct {
 	data *data.Data
+	// bucketLocks the token buckets and counters are read and written under the lock selected by the hash of the key,
+	// so that the concurrent requests can not take the same token or pass the same count
+	bucketLocks [bucketLockCount]sync.Mutex
 }
 
 // NewRateLimitRepo new repository
@@ -62,3 +73,147 @@ func (lr *LimitRepo) CheckAndRecord(ctx context.Context, key string) (limit bool
 func (lr *LimitRepo) ClearRecord(ctx context.Context, key string) error {
 	return lr.data.Cache.Del(ctx, constant.RateLimitCacheKeyPrefix+key)
 }
+
+// CheckAndIncrease check whether the count of key has reached max in the window, if not, increase the count
+func (lr *LimitRepo) CheckAndIncrease(ctx context.Context, key string, max int64, window time.Duration) (limit bool, err error) {
+	cacheKey := constant.RateLimitCacheKeyPrefix + key
+	lock := lr.getBucketLock(cacheKey)
+	lock.Lock()
+	defer lock.Unlock()
+
+	count, exist, err := lr.data.Cache.GetInt64(ctx, cacheKey)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		err = lr.data.Cache.SetInt64(ctx, cacheKey, 1, window)
+		if err != nil {
+			return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		return false, nil
+	}
+	if count >= max {
+		return true, nil
+	}
+	if _, err = lr.data.Cache.Increase(ctx, cacheKey, 1); err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return false, nil
+}
+
+// tokenBucket the state of the token bucket kept in the cache
+type tokenBucket struct {
+	Tokens float64 `json:"tokens"`
+	// UpdatedAt unix milliseconds of the last refill
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// TokenBucketResult the result of taking a token from the bucket
+type TokenBucketResult struct {
+	Allowed   bool
+	Remaining int64
+	// RetryAfter the time until the next token is available, only set when not allowed
+	RetryAfter time.Duration
+	// ResetAfter the time until the bucket is full again
+	ResetAfter time.Duration
+}
+
+// TakeToken take a token from the bucket of the key.
+// The bucket holds at most capacity tokens and is refilled by rate tokens per second.
+func (lr *LimitRepo) TakeToken(ctx context.Context, key string, capacity int64, rate float64) (
+	result *TokenBucketResult, err error) {
+	cacheKey := constant.RateLimitBucketCacheKeyPrefix + key
+	lock := lr.getBucketLock(cacheKey)
+	lock.Lock()
+	defer lock.Unlock()
+
+	now := time.Now().UnixMilli()
+	bucket := &tokenBucket{Tokens: float64(capacity), UpdatedAt: now}
+	cacheData, exist, err := lr.data.Cache.GetString(ctx, cacheKey)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist && json.Unmarshal([]byte(cacheData), bucket) == nil {
+		elapsed := float64(now-bucket.UpdatedAt) / 1000
+		bucket.Tokens = math.Min(float64(capacity), bucket.Tokens+elapsed*rate)
+		bucket.UpdatedAt = now
+	}
+
+	result = &TokenBucketResult{}
+	if bucket.Tokens >= 1 {
+		bucket.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - bucket.Tokens) / rate)
+	}
+	result.Remaining = int64(bucket.Tokens)
+	result.ResetAfter = secondsToDuration((float64(capacity) - bucket.Tokens) / rate)
+
+	// the bucket that is full again is the same as the one never used, so it can expire
+	data, _ := json.Marshal(bucket)
+	err = lr.data.Cache.SetString(ctx, cacheKey, string(data), result.ResetAfter+time.Second)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return result, nil
+}
+
+func (lr *LimitRepo) getBucketLock(key string) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return &lr.bucketLocks[h.Sum32()%bucketLockCount]
+}
+
+// IncreaseCounter increase the counter of the key by one
+func (lr *LimitRepo) IncreaseCounter(ctx context.Context, key string) (err error) {
+	cacheKey := constant.RateLimitCounterCacheKeyPrefix + key
+	lock := lr.getBucketLock(cacheKey)
+	lock.Lock()
+	defer lock.Unlock()
+
+	_, exist, err := lr.data.Cache.GetInt64(ctx, cacheKey)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		err = lr.data.Cache.SetInt64(ctx, cacheKey, 1, constant.RateLimitCounterCacheTime)
+	} else {
+		_, err = lr.data.Cache.Increase(ctx, cacheKey, 1)
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetCounter get the counter of the key
+func (lr *LimitRepo) GetCounter(ctx context.Context, key string) (count int64, err error) {
+	count, _, err = lr.data.Cache.GetInt64(ctx, constant.RateLimitCounterCacheKeyPrefix+key)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count, nil
+}
+
+// GetUserRank get the user rank cached by the rate limit
+func (lr *LimitRepo) GetUserRank(ctx context.Context, userID string) (rank int64, exist bool, err error) {
+	rank, exist, err = lr.data.Cache.GetInt64(ctx, constant.RateLimitUserRankCacheKeyPrefix+userID)
+	if err != nil {
+		return 0, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return rank, exist, nil
+}
+
+// SetUserRank cache the user rank for a while, so the rate limit does not query the user on every request
+func (lr *LimitRepo) SetUserRank(ctx context.Context, userID string, rank int64) (err error) {
+	err = lr.data.Cache.SetInt64(ctx, constant.RateLimitUserRankCacheKeyPrefix+userID, rank,
+		constant.RateLimitUserRankCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package personal_access_token
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	pat "github.com/apache/incubator-answer/internal/service/personal_access_token"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// personalAccessTokenRepo personal access token repository
+type personalAccessTokenRepo struct {
+	data *data.Data
+}
+
+// NewPersonalAccessTokenRepo new repository
+func NewPersonalAccessTokenRepo(data *data.Data) pat.PersonalAccessTokenRepo {
+	return &personalAccessTokenRepo{
+		data: data,
+	}
+}
+
+// AddPersonalAccessToken add personal access token
+func (pr *personalAccessTokenRepo) AddPersonalAccessToken(ctx context.Context, token *entity.PersonalAccessToken) (err error) {
+	_, err = pr.data.DB.Context(ctx).Insert(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPersonalAccessToken get personal access token by id
+func (pr *personalAccessTokenRepo) GetPersonalAccessToken(ctx context.Context, id string) (
+	token *entity.PersonalAccessToken, exist bool, err error) {
+	token = &entity.PersonalAccessToken{}
+	exist, err = pr.data.DB.Context(ctx).Where(builder.Eq{"id": id}).Get(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPersonalAccessTokenByHash get personal access token by the hash of token
+func (pr *personalAccessTokenRepo) GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (
+	token *entity.PersonalAccessToken, exist bool, err error) {
+	token = &entity.PersonalAccessToken{}
+	exist, err = pr.data.DB.Context(ctx).Where(builder.Eq{"token_hash": tokenHash}).Get(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPersonalAccessTokenListByUserID get the available personal access tokens of the user
+func (pr *personalAccessTokenRepo) GetPersonalAccessTokenListByUserID(ctx context.Context, userID string) (
+	tokenList []*entity.PersonalAccessToken, err error) {
+	tokenList = make([]*entity.PersonalAccessToken, 0)
+	err = pr.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID, "status": entity.PersonalAccessTokenStatusAvailable}).
+		Desc("id").Find(&tokenList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountPersonalAccessTokenByUserID count the available personal access tokens of the user
+func (pr *personalAccessTokenRepo) CountPersonalAccessTokenByUserID(ctx context.Context, userID string) (count int64, err error) {
+	count, err = pr.data.DB.Context(ctx).
+		Where(builder.Eq{"user_id": userID, "status": entity.PersonalAccessTokenStatusAvailable}).
+		Count(&entity.PersonalAccessToken{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPersonalAccessTokenPage get personal access token page, filter by user id and status if set
+func (pr *personalAccessTokenRepo) GetPersonalAccessTokenPage(ctx context.Context, page, pageSize int,
+	cond *entity.PersonalAccessToken) (tokenList []*entity.PersonalAccessToken, total int64, err error) {
+	tokenList = make([]*entity.PersonalAccessToken, 0)
+	session := pr.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &tokenList, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateLastUsedAt update the last used time of the personal access token
+func (pr *personalAccessTokenRepo) UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) (err error) {
+	_, err = pr.data.DB.Context(ctx).Where(builder.Eq{"id": id}).Cols("last_used_at").
+		Update(&entity.PersonalAccessToken{LastUsedAt: lastUsedAt})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RevokePersonalAccessToken revoke the personal access token
+func (pr *personalAccessTokenRepo) RevokePersonalAccessToken(ctx context.Context, id string) (err error) {
+	_, err = pr.data.DB.Context(ctx).Where(builder.Eq{"id": id}).Cols("status").
+		Update(&entity.PersonalAccessToken{Status: entity.PersonalAccessTokenStatusRevoked})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/activity_common"
 	"github.com/apache/incubator-answer/internal/repo/answer"
 	"github.com/apache/incubator-answer/internal/repo/article"
+	"github.com/apache/incubator-answer/internal/repo/audit_log"
 	"github.com/apache/incubator-answer/internal/repo/auth"
 	"github.com/apache/incubator-answer/internal/repo/badge"
 	"github.com/apache/incubator-answer/internal/repo/badge_award"
@@ -33,10 +34,12 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/collection"
 	"github.com/apache/incubator-answer/internal/repo/comment"
 	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/content_filter"
 	"github.com/apache/incubator-answer/internal/repo/export"
 	"github.com/apache/incubator-answer/internal/repo/limit"
 	"github.com/apache/incubator-answer/internal/repo/meta"
 	"github.com/apache/incubator-answer/internal/repo/notification"
+	"github.com/apache/incubator-answer/internal/repo/personal_access_token"
 	"github.com/apache/incubator-answer/internal/repo/plugin_config"
 	"github.com/apache/incubator-answer/internal/repo/question"
 	"github.com/apache/incubator-answer/internal/repo/quote"
@@ -48,14 +51,19 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/review"
 	"github.com/apache/incubator-answer/internal/repo/revision"
 	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/apache/incubator-answer/internal/repo/saved_search"
 	"github.com/apache/incubator-answer/internal/repo/search_common"
 	"github.com/apache/incubator-answer/internal/repo/site_info"
 	"github.com/apache/incubator-answer/internal/repo/tag"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
 	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/repo/user_data_export"
 	"github.com/apache/incubator-answer/internal/repo/user_external_login"
+	"github.com/apache/incubator-answer/internal/repo/user_login_security"
 	"github.com/apache/incubator-answer/internal/repo/user_notification_config"
+	"github.com/apache/incubator-answer/internal/repo/user_relation"
+	"github.com/apache/incubator-answer/internal/repo/user_two_factor"
 	"github.com/google/wire"
 )
 
@@ -87,11 +95,20 @@ var ProviderSetRepo = wire.NewSet(
 	tag.NewTagRepo,
 	tag_common.NewTagCommonRepo,
 	tag.NewTagRelRepo,
+	tag.NewTagRelatedRepo,
 	collection.NewCollectionRepo,
 	collection.NewCollectionGroupRepo,
 	auth.NewAuthRepo,
 	revision.NewRevisionRepo,
 	search_common.NewSearchRepo,
+	saved_search.NewSavedSearchRepo,
+	personal_access_token.NewPersonalAccessTokenRepo,
+	user_two_factor.NewUserTwoFactorRepo,
+	user_data_export.NewUserDataExportRepo,
+	user_relation.NewUserRelationRepo,
+	user_login_security.NewUserLoginSecurityRepo,
+	audit_log.NewAuditLogRepo,
+	content_filter.NewContentFilterRepo,
 	meta.NewMetaRepo,
 	export.NewEmailRepo,
 	reason.NewReasonRepo,
@@ -355,9 +355,9 @@ func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
 	}
 	session.In("question.status", status)
 	if len(tagIDs) > 0 {
-		session.Join("LEFT", "tag_rel", "question.id = tag_rel.object_id")
-		session.In("tag_rel.tag_id", tagIDs)
-		session.And("tag_rel.status = ?", entity.TagRelStatusAvailable)
+		// use sub query to avoid duplicate rows when the question has more than one of the tags
+		session.In("question.id", builder.Select("object_id").From("tag_rel").
+			Where(builder.In("tag_id", tagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable})))
 	}
 	if len(userID) > 0 {
 		session.And("question.user_id = ?", userID)
@@ -360,9 +360,9 @@ func (qr *quoteRepo) GetQuotePage(ctx context.Context, page, pageSize int,
 	}
 	session.In("quote.status", status)
 	if len(tagIDs) > 0 {
-		session.Join("LEFT", "tag_rel", "quote.id = tag_rel.object_id")
-		session.In("tag_rel.tag_id", tagIDs)
-		session.And("tag_rel.status = ?", entity.TagRelStatusAvailable)
+		// use sub query to avoid duplicate rows when the quote has more than one of the tags
+		session.In("quote.id", builder.Select("object_id").From("tag_rel").
+			Where(builder.In("tag_id", tagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable})))
 	}
 	session.Join("LEFT", "tq_quote_author", "quote.quote_author_id = tq_quote_author.id")
 	session.Join("LEFT", "tq_quote_piece", "quote.quote_piece_id = tq_quote_piece.id")
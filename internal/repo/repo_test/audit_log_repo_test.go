@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/audit_log"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_auditLogRepo_AuditLog(t *testing.T) {
+	auditLogRepo := audit_log.NewAuditLogRepo(testDataSource)
+	assert.NoError(t, auditLogRepo.AddAuditLog(context.TODO(), &entity.AuditLog{
+		UserID:      "1",
+		Action:      entity.AuditLogActionUserUpdateStatus,
+		ObjectType:  "user",
+		ObjectID:    "950",
+		BeforeValue: `{"status":1}`,
+		AfterValue:  `{"status":9}`,
+		IP:          "127.0.0.1",
+	}))
+	assert.NoError(t, auditLogRepo.AddAuditLog(context.TODO(), &entity.AuditLog{
+		UserID:     "1",
+		Action:     entity.AuditLogActionUserUpdateRole,
+		ObjectType: "user",
+		ObjectID:   "950",
+		IP:         "127.0.0.1",
+	}))
+
+	cond := &entity.AuditLog{ObjectID: "950"}
+	auditLogList, total, err := auditLogRepo.GetAuditLogPage(context.TODO(), 1, 10, cond, time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, entity.AuditLogActionUserUpdateRole, auditLogList[0].Action)
+
+	cond = &entity.AuditLog{ObjectID: "950", Action: entity.AuditLogActionUserUpdateStatus}
+	auditLogList, total, err = auditLogRepo.GetAuditLogPage(context.TODO(), 1, 10, cond, time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, `{"status":9}`, auditLogList[0].AfterValue)
+
+	_, total, err = auditLogRepo.GetAuditLogPage(context.TODO(), 1, 10, cond, time.Now().Add(time.Hour), time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	cond = &entity.AuditLog{ObjectID: "950"}
+	auditLogList, err = auditLogRepo.GetAuditLogListBeforeID(context.TODO(), cond, time.Time{}, time.Time{}, 0, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, auditLogList, 1) {
+		assert.Equal(t, entity.AuditLogActionUserUpdateRole, auditLogList[0].Action)
+		lastID := converter.StringToInt64(auditLogList[0].ID)
+		auditLogList, err = auditLogRepo.GetAuditLogListBeforeID(context.TODO(), cond, time.Time{}, time.Time{}, lastID, 10)
+		assert.NoError(t, err)
+		if assert.Len(t, auditLogList, 1) {
+			assert.Equal(t, entity.AuditLogActionUserUpdateStatus, auditLogList[0].Action)
+		}
+	}
+
+	affected, err := auditLogRepo.RemoveAuditLogBefore(context.TODO(), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, affected, int64(2))
+	_, total, err = auditLogRepo.GetAuditLogPage(context.TODO(), 1, 10, &entity.AuditLog{ObjectID: "950"},
+		time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
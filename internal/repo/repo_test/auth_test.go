@@ -107,3 +107,54 @@ func Test_authRepo_RemoveAdminUserCacheInfo(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, userInfo)
 }
+
+func Test_authRepo_SetUserSessionInfo(t *testing.T) {
+	authRepo := auth.NewAuthRepo(testDataSource)
+
+	err := authRepo.SetUserSessionInfo(context.TODO(), accessToken, &entity.UserSessionInfo{
+		CreatedAt: 1700000000, LastSeenAt: 1700000060, IP: "127.0.0.1", UserAgent: "curl/8.0"})
+	assert.NoError(t, err)
+
+	sessionInfo, err := authRepo.GetUserSessionInfo(context.TODO(), accessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", sessionInfo.IP)
+	assert.Equal(t, int64(1700000060), sessionInfo.LastSeenAt)
+
+	err = authRepo.RemoveUserCacheInfo(context.TODO(), accessToken)
+	assert.NoError(t, err)
+	sessionInfo, err = authRepo.GetUserSessionInfo(context.TODO(), accessToken)
+	assert.NoError(t, err)
+	assert.Nil(t, sessionInfo)
+}
+
+func Test_authRepo_RemoveUserToken(t *testing.T) {
+	authRepo := auth.NewAuthRepo(testDataSource)
+	sessionUserID := "930"
+
+	err := authRepo.SetUserCacheInfo(context.TODO(), "token1", "visit1", &entity.UserCacheInfo{UserID: sessionUserID})
+	assert.NoError(t, err)
+	err = authRepo.SetUserCacheInfo(context.TODO(), "token2", "visit2", &entity.UserCacheInfo{UserID: sessionUserID})
+	assert.NoError(t, err)
+	err = authRepo.SetAdminUserCacheInfo(context.TODO(), "token1", &entity.UserCacheInfo{UserID: sessionUserID})
+	assert.NoError(t, err)
+
+	tokens, err := authRepo.GetUserTokens(context.TODO(), sessionUserID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"token1", "token2"}, tokens)
+
+	err = authRepo.RemoveUserToken(context.TODO(), sessionUserID, "token1")
+	assert.NoError(t, err)
+
+	tokens, err = authRepo.GetUserTokens(context.TODO(), sessionUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"token2"}, tokens)
+	userInfo, err := authRepo.GetUserCacheInfo(context.TODO(), "token1")
+	assert.NoError(t, err)
+	assert.Nil(t, userInfo)
+	adminInfo, err := authRepo.GetAdminUserCacheInfo(context.TODO(), "token1")
+	assert.NoError(t, err)
+	assert.Nil(t, adminInfo)
+	visitAccessToken, err := authRepo.GetUserVisitCacheInfo(context.TODO(), "visit1")
+	assert.NoError(t, err)
+	assert.Empty(t, visitAccessToken)
+}
@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/content_filter"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_contentFilterRepo_Rule(t *testing.T) {
+	contentFilterRepo := content_filter.NewContentFilterRepo(testDataSource)
+	err := contentFilterRepo.AddContentFilterRules(context.TODO(), []*entity.ContentFilterRule{
+		{RuleType: entity.ContentFilterRuleTypeWord, Pattern: "filterword", Severity: entity.ContentFilterSeverityMask, UserID: "1"},
+		{RuleType: entity.ContentFilterRuleTypeDomain, Pattern: "filter.example.com", Severity: entity.ContentFilterSeverityReject, UserID: "1"},
+	})
+	assert.NoError(t, err)
+
+	rules, total, err := contentFilterRepo.GetContentFilterRulePage(context.TODO(), 1, 10,
+		&entity.ContentFilterRule{RuleType: entity.ContentFilterRuleTypeWord}, "filterword")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	rule := rules[0]
+
+	rule.Pattern = "filter-word"
+	rule.Severity = entity.ContentFilterSeverityReview
+	assert.NoError(t, contentFilterRepo.UpdateContentFilterRule(context.TODO(), rule))
+	got, exist, err := contentFilterRepo.GetContentFilterRule(context.TODO(), rule.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "filter-word", got.Pattern)
+	assert.Equal(t, entity.ContentFilterSeverityReview, got.Severity)
+
+	allRules, err := contentFilterRepo.GetAllContentFilterRules(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, allRules, 2)
+
+	for _, r := range allRules {
+		assert.NoError(t, contentFilterRepo.RemoveContentFilterRule(context.TODO(), r.ID))
+	}
+	_, exist, err = contentFilterRepo.GetContentFilterRule(context.TODO(), rule.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_contentFilterRepo_GetContentFilterLogPage(t *testing.T) {
+	contentFilterRepo := content_filter.NewContentFilterRepo(testDataSource)
+	err := contentFilterRepo.AddContentFilterLogs(context.TODO(), []*entity.ContentFilterLog{
+		{UserID: "950", ObjectType: "question", ObjectID: "0", Field: "title", RuleID: "1",
+			RuleType: entity.ContentFilterRuleTypeWord, Pattern: "spam", Severity: entity.ContentFilterSeverityReject,
+			Matched: "spam", Excerpt: "buy spam now"},
+		{UserID: "950", ObjectType: "comment", ObjectID: "0", Field: "content", RuleID: "2",
+			RuleType: entity.ContentFilterRuleTypeWord, Pattern: "darn", Severity: entity.ContentFilterSeverityMask,
+			Matched: "darn", Excerpt: "darn it"},
+	})
+	assert.NoError(t, err)
+
+	filterLogs, total, err := contentFilterRepo.GetContentFilterLogPage(context.TODO(), 1, 10,
+		&entity.ContentFilterLog{UserID: "950"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "comment", filterLogs[0].ObjectType)
+
+	_, total, err = contentFilterRepo.GetContentFilterLogPage(context.TODO(), 1, 10,
+		&entity.ContentFilterLog{UserID: "950", Severity: entity.ContentFilterSeverityReject})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/repo/limit"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_limitRepo_TakeToken(t *testing.T) {
+	limitRepo := limit.NewRateLimitRepo(testDataSource)
+	for i := 2; i >= 0; i-- {
+		result, err := limitRepo.TakeToken(context.TODO(), "test:user:940", 3, 0.1)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, int64(i), result.Remaining)
+	}
+
+	// the bucket is empty and the next token is refilled after about 10 seconds
+	result, err := limitRepo.TakeToken(context.TODO(), "test:user:940", 3, 0.1)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, int64(0), result.Remaining)
+	assert.InDelta(t, 10, result.RetryAfter.Seconds(), 1)
+	assert.InDelta(t, 30, result.ResetAfter.Seconds(), 1)
+
+	// the bucket of other key is not affected
+	result, err = limitRepo.TakeToken(context.TODO(), "test:user:941", 3, 0.1)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func Test_limitRepo_IncreaseCounter(t *testing.T) {
+	limitRepo := limit.NewRateLimitRepo(testDataSource)
+	assert.NoError(t, limitRepo.IncreaseCounter(context.TODO(), "test:counter"))
+	assert.NoError(t, limitRepo.IncreaseCounter(context.TODO(), "test:counter"))
+	count, err := limitRepo.GetCounter(context.TODO(), "test:counter")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func Test_limitRepo_CheckAndIncreaseConcurrently(t *testing.T) {
+	limitRepo := limit.NewRateLimitRepo(testDataSource)
+	var (
+		wg     sync.WaitGroup
+		passed int64
+	)
+	start := make(chan struct{})
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			reject, err := limitRepo.CheckAndIncrease(context.TODO(), "test:suggest:942", 5, time.Minute)
+			assert.NoError(t, err)
+			if !reject {
+				atomic.AddInt64(&passed, 1)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	assert.Equal(t, int64(5), passed)
+}
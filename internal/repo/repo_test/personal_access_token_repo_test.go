@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/personal_access_token"
+	"github.com/apache/incubator-answer/pkg/encryption"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildPersonalAccessTokenEntity(userID, plainToken string) *entity.PersonalAccessToken {
+	return &entity.PersonalAccessToken{
+		UserID:      userID,
+		Name:        "deploy script",
+		TokenHash:   encryption.SHA256(plainToken),
+		TokenPrefix: plainToken[:10],
+		Scopes:      entity.PersonalAccessTokenScopeRead,
+		Status:      entity.PersonalAccessTokenStatusAvailable,
+	}
+}
+
+func Test_personalAccessTokenRepo_GetPersonalAccessTokenByHash(t *testing.T) {
+	personalAccessTokenRepo := personal_access_token.NewPersonalAccessTokenRepo(testDataSource)
+	accessToken := buildPersonalAccessTokenEntity("910", "answer_pat_test_token_1")
+	err := personalAccessTokenRepo.AddPersonalAccessToken(context.TODO(), accessToken)
+	assert.NoError(t, err)
+
+	got, exist, err := personalAccessTokenRepo.GetPersonalAccessTokenByHash(context.TODO(),
+		encryption.SHA256("answer_pat_test_token_1"))
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, accessToken.ID, got.ID)
+
+	_, exist, err = personalAccessTokenRepo.GetPersonalAccessTokenByHash(context.TODO(),
+		encryption.SHA256("answer_pat_test_token_2"))
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	lastUsedAt := time.Now()
+	err = personalAccessTokenRepo.UpdateLastUsedAt(context.TODO(), accessToken.ID, lastUsedAt)
+	assert.NoError(t, err)
+	got, _, err = personalAccessTokenRepo.GetPersonalAccessToken(context.TODO(), accessToken.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, lastUsedAt.Unix(), got.LastUsedAt.Unix())
+}
+
+func Test_personalAccessTokenRepo_RevokePersonalAccessToken(t *testing.T) {
+	personalAccessTokenRepo := personal_access_token.NewPersonalAccessTokenRepo(testDataSource)
+	accessToken := buildPersonalAccessTokenEntity("911", "answer_pat_test_token_3")
+	assert.NoError(t, personalAccessTokenRepo.AddPersonalAccessToken(context.TODO(), accessToken))
+	assert.NoError(t, personalAccessTokenRepo.AddPersonalAccessToken(context.TODO(),
+		buildPersonalAccessTokenEntity("911", "answer_pat_test_token_4")))
+
+	count, err := personalAccessTokenRepo.CountPersonalAccessTokenByUserID(context.TODO(), "911")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	err = personalAccessTokenRepo.RevokePersonalAccessToken(context.TODO(), accessToken.ID)
+	assert.NoError(t, err)
+
+	list, err := personalAccessTokenRepo.GetPersonalAccessTokenListByUserID(context.TODO(), "911")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.NotEqual(t, accessToken.ID, list[0].ID)
+
+	revokedList, total, err := personalAccessTokenRepo.GetPersonalAccessTokenPage(context.TODO(), 1, 10,
+		&entity.PersonalAccessToken{UserID: "911", Status: entity.PersonalAccessTokenStatusRevoked})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, accessToken.ID, revokedList[0].ID)
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/review"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_reviewRepo_RejectWithReason(t *testing.T) {
+	reviewRepo := review.NewReviewRepo(testDataSource)
+	r := &entity.Review{
+		UserID:           "1",
+		ObjectID:         "11100000000000001",
+		ObjectType:       constant.ObjectTypeStrMapping[constant.ArticleObjectType],
+		ReviewerUserID:   "0",
+		Status:           entity.ReviewStatusPending,
+		SubmittedTitle:   "queued article title",
+		SubmittedContent: "<p>queued article content</p>",
+	}
+	assert.NoError(t, reviewRepo.AddReview(context.TODO(), r))
+
+	err := reviewRepo.UpdateReviewStatus(context.TODO(), r.ID, "2", entity.ReviewStatusRejected, "off topic")
+	assert.NoError(t, err)
+
+	got, exist, err := reviewRepo.GetReview(context.TODO(), r.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, entity.ReviewStatusRejected, got.Status)
+	assert.Equal(t, "2", got.ReviewerUserID)
+	assert.Equal(t, "off topic", got.RejectReason)
+	assert.Equal(t, "queued article title", got.SubmittedTitle)
+	assert.Equal(t, "<p>queued article content</p>", got.SubmittedContent)
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_roleRepo_AddRole(t *testing.T) {
+	roleRepo := role.NewRoleRepo(testDataSource)
+	newRole := &entity.Role{Name: "Reviewer", Description: "Review the content"}
+	assert.NoError(t, roleRepo.AddRole(context.TODO(), newRole))
+	assert.NotZero(t, newRole.ID)
+
+	gotRole, exist, err := roleRepo.GetRoleByName(context.TODO(), "Reviewer")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, newRole.ID, gotRole.ID)
+
+	newRole.Description = "Review the questions and answers"
+	assert.NoError(t, roleRepo.UpdateRole(context.TODO(), newRole))
+	gotRole, exist, err = roleRepo.GetRole(context.TODO(), newRole.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "Review the questions and answers", gotRole.Description)
+
+	assert.NoError(t, roleRepo.RemoveRole(context.TODO(), newRole.ID))
+	_, exist, err = roleRepo.GetRole(context.TODO(), newRole.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_rolePowerRelRepo_SaveRolePowers(t *testing.T) {
+	rolePowerRelRepo := role.NewRolePowerRelRepo(testDataSource)
+	powers := []string{"question.add", "answer.add"}
+	assert.NoError(t, rolePowerRelRepo.SaveRolePowers(context.TODO(), 940, powers))
+
+	gotPowers, err := rolePowerRelRepo.GetRolePowerTypeList(context.TODO(), 940)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, powers, gotPowers)
+
+	// save again will replace the powers and refresh the cache
+	assert.NoError(t, rolePowerRelRepo.SaveRolePowers(context.TODO(), 940, []string{"comment.add"}))
+	gotPowers, err = rolePowerRelRepo.GetRolePowerTypeList(context.TODO(), 940)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"comment.add"}, gotPowers)
+
+	assert.NoError(t, rolePowerRelRepo.RemoveRolePowers(context.TODO(), 940))
+	gotPowers, err = rolePowerRelRepo.GetRolePowerTypeList(context.TODO(), 940)
+	assert.NoError(t, err)
+	assert.Empty(t, gotPowers)
+}
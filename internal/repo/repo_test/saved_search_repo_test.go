@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/saved_search"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildSavedSearchEntity(userID string, alert bool) *entity.SavedSearch {
+	return &entity.SavedSearch{
+		UserID:         userID,
+		Name:           "postgres unanswered",
+		Query:          "[postgres] is:question answers:0",
+		Alert:          alert,
+		AlertFrequency: entity.SavedSearchAlertDaily,
+		LastSeenAt:     time.Now(),
+		LastCheckedAt:  time.Now(),
+	}
+}
+
+func Test_savedSearchRepo_AddSavedSearch(t *testing.T) {
+	savedSearchRepo := saved_search.NewSavedSearchRepo(testDataSource)
+	savedSearch := buildSavedSearchEntity("900", true)
+
+	err := savedSearchRepo.AddSavedSearch(context.TODO(), savedSearch)
+	assert.NoError(t, err)
+
+	got, exist, err := savedSearchRepo.GetSavedSearch(context.TODO(), savedSearch.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, savedSearch.Query, got.Query)
+
+	count, err := savedSearchRepo.CountSavedSearchByUserID(context.TODO(), savedSearch.UserID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	err = savedSearchRepo.RemoveSavedSearch(context.TODO(), savedSearch.ID, "901")
+	assert.NoError(t, err)
+	_, exist, err = savedSearchRepo.GetSavedSearch(context.TODO(), savedSearch.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	err = savedSearchRepo.RemoveSavedSearch(context.TODO(), savedSearch.ID, savedSearch.UserID)
+	assert.NoError(t, err)
+	_, exist, err = savedSearchRepo.GetSavedSearch(context.TODO(), savedSearch.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_savedSearchRepo_GetAlertSavedSearchList(t *testing.T) {
+	savedSearchRepo := saved_search.NewSavedSearchRepo(testDataSource)
+	alertSavedSearch := buildSavedSearchEntity("902", true)
+	normalSavedSearch := buildSavedSearchEntity("902", false)
+	assert.NoError(t, savedSearchRepo.AddSavedSearch(context.TODO(), alertSavedSearch))
+	assert.NoError(t, savedSearchRepo.AddSavedSearch(context.TODO(), normalSavedSearch))
+
+	list, err := savedSearchRepo.GetAlertSavedSearchList(context.TODO(), "0", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(list))
+	assert.Equal(t, alertSavedSearch.ID, list[0].ID)
+
+	alertSavedSearch.Alert = false
+	err = savedSearchRepo.UpdateSavedSearch(context.TODO(), alertSavedSearch, []string{"alert"})
+	assert.NoError(t, err)
+	list, err = savedSearchRepo.GetAlertSavedSearchList(context.TODO(), "0", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(list))
+
+	userList, err := savedSearchRepo.GetSavedSearchListByUserID(context.TODO(), "902")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(userList))
+
+	assert.NoError(t, savedSearchRepo.RemoveSavedSearch(context.TODO(), alertSavedSearch.ID, "902"))
+	assert.NoError(t, savedSearchRepo.RemoveSavedSearch(context.TODO(), normalSavedSearch.ID, "902"))
+}
@@ -25,6 +25,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/repo/unique"
 
 	"github.com/apache/incubator-answer/internal/entity"
@@ -73,6 +74,29 @@ func Test_tagListRepo_CountTagRelByTagID(t *testing.T) {
 	assert.Equal(t, int64(1), count)
 }
 
+func Test_tagListRepo_CountTagRelByTagIDAndObjectType(t *testing.T) {
+	tagRelRepo := tag.NewTagRelRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+	err := tagRelRepo.AddTagRelList(context.TODO(), []*entity.TagRel{
+		{ObjectID: "10010000000000303", TagID: "10030000000000303", Status: entity.TagRelStatusAvailable},
+		{ObjectID: "10110000000000303", TagID: "10030000000000303", Status: entity.TagRelStatusAvailable},
+		{ObjectID: "10110000000000304", TagID: "10030000000000303", Status: entity.TagRelStatusAvailable},
+		{ObjectID: "10120000000000303", TagID: "10030000000000303", Status: entity.TagRelStatusHide},
+	})
+	assert.NoError(t, err)
+
+	count, err := tagRelRepo.CountTagRelByTagIDAndObjectType(context.TODO(), "10030000000000303", constant.QuestionObjectType)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = tagRelRepo.CountTagRelByTagIDAndObjectType(context.TODO(), "10030000000000303", constant.ArticleObjectType)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	count, err = tagRelRepo.CountTagRelByTagIDAndObjectType(context.TODO(), "10030000000000303", constant.QuoteObjectType)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
 func Test_tagListRepo_GetObjectTagRelList(t *testing.T) {
 	tagRelOnce.Do(addTagRelList)
 	tagRelRepo := tag.NewTagRelRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/tag"
+	"github.com/apache/incubator-answer/internal/repo/tag_common"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tagRelatedRepo_GetTagCooccurrenceList(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	tagCommonRepo := tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo)
+	tagRelRepo := tag.NewTagRelRepo(testDataSource, uniqueIDRepo)
+	tagRelatedRepo := tag.NewTagRelatedRepo(testDataSource)
+
+	relatedTagList := []*entity.Tag{
+		{SlugName: "related-go", DisplayName: "related-go", Status: entity.TagStatusAvailable},
+		{SlugName: "related-gin", DisplayName: "related-gin", Status: entity.TagStatusAvailable},
+	}
+	err := tagCommonRepo.AddTagList(context.TODO(), relatedTagList)
+	assert.NoError(t, err)
+	goTag, ginTag := relatedTagList[0], relatedTagList[1]
+
+	// question and article are both tagged with go and gin
+	err = tagRelRepo.AddTagRelList(context.TODO(), []*entity.TagRel{
+		{TagID: goTag.ID, ObjectID: "10010000000000901", Status: entity.TagRelStatusAvailable},
+		{TagID: ginTag.ID, ObjectID: "10010000000000901", Status: entity.TagRelStatusAvailable},
+		{TagID: goTag.ID, ObjectID: "10110000000000901", Status: entity.TagRelStatusAvailable},
+		{TagID: ginTag.ID, ObjectID: "10110000000000901", Status: entity.TagRelStatusAvailable},
+		{TagID: goTag.ID, ObjectID: "10010000000000902", Status: entity.TagRelStatusAvailable},
+	})
+	assert.NoError(t, err)
+
+	cooccurrenceList, err := tagRelatedRepo.GetTagCooccurrenceList(context.TODO(), 2)
+	assert.NoError(t, err)
+	var got *entity.TagRelated
+	for _, item := range cooccurrenceList {
+		if item.TagID == goTag.ID && item.RelatedTagID == ginTag.ID {
+			got = item
+		}
+	}
+	if assert.NotNil(t, got) {
+		assert.Equal(t, 2, got.CoCount)
+	}
+
+	countMapping, err := tagRelatedRepo.GetTagRelCountMapping(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, countMapping[goTag.ID])
+	assert.Equal(t, 2, countMapping[ginTag.ID])
+}
+
+func Test_tagRelatedRepo_ReplaceTagRelatedList(t *testing.T) {
+	tagRelatedRepo := tag.NewTagRelatedRepo(testDataSource)
+
+	err := tagRelatedRepo.ReplaceTagRelatedList(context.TODO(), []*entity.TagRelated{
+		{TagID: "10030000000000901", RelatedTagID: "10030000000000902", CoCount: 2, Strength: 0.5},
+		{TagID: "10030000000000901", RelatedTagID: "10030000000000903", CoCount: 3, Strength: 0.75},
+	})
+	assert.NoError(t, err)
+	err = tagRelatedRepo.ReplaceTagRelatedList(context.TODO(), []*entity.TagRelated{
+		{TagID: "10030000000000901", RelatedTagID: "10030000000000902", CoCount: 4, Strength: 0.8},
+	})
+	assert.NoError(t, err)
+
+	relatedList, err := tagRelatedRepo.GetRelatedTagList(context.TODO(), []string{"10030000000000901"})
+	assert.NoError(t, err)
+	if assert.Len(t, relatedList, 1) {
+		assert.Equal(t, "10030000000000902", relatedList[0].RelatedTagID)
+		assert.Equal(t, 4, relatedList[0].CoCount)
+	}
+}
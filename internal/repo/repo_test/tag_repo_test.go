@@ -26,6 +26,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/repo/tag"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
@@ -178,6 +179,22 @@ func Test_tagRepo_UpdateTagQuestionCount(t *testing.T) {
 	assert.Equal(t, 100, gotTag.QuestionCount)
 }
 
+func Test_tagRepo_UpdateTagContentCount(t *testing.T) {
+	tagOnce.Do(addTagList)
+	tagCommonRepo := tag_common.NewTagCommonRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+
+	err := tagCommonRepo.UpdateTagContentCount(context.TODO(), testTagList[0].ID, constant.ArticleObjectType, 7)
+	assert.NoError(t, err)
+	err = tagCommonRepo.UpdateTagContentCount(context.TODO(), testTagList[0].ID, constant.QuotePieceObjectType, 3)
+	assert.NoError(t, err)
+
+	gotTag, exist, err := tagCommonRepo.GetTagByID(context.TODO(), testTagList[0].ID, true)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 7, gotTag.ArticleCount)
+	assert.Equal(t, 3, gotTag.PieceCount)
+}
+
 func Test_tagRepo_UpdateTagSynonym(t *testing.T) {
 	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
 	tagRepo := tag.NewTagRepo(testDataSource, uniqueIDRepo)
@@ -197,3 +214,177 @@ func Test_tagRepo_UpdateTagSynonym(t *testing.T) {
 	assert.True(t, exist)
 	assert.Equal(t, testTagList[0].ID, fmt.Sprintf("%d", gotTag.MainTagID))
 }
+
+func Test_tagRepo_UpdateTagParent(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	tagRepo := tag.NewTagRepo(testDataSource, uniqueIDRepo)
+	tagCommonRepo := tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo)
+
+	treeTagList := []*entity.Tag{
+		{SlugName: "tree-parent", DisplayName: "tree-parent", Status: entity.TagStatusAvailable},
+		{SlugName: "tree-child-a", DisplayName: "tree-child-a", Status: entity.TagStatusAvailable},
+		{SlugName: "tree-child-b", DisplayName: "tree-child-b", Status: entity.TagStatusAvailable},
+	}
+	err := tagCommonRepo.AddTagList(context.TODO(), treeTagList)
+	assert.NoError(t, err)
+	parent, childA, childB := treeTagList[0], treeTagList[1], treeTagList[2]
+
+	err = tagRepo.UpdateTagParent(context.TODO(), childA.ID, converter.StringToInt64(parent.ID), parent.SlugName, 1)
+	assert.NoError(t, err)
+	err = tagRepo.UpdateTagParent(context.TODO(), childB.ID, converter.StringToInt64(parent.ID), parent.SlugName, 2)
+	assert.NoError(t, err)
+
+	maxSort, err := tagRepo.GetMaxTagSortByParentID(context.TODO(), parent.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), maxSort)
+
+	err = tagRepo.UpdateTagSortList(context.TODO(), []string{childB.ID, childA.ID})
+	assert.NoError(t, err)
+
+	children, err := tagRepo.GetTagListByParentIDs(context.TODO(), []string{parent.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, children, 2) {
+		assert.Equal(t, childB.ID, children[0].ID)
+		assert.Equal(t, childA.ID, children[1].ID)
+		assert.Equal(t, parent.SlugName, children[0].ParentTagSlugName)
+	}
+}
+
+func Test_tagRepo_MergeTag(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	tagRepo := tag.NewTagRepo(testDataSource, uniqueIDRepo)
+	tagCommonRepo := tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo)
+	tagRelRepo := tag.NewTagRelRepo(testDataSource, uniqueIDRepo)
+
+	mergeTagList := []*entity.Tag{
+		{SlugName: "go-lang", DisplayName: "go-lang", Status: entity.TagStatusAvailable},
+		{SlugName: "golang-main", DisplayName: "golang-main", Status: entity.TagStatusAvailable},
+	}
+	err := tagCommonRepo.AddTagList(context.TODO(), mergeTagList)
+	assert.NoError(t, err)
+	sourceTag, targetTag := mergeTagList[0], mergeTagList[1]
+
+	err = tagRelRepo.AddTagRelList(context.TODO(), []*entity.TagRel{
+		{ObjectID: "10010000000000901", TagID: sourceTag.ID, Status: entity.TagRelStatusAvailable},
+		{ObjectID: "10010000000000902", TagID: sourceTag.ID, Status: entity.TagRelStatusAvailable},
+		{ObjectID: "10010000000000901", TagID: targetTag.ID, Status: entity.TagRelStatusDeleted},
+	})
+	assert.NoError(t, err)
+
+	const followActivityType = 999
+	_, err = testDataSource.DB.Insert([]*entity.Activity{
+		{UserID: "901", ObjectID: sourceTag.ID, OriginalObjectID: sourceTag.ID, ActivityType: followActivityType},
+		{UserID: "902", ObjectID: sourceTag.ID, OriginalObjectID: sourceTag.ID, ActivityType: followActivityType},
+		{UserID: "902", ObjectID: targetTag.ID, OriginalObjectID: targetTag.ID, ActivityType: followActivityType},
+	})
+	assert.NoError(t, err)
+
+	revision := &entity.Revision{ObjectID: targetTag.ID, Title: targetTag.SlugName, Content: "{}"}
+	err = tagRepo.MergeTag(context.TODO(), sourceTag, targetTag, followActivityType, revision)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, revision.ID)
+
+	count, err := tagRelRepo.CountTagRelByTagID(context.TODO(), targetTag.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	count, err = tagRelRepo.CountTagRelByTagID(context.TODO(), sourceTag.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	gotTarget, exist, err := tagCommonRepo.GetTagByID(context.TODO(), targetTag.ID, true)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 2, gotTarget.QuestionCount)
+	assert.Equal(t, 2, gotTarget.FollowCount)
+	assert.Equal(t, revision.ID, gotTarget.RevisionID)
+
+	gotSource, exist, err := tagCommonRepo.GetTagByID(context.TODO(), sourceTag.ID, true)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, targetTag.ID, fmt.Sprintf("%d", gotSource.MainTagID))
+	assert.Equal(t, targetTag.SlugName, gotSource.MainTagSlugName)
+	assert.Equal(t, 0, gotSource.FollowCount)
+}
+
+func Test_tagRepo_MergeTagIntoGrandchild(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	tagRepo := tag.NewTagRepo(testDataSource, uniqueIDRepo)
+	tagCommonRepo := tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo)
+
+	treeTagList := []*entity.Tag{
+		{SlugName: "merge-root", DisplayName: "merge-root", Status: entity.TagStatusAvailable},
+		{SlugName: "merge-source", DisplayName: "merge-source", Status: entity.TagStatusAvailable},
+		{SlugName: "merge-child", DisplayName: "merge-child", Status: entity.TagStatusAvailable},
+		{SlugName: "merge-grandchild", DisplayName: "merge-grandchild", Status: entity.TagStatusAvailable},
+	}
+	err := tagCommonRepo.AddTagList(context.TODO(), treeTagList)
+	assert.NoError(t, err)
+	rootTag, sourceTag, childTag, grandchildTag := treeTagList[0], treeTagList[1], treeTagList[2], treeTagList[3]
+	for _, tr := range [][2]*entity.Tag{{sourceTag, rootTag}, {childTag, sourceTag}, {grandchildTag, childTag}} {
+		err = tagRepo.UpdateTagParent(context.TODO(), tr[0].ID, converter.StringToInt64(tr[1].ID), tr[1].SlugName, 1)
+		assert.NoError(t, err)
+	}
+
+	// the grandchild takes the place of the source tag, as the tag service does when it is a descendant
+	grandchildTag.ParentTagId = converter.StringToInt64(rootTag.ID)
+	grandchildTag.ParentTagSlugName = rootTag.SlugName
+	revision := &entity.Revision{ObjectID: grandchildTag.ID, Title: grandchildTag.SlugName, Content: "{}"}
+	err = tagRepo.MergeTag(context.TODO(), sourceTag, grandchildTag, 999, revision)
+	assert.NoError(t, err)
+
+	// the child is moved under the grandchild, and the grandchild is under the root without any cycle
+	children, err := tagRepo.GetTagListByParentIDs(context.TODO(), []string{rootTag.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, children, 1) {
+		assert.Equal(t, grandchildTag.ID, children[0].ID)
+	}
+	children, err = tagRepo.GetTagListByParentIDs(context.TODO(), []string{grandchildTag.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, children, 1) {
+		assert.Equal(t, childTag.ID, children[0].ID)
+		assert.Equal(t, grandchildTag.SlugName, children[0].ParentTagSlugName)
+	}
+	children, err = tagRepo.GetTagListByParentIDs(context.TODO(), []string{childTag.ID})
+	assert.NoError(t, err)
+	assert.Empty(t, children)
+}
+
+func Test_tagRepo_UpdateTagSlugName(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	tagRepo := tag.NewTagRepo(testDataSource, uniqueIDRepo)
+	tagCommonRepo := tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo)
+
+	slugTagList := []*entity.Tag{
+		{SlugName: "数据库", DisplayName: "数据库", Status: entity.TagStatusAvailable},
+		{SlugName: "db", DisplayName: "db", Status: entity.TagStatusAvailable},
+		{SlugName: "mysql", DisplayName: "mysql", Status: entity.TagStatusAvailable},
+	}
+	err := tagCommonRepo.AddTagList(context.TODO(), slugTagList)
+	assert.NoError(t, err)
+	mainTag, synonymTag, childTag := slugTagList[0], slugTagList[1], slugTagList[2]
+
+	err = tagRepo.UpdateTagSynonym(context.TODO(), []string{synonymTag.SlugName},
+		converter.StringToInt64(mainTag.ID), mainTag.SlugName)
+	assert.NoError(t, err)
+	err = tagRepo.UpdateTagParent(context.TODO(), childTag.ID, converter.StringToInt64(mainTag.ID), mainTag.SlugName, 1)
+	assert.NoError(t, err)
+
+	err = tagRepo.UpdateTagSlugName(context.TODO(), mainTag.ID, "shu-ju-ku")
+	assert.NoError(t, err)
+
+	gotTag, exist, err := tagCommonRepo.GetTagBySlugName(context.TODO(), "shu-ju-ku")
+	assert.NoError(t, err)
+	if assert.True(t, exist) {
+		assert.Equal(t, mainTag.ID, gotTag.ID)
+	}
+	gotTag, exist, err = tagCommonRepo.GetTagByID(context.TODO(), synonymTag.ID, false)
+	assert.NoError(t, err)
+	if assert.True(t, exist) {
+		assert.Equal(t, "shu-ju-ku", gotTag.MainTagSlugName)
+	}
+	gotTag, exist, err = tagCommonRepo.GetTagByID(context.TODO(), childTag.ID, false)
+	assert.NoError(t, err)
+	if assert.True(t, exist) {
+		assert.Equal(t, "shu-ju-ku", gotTag.ParentTagSlugName)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/repo/user_data_export"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userDataExportRepo_AddUserDataExportIfNoneSince(t *testing.T) {
+	userInfo := &entity.User{
+		Username:    "data-export",
+		Pass:        "data-export",
+		EMail:       "data-export@example.com",
+		Status:      entity.UserStatusAvailable,
+		DisplayName: "data-export",
+	}
+	assert.NoError(t, user.NewUserRepo(testDataSource).AddUser(context.TODO(), userInfo))
+	userDataExportRepo := user_data_export.NewUserDataExportRepo(testDataSource)
+	since := time.Now().Add(-time.Hour)
+
+	// the failed export is not counted
+	failed := &entity.UserDataExport{UserID: userInfo.ID, Status: entity.UserDataExportStatusFailed}
+	assert.NoError(t, userDataExportRepo.AddUserDataExport(context.TODO(), failed))
+
+	// only one of the concurrent requests is added
+	var (
+		wg         sync.WaitGroup
+		addedCount int32
+	)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			added, err := userDataExportRepo.AddUserDataExportIfNoneSince(context.TODO(),
+				&entity.UserDataExport{UserID: userInfo.ID, Status: entity.UserDataExportStatusPending}, since)
+			if err == nil && added {
+				atomic.AddInt32(&addedCount, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), addedCount)
+
+	latest, exist, err := userDataExportRepo.GetLatestUserDataExport(context.TODO(), userInfo.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, entity.UserDataExportStatusPending, latest.Status)
+
+	added, err := userDataExportRepo.AddUserDataExportIfNoneSince(context.TODO(),
+		&entity.UserDataExport{UserID: userInfo.ID, Status: entity.UserDataExportStatusPending}, since)
+	assert.NoError(t, err)
+	assert.False(t, added)
+}
+
+func Test_userDataExportRepo_GetExpiredUserDataExportList(t *testing.T) {
+	userDataExportRepo := user_data_export.NewUserDataExportRepo(testDataSource)
+	dataExport := &entity.UserDataExport{UserID: "931", Status: entity.UserDataExportStatusPending}
+	assert.NoError(t, userDataExportRepo.AddUserDataExport(context.TODO(), dataExport))
+
+	dataExport.Status = entity.UserDataExportStatusCompleted
+	dataExport.Progress = 100
+	dataExport.FileName = dataExport.ID + ".zip"
+	dataExport.ExpiredAt = time.Now().Add(-time.Minute)
+	err := userDataExportRepo.UpdateUserDataExport(context.TODO(), dataExport,
+		[]string{"status", "progress", "file_name", "expired_at"})
+	assert.NoError(t, err)
+
+	got, exist, err := userDataExportRepo.GetUserDataExport(context.TODO(), dataExport.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 100, got.Progress)
+	assert.Equal(t, dataExport.FileName, got.FileName)
+
+	expiredList, err := userDataExportRepo.GetExpiredUserDataExportList(context.TODO(), time.Now())
+	assert.NoError(t, err)
+	found := false
+	for _, expired := range expiredList {
+		if expired.ID == dataExport.ID {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/user_login_security"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userLoginSecurityRepo_UserLoginLockout(t *testing.T) {
+	userLoginSecurityRepo := user_login_security.NewUserLoginSecurityRepo(testDataSource)
+	lockout := &entity.UserLoginLockout{
+		UserID:       "940",
+		FailedCount:  1,
+		LastFailedAt: time.Now(),
+		LastFailedIP: "127.0.0.1",
+	}
+	assert.NoError(t, userLoginSecurityRepo.SaveUserLoginLockout(context.TODO(), lockout))
+
+	lockout.FailedCount = 0
+	lockout.LockoutCount = 1
+	lockout.LockedUntil = time.Now().Add(time.Hour)
+	assert.NoError(t, userLoginSecurityRepo.SaveUserLoginLockout(context.TODO(), lockout))
+
+	got, exist, err := userLoginSecurityRepo.GetUserLoginLockout(context.TODO(), "940")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 1, got.LockoutCount)
+	assert.True(t, got.IsLocked(time.Now()))
+
+	lockoutList, total, err := userLoginSecurityRepo.GetLockedUserLoginLockoutPage(context.TODO(), 1, 10, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "940", lockoutList[0].UserID)
+
+	assert.NoError(t, userLoginSecurityRepo.RemoveUserLoginLockout(context.TODO(), "940"))
+	_, exist, err = userLoginSecurityRepo.GetUserLoginLockout(context.TODO(), "940")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_userLoginSecurityRepo_GetUserLoginLogPage(t *testing.T) {
+	userLoginSecurityRepo := user_login_security.NewUserLoginSecurityRepo(testDataSource)
+	assert.NoError(t, userLoginSecurityRepo.AddUserLoginLog(context.TODO(), &entity.UserLoginLog{
+		UserID: "941", Email: "login@example.com", IP: "127.0.0.2", Status: entity.UserLoginLogStatusFailed,
+		Reason: entity.UserLoginLogReasonWrongPassword,
+	}))
+	assert.NoError(t, userLoginSecurityRepo.AddUserLoginLog(context.TODO(), &entity.UserLoginLog{
+		UserID: "941", Email: "login@example.com", IP: "127.0.0.2", Status: entity.UserLoginLogStatusSuccess,
+	}))
+
+	loginLogList, total, err := userLoginSecurityRepo.GetUserLoginLogPage(context.TODO(), 1, 10,
+		&entity.UserLoginLog{UserID: "941"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, entity.UserLoginLogStatusSuccess, loginLogList[0].Status)
+
+	_, total, err = userLoginSecurityRepo.GetUserLoginLogPage(context.TODO(), 1, 10,
+		&entity.UserLoginLog{UserID: "941", Status: entity.UserLoginLogStatusFailed})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
+
+func Test_userLoginSecurityRepo_IPLockout(t *testing.T) {
+	userLoginSecurityRepo := user_login_security.NewUserLoginSecurityRepo(testDataSource)
+	for i := int64(1); i <= 3; i++ {
+		count, err := userLoginSecurityRepo.IncreaseIPFailedCount(context.TODO(), "127.0.0.3", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, i, count)
+	}
+
+	lockedUntil := time.Now().Add(time.Minute)
+	assert.NoError(t, userLoginSecurityRepo.LockIP(context.TODO(), "127.0.0.3", lockedUntil))
+	got, err := userLoginSecurityRepo.GetIPLockedUntil(context.TODO(), "127.0.0.3")
+	assert.NoError(t, err)
+	assert.Equal(t, lockedUntil.Unix(), got)
+
+	// the failed count is reset after the ip is locked
+	count, err := userLoginSecurityRepo.IncreaseIPFailedCount(context.TODO(), "127.0.0.3", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func Test_userLoginSecurityRepo_EmailLoginLockout(t *testing.T) {
+	userLoginSecurityRepo := user_login_security.NewUserLoginSecurityRepo(testDataSource)
+	_, exist, err := userLoginSecurityRepo.GetEmailLoginLockout(context.TODO(), "unknown@example.com")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	lockout := &entity.UserLoginLockout{
+		LockoutCount: 1,
+		LastFailedAt: time.Now(),
+		LastFailedIP: "127.0.0.4",
+		LockedUntil:  time.Now().Add(time.Hour),
+	}
+	assert.NoError(t, userLoginSecurityRepo.SaveEmailLoginLockout(context.TODO(), "unknown@example.com", lockout))
+
+	// the email is case-insensitive
+	got, exist, err := userLoginSecurityRepo.GetEmailLoginLockout(context.TODO(), "Unknown@Example.com")
+	assert.NoError(t, err)
+	if assert.True(t, exist) {
+		assert.Equal(t, 1, got.LockoutCount)
+		assert.Equal(t, "127.0.0.4", got.LastFailedIP)
+		assert.True(t, got.IsLocked(time.Now()))
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/user_relation"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userRelationRepo_AddUserRelation(t *testing.T) {
+	userRelationRepo := user_relation.NewUserRelationRepo(testDataSource)
+	relation := &entity.UserRelation{UserID: "940", TargetUserID: "941", RelationType: entity.UserRelationTypeBlock}
+	assert.NoError(t, userRelationRepo.AddUserRelation(context.TODO(), relation))
+	// add the same relation again does nothing
+	assert.NoError(t, userRelationRepo.AddUserRelation(context.TODO(), &entity.UserRelation{
+		UserID: "940", TargetUserID: "941", RelationType: entity.UserRelationTypeBlock}))
+
+	exist, err := userRelationRepo.ExistUserRelation(context.TODO(), "940", "941", entity.UserRelationTypeBlock)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	// the relation is one-way
+	exist, err = userRelationRepo.ExistUserRelation(context.TODO(), "941", "940", entity.UserRelationTypeBlock)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	relationList, total, err := userRelationRepo.GetUserRelationPage(context.TODO(), "940",
+		entity.UserRelationTypeBlock, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, relationList, 1)
+
+	err = userRelationRepo.RemoveUserRelation(context.TODO(), "940", "941", entity.UserRelationTypeBlock)
+	assert.NoError(t, err)
+	exist, err = userRelationRepo.ExistUserRelation(context.TODO(), "940", "941", entity.UserRelationTypeBlock)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_userRelationRepo_GetTargetUserIDs(t *testing.T) {
+	userRelationRepo := user_relation.NewUserRelationRepo(testDataSource)
+	assert.NoError(t, userRelationRepo.AddUserRelation(context.TODO(), &entity.UserRelation{
+		UserID: "942", TargetUserID: "943", RelationType: entity.UserRelationTypeMute}))
+	assert.NoError(t, userRelationRepo.AddUserRelation(context.TODO(), &entity.UserRelation{
+		UserID: "942", TargetUserID: "944", RelationType: entity.UserRelationTypeBlock}))
+
+	mutedUserIDs, err := userRelationRepo.GetTargetUserIDs(context.TODO(), "942", entity.UserRelationTypeMute)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"943"}, mutedUserIDs)
+}
@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/user_two_factor"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userTwoFactorRepo_SaveUserTwoFactor(t *testing.T) {
+	userTwoFactorRepo := user_two_factor.NewUserTwoFactorRepo(testDataSource)
+	err := userTwoFactorRepo.SaveUserTwoFactor(context.TODO(), &entity.UserTwoFactor{
+		UserID: "920",
+		Secret: "JBSWY3DPEHPK3PXP",
+		Status: entity.UserTwoFactorStatusPending,
+	})
+	assert.NoError(t, err)
+
+	err = userTwoFactorRepo.SaveUserTwoFactor(context.TODO(), &entity.UserTwoFactor{
+		UserID:        "920",
+		Secret:        "KRSXG5CTMVRXEZLU",
+		Status:        entity.UserTwoFactorStatusEnabled,
+		RecoveryCodes: "hash1,hash2",
+	})
+	assert.NoError(t, err)
+
+	got, exist, err := userTwoFactorRepo.GetUserTwoFactor(context.TODO(), "920")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "KRSXG5CTMVRXEZLU", got.Secret)
+	assert.Equal(t, entity.UserTwoFactorStatusEnabled, got.Status)
+
+	err = userTwoFactorRepo.RemoveUserTwoFactor(context.TODO(), "920")
+	assert.NoError(t, err)
+	_, exist, err = userTwoFactorRepo.GetUserTwoFactor(context.TODO(), "920")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_userTwoFactorRepo_UpdateUserTwoFactorUsage(t *testing.T) {
+	userTwoFactorRepo := user_two_factor.NewUserTwoFactorRepo(testDataSource)
+	twoFactor := &entity.UserTwoFactor{
+		UserID:        "921",
+		Secret:        "JBSWY3DPEHPK3PXP",
+		Status:        entity.UserTwoFactorStatusEnabled,
+		RecoveryCodes: "hash1,hash2",
+		LastUsedStep:  100,
+	}
+	assert.NoError(t, userTwoFactorRepo.SaveUserTwoFactor(context.TODO(), twoFactor))
+
+	twoFactor.RecoveryCodes = "hash2"
+	updated, err := userTwoFactorRepo.UpdateUserTwoFactorUsage(context.TODO(), twoFactor, "hash1,hash2", 100)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+
+	// the recovery code is already used
+	updated, err = userTwoFactorRepo.UpdateUserTwoFactorUsage(context.TODO(), twoFactor, "hash1,hash2", 100)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+}
+
+func Test_userTwoFactorRepo_PendingLogin(t *testing.T) {
+	userTwoFactorRepo := user_two_factor.NewUserTwoFactorRepo(testDataSource)
+	err := userTwoFactorRepo.SetPendingLogin(context.TODO(), "token", &schema.TwoFactorPendingLogin{
+		UserID:     "922",
+		ExternalID: "external",
+	})
+	assert.NoError(t, err)
+
+	pendingLogin, err := userTwoFactorRepo.GetPendingLogin(context.TODO(), "token")
+	assert.NoError(t, err)
+	assert.Equal(t, "922", pendingLogin.UserID)
+	assert.Equal(t, "external", pendingLogin.ExternalID)
+
+	assert.NoError(t, userTwoFactorRepo.RemovePendingLogin(context.TODO(), "token"))
+	pendingLogin, err = userTwoFactorRepo.GetPendingLogin(context.TODO(), "token")
+	assert.NoError(t, err)
+	assert.Nil(t, pendingLogin)
+}
+
+func Test_userTwoFactorRepo_IncreasePendingLoginAttempts(t *testing.T) {
+	userTwoFactorRepo := user_two_factor.NewUserTwoFactorRepo(testDataSource)
+	err := userTwoFactorRepo.SetPendingLogin(context.TODO(), "attempts-token", &schema.TwoFactorPendingLogin{
+		UserID: "922",
+	})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := userTwoFactorRepo.IncreasePendingLoginAttempts(context.TODO(), "attempts-token")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	attempts, err := userTwoFactorRepo.IncreasePendingLoginAttempts(context.TODO(), "attempts-token")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), attempts)
+
+	assert.NoError(t, userTwoFactorRepo.RemovePendingLogin(context.TODO(), "attempts-token"))
+	_, err = userTwoFactorRepo.IncreasePendingLoginAttempts(context.TODO(), "attempts-token")
+	assert.Error(t, err)
+}
@@ -52,9 +52,10 @@ func (cr *reviewRepo) AddReview(ctx context.Context, review *entity.Review) (err
 }
 
 // UpdateReviewStatus update review status
-func (cr *reviewRepo) UpdateReviewStatus(ctx context.Context, reviewID int, reviewerUserID string, status int) (err error) {
+func (cr *reviewRepo) UpdateReviewStatus(ctx context.Context, reviewID int, reviewerUserID string, status int,
+	rejectReason string) (err error) {
 	_, err = cr.data.DB.Context(ctx).ID(reviewID).Update(&entity.Review{
-		ReviewerUserID: reviewerUserID, Status: status})
+		ReviewerUserID: reviewerUserID, Status: status, RejectReason: rejectReason})
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
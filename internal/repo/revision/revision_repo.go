@@ -188,6 +188,8 @@ func (rr *revisionRepo) allowRecord(objectType int) (ok bool) {
 		return true
 	case constant.ObjectTypeStrMapping["article"]:
 		return true //@cws 允许记录版本
+	case constant.ObjectTypeStrMapping[constant.QuoteObjectType]:
+		return true
 	default:
 		return false
 	}
@@ -21,12 +21,18 @@ package role
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
 	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // rolePowerRelRepo rolePowerRel repository
@@ -43,11 +49,67 @@ func NewRolePowerRelRepo(data *data.Data) role.RolePowerRelRepo {
 
 // GetRolePowerTypeList get role power type list
 func (rr *rolePowerRelRepo) GetRolePowerTypeList(ctx context.Context, roleID int) (powers []string, err error) {
+	cacheKey := fmt.Sprintf("%s%d", constant.RolePowerCacheKeyPrefix, roleID)
+	cacheData, exist, err := rr.data.Cache.GetString(ctx, cacheKey)
+	if err == nil && exist && len(cacheData) > 0 {
+		powers = make([]string, 0)
+		if err = json.Unmarshal([]byte(cacheData), &powers); err == nil {
+			return powers, nil
+		}
+	}
+
 	powers = make([]string, 0)
 	err = rr.data.DB.Context(ctx).Table("role_power_rel").
 		Cols("power_type").Where(builder.Eq{"role_id": roleID}).Find(&powers)
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	cacheVal, _ := json.Marshal(powers)
+	if err := rr.data.Cache.SetString(ctx, cacheKey, string(cacheVal), constant.RolePowerCacheTime); err != nil {
+		log.Error(err)
+	}
+	return powers, nil
+}
+
+// SaveRolePowers replace all the powers of the role
+func (rr *rolePowerRelRepo) SaveRolePowers(ctx context.Context, roleID int, powers []string) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where(builder.Eq{"role_id": roleID}).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		if len(powers) == 0 {
+			return nil, nil
+		}
+		rels := make([]*entity.RolePowerRel, 0, len(powers))
+		for _, power := range powers {
+			rels = append(rels, &entity.RolePowerRel{RoleID: roleID, PowerType: power})
+		}
+		_, err := session.Insert(rels)
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	rr.removeRolePowerCache(ctx, roleID)
+	return nil
+}
+
+// RemoveRolePowers remove all the powers of the role
+func (rr *rolePowerRelRepo) RemoveRolePowers(ctx context.Context, roleID int) (err error) {
+	_, err = rr.data.DB.Context(ctx).Where(builder.Eq{"role_id": roleID}).Delete(&entity.RolePowerRel{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	rr.removeRolePowerCache(ctx, roleID)
+	return nil
+}
+
+// removeRolePowerCache the powers of the role are loaded from database again in the next permission check
+func (rr *rolePowerRelRepo) removeRolePowerCache(ctx context.Context, roleID int) {
+	cacheKey := fmt.Sprintf("%s%d", constant.RolePowerCacheKeyPrefix, roleID)
+	if err := rr.data.Cache.Del(ctx, cacheKey); err != nil {
+		log.Error(err)
 	}
-	return
 }
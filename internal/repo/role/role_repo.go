@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	service "github.com/apache/incubator-answer/internal/service/role"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // roleRepo role repository
@@ -63,3 +64,50 @@ func (rr *roleRepo) GetRoleAllMapping(ctx context.Context) (roleMapping map[int]
 	}
 	return roleMapping, nil
 }
+
+// AddRole add role
+func (rr *roleRepo) AddRole(ctx context.Context, role *entity.Role) (err error) {
+	_, err = rr.data.DB.Context(ctx).Insert(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateRole update role name and description
+func (rr *roleRepo) UpdateRole(ctx context.Context, role *entity.Role) (err error) {
+	_, err = rr.data.DB.Context(ctx).ID(role.ID).Cols("name", "description").Update(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveRole remove role
+func (rr *roleRepo) RemoveRole(ctx context.Context, roleID int) (err error) {
+	_, err = rr.data.DB.Context(ctx).ID(roleID).Delete(&entity.Role{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRole get role by id
+func (rr *roleRepo) GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error) {
+	role = &entity.Role{}
+	exist, err = rr.data.DB.Context(ctx).ID(roleID).Get(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRoleByName get role by name
+func (rr *roleRepo) GetRoleByName(ctx context.Context, name string) (role *entity.Role, exist bool, err error) {
+	role = &entity.Role{}
+	exist, err = rr.data.DB.Context(ctx).Where(builder.Eq{"name": name}).Get(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package saved_search
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	savedsearch "github.com/apache/incubator-answer/internal/service/saved_search"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// savedSearchRepo saved search repository
+type savedSearchRepo struct {
+	data *data.Data
+}
+
+// NewSavedSearchRepo new repository
+func NewSavedSearchRepo(data *data.Data) savedsearch.SavedSearchRepo {
+	return &savedSearchRepo{
+		data: data,
+	}
+}
+
+// AddSavedSearch add saved search
+func (sr *savedSearchRepo) AddSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (err error) {
+	_, err = sr.data.DB.Context(ctx).Insert(savedSearch)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateSavedSearch update saved search
+func (sr *savedSearchRepo) UpdateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch, cols []string) (err error) {
+	_, err = sr.data.DB.Context(ctx).ID(savedSearch.ID).UseBool("alert").Cols(cols...).Update(savedSearch)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveSavedSearch remove saved search of the user
+func (sr *savedSearchRepo) RemoveSavedSearch(ctx context.Context, id, userID string) (err error) {
+	_, err = sr.data.DB.Context(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&entity.SavedSearch{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSavedSearch get saved search by id
+func (sr *savedSearchRepo) GetSavedSearch(ctx context.Context, id string) (
+	savedSearch *entity.SavedSearch, exist bool, err error) {
+	savedSearch = &entity.SavedSearch{}
+	exist, err = sr.data.DB.Context(ctx).ID(id).Get(savedSearch)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSavedSearchListByUserID get all saved searches of the user
+func (sr *savedSearchRepo) GetSavedSearchListByUserID(ctx context.Context, userID string) (
+	savedSearchList []*entity.SavedSearch, err error) {
+	savedSearchList = make([]*entity.SavedSearch, 0)
+	err = sr.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id").Find(&savedSearchList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountSavedSearchByUserID count the saved searches of the user
+func (sr *savedSearchRepo) CountSavedSearchByUserID(ctx context.Context, userID string) (count int64, err error) {
+	count, err = sr.data.DB.Context(ctx).Where("user_id = ?", userID).Count(&entity.SavedSearch{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAlertSavedSearchList get the saved searches which enable alert and the id is greater than the startID
+func (sr *savedSearchRepo) GetAlertSavedSearchList(ctx context.Context, startID string, limit int) (
+	savedSearchList []*entity.SavedSearch, err error) {
+	savedSearchList = make([]*entity.SavedSearch, 0)
+	err = sr.data.DB.Context(ctx).Where("alert = ?", true).And("id > ?", startID).
+		Asc("id").Limit(limit).Find(&savedSearchList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -21,8 +21,9 @@ package search_common
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"github.com/segmentfault/pacman/log"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -32,6 +33,7 @@ import (
 
 	"github.com/apache/incubator-answer/pkg/htmltext"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/reason"
@@ -41,6 +43,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/unique"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/encryption"
 	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
@@ -60,6 +63,7 @@ var (
 		"CASE WHEN `accepted_answer_id` > 0 THEN 2 ELSE 0 END as `accepted`",
 		"`question`.`status` as `status`",
 		"`post_update_time`",
+		"'question' as `object_type`",
 	}
 	//@cws 必须要和afield字段数一样
 	articleFields = []string{
@@ -75,6 +79,22 @@ var (
 		" 0 as accepted",
 		"`article`.`status` as `status`",
 		"`post_update_time`",
+		"'article' as `object_type`",
+	}
+
+	quoteFields = []string{
+		"`quote`.`id`",
+		"`quote`.`id` as `quote_id`",
+		"`title`",
+		"`parsed_text`",
+		"`quote`.`created_at` as `created_at`",
+		"`user_id`",
+		"`vote_count`",
+		"0 as `answer_count`",
+		"0 as `accepted`",
+		"`quote`.`status` as `status`",
+		"`post_update_time`",
+		"'tq_quote' as `object_type`",
 	}
 
 	aFields = []string{
@@ -89,9 +109,13 @@ var (
 		"`adopted` as `accepted`",
 		"`answer`.`status` as `status`",
 		"`answer`.`created_at` as `post_update_time`",
+		"'answer' as `object_type`",
 	}
 )
 
+// searchFacetLimit the maximum number of tags and authors in search facets
+const searchFacetLimit = 10
+
 // searchRepo tag repository
 type searchRepo struct {
 	data         *data.Data
@@ -116,144 +140,31 @@ func NewSearchRepo(
 }
 
 // SearchContents search question and answer data
-func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs [][]string, userID string, votes int, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
-
-	var (
-		b              *builder.Builder
-		ub             *builder.Builder
-		articleBuilder *builder.Builder
-		qfs            = qFields
-		afs            = aFields
-		articleFs      = articleFields
-		argsQ          = []interface{}{}
-		argsA          = []interface{}{}
-		argsArticle    = []interface{}{}
-	)
-
-	if order == "relevance" {
-		if len(words) > 0 {
-			qfs, argsQ = addRelevanceField([]string{"title", "original_text"}, words, qfs)
-			articleFs, argsArticle = addRelevanceField([]string{"title", "original_text"}, words, articleFs)
-
-			afs, argsA = addRelevanceField([]string{"`answer`.`original_text`"}, words, afs)
-		} else {
-			order = "newest"
-		}
-	}
-	log.Infof("articleFs: first", articleFs)
-
-	b = builder.MySQL().Select(qfs...).From("`question`")
-
-	articleBuilder = builder.MySQL().Select(articleFs...).From("`ta_article`", " article ")
-
-	ub = builder.MySQL().Select(afs...).From("`answer`").
-		LeftJoin("`question`", "`question`.id = `answer`.question_id")
-
-	b.Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
-		And(builder.Eq{"`question`.`show`": entity.QuestionShow})
-
-	articleBuilder.Where(builder.Lt{"`article`.`status`": entity.ArticleStatusDeleted}).
-		And(builder.Eq{"`article`.`show`": entity.ArticleShow})
-
-	ub.Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
-		And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).
-		And(builder.Eq{"`question`.`show`": entity.QuestionShow})
-
-	argsQ = append(argsQ, entity.QuestionStatusDeleted, entity.QuestionShow)
-	argsA = append(argsA, entity.QuestionStatusDeleted, entity.AnswerStatusDeleted, entity.QuestionShow)
-
-	argsArticle = append(argsArticle, entity.ArticleStatusDeleted, entity.ArticleShow)
-
-	likeConQ := builder.NewCond()
-	likeConA := builder.NewCond()
-	likeConArticle := builder.NewCond()
-
-	for _, word := range words {
-		likeConQ = likeConQ.Or(builder.Like{"title", word}).
-			Or(builder.Like{"original_text", word})
-		argsQ = append(argsQ, "%"+word+"%")
-		argsQ = append(argsQ, "%"+word+"%")
-
-		likeConArticle = likeConArticle.Or(builder.Like{"title", word}).
-			Or(builder.Like{"original_text", word})
-		argsArticle = append(argsArticle, "%"+word+"%")
-		argsArticle = append(argsArticle, "%"+word+"%")
-
-		likeConA = likeConA.Or(builder.Like{"`answer`.original_text", word})
-		argsA = append(argsA, "%"+word+"%")
-	}
-
-	b.Where(likeConQ)
-	ub.Where(likeConA)
-
-	articleBuilder.Where(likeConArticle)
+func (sr *searchRepo) SearchContents(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	return sr.searchContentsByType(ctx, "", cond, page, size, order)
+}
 
-	// check tag
-	for ti, tagID := range tagIDs {
-		ast := "tag_rel" + strconv.Itoa(ti)
-		b.Join("INNER", "tag_rel as "+ast, "question.id = "+ast+".object_id").
-			And(builder.Eq{
-				ast + ".status": entity.TagRelStatusAvailable,
-			}).
-			And(builder.In(ast+".tag_id", tagID))
-		ub.Join("INNER", "tag_rel as "+ast, "question_id = "+ast+".object_id").
-			And(builder.Eq{
-				ast + ".status": entity.TagRelStatusAvailable,
-			}).
-			And(builder.In(ast+".tag_id", tagID))
-		argsQ = append(argsQ, entity.TagRelStatusAvailable)
-		argsA = append(argsA, entity.TagRelStatusAvailable)
-		for _, t := range tagID {
-			argsQ = append(argsQ, t)
-			argsA = append(argsA, t)
-		}
-	}
+// SearchArticles search article data
+func (sr *searchRepo) SearchArticles(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	return sr.searchContentsByType(ctx, constant.ArticleObjectType, cond, page, size, order)
+}
 
-	// check user
-	if userID != "" {
-		b.Where(builder.Eq{"question.user_id": userID})
-		ub.Where(builder.Eq{"answer.user_id": userID})
-		articleBuilder.Where(builder.Eq{"article.user_id": userID})
-		argsQ = append(argsQ, userID)
-		argsA = append(argsA, userID)
-		argsArticle = append(argsArticle, userID)
-	}
+// SearchQuotes search quote data
+func (sr *searchRepo) SearchQuotes(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	return sr.searchContentsByType(ctx, constant.QuoteObjectType, cond, page, size, order)
+}
 
-	// check vote
-	if votes == 0 {
-		b.Where(builder.Eq{"question.vote_count": votes})
-		articleBuilder.Where(builder.Eq{"article.vote_count": votes})
-		ub.Where(builder.Eq{"answer.vote_count": votes})
-		argsQ = append(argsQ, votes)
-		argsA = append(argsA, votes)
-		argsArticle = append(argsArticle, votes)
-	} else if votes > 0 {
-		b.Where(builder.Gte{"question.vote_count": votes})
-		articleBuilder.Where(builder.Gte{"article.vote_count": votes})
-		ub.Where(builder.Gte{"answer.vote_count": votes})
-		argsQ = append(argsQ, votes)
-		argsA = append(argsA, votes)
-		argsArticle = append(argsArticle, votes)
-	}
-
-	//b = b.Union("all", ub)
-	ubSQL, _, err := ub.ToSQL()
-	if err != nil {
-		return
-	}
-	bSQL, _, err := b.ToSQL()
-	if err != nil {
-		return
+func (sr *searchRepo) searchContentsByType(ctx context.Context, objectType string, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
+	if order == "relevance" && len(words) == 0 {
+		order = "newest"
 	}
 
-	articleSQL, _, err := articleBuilder.ToSQL()
+	sql, args, err := sr.buildContentsSQL(objectType, cond, words, order == "relevance")
 	if err != nil {
 		return
 	}
 
-	sql := fmt.Sprintf("(%s UNION ALL %s UNION ALL %s)", bSQL, ubSQL, articleSQL)
-
 	countSQL, _, err := builder.MySQL().Select("count(*) total").From(sql, "c").ToSQL()
 	if err != nil {
 		return
@@ -268,14 +179,10 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	countArgs := []interface{}{}
 
 	queryArgs = append(queryArgs, querySQL)
-	queryArgs = append(queryArgs, argsQ...)
-	queryArgs = append(queryArgs, argsA...)
-	queryArgs = append(queryArgs, argsArticle...)
+	queryArgs = append(queryArgs, args...)
 
 	countArgs = append(countArgs, countSQL)
-	countArgs = append(countArgs, argsQ...)
-	countArgs = append(countArgs, argsA...)
-	countArgs = append(countArgs, argsArticle...)
+	countArgs = append(countArgs, args...)
 
 	res, err := sr.data.DB.Context(ctx).Query(queryArgs...)
 	if err != nil {
@@ -295,191 +202,474 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	}
 }
 
-// SearchQuestions search question data
-func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagIDs [][]string, notAccepted bool, views, answers int, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
-	var (
-		qfs  = qFields
-		args = []interface{}{}
-	)
-	if order == "relevance" {
-		if len(words) > 0 {
-			qfs, args = addRelevanceField([]string{"title", "original_text"}, words, qfs)
-		} else {
-			order = "newest"
+// buildContentsSQL build the union sql of all content types that can be searched together.
+// If objectType is not empty, only the content of this type will be included, with the conditions only for this type.
+func (sr *searchRepo) buildContentsSQL(objectType string, cond *schema.SearchCondition, words []string, withRelevance bool) (
+	sql string, args []interface{}, err error) {
+	typeBuilders := []struct {
+		objectType      string
+		fields          []string
+		relevanceFields []string
+		cols            *searchColumns
+		newBuilder      func(fields []string) *builder.Builder
+		addTypeCond     func(b *builder.Builder, cond *schema.SearchCondition)
+	}{
+		{constant.QuestionObjectType, qFields, []string{"title", "original_text"}, questionColumns, newQuestionBuilder, addQuestionCond},
+		{constant.AnswerObjectType, aFields, []string{"`answer`.`original_text`"}, answerColumns, newAnswerBuilder, addAnswerCond},
+		{constant.ArticleObjectType, articleFields, []string{"title", "original_text"}, articleColumns, newArticleBuilder, nil},
+		{constant.QuoteObjectType, quoteFields, []string{"title", "original_text"}, quoteColumns, newQuoteBuilder, nil},
+	}
+
+	subSQLs := make([]string, 0, len(typeBuilders))
+	for _, tb := range typeBuilders {
+		if len(objectType) > 0 && tb.objectType != objectType {
+			continue
+		}
+		var (
+			fields        = tb.fields
+			relevanceArgs = []interface{}{}
+			subSQL        string
+			subArgs       []interface{}
+		)
+		if withRelevance {
+			fields, relevanceArgs = addRelevanceField(tb.relevanceFields, words, fields)
+		}
+		b := tb.newBuilder(fields)
+		addSearchCond(b, tb.cols, cond, words)
+		if len(objectType) > 0 && tb.addTypeCond != nil {
+			tb.addTypeCond(b, cond)
+		}
+		subSQL, subArgs, err = b.ToSQL()
+		if err != nil {
+			return "", nil, err
 		}
+		subSQLs = append(subSQLs, subSQL)
+		args = append(args, relevanceArgs...)
+		args = append(args, subArgs...)
 	}
 
-	b := builder.MySQL().Select(qfs...).From("question")
+	sql = "(" + strings.Join(subSQLs, " UNION ALL ") + ")"
+	return sql, args, nil
+}
+
+// searchColumns the columns used by the search conditions of each content type
+type searchColumns struct {
+	// the column joined with tag_rel object_id
+	tagObjectID string
+	// the columns matched with keywords
+	text    []string
+	user    string
+	vote    string
+	created string
+	updated string
+	active  string
+}
+
+var (
+	questionColumns = &searchColumns{
+		tagObjectID: "`question`.`id`",
+		text:        []string{"`question`.`title`", "`question`.`original_text`"},
+		user:        "`question`.`user_id`",
+		vote:        "`question`.`vote_count`",
+		created:     "`question`.`created_at`",
+		updated:     "`question`.`updated_at`",
+		active:      "`question`.`post_update_time`",
+	}
+	answerColumns = &searchColumns{
+		tagObjectID: "`answer`.`question_id`",
+		text:        []string{"`answer`.`original_text`"},
+		user:        "`answer`.`user_id`",
+		vote:        "`answer`.`vote_count`",
+		created:     "`answer`.`created_at`",
+		updated:     "`answer`.`updated_at`",
+		active:      "`answer`.`updated_at`",
+	}
+	articleColumns = &searchColumns{
+		tagObjectID: "`article`.`id`",
+		text:        []string{"`article`.`title`", "`article`.`original_text`"},
+		user:        "`article`.`user_id`",
+		vote:        "`article`.`vote_count`",
+		created:     "`article`.`created_at`",
+		updated:     "`article`.`updated_at`",
+		active:      "`article`.`post_update_time`",
+	}
+	quoteColumns = &searchColumns{
+		tagObjectID: "`quote`.`id`",
+		text:        []string{"`quote`.`title`", "`quote`.`original_text`"},
+		user:        "`quote`.`user_id`",
+		vote:        "`quote`.`vote_count`",
+		created:     "`quote`.`created_at`",
+		updated:     "`quote`.`updated_at`",
+		active:      "`quote`.`post_update_time`",
+	}
+)
+
+func newQuestionBuilder(fields []string) *builder.Builder {
+	return builder.MySQL().Select(fields...).From("`question`").
+		Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
+		And(builder.Eq{"`question`.`show`": entity.QuestionShow})
+}
+
+func newAnswerBuilder(fields []string) *builder.Builder {
+	return builder.MySQL().Select(fields...).From("`answer`").
+		LeftJoin("`question`", "`question`.id = `answer`.question_id").
+		Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
+		And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).
+		And(builder.Eq{"`question`.`show`": entity.QuestionShow})
+}
 
-	b.Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow})
-	args = append(args, entity.QuestionStatusDeleted, entity.QuestionShow)
+func newArticleBuilder(fields []string) *builder.Builder {
+	return builder.MySQL().Select(fields...).From("`ta_article`", " article ").
+		Where(builder.Lt{"`article`.`status`": entity.ArticleStatusDeleted}).
+		And(builder.Eq{"`article`.`show`": entity.ArticleShow})
+}
 
-	likeConQ := builder.NewCond()
+func newQuoteBuilder(fields []string) *builder.Builder {
+	return builder.MySQL().Select(fields...).From("`tq_quote`", " quote ").
+		Where(builder.Lt{"`quote`.`status`": entity.QuoteStatusDeleted}).
+		And(builder.Eq{"`quote`.`show`": entity.QuoteShow})
+}
+
+// addSearchCond add the search conditions shared by all content types
+func addSearchCond(b *builder.Builder, cols *searchColumns, cond *schema.SearchCondition, words []string) {
+	// check words
+	likeCon := builder.NewCond()
 	for _, word := range words {
-		likeConQ = likeConQ.Or(builder.Like{"title", word}).
-			Or(builder.Like{"original_text", word})
-		args = append(args, "%"+word+"%")
-		args = append(args, "%"+word+"%")
+		for _, col := range cols.text {
+			likeCon = likeCon.Or(builder.Like{col, word})
+		}
+	}
+	b.Where(likeCon)
+
+	// check excluded words
+	for _, word := range filterWords(cond.ExcludedWords) {
+		notLikeCon := builder.NewCond()
+		for _, col := range cols.text {
+			notLikeCon = notLikeCon.Or(builder.Like{col, word})
+		}
+		b.Where(builder.Not{notLikeCon})
 	}
-	b.Where(likeConQ)
 
 	// check tag
-	for ti, tagID := range tagIDs {
+	for ti, tagID := range cond.Tags {
 		ast := "tag_rel" + strconv.Itoa(ti)
-		b.Join("INNER", "tag_rel as "+ast, "question.id = "+ast+".object_id").
+		b.Join("INNER", "tag_rel as "+ast, cols.tagObjectID+" = "+ast+".object_id").
 			And(builder.Eq{
 				ast + ".status": entity.TagRelStatusAvailable,
 			}).
 			And(builder.In(ast+".tag_id", tagID))
-		args = append(args, entity.TagRelStatusAvailable)
-		for _, t := range tagID {
-			args = append(args, t)
-		}
 	}
 
-	// check need filter has not accepted
-	if notAccepted {
-		b.And(builder.Eq{"accepted_answer_id": 0})
-		args = append(args, 0)
+	// check excluded tag
+	for _, tagID := range cond.ExcludedTags {
+		b.Where(builder.NotIn(cols.tagObjectID, builder.Select("object_id").From("tag_rel").
+			Where(builder.Eq{"status": entity.TagRelStatusAvailable}).
+			And(builder.In("tag_id", tagID))))
 	}
 
-	// check views
-	if views > -1 {
-		b.And(builder.Gte{"view_count": views})
-		args = append(args, views)
+	// check user
+	if cond.UserID != "" {
+		b.Where(builder.Eq{cols.user: cond.UserID})
 	}
 
-	// check answers
-	if answers == 0 {
-		b.And(builder.Eq{"answer_count": answers})
-		args = append(args, answers)
-	} else if answers > 0 {
-		b.And(builder.Gte{"answer_count": answers})
-		args = append(args, answers)
+	// check vote
+	if cond.VoteAmount == 0 {
+		b.Where(builder.Eq{cols.vote: cond.VoteAmount})
+	} else if cond.VoteAmount > 0 {
+		b.Where(builder.Gte{cols.vote: cond.VoteAmount})
+	}
+
+	// check time range, some content types use the same column for several ranges, so all of them must be applied
+	for _, tr := range []struct {
+		col       string
+		timeRange *schema.SearchTimeRange
+	}{
+		{cols.created, cond.Created},
+		{cols.updated, cond.Updated},
+		{cols.active, cond.Active},
+	} {
+		if tr.timeRange.IsEmpty() {
+			continue
+		}
+		if !tr.timeRange.Start.IsZero() {
+			b.Where(builder.Gte{tr.col: tr.timeRange.Start})
+		}
+		if !tr.timeRange.End.IsZero() {
+			b.Where(builder.Lt{tr.col: tr.timeRange.End})
+		}
 	}
+}
 
-	if answers == 0 {
-		b.And(builder.Eq{"answer_count": 0})
-		args = append(args, 0)
-	} else if answers > 0 {
-		b.And(builder.Gte{"answer_count": answers})
-		args = append(args, answers)
+// SearchFacets count the search contents grouped by object type, tag, author and created time.
+// The facets are counted by the same target type and conditions as the search results, so that each facet can be used to refine the query.
+func (sr *searchRepo) SearchFacets(ctx context.Context, cond *schema.SearchCondition) (
+	facets *schema.SearchFacets, err error) {
+	words := filterWords(cond.Words)
+	sql, args, err := sr.buildContentsSQL(cond.TargetType, cond, words, false)
+	if err != nil {
+		return nil, err
 	}
 
-	queryArgs := []interface{}{}
-	countArgs := []interface{}{}
-
-	countSQL, _, err := builder.MySQL().Select("count(*) total").From(b, "c").ToSQL()
+	// object type
+	typeSQL := "SELECT `object_type`, count(*) AS `total` FROM " + sql + " t GROUP BY `object_type`"
+	res, err := sr.data.DB.Context(ctx).Query(append([]interface{}{typeSQL}, args...)...)
 	if err != nil {
-		return
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	typeCount := make(map[string]int64, len(res))
+	for _, r := range res {
+		typeCount[string(r["object_type"])] = converter.StringToInt64(string(r["total"]))
 	}
 
-	querySQL, _, err := b.OrderBy(sr.parseOrder(ctx, order)).Limit(size, page-1).ToSQL()
+	// tag
+	tagSQL := "SELECT `tr`.`tag_id` AS `tag_id`, count(*) AS `total` FROM " + sql + " t " +
+		"INNER JOIN `tag_rel` tr ON `tr`.`object_id` = t.`question_id` AND `tr`.`status` = ? " +
+		"GROUP BY `tr`.`tag_id` ORDER BY `total` DESC"
+	tagSQL, _, err = builder.MySQL().Select("*").From("("+tagSQL+")", "tc").Limit(searchFacetLimit).ToSQL()
 	if err != nil {
-		return
+		return nil, err
 	}
-	queryArgs = append(queryArgs, querySQL)
-	queryArgs = append(queryArgs, args...)
-
-	countArgs = append(countArgs, countSQL)
-	countArgs = append(countArgs, args...)
-
-	res, err := sr.data.DB.Context(ctx).Query(queryArgs...)
+	res, err = sr.data.DB.Context(ctx).Query(append(append([]interface{}{tagSQL}, args...), entity.TagRelStatusAvailable)...)
 	if err != nil {
-		return
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	tagCount := make(map[string]int64, len(res))
+	for _, r := range res {
+		tagCount[string(r["tag_id"])] = converter.StringToInt64(string(r["total"]))
 	}
 
-	tr, err := sr.data.DB.Context(ctx).Query(countArgs...)
+	// author
+	authorSQL, _, err := builder.MySQL().Select("`user_id`", "count(*) AS `total`").From(sql, "t").
+		GroupBy("`user_id`").OrderBy("`total` DESC").Limit(searchFacetLimit).ToSQL()
 	if err != nil {
-		return
+		return nil, err
+	}
+	res, err = sr.data.DB.Context(ctx).Query(append([]interface{}{authorSQL}, args...)...)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	authorCount := make(map[string]int64, len(res))
+	for _, r := range res {
+		authorCount[string(r["user_id"])] = converter.StringToInt64(string(r["total"]))
 	}
 
-	if len(tr) != 0 {
-		total = converter.StringToInt64(string(tr[0]["total"]))
+	// created time
+	now := time.Now()
+	bucketFields := make([]string, 0, len(schema.SearchFacetTimeBuckets))
+	bucketArgs := make([]interface{}, 0)
+	for _, bucket := range schema.SearchFacetTimeBuckets {
+		op := ">="
+		if bucket.Before {
+			op = "<"
+		}
+		bucketFields = append(bucketFields, fmt.Sprintf("SUM(CASE WHEN `created_at` %s ? THEN 1 ELSE 0 END) AS `%s`", op, bucket.Key))
+		bucketArgs = append(bucketArgs, now.Add(-bucket.Duration))
 	}
-	resp, err = sr.parseResult(ctx, res, words)
+	timeSQL, _, err := builder.MySQL().Select(bucketFields...).From(sql, "t").ToSQL()
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return nil, err
 	}
-	return
+	res, err = sr.data.DB.Context(ctx).Query(append(append([]interface{}{timeSQL}, bucketArgs...), args...)...)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	timeCount := make(map[string]int64)
+	if len(res) > 0 {
+		for _, bucket := range schema.SearchFacetTimeBuckets {
+			timeCount[bucket.Key] = converter.StringToInt64(string(res[0][bucket.Key]))
+		}
+	}
+
+	return sr.formatFacets(ctx, typeCount, tagCount, authorCount, timeCount)
 }
 
-// SearchAnswers search answer data
-func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs [][]string, accepted bool, questionID string, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
+// ParseSearchPluginFacets parse the facets returned by search plugin, return the data structure
+func (sr *searchRepo) ParseSearchPluginFacets(ctx context.Context, pluginFacets *plugin.SearchFacets) (
+	facets *schema.SearchFacets, err error) {
+	if pluginFacets == nil {
+		return nil, nil
+	}
+	return sr.formatFacets(ctx, pluginFacets.ObjectTypes, pluginFacets.TagIDs, pluginFacets.UserIDs, pluginFacets.TimeBuckets)
+}
+
+func (sr *searchRepo) formatFacets(ctx context.Context, typeCount, tagCount, authorCount, timeCount map[string]int64) (
+	facets *schema.SearchFacets, err error) {
+	facets = &schema.SearchFacets{
+		ObjectTypes: make([]*schema.SearchFacetItem, 0),
+		Tags:        make([]*schema.SearchFacetItem, 0),
+		Authors:     make([]*schema.SearchFacetItem, 0),
+		TimeBuckets: make([]*schema.SearchFacetItem, 0),
+	}
+
+	for _, objectType := range schema.SearchFacetObjectTypes {
+		facets.ObjectTypes = append(facets.ObjectTypes, &schema.SearchFacetItem{
+			Key:   objectType,
+			Name:  objectType,
+			Count: typeCount[objectType],
+			Query: "is:" + schema.SearchFacetObjectTypeOperator[objectType],
+		})
+	}
 
+	if len(tagCount) > 0 {
+		tagIDs := make([]string, 0, len(tagCount))
+		for tagID := range tagCount {
+			tagIDs = append(tagIDs, tagID)
+		}
+		tagList, err := sr.tagCommon.GetTagListByIDs(ctx, tagIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tagList {
+			facets.Tags = append(facets.Tags, &schema.SearchFacetItem{
+				Key:   tag.SlugName,
+				Name:  tag.DisplayName,
+				Count: tagCount[tag.ID],
+				Query: "[" + tag.SlugName + "]",
+			})
+		}
+	}
+
+	if len(authorCount) > 0 {
+		userIDs := make([]string, 0, len(authorCount))
+		for userID := range authorCount {
+			userIDs = append(userIDs, userID)
+		}
+		userInfoMap, err := sr.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for userID, count := range authorCount {
+			userInfo := userInfoMap[userID]
+			if userInfo == nil {
+				continue
+			}
+			facets.Authors = append(facets.Authors, &schema.SearchFacetItem{
+				Key:   userInfo.Username,
+				Name:  userInfo.DisplayName,
+				Count: count,
+				Query: "user:" + userInfo.Username,
+			})
+		}
+	}
+
+	now := time.Now()
+	for _, bucket := range schema.SearchFacetTimeBuckets {
+		facets.TimeBuckets = append(facets.TimeBuckets, &schema.SearchFacetItem{
+			Key:   bucket.Key,
+			Name:  bucket.Key,
+			Count: timeCount[bucket.Key],
+			Query: bucket.Query(now),
+		})
+	}
+
+	sort.SliceStable(facets.Tags, func(i, j int) bool {
+		return facets.Tags[i].Count > facets.Tags[j].Count
+	})
+	sort.SliceStable(facets.Authors, func(i, j int) bool {
+		return facets.Authors[i].Count > facets.Authors[j].Count
+	})
+	return facets, nil
+}
+
+// SearchQuestions search question data
+func (sr *searchRepo) SearchQuestions(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
 	var (
-		afs  = aFields
+		qfs  = qFields
 		args = []interface{}{}
 	)
 	if order == "relevance" {
 		if len(words) > 0 {
-			afs, args = addRelevanceField([]string{"`answer`.`original_text`"}, words, afs)
+			qfs, args = addRelevanceField([]string{"title", "original_text"}, words, qfs)
 		} else {
 			order = "newest"
 		}
 	}
 
-	b := builder.MySQL().Select(afs...).From("`answer`").
-		LeftJoin("`question`", "`question`.id = `answer`.question_id")
+	b := newQuestionBuilder(qfs)
+	addSearchCond(b, questionColumns, cond, words)
+	addQuestionCond(b, cond)
+	return sr.searchByBuilder(ctx, b, args, words, page, size, order)
+}
 
-	b.Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
-		And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow})
-	args = append(args, entity.QuestionStatusDeleted, entity.AnswerStatusDeleted, entity.QuestionShow)
+// addQuestionCond add the search conditions only for question
+func addQuestionCond(b *builder.Builder, cond *schema.SearchCondition) {
+	// check need filter has not accepted
+	if cond.NotAccepted {
+		b.And(builder.Eq{"accepted_answer_id": 0})
+	}
 
-	likeConA := builder.NewCond()
-	for _, word := range words {
-		likeConA = likeConA.Or(builder.Like{"`answer`.original_text", word})
-		args = append(args, "%"+word+"%")
+	// check views
+	if cond.Views > -1 {
+		b.And(builder.Gte{"view_count": cond.Views})
 	}
 
-	b.Where(likeConA)
+	// check answers
+	if cond.AnswerAmount == 0 {
+		b.And(builder.Eq{"answer_count": cond.AnswerAmount})
+	} else if cond.AnswerAmount > 0 {
+		b.And(builder.Gte{"answer_count": cond.AnswerAmount})
+	}
+}
 
-	// check tag
-	for ti, tagID := range tagIDs {
-		ast := "tag_rel" + strconv.Itoa(ti)
-		b.Join("INNER", "tag_rel as "+ast, "question_id = "+ast+".object_id").
-			And(builder.Eq{
-				ast + ".status": entity.TagRelStatusAvailable,
-			}).
-			And(builder.In(ast+".tag_id", tagID))
-		args = append(args, entity.TagRelStatusAvailable)
-		for _, t := range tagID {
-			args = append(args, t)
+// SearchAnswers search answer data
+func (sr *searchRepo) SearchAnswers(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
+
+	var (
+		afs  = aFields
+		args = []interface{}{}
+	)
+	if order == "relevance" {
+		if len(words) > 0 {
+			afs, args = addRelevanceField([]string{"`answer`.`original_text`"}, words, afs)
+		} else {
+			order = "newest"
 		}
 	}
 
+	b := newAnswerBuilder(afs)
+	addSearchCond(b, answerColumns, cond, words)
+	addAnswerCond(b, cond)
+	return sr.searchByBuilder(ctx, b, args, words, page, size, order)
+}
+
+// addAnswerCond add the search conditions only for answer
+func addAnswerCond(b *builder.Builder, cond *schema.SearchCondition) {
 	// check limit accepted
-	if accepted {
+	if cond.Accepted {
 		b.Where(builder.Eq{"adopted": schema.AnswerAcceptedEnable})
-		args = append(args, schema.AnswerAcceptedEnable)
 	}
 
 	// check question id
-	if questionID != "" {
-		b.Where(builder.Eq{"question_id": questionID})
-		args = append(args, questionID)
+	if cond.QuestionID != "" {
+		b.Where(builder.Eq{"question_id": cond.QuestionID})
 	}
+}
 
-	queryArgs := []interface{}{}
-	countArgs := []interface{}{}
-
-	countSQL, _, err := builder.MySQL().Select("count(*) total").From(b, "c").ToSQL()
+// searchByBuilder query the page and total of the builder, the relevanceArgs is the args of relevance field in select
+func (sr *searchRepo) searchByBuilder(ctx context.Context, b *builder.Builder, relevanceArgs []interface{}, words []string, page, size int, order string) (
+	resp []*schema.SearchResult, total int64, err error) {
+	countSQL, countBuilderArgs, err := builder.MySQL().Select("count(*) total").From(b, "c").ToSQL()
 	if err != nil {
 		return
 	}
 
-	querySQL, _, err := b.OrderBy(sr.parseOrder(ctx, order)).Limit(size, page-1).ToSQL()
+	querySQL, queryBuilderArgs, err := b.OrderBy(sr.parseOrder(ctx, order)).Limit(size, page-1).ToSQL()
 	if err != nil {
 		return
 	}
 
+	queryArgs := []interface{}{}
+	countArgs := []interface{}{}
+
 	queryArgs = append(queryArgs, querySQL)
-	queryArgs = append(queryArgs, args...)
+	queryArgs = append(queryArgs, relevanceArgs...)
+	queryArgs = append(queryArgs, queryBuilderArgs...)
 
 	countArgs = append(countArgs, countSQL)
-	countArgs = append(countArgs, args...)
+	countArgs = append(countArgs, relevanceArgs...)
+	countArgs = append(countArgs, countBuilderArgs...)
 
 	res, err := sr.data.DB.Context(ctx).Query(queryArgs...)
 	if err != nil {
@@ -491,7 +681,9 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 		return
 	}
 
-	total = converter.StringToInt64(string(tr[0]["total"]))
+	if len(tr) != 0 {
+		total = converter.StringToInt64(string(tr[0]["total"]))
+	}
 	resp, err = sr.parseResult(ctx, res, words)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -596,6 +788,13 @@ func (sr *searchRepo) parseResult(ctx context.Context, res []map[string][]byte,
 				}
 			}
 
+		case constant.QuoteObjectType:
+			for k, v := range entity.AdminQuoteSearchStatus {
+				if v == converter.StringToInt(string(r["status"])) {
+					object.StatusStr = k
+					break
+				}
+			}
 		case "answer":
 			for k, v := range entity.AdminAnswerSearchStatus {
 				if v == converter.StringToInt(string(r["status"])) {
@@ -676,3 +875,126 @@ func filterWords(words []string) (res []string) {
 	}
 	return
 }
+
+// suggestTables the tables that titles can be suggested from
+var suggestTables = map[string]struct {
+	table         string
+	deletedStatus int
+	showStatus    int
+}{
+	constant.QuestionObjectType: {"question", entity.QuestionStatusDeleted, entity.QuestionShow},
+	constant.ArticleObjectType:  {entity.ARTICLE_TABLE_NAME, entity.ArticleStatusDeleted, entity.ArticleShow},
+	constant.QuoteObjectType:    {"tq_quote", entity.QuoteStatusDeleted, entity.QuoteShow},
+}
+
+// likePatternEscaper escape the wildcard characters of LIKE with '!',
+// the backslash is not used because it is also the escape character of the string literal in mysql.
+var likePatternEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// escapeLikePattern escape the text to be matched literally by LIKE ... ESCAPE '!'
+func escapeLikePattern(text string) string {
+	return likePatternEscaper.Replace(text)
+}
+
+// SuggestTitles get the titles start with prefix first, then the titles contain a word start with prefix.
+func (sr *searchRepo) SuggestTitles(ctx context.Context, objectType, prefix string, limit int) (
+	resp []*schema.SearchSuggestTitle, err error) {
+	resp = make([]*schema.SearchSuggestTitle, 0)
+	t, ok := suggestTables[objectType]
+	if !ok {
+		return resp, nil
+	}
+
+	type suggestTitle struct {
+		ID       string `xorm:"id"`
+		Title    string `xorm:"title"`
+		HotScore int    `xorm:"hot_score"`
+	}
+	prefix = escapeLikePattern(prefix)
+	patterns := []string{prefix + "%", "% " + prefix + "%"}
+	for i, pattern := range patterns {
+		if len(resp) >= limit {
+			break
+		}
+		titles := make([]*suggestTitle, 0)
+		session := sr.data.DB.Context(ctx).Table(t.table).Select("`id`, `title`, `hot_score`").
+			Where("`status` < ?", t.deletedStatus).And("`show` = ?", t.showStatus).
+			And("`title` LIKE ? ESCAPE '!'", pattern)
+		if i > 0 {
+			session.And("`title` NOT LIKE ? ESCAPE '!'", patterns[0])
+		}
+		err = session.OrderBy("`hot_score` DESC").Limit(limit - len(resp)).Find(&titles)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, title := range titles {
+			id := title.ID
+			if handler.GetEnableShortID(ctx) {
+				id = uid.EnShortID(id)
+			}
+			resp = append(resp, &schema.SearchSuggestTitle{
+				ID:          id,
+				ObjectType:  objectType,
+				Title:       title.Title,
+				UrlTitle:    htmltext.UrlTitle(title.Title),
+				HotScore:    title.HotScore,
+				ExactPrefix: i == 0,
+			})
+		}
+	}
+	return resp, nil
+}
+
+// SuggestTags get the tags whose slug name or display name start with prefix
+func (sr *searchRepo) SuggestTags(ctx context.Context, prefix string, limit int) (resp []*schema.TagResp, err error) {
+	tagList := make([]*entity.Tag, 0)
+	prefix = escapeLikePattern(prefix)
+	err = sr.data.DB.Context(ctx).Where("`status` = ?", entity.TagStatusAvailable).
+		And("(`slug_name` LIKE ? ESCAPE '!' OR `display_name` LIKE ? ESCAPE '!')", prefix+"%", prefix+"%").
+		OrderBy("`question_count` DESC").Limit(limit).Find(&tagList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return sr.tagCommon.TagFormat(ctx, tagList)
+}
+
+// SuggestUsers get the users whose username or display name start with prefix
+func (sr *searchRepo) SuggestUsers(ctx context.Context, prefix string, limit int) (resp []*schema.UserBasicInfo, err error) {
+	userList := make([]*entity.User, 0)
+	prefix = escapeLikePattern(prefix)
+	err = sr.data.DB.Context(ctx).Where("`status` = ?", entity.UserStatusAvailable).
+		And("(`username` LIKE ? ESCAPE '!' OR `display_name` LIKE ? ESCAPE '!')", prefix+"%", prefix+"%").
+		OrderBy("`rank` DESC").Limit(limit).Find(&userList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	resp = make([]*schema.UserBasicInfo, 0, len(userList))
+	for _, user := range userList {
+		resp = append(resp, sr.userCommon.FormatUserBasicInfo(ctx, user))
+	}
+	return resp, nil
+}
+
+// GetSuggestCache get the cached suggestion of the prefix
+func (sr *searchRepo) GetSuggestCache(ctx context.Context, prefix string, limit int) (
+	resp *schema.SearchSuggestResp, exist bool, err error) {
+	cacheData, exist, err := sr.data.Cache.GetString(ctx, suggestCacheKey(prefix, limit))
+	if err != nil || !exist {
+		return nil, false, err
+	}
+	resp = &schema.SearchSuggestResp{}
+	if err = json.Unmarshal([]byte(cacheData), resp); err != nil {
+		return nil, false, nil
+	}
+	return resp, true, nil
+}
+
+// SetSuggestCache cache the suggestion of the prefix
+func (sr *searchRepo) SetSuggestCache(ctx context.Context, prefix string, limit int, resp *schema.SearchSuggestResp) (err error) {
+	cacheData, _ := json.Marshal(resp)
+	return sr.data.Cache.SetString(ctx, suggestCacheKey(prefix, limit), string(cacheData), constant.SearchSuggestCacheTime)
+}
+
+func suggestCacheKey(prefix string, limit int) string {
+	return fmt.Sprintf("%s%d:%s", constant.SearchSuggestCacheKeyPrefix, limit, encryption.MD5(strings.ToLower(prefix)))
+}
@@ -27,8 +27,10 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	"github.com/apache/incubator-answer/internal/service/unique"
+	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // tagRelRepo tag rel repository
@@ -185,3 +187,17 @@ func (tr *tagRelRepo) CountTagRelByTagID(ctx context.Context, tagID string) (cou
 	}
 	return
 }
+
+// CountTagRelByTagIDAndObjectType count the available tag relation of the object type
+func (tr *tagRelRepo) CountTagRelByTagIDAndObjectType(ctx context.Context, tagID, objectType string) (count int64, err error) {
+	minID, maxID, err := obj.GetObjectIDRangeByObjectType(objectType)
+	if err != nil {
+		return 0, err
+	}
+	count, err = tr.data.DB.Context(ctx).Where(builder.Eq{"tag_id": tagID, "status": entity.TagRelStatusAvailable}).
+		And(builder.Between{Col: "object_id", LessVal: minID, MoreVal: maxID}).Count(&entity.TagRel{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	tagservice "github.com/apache/incubator-answer/internal/service/tag"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// tagRelatedRepo tag related repository
+type tagRelatedRepo struct {
+	data *data.Data
+}
+
+// NewTagRelatedRepo new repository
+func NewTagRelatedRepo(data *data.Data) tagservice.TagRelatedRepo {
+	return &tagRelatedRepo{
+		data: data,
+	}
+}
+
+// GetTagCooccurrenceList count the contents tagged with both tags for every pair of tags,
+// each pair is returned only once with the smaller tag id as TagID
+func (tr *tagRelatedRepo) GetTagCooccurrenceList(ctx context.Context, minCoCount int) (
+	cooccurrenceList []*entity.TagRelated, err error) {
+	cooccurrenceList = make([]*entity.TagRelated, 0)
+	err = tr.data.DB.Context(ctx).SQL("SELECT a.tag_id AS tag_id, b.tag_id AS related_tag_id, COUNT(*) AS co_count "+
+		"FROM tag_rel a INNER JOIN tag_rel b ON a.object_id = b.object_id AND a.tag_id < b.tag_id "+
+		"WHERE a.status = ? AND b.status = ? GROUP BY a.tag_id, b.tag_id HAVING COUNT(*) >= ?",
+		entity.TagRelStatusAvailable, entity.TagRelStatusAvailable, minCoCount).Find(&cooccurrenceList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagRelCountMapping count the available tag relations of every tag
+func (tr *tagRelatedRepo) GetTagRelCountMapping(ctx context.Context) (countMapping map[string]int, err error) {
+	rows := make([]*struct {
+		TagID string `xorm:"tag_id"`
+		Count int    `xorm:"count"`
+	}, 0)
+	err = tr.data.DB.Context(ctx).SQL("SELECT tag_id, COUNT(*) AS count FROM tag_rel WHERE status = ? GROUP BY tag_id",
+		entity.TagRelStatusAvailable).Find(&rows)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	countMapping = make(map[string]int, len(rows))
+	for _, row := range rows {
+		countMapping[row.TagID] = row.Count
+	}
+	return countMapping, nil
+}
+
+// ReplaceTagRelatedList replace all the related tags with the new statistics
+func (tr *tagRelatedRepo) ReplaceTagRelatedList(ctx context.Context, tagRelatedList []*entity.TagRelated) (err error) {
+	_, err = tr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("1 = 1").Delete(&entity.TagRelated{}); err != nil {
+			return nil, err
+		}
+		for start := 0; start < len(tagRelatedList); start += 500 {
+			end := start + 500
+			if end > len(tagRelatedList) {
+				end = len(tagRelatedList)
+			}
+			if _, err = session.Insert(tagRelatedList[start:end]); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRelatedTagList get the related tags of the tags ordered by strength
+func (tr *tagRelatedRepo) GetRelatedTagList(ctx context.Context, tagIDs []string) (
+	tagRelatedList []*entity.TagRelated, err error) {
+	tagRelatedList = make([]*entity.TagRelated, 0)
+	err = tr.data.DB.Context(ctx).Where(builder.In("tag_id", tagIDs)).
+		Desc("strength").Desc("co_count").Find(&tagRelatedList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
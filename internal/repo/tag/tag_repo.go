@@ -21,6 +21,7 @@ package tag
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/reason"
@@ -28,8 +29,10 @@ import (
 	"github.com/apache/incubator-answer/internal/service/tag_common"
 	"github.com/apache/incubator-answer/internal/service/unique"
 	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/segmentfault/pacman/errors"
 	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // tagRepo tag repository
@@ -145,3 +148,284 @@ func (tr *tagRepo) GetTagList(ctx context.Context, tag *entity.Tag) (tagList []*
 	}
 	return
 }
+
+// GetTagTreeList get all available main tags (not synonym) ordered by tag sort for building the tag tree
+func (tr *tagRepo) GetTagTreeList(ctx context.Context) (tagList []*entity.Tag, err error) {
+	tagList = make([]*entity.Tag, 0)
+	err = tr.data.DB.Context(ctx).Where(builder.Eq{"status": entity.TagStatusAvailable, "main_tag_id": 0}).
+		Asc("tag_sort", "slug_name").Find(&tagList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagListByParentIDs get the available child tags of the parent tags
+func (tr *tagRepo) GetTagListByParentIDs(ctx context.Context, parentTagIDs []string) (tagList []*entity.Tag, err error) {
+	tagList = make([]*entity.Tag, 0)
+	err = tr.data.DB.Context(ctx).Where(builder.Eq{"status": entity.TagStatusAvailable}).
+		And(builder.In("parent_tag_id", parentTagIDs)).Asc("tag_sort", "slug_name").Find(&tagList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetMaxTagSortByParentID get the max tag sort of the child tags of the parent tag
+func (tr *tagRepo) GetMaxTagSortByParentID(ctx context.Context, parentTagID string) (maxSort int64, err error) {
+	tag := &entity.Tag{}
+	exist, err := tr.data.DB.Context(ctx).Where(builder.Eq{"status": entity.TagStatusAvailable}).
+		And(builder.Eq{"parent_tag_id": converter.StringToInt64(parentTagID)}).Desc("tag_sort").Get(tag)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return 0, nil
+	}
+	return tag.TagSort, nil
+}
+
+// UpdateTagParent move the tag to the parent tag
+func (tr *tagRepo) UpdateTagParent(ctx context.Context, tagID string, parentTagID int64, parentTagSlugName string, tagSort int64) (err error) {
+	_, err = tr.data.DB.Context(ctx).ID(tagID).Cols("parent_tag_id", "parent_tag_slug_name", "tag_sort").
+		Update(&entity.Tag{ParentTagId: parentTagID, ParentTagSlugName: parentTagSlugName, TagSort: tagSort})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateTagSortList update the tag sort by the order of tag ids, the first one is the smallest
+func (tr *tagRepo) UpdateTagSortList(ctx context.Context, tagIDs []string) (err error) {
+	_, err = tr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		for i, tagID := range tagIDs {
+			_, err = session.ID(tagID).Cols("tag_sort").Update(&entity.Tag{TagSort: int64(i + 1)})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateTagSlugName update the slug name of the tag and the synonyms and children which reference it
+func (tr *tagRepo) UpdateTagSlugName(ctx context.Context, tagID, slugName string) (err error) {
+	_, err = tr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.ID(tagID).Cols("slug_name").Update(&entity.Tag{SlugName: slugName})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Where(builder.Eq{"main_tag_id": tagID}).Cols("main_tag_slug_name").
+			Update(&entity.Tag{MainTagSlugName: slugName})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Where(builder.Eq{"parent_tag_id": tagID}).Cols("parent_tag_slug_name").
+			Update(&entity.Tag{ParentTagSlugName: slugName})
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// MergeTag merge the source tag into the target tag in one transaction.
+// All tag relations and followers of the source tag are moved to the target tag, the counts of the target tag are
+// recounted, the source tag becomes a synonym of the target tag and the revision of the target tag is added.
+func (tr *tagRepo) MergeTag(ctx context.Context, sourceTag, targetTag *entity.Tag, followActivityType int,
+	revision *entity.Revision) (err error) {
+	_, err = tr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if err = tr.mergeTagRel(session, sourceTag.ID, targetTag.ID); err != nil {
+			return nil, err
+		}
+		if err = tr.mergeTagFollower(session, sourceTag.ID, targetTag.ID, followActivityType); err != nil {
+			return nil, err
+		}
+
+		// the synonyms and children of the source tag belong to the target tag now
+		targetTagID := converter.StringToInt64(targetTag.ID)
+		_, err = session.Where(builder.Eq{"main_tag_id": converter.StringToInt64(sourceTag.ID)}).
+			Cols("main_tag_id", "main_tag_slug_name").
+			Update(&entity.Tag{MainTagID: targetTagID, MainTagSlugName: targetTag.SlugName})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Where(builder.Eq{"parent_tag_id": converter.StringToInt64(sourceTag.ID)}).
+			And(builder.Neq{"id": targetTag.ID}).
+			Cols("parent_tag_id", "parent_tag_slug_name").
+			Update(&entity.Tag{ParentTagId: targetTagID, ParentTagSlugName: targetTag.SlugName})
+		if err != nil {
+			return nil, err
+		}
+
+		// keep the source tag as a synonym without any content
+		_, err = session.Table(entity.Tag{}.TableName()).Where(builder.Eq{"id": sourceTag.ID}).Update(map[string]any{
+			"main_tag_id":          targetTagID,
+			"main_tag_slug_name":   targetTag.SlugName,
+			"parent_tag_id":        0,
+			"parent_tag_slug_name": "",
+			"follow_count":         0,
+			"question_count":       0,
+			"article_count":        0,
+			"quote_count":          0,
+			"author_count":         0,
+			"piece_count":          0,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = session.Insert(revision); err != nil {
+			return nil, err
+		}
+		targetTag.RevisionID = revision.ID
+		_, err = session.ID(targetTag.ID).
+			Cols("original_text", "parsed_text", "parent_tag_id", "parent_tag_slug_name", "revision_id").
+			Update(targetTag)
+		if err != nil {
+			return nil, err
+		}
+		return nil, tr.recountTagContent(session, targetTag.ID, followActivityType)
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// mergeTagRel move the tag relations of the source tag to the target tag.
+// If the target tag is already attached to the object, the source relation is removed and
+// the target relation keeps the more visible status of the two.
+func (tr *tagRepo) mergeTagRel(session *xorm.Session, sourceTagID, targetTagID string) (err error) {
+	sourceRelList := make([]*entity.TagRel, 0)
+	if err = session.Where(builder.Eq{"tag_id": sourceTagID}).Find(&sourceRelList); err != nil {
+		return err
+	}
+	if len(sourceRelList) == 0 {
+		return nil
+	}
+	objectIDs := make([]string, 0, len(sourceRelList))
+	for _, rel := range sourceRelList {
+		objectIDs = append(objectIDs, rel.ObjectID)
+	}
+	targetRelList := make([]*entity.TagRel, 0)
+	err = session.Where(builder.Eq{"tag_id": targetTagID}).And(builder.In("object_id", objectIDs)).Find(&targetRelList)
+	if err != nil {
+		return err
+	}
+	targetRelMapping := make(map[string]*entity.TagRel, len(targetRelList))
+	for _, rel := range targetRelList {
+		targetRelMapping[rel.ObjectID] = rel
+	}
+
+	for _, rel := range sourceRelList {
+		targetRel, ok := targetRelMapping[rel.ObjectID]
+		if !ok {
+			_, err = session.ID(rel.ID).Cols("tag_id").Update(&entity.TagRel{TagID: targetTagID})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		// available(1) < hide(2) < deleted(10), the smaller one is more visible
+		if rel.Status < targetRel.Status {
+			_, err = session.ID(targetRel.ID).Cols("status").Update(&entity.TagRel{Status: rel.Status})
+			if err != nil {
+				return err
+			}
+		}
+		if _, err = session.ID(rel.ID).Delete(&entity.TagRel{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeTagFollower move the followers of the source tag to the target tag
+func (tr *tagRepo) mergeTagFollower(session *xorm.Session, sourceTagID, targetTagID string, followActivityType int) (err error) {
+	sourceFollowList := make([]*entity.Activity, 0)
+	err = session.Where(builder.Eq{"object_id": sourceTagID, "activity_type": followActivityType}).Find(&sourceFollowList)
+	if err != nil {
+		return err
+	}
+	if len(sourceFollowList) == 0 {
+		return nil
+	}
+	userIDs := make([]string, 0, len(sourceFollowList))
+	for _, act := range sourceFollowList {
+		userIDs = append(userIDs, act.UserID)
+	}
+	targetFollowList := make([]*entity.Activity, 0)
+	err = session.Where(builder.Eq{"object_id": targetTagID, "activity_type": followActivityType}).
+		And(builder.In("user_id", userIDs)).Find(&targetFollowList)
+	if err != nil {
+		return err
+	}
+	targetFollowMapping := make(map[string]*entity.Activity, len(targetFollowList))
+	for _, act := range targetFollowList {
+		targetFollowMapping[act.UserID] = act
+	}
+
+	for _, act := range sourceFollowList {
+		targetFollow, ok := targetFollowMapping[act.UserID]
+		if !ok {
+			_, err = session.ID(act.ID).Cols("object_id", "original_object_id").
+				Update(&entity.Activity{ObjectID: targetTagID, OriginalObjectID: targetTagID})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if act.Cancelled == entity.ActivityAvailable && targetFollow.Cancelled == entity.ActivityCancelled {
+			_, err = session.ID(targetFollow.ID).Cols("cancelled").
+				Update(&entity.Activity{Cancelled: entity.ActivityAvailable})
+			if err != nil {
+				return err
+			}
+		}
+		if act.Cancelled == entity.ActivityAvailable {
+			_, err = session.ID(act.ID).Cols("cancelled", "cancelled_at").
+				Update(&entity.Activity{Cancelled: entity.ActivityCancelled, CancelledAt: time.Now()})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recountTagContent recount the follow count and the content count of every type of the tag
+func (tr *tagRepo) recountTagContent(session *xorm.Session, tagID string, followActivityType int) (err error) {
+	counts := make(map[string]any, len(entity.TagContentCountColumnMapping)+1)
+	followCount, err := session.Where(builder.Eq{"object_id": tagID, "activity_type": followActivityType,
+		"cancelled": entity.ActivityAvailable}).Count(&entity.Activity{})
+	if err != nil {
+		return err
+	}
+	counts["follow_count"] = followCount
+	for objectType, column := range entity.TagContentCountColumnMapping {
+		minID, maxID, err := obj.GetObjectIDRangeByObjectType(objectType)
+		if err != nil {
+			return err
+		}
+		count, err := session.Where(builder.Eq{"tag_id": tagID, "status": entity.TagRelStatusAvailable}).
+			And(builder.Between{Col: "object_id", LessVal: minID, MoreVal: maxID}).Count(&entity.TagRel{})
+		if err != nil {
+			return err
+		}
+		counts[column] = count
+	}
+	_, err = session.Table(entity.Tag{}.TableName()).Where(builder.Eq{"id": tagID}).Update(counts)
+	return err
+}
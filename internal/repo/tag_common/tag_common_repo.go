@@ -152,6 +152,18 @@ func (tr *tagCommonRepo) GetTagListByNames(ctx context.Context, names []string)
 	return
 }
 
+// GetTagListByDisplayNames get available tag list by display names
+func (tr *tagCommonRepo) GetTagListByDisplayNames(ctx context.Context, displayNames []string) (tagList []*entity.Tag, err error) {
+	tagList = make([]*entity.Tag, 0)
+	session := tr.data.DB.Context(ctx).In("display_name", displayNames).UseBool("recommend", "reserved")
+	session.Where(builder.Eq{"status": entity.TagStatusAvailable})
+	err = session.OrderBy("main_tag_id asc,id asc").Find(&tagList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetTagByID get tag one
 func (tr *tagCommonRepo) GetTagByID(ctx context.Context, tagID string, includeDeleted bool) (
 	tag *entity.Tag, exist bool, err error,
@@ -199,9 +211,17 @@ func (tr *tagCommonRepo) GetTagPage(ctx context.Context, page, pageSize int, tag
 	}
 	session.Where(builder.Eq{"status": entity.TagStatusAvailable})
 
+	countColumn := entity.TagContentCountColumnMapping[constant.QuestionObjectType]
+	if tagSearchCond != nil && len(tagSearchCond.ContentObjectType) > 0 {
+		if column, ok := entity.TagContentCountColumnMapping[tagSearchCond.ContentObjectType]; ok {
+			countColumn = column
+			session.Where(builder.Gt{column: 0})
+		}
+	}
+
 	switch queryCond {
 	case "popular":
-		session.Desc("question_count")
+		session.Desc(countColumn)
 	case "name":
 		session.Asc("slug_name")
 	case "newest":
@@ -304,30 +324,20 @@ func (tr *tagCommonRepo) UpdateTagQuestionCount(ctx context.Context, tagID strin
 	return
 }
 
-func (tr *tagCommonRepo) UpdateTagArticleCount(ctx context.Context, tagID string, articleCount int) (err error) {
-	cond := &entity.Tag{QuestionCount: articleCount}
-	_, err = tr.data.DB.Context(ctx).Where(builder.Eq{"id": tagID}).MustCols("question_count").Update(cond)
-	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-	}
-	return
-}
-func (tr *tagCommonRepo) UpdateTagQuoteCount(ctx context.Context, tagID string, articleCount int) (err error) {
-	cond := &entity.Tag{QuestionCount: articleCount}
-	_, err = tr.data.DB.Context(ctx).Where(builder.Eq{"id": tagID}).MustCols("question_count").Update(cond)
-	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+// UpdateTagContentCount update the tag count of the content type
+func (tr *tagCommonRepo) UpdateTagContentCount(ctx context.Context, tagID, objectType string, count int) (err error) {
+	column, ok := entity.TagContentCountColumnMapping[objectType]
+	if !ok {
+		return errors.BadRequest(reason.ObjectNotFound)
 	}
-	return
-}
-func (tr *tagCommonRepo) UpdateTagQuoteAuthorCount(ctx context.Context, tagID string, articleCount int) (err error) {
-	cond := &entity.Tag{QuestionCount: articleCount}
-	_, err = tr.data.DB.Context(ctx).Where(builder.Eq{"id": tagID}).MustCols("question_count").Update(cond)
+	_, err = tr.data.DB.Context(ctx).Table(entity.Tag{}.TableName()).Where(builder.Eq{"id": tagID}).
+		Update(map[string]any{column: count})
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	return
 }
+
 func (tr *tagCommonRepo) UpdateTagsAttribute(ctx context.Context, tags []string, attribute string, value bool) (err error) {
 	bean := &entity.Tag{}
 	switch attribute {
@@ -0,0 +1,173 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data_export
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	userdataexport "github.com/apache/incubator-answer/internal/service/user_data_export"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// userDataExportRepo user data export repository
+type userDataExportRepo struct {
+	data *data.Data
+}
+
+// NewUserDataExportRepo new repository
+func NewUserDataExportRepo(data *data.Data) userdataexport.UserDataExportRepo {
+	return &userDataExportRepo{
+		data: data,
+	}
+}
+
+// AddUserDataExport add user data export
+func (ur *userDataExportRepo) AddUserDataExport(ctx context.Context, export *entity.UserDataExport) (err error) {
+	_, err = ur.data.DB.Context(ctx).Insert(export)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateUserDataExport update user data export
+func (ur *userDataExportRepo) UpdateUserDataExport(ctx context.Context, export *entity.UserDataExport, cols []string) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(export.ID).Cols(cols...).Update(export)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserDataExport get user data export by id
+func (ur *userDataExportRepo) GetUserDataExport(ctx context.Context, id string) (
+	export *entity.UserDataExport, exist bool, err error) {
+	export = &entity.UserDataExport{}
+	exist, err = ur.data.DB.Context(ctx).ID(id).Get(export)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetLatestUserDataExport get the latest user data export of the user
+func (ur *userDataExportRepo) GetLatestUserDataExport(ctx context.Context, userID string) (
+	export *entity.UserDataExport, exist bool, err error) {
+	export = &entity.UserDataExport{}
+	exist, err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id").Get(export)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddUserDataExportIfNoneSince add the user data export unless the user has an unfinished or completed one
+// created after the time. The user row is locked, so the concurrent requests of the user can not both be added.
+func (ur *userDataExportRepo) AddUserDataExportIfNoneSince(ctx context.Context, export *entity.UserDataExport,
+	since time.Time) (added bool, err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		user := &entity.User{}
+		exist, err := session.ID(export.UserID).ForUpdate().Get(user)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, fmt.Errorf("user not exist")
+		}
+
+		count, err := session.Where("user_id = ?", export.UserID).And("created_at > ?", since).
+			In("status", entity.UserDataExportStatusPending, entity.UserDataExportStatusProcessing,
+				entity.UserDataExportStatusCompleted).
+			Count(&entity.UserDataExport{})
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, nil
+		}
+
+		if _, err = session.Insert(export); err != nil {
+			return nil, err
+		}
+		added = true
+		return nil, nil
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return added, nil
+}
+
+// GetExpiredUserDataExportList get the completed user data exports which expired before the time
+func (ur *userDataExportRepo) GetExpiredUserDataExportList(ctx context.Context, before time.Time) (
+	exportList []*entity.UserDataExport, err error) {
+	exportList = make([]*entity.UserDataExport, 0)
+	err = ur.data.DB.Context(ctx).Where("status = ?", entity.UserDataExportStatusCompleted).
+		And("expired_at < ?", before).Find(&exportList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetStaleUserDataExportList get the unfinished user data exports which have not been updated since the time
+func (ur *userDataExportRepo) GetStaleUserDataExportList(ctx context.Context, before time.Time) (
+	exportList []*entity.UserDataExport, err error) {
+	exportList = make([]*entity.UserDataExport, 0)
+	err = ur.data.DB.Context(ctx).
+		In("status", entity.UserDataExportStatusPending, entity.UserDataExportStatusProcessing).
+		And("updated_at < ?", before).Find(&exportList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserRecords get all records of the table which belong to the user, records must be a pointer to a slice of entity
+func (ur *userDataExportRepo) GetUserRecords(ctx context.Context, userID string, records any) (err error) {
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Asc("created_at").Find(records)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserActivities get the available activities of the user with the given types
+func (ur *userDataExportRepo) GetUserActivities(ctx context.Context, userID string, activityTypes []int) (
+	activities []*entity.Activity, err error) {
+	activities = make([]*entity.Activity, 0)
+	if len(activityTypes) == 0 {
+		return activities, nil
+	}
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).And("cancelled = ?", entity.ActivityAvailable).
+		In("activity_type", activityTypes).Asc("created_at").Find(&activities)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
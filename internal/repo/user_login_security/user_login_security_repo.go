@@ -0,0 +1,196 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_login_security
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/user_login_security"
+	"github.com/apache/incubator-answer/pkg/encryption"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// userLoginSecurityRepo user login security repository
+type userLoginSecurityRepo struct {
+	data *data.Data
+}
+
+// NewUserLoginSecurityRepo new repository
+func NewUserLoginSecurityRepo(data *data.Data) user_login_security.UserLoginSecurityRepo {
+	return &userLoginSecurityRepo{
+		data: data,
+	}
+}
+
+// GetUserLoginLockout get the failed login state of user
+func (ur *userLoginSecurityRepo) GetUserLoginLockout(ctx context.Context, userID string) (
+	lockout *entity.UserLoginLockout, exist bool, err error) {
+	lockout = &entity.UserLoginLockout{}
+	exist, err = ur.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Get(lockout)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveUserLoginLockout add the failed login state of user or update the existing one
+func (ur *userLoginSecurityRepo) SaveUserLoginLockout(ctx context.Context, lockout *entity.UserLoginLockout) (err error) {
+	if len(lockout.ID) > 0 {
+		_, err = ur.data.DB.Context(ctx).ID(lockout.ID).
+			Cols("failed_count", "lockout_count", "last_failed_at", "last_failed_ip", "locked_until").Update(lockout)
+	} else {
+		_, err = ur.data.DB.Context(ctx).Insert(lockout)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveUserLoginLockout remove the failed login state of user
+func (ur *userLoginSecurityRepo) RemoveUserLoginLockout(ctx context.Context, userID string) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Delete(&entity.UserLoginLockout{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetEmailLoginLockout get the failed login state of the email which is not registered
+func (ur *userLoginSecurityRepo) GetEmailLoginLockout(ctx context.Context, email string) (
+	lockout *entity.UserLoginLockout, exist bool, err error) {
+	cacheData, exist, err := ur.data.Cache.GetString(ctx, emailLoginLockoutCacheKey(email))
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	lockout = &entity.UserLoginLockout{}
+	if err = json.Unmarshal([]byte(cacheData), lockout); err != nil {
+		return nil, false, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return lockout, true, nil
+}
+
+// SaveEmailLoginLockout save the failed login state of the email which is not registered,
+// it is kept for a while after the lockout ends so that the lockout duration keeps doubling
+func (ur *userLoginSecurityRepo) SaveEmailLoginLockout(ctx context.Context, email string,
+	lockout *entity.UserLoginLockout) (err error) {
+	expire := constant.LoginLockoutEmailCacheTime
+	if remaining := time.Until(lockout.LockedUntil); remaining > 0 {
+		expire += remaining
+	}
+	cacheData, _ := json.Marshal(lockout)
+	err = ur.data.Cache.SetString(ctx, emailLoginLockoutCacheKey(email), string(cacheData), expire)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetLockedUserLoginLockoutPage get the failed login state of the users which are locked at the time
+func (ur *userLoginSecurityRepo) GetLockedUserLoginLockoutPage(ctx context.Context, page, pageSize int, now time.Time) (
+	lockoutList []*entity.UserLoginLockout, total int64, err error) {
+	lockoutList = make([]*entity.UserLoginLockout, 0)
+	session := ur.data.DB.Context(ctx).Where(builder.Gt{"locked_until": now}).Desc("locked_until")
+	total, err = pager.Help(page, pageSize, &lockoutList, &entity.UserLoginLockout{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddUserLoginLog add the login audit log
+func (ur *userLoginSecurityRepo) AddUserLoginLog(ctx context.Context, loginLog *entity.UserLoginLog) (err error) {
+	_, err = ur.data.DB.Context(ctx).Insert(loginLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserLoginLogPage get the login audit log filtered by the non-empty fields of cond
+func (ur *userLoginSecurityRepo) GetUserLoginLogPage(ctx context.Context, page, pageSize int,
+	cond *entity.UserLoginLog) (loginLogList []*entity.UserLoginLog, total int64, err error) {
+	loginLogList = make([]*entity.UserLoginLog, 0)
+	session := ur.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &loginLogList, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// IncreaseIPFailedCount increase the failed login count of ip, the count is reset after the window
+func (ur *userLoginSecurityRepo) IncreaseIPFailedCount(ctx context.Context, ip string, window time.Duration) (
+	count int64, err error) {
+	cacheKey := constant.LoginFailedIPCacheKeyPrefix + ip
+	_, exist, err := ur.data.Cache.GetInt64(ctx, cacheKey)
+	if err != nil {
+		return 0, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if !exist {
+		count = 1
+		err = ur.data.Cache.SetInt64(ctx, cacheKey, count, window)
+	} else {
+		count, err = ur.data.Cache.Increase(ctx, cacheKey, 1)
+	}
+	if err != nil {
+		return 0, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return count, nil
+}
+
+// GetIPLockedUntil get the unix time until which the ip is locked, 0 means not locked
+func (ur *userLoginSecurityRepo) GetIPLockedUntil(ctx context.Context, ip string) (lockedUntil int64, err error) {
+	lockedUntil, _, err = ur.data.Cache.GetInt64(ctx, constant.LoginLockedIPCacheKeyPrefix+ip)
+	if err != nil {
+		return 0, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return lockedUntil, nil
+}
+
+func emailLoginLockoutCacheKey(email string) string {
+	return constant.LoginLockoutEmailCacheKeyPrefix + encryption.MD5(strings.ToLower(email))
+}
+
+// LockIP lock the ip until the time
+func (ur *userLoginSecurityRepo) LockIP(ctx context.Context, ip string, lockedUntil time.Time) (err error) {
+	err = ur.data.Cache.SetInt64(ctx, constant.LoginLockedIPCacheKeyPrefix+ip,
+		lockedUntil.Unix(), time.Until(lockedUntil))
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	err = ur.data.Cache.Del(ctx, constant.LoginFailedIPCacheKeyPrefix+ip)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_relation
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	userrelation "github.com/apache/incubator-answer/internal/service/user_relation"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// userRelationRepo user relation repository
+type userRelationRepo struct {
+	data *data.Data
+}
+
+// NewUserRelationRepo new repository
+func NewUserRelationRepo(data *data.Data) userrelation.UserRelationRepo {
+	return &userRelationRepo{
+		data: data,
+	}
+}
+
+// AddUserRelation add user relation, it does nothing if the relation already exists
+func (ur *userRelationRepo) AddUserRelation(ctx context.Context, relation *entity.UserRelation) (err error) {
+	exist, err := ur.ExistUserRelation(ctx, relation.UserID, relation.TargetUserID, relation.RelationType)
+	if err != nil || exist {
+		return err
+	}
+	_, err = ur.data.DB.Context(ctx).Insert(relation)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveUserRelation remove user relation
+func (ur *userRelationRepo) RemoveUserRelation(ctx context.Context, userID, targetUserID string, relationType int) (
+	err error) {
+	_, err = ur.data.DB.Context(ctx).Where("user_id = ? AND target_user_id = ? AND relation_type = ?",
+		userID, targetUserID, relationType).Delete(&entity.UserRelation{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ExistUserRelation check whether the user has the relation to the target user
+func (ur *userRelationRepo) ExistUserRelation(ctx context.Context, userID, targetUserID string, relationType int) (
+	exist bool, err error) {
+	exist, err = ur.data.DB.Context(ctx).Where("user_id = ? AND target_user_id = ? AND relation_type = ?",
+		userID, targetUserID, relationType).Exist(&entity.UserRelation{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserRelationPage get the relations of the user by page
+func (ur *userRelationRepo) GetUserRelationPage(ctx context.Context, userID string, relationType, page, pageSize int) (
+	relationList []*entity.UserRelation, total int64, err error) {
+	relationList = make([]*entity.UserRelation, 0)
+	session := ur.data.DB.Context(ctx).Where("user_id = ? AND relation_type = ?", userID, relationType).Desc("id")
+	total, err = pager.Help(page, pageSize, &relationList, &entity.UserRelation{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTargetUserIDs get the ids of all target users of the user with the relation
+func (ur *userRelationRepo) GetTargetUserIDs(ctx context.Context, userID string, relationType int) (
+	targetUserIDs []string, err error) {
+	targetUserIDs = make([]string, 0)
+	err = ur.data.DB.Context(ctx).Table(entity.UserRelation{}.TableName()).Select("target_user_id").
+		Where("user_id = ? AND relation_type = ?", userID, relationType).Find(&targetUserIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_two_factor
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/user_two_factor"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// userTwoFactorRepo user two-factor authentication repository
+type userTwoFactorRepo struct {
+	data *data.Data
+}
+
+// NewUserTwoFactorRepo new repository
+func NewUserTwoFactorRepo(data *data.Data) user_two_factor.UserTwoFactorRepo {
+	return &userTwoFactorRepo{
+		data: data,
+	}
+}
+
+// GetUserTwoFactor get user two-factor authentication by user id
+func (ur *userTwoFactorRepo) GetUserTwoFactor(ctx context.Context, userID string) (
+	twoFactor *entity.UserTwoFactor, exist bool, err error) {
+	twoFactor = &entity.UserTwoFactor{}
+	exist, err = ur.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Get(twoFactor)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveUserTwoFactor add the user two-factor authentication or replace the existing one
+func (ur *userTwoFactorRepo) SaveUserTwoFactor(ctx context.Context, twoFactor *entity.UserTwoFactor) (err error) {
+	old := &entity.UserTwoFactor{}
+	exist, err := ur.data.DB.Context(ctx).Where(builder.Eq{"user_id": twoFactor.UserID}).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		twoFactor.ID = old.ID
+		_, err = ur.data.DB.Context(ctx).Where(builder.Eq{"id": old.ID}).
+			Cols("secret", "status", "recovery_codes", "last_used_step").Update(twoFactor)
+	} else {
+		_, err = ur.data.DB.Context(ctx).Insert(twoFactor)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateUserTwoFactorUsage update the recovery codes and the last used step,
+// only if they are not changed by another request, to make sure a code can only be used once
+func (ur *userTwoFactorRepo) UpdateUserTwoFactorUsage(ctx context.Context, twoFactor *entity.UserTwoFactor,
+	oldRecoveryCodes string, oldLastUsedStep int64) (updated bool, err error) {
+	affected, err := ur.data.DB.Context(ctx).Where(builder.Eq{
+		"id":             twoFactor.ID,
+		"recovery_codes": oldRecoveryCodes,
+		"last_used_step": oldLastUsedStep,
+	}).Cols("recovery_codes", "last_used_step").Update(twoFactor)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// RemoveUserTwoFactor remove the user two-factor authentication
+func (ur *userTwoFactorRepo) RemoveUserTwoFactor(ctx context.Context, userID string) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Delete(&entity.UserTwoFactor{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SetPendingLogin cache the login waiting for the second factor, and reset the attempts of it
+func (ur *userTwoFactorRepo) SetPendingLogin(ctx context.Context, token string,
+	pendingLogin *schema.TwoFactorPendingLogin) (err error) {
+	cacheData, _ := json.Marshal(pendingLogin)
+	err = ur.data.Cache.SetString(ctx, constant.UserTwoFactorPendingLoginCacheKeyPrefix+token,
+		string(cacheData), constant.UserTwoFactorPendingLoginCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	err = ur.data.Cache.SetInt64(ctx, constant.UserTwoFactorPendingAttemptsCacheKeyPrefix+token,
+		0, constant.UserTwoFactorPendingLoginCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// IncreasePendingLoginAttempts increase the attempts of the pending login atomically and return the increased value,
+// so that the concurrent attempts can not read the same count
+func (ur *userTwoFactorRepo) IncreasePendingLoginAttempts(ctx context.Context, token string) (attempts int64, err error) {
+	attempts, err = ur.data.Cache.Increase(ctx, constant.UserTwoFactorPendingAttemptsCacheKeyPrefix+token, 1)
+	if err != nil {
+		return 0, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return attempts, nil
+}
+
+// GetPendingLogin get the login waiting for the second factor, returns nil if not exist or expired
+func (ur *userTwoFactorRepo) GetPendingLogin(ctx context.Context, token string) (
+	pendingLogin *schema.TwoFactorPendingLogin, err error) {
+	res, exist, err := ur.data.Cache.GetString(ctx, constant.UserTwoFactorPendingLoginCacheKeyPrefix+token)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, nil
+	}
+	pendingLogin = &schema.TwoFactorPendingLogin{}
+	if err = json.Unmarshal([]byte(res), pendingLogin); err != nil {
+		return nil, nil
+	}
+	return pendingLogin, nil
+}
+
+// RemovePendingLogin remove the login waiting for the second factor
+func (ur *userTwoFactorRepo) RemovePendingLogin(ctx context.Context, token string) (err error) {
+	err = ur.data.Cache.Del(ctx, constant.UserTwoFactorPendingLoginCacheKeyPrefix+token)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	err = ur.data.Cache.Del(ctx, constant.UserTwoFactorPendingAttemptsCacheKeyPrefix+token)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
@@ -27,36 +27,42 @@ import (
 )
 
 type AnswerAPIRouter struct {
-	langController          *controller.LangController
-	userController          *controller.UserController
-	commentController       *controller.CommentController
-	reportController        *controller.ReportController
-	voteController          *controller.VoteController
-	tagController           *controller.TagController
-	followController        *controller.FollowController
-	collectionController    *controller.CollectionController
-	questionController      *controller.QuestionController
-	answerController        *controller.AnswerController
-	searchController        *controller.SearchController
-	revisionController      *controller.RevisionController
-	rankController          *controller.RankController
-	adminUserController     *controller_admin.UserAdminController
-	reasonController        *controller.ReasonController
-	themeController         *controller_admin.ThemeController
-	adminSiteInfoController *controller_admin.SiteInfoController
-	siteInfoController      *controller.SiteInfoController
-	notificationController  *controller.NotificationController
-	dashboardController     *controller.DashboardController
-	uploadController        *controller.UploadController
-	activityController      *controller.ActivityController
-	roleController          *controller_admin.RoleController
-	pluginController        *controller_admin.PluginController
-	permissionController    *controller.PermissionController
-	userPluginController    *controller.UserPluginController
-	reviewController        *controller.ReviewController
-	metaController          *controller.MetaController
-	badgeController         *controller.BadgeController
-	adminBadgeController    *controller_admin.BadgeController
+	langController                *controller.LangController
+	userController                *controller.UserController
+	commentController             *controller.CommentController
+	reportController              *controller.ReportController
+	voteController                *controller.VoteController
+	tagController                 *controller.TagController
+	followController              *controller.FollowController
+	collectionController          *controller.CollectionController
+	questionController            *controller.QuestionController
+	answerController              *controller.AnswerController
+	searchController              *controller.SearchController
+	revisionController            *controller.RevisionController
+	rankController                *controller.RankController
+	adminUserController           *controller_admin.UserAdminController
+	reasonController              *controller.ReasonController
+	themeController               *controller_admin.ThemeController
+	adminSiteInfoController       *controller_admin.SiteInfoController
+	siteInfoController            *controller.SiteInfoController
+	notificationController        *controller.NotificationController
+	dashboardController           *controller.DashboardController
+	uploadController              *controller.UploadController
+	activityController            *controller.ActivityController
+	roleController                *controller_admin.RoleController
+	pluginController              *controller_admin.PluginController
+	permissionController          *controller.PermissionController
+	userPluginController          *controller.UserPluginController
+	reviewController              *controller.ReviewController
+	metaController                *controller.MetaController
+	badgeController               *controller.BadgeController
+	adminBadgeController          *controller_admin.BadgeController
+	contentFilterController       *controller_admin.ContentFilterController
+	auditLogController            *controller_admin.AuditLogController
+	personalAccessTokenController *controller.PersonalAccessTokenController
+	userTwoFactorController       *controller.UserTwoFactorController
+	userDataExportController      *controller.UserDataExportController
+	userRelationController        *controller.UserRelationController
 }
 
 func NewAnswerAPIRouter(
@@ -90,38 +96,50 @@ func NewAnswerAPIRouter(
 	metaController *controller.MetaController,
 	badgeController *controller.BadgeController,
 	adminBadgeController *controller_admin.BadgeController,
+	personalAccessTokenController *controller.PersonalAccessTokenController,
+	userTwoFactorController *controller.UserTwoFactorController,
+	userDataExportController *controller.UserDataExportController,
+	userRelationController *controller.UserRelationController,
+	contentFilterController *controller_admin.ContentFilterController,
+	auditLogController *controller_admin.AuditLogController,
 ) *AnswerAPIRouter {
 	return &AnswerAPIRouter{
-		langController:          langController,
-		userController:          userController,
-		commentController:       commentController,
-		reportController:        reportController,
-		voteController:          voteController,
-		tagController:           tagController,
-		followController:        followController,
-		collectionController:    collectionController,
-		questionController:      questionController,
-		answerController:        answerController,
-		searchController:        searchController,
-		revisionController:      revisionController,
-		rankController:          rankController,
-		adminUserController:     adminUserController,
-		reasonController:        reasonController,
-		themeController:         themeController,
-		adminSiteInfoController: adminSiteInfoController,
-		notificationController:  notificationController,
-		siteInfoController:      siteInfoController,
-		dashboardController:     dashboardController,
-		uploadController:        uploadController,
-		activityController:      activityController,
-		roleController:          roleController,
-		pluginController:        pluginController,
-		permissionController:    permissionController,
-		userPluginController:    userPluginController,
-		reviewController:        reviewController,
-		metaController:          metaController,
-		badgeController:         badgeController,
-		adminBadgeController:    adminBadgeController,
+		langController:                langController,
+		userController:                userController,
+		commentController:             commentController,
+		reportController:              reportController,
+		voteController:                voteController,
+		tagController:                 tagController,
+		followController:              followController,
+		collectionController:          collectionController,
+		questionController:            questionController,
+		answerController:              answerController,
+		searchController:              searchController,
+		revisionController:            revisionController,
+		rankController:                rankController,
+		adminUserController:           adminUserController,
+		reasonController:              reasonController,
+		themeController:               themeController,
+		adminSiteInfoController:       adminSiteInfoController,
+		notificationController:        notificationController,
+		siteInfoController:            siteInfoController,
+		dashboardController:           dashboardController,
+		uploadController:              uploadController,
+		activityController:            activityController,
+		roleController:                roleController,
+		pluginController:              pluginController,
+		permissionController:          permissionController,
+		userPluginController:          userPluginController,
+		reviewController:              reviewController,
+		metaController:                metaController,
+		badgeController:               badgeController,
+		adminBadgeController:          adminBadgeController,
+		personalAccessTokenController: personalAccessTokenController,
+		userTwoFactorController:       userTwoFactorController,
+		userDataExportController:      userDataExportController,
+		userRelationController:        userRelationController,
+		contentFilterController:       contentFilterController,
+		auditLogController:            auditLogController,
 	}
 }
 
@@ -140,6 +158,12 @@ func (a *AnswerAPIRouter) RegisterMustUnAuthAnswerAPIRouter(authUserMiddleware *
 	// user
 	r.GET("/user/info", a.userController.GetUserInfoByUserID)
 	r.GET("/user/action/record", authUserMiddleware.Auth(), a.userController.ActionRecord)
+	r.GET("/user/action/policy", authUserMiddleware.Auth(), a.userController.GetActionPolicy)
+	// the second factor is also required by the external login and user center login
+	r.POST("/user/login/two-factor", a.userController.UserTwoFactorLogin)
+	r.POST("/user/login/two-factor/enroll", a.userController.UserTwoFactorLoginEnroll)
+	// the download link of data export is signed, so it can be opened from the email without login
+	r.GET("/user/data-export/download", a.userDataExportController.DownloadUserDataExport)
 	routerGroup := r.Group("", middleware.BanAPIForUserCenter)
 	routerGroup.POST("/user/login/email", a.userController.UserEmailLogin)
 	routerGroup.POST("/user/register/email", a.userController.UserRegisterByEmail)
@@ -187,10 +211,13 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/tag", a.tagController.GetTagInfo)
 	r.GET("/tags", a.tagController.GetTagsBySlugName)
 	r.GET("/tag/synonyms", a.tagController.GetTagSynonyms)
+	r.GET("/tags/tree", a.tagController.GetTagTree)
+	r.GET("/tags/related", a.tagController.GetRelatedTags)
 
 	// search
 	r.GET("/search", a.searchController.Search)
 	r.GET("/search/desc", a.searchController.SearchDesc)
+	r.GET("/search/suggest", a.searchController.SearchSuggest)
 
 	// rank
 	r.GET("/personal/rank/page", a.rankController.GetRankPersonalWithPage)
@@ -248,6 +275,8 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.POST("/tag/recover", a.tagController.RecoverTag)
 	r.DELETE("/tag", a.tagController.RemoveTag)
 	r.PUT("/tag/synonym", a.tagController.UpdateTagSynonym)
+	r.PUT("/tag/parent", a.tagController.MoveTag)
+	r.PUT("/tag/sort", a.tagController.ReorderTags)
 
 	// collection
 	r.POST("/collection/switch", a.collectionController.CollectionSwitch)
@@ -283,6 +312,37 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	// vote
 	r.GET("/personal/vote/page", a.voteController.UserVotes)
 
+	// saved search
+	r.GET("/search/saved", a.searchController.GetSavedSearchList)
+	r.POST("/search/saved", a.searchController.AddSavedSearch)
+	r.PUT("/search/saved", a.searchController.UpdateSavedSearch)
+	r.DELETE("/search/saved", a.searchController.RemoveSavedSearch)
+
+	// personal access token
+	r.GET("/user/personal-access-tokens", a.personalAccessTokenController.GetPersonalAccessTokenList)
+	r.POST("/user/personal-access-token", a.personalAccessTokenController.AddPersonalAccessToken)
+	r.DELETE("/user/personal-access-token", a.personalAccessTokenController.RevokePersonalAccessToken)
+
+	// two-factor authentication
+	r.GET("/user/two-factor", a.userTwoFactorController.GetUserTwoFactor)
+	r.POST("/user/two-factor/enroll", a.userTwoFactorController.EnrollUserTwoFactor)
+	r.POST("/user/two-factor", a.userTwoFactorController.EnableUserTwoFactor)
+	r.DELETE("/user/two-factor", a.userTwoFactorController.DisableUserTwoFactor)
+	r.PUT("/user/two-factor/recovery-codes", a.userTwoFactorController.RegenerateRecoveryCodes)
+
+	// personal data export
+	r.GET("/user/data-export", a.userDataExportController.GetUserDataExport)
+	r.POST("/user/data-export", a.userDataExportController.RequestUserDataExport)
+
+	// block and mute
+	r.GET("/user/relation/page", a.userRelationController.GetUserRelationPage)
+	r.POST("/user/relation", a.userRelationController.AddUserRelation)
+	r.DELETE("/user/relation", a.userRelationController.RemoveUserRelation)
+
+	// login sessions
+	r.GET("/user/sessions", a.userController.GetUserSessionList)
+	r.DELETE("/user/session", a.userController.RevokeUserSession)
+
 	// reason
 	r.GET("/reasons", a.reasonController.Reasons)
 
@@ -319,12 +379,29 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.GET("/answer/page", a.questionController.AdminAnswerPage)
 	r.PUT("/answer/status", a.answerController.AdminUpdateAnswerStatus)
 
+	// tag
+	r.POST("/tag/merge", a.tagController.AdminMergeTag)
+	r.GET("/tag/slug/proposals", a.tagController.AdminGetTagSlugProposals)
+	r.PUT("/tag/slug/proposals", a.tagController.AdminApplyTagSlugProposals)
+
+	// personal access token
+	r.GET("/personal-access-tokens/page", a.personalAccessTokenController.AdminGetPersonalAccessTokenPage)
+	r.PUT("/personal-access-token/revoke", a.personalAccessTokenController.AdminRevokePersonalAccessToken)
+
+	// two-factor authentication
+	r.PUT("/user/two-factor/reset", a.userTwoFactorController.AdminResetUserTwoFactor)
+
 	// user
 	r.GET("/users/page", a.adminUserController.GetUserPage)
 	r.PUT("/user/status", a.adminUserController.UpdateUserStatus)
 	r.PUT("/user/role", a.adminUserController.UpdateUserRole)
 	r.GET("/user/activation", a.adminUserController.GetUserActivation)
 	r.POST("/user/activation", a.adminUserController.SendUserActivation)
+	r.GET("/user/sessions", a.adminUserController.GetUserSessionList)
+	r.DELETE("/user/session", a.adminUserController.RevokeUserSession)
+	r.GET("/users/locked", a.adminUserController.GetLockedUserPage)
+	r.PUT("/user/unlock", a.adminUserController.UnlockUser)
+	r.GET("/user/login-logs", a.adminUserController.GetUserLoginLogPage)
 	r.POST("/user", a.adminUserController.AddUser)
 	r.POST("/users", a.adminUserController.AddUsers)
 	r.PUT("/user/password", a.adminUserController.UpdateUserPassword)
@@ -364,12 +441,26 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/setting/smtp", a.adminSiteInfoController.UpdateSMTPConfig)
 	r.GET("/setting/privileges", a.adminSiteInfoController.GetPrivilegesConfig)
 	r.PUT("/setting/privileges", a.adminSiteInfoController.UpdatePrivilegesConfig)
+	r.GET("/setting/action-policy", a.adminSiteInfoController.GetActionPolicy)
+	r.PUT("/setting/action-policy", a.adminSiteInfoController.UpdateActionPolicy)
+	r.GET("/setting/rate-limit", a.adminSiteInfoController.GetRateLimit)
+	r.PUT("/setting/rate-limit", a.adminSiteInfoController.UpdateRateLimit)
+	r.GET("/setting/rate-limit/stats", a.adminSiteInfoController.GetRateLimitStats)
+	r.GET("/setting/html-sanitizer", a.adminSiteInfoController.GetHTMLSanitizer)
+	r.PUT("/setting/html-sanitizer", a.adminSiteInfoController.UpdateHTMLSanitizer)
+	r.GET("/setting/audit-log", a.adminSiteInfoController.GetAuditLog)
+	r.PUT("/setting/audit-log", a.adminSiteInfoController.UpdateAuditLog)
 
 	// dashboard
 	r.GET("/dashboard", a.dashboardController.DashboardInfo)
 
 	// roles
 	r.GET("/roles", a.roleController.GetRoleList)
+	r.GET("/role/info", a.roleController.GetRoleInfo)
+	r.POST("/role", a.roleController.AddRole)
+	r.PUT("/role", a.roleController.UpdateRole)
+	r.DELETE("/role", a.roleController.RemoveRole)
+	r.GET("/powers", a.roleController.GetPowerList)
 
 	// plugin
 	r.GET("/plugins", a.pluginController.GetPluginList)
@@ -380,4 +471,15 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	// badge
 	r.GET("/badges", a.adminBadgeController.GetBadgeList)
 	r.PUT("/badge/status", a.adminBadgeController.UpdateBadgeStatus)
+
+	// content filter
+	r.GET("/content-filter/rules", a.contentFilterController.GetContentFilterRulePage)
+	r.POST("/content-filter/rules", a.contentFilterController.AddContentFilterRules)
+	r.PUT("/content-filter/rule", a.contentFilterController.UpdateContentFilterRule)
+	r.DELETE("/content-filter/rule", a.contentFilterController.RemoveContentFilterRule)
+	r.GET("/content-filter/logs", a.contentFilterController.GetContentFilterLogPage)
+
+	// audit log
+	r.GET("/audit-logs", a.auditLogController.GetAuditLogPage)
+	r.GET("/audit-logs/export", a.auditLogController.ExportAuditLog)
 }
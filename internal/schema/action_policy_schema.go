@@ -0,0 +1,117 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/incubator-answer/internal/entity"
+
+const (
+	// ActionMeasureNone let the action pass directly
+	ActionMeasureNone = "none"
+	// ActionMeasureCaptcha the action needs a captcha
+	ActionMeasureCaptcha = "captcha"
+	// ActionMeasureDelay the action is rejected until the interval or window is passed
+	ActionMeasureDelay = "delay"
+	// ActionMeasureBlock the action is rejected
+	ActionMeasureBlock = "block"
+)
+
+// ActionPolicyRule decides the measure of an action for the users whose reputation is in [MinReputation, MaxReputation).
+// The rule is triggered when the last action is within Interval seconds,
+// or the action has been done Limit times and the last one is within Window seconds.
+// A rule without Interval and Limit is always triggered.
+type ActionPolicyRule struct {
+	Action        string `validate:"required,oneof=email password edit_userinfo question answer comment edit invitation_answer search report delete vote article quote quote_author quote_piece" json:"action"`
+	MinReputation int    `validate:"gte=0" json:"min_reputation"`
+	// MaxReputation 0 means no upper limit
+	MaxReputation int `validate:"gte=0" json:"max_reputation"`
+	// Interval the minimum seconds between two actions
+	Interval int64 `validate:"gte=0" json:"interval"`
+	// Limit the maximum number of actions in the window
+	Limit int `validate:"gte=0" json:"limit"`
+	// Window the count of actions is reset when the last action is older than Window seconds, 0 means never reset
+	Window  int64  `validate:"gte=0" json:"window"`
+	Measure string `validate:"required,oneof=none captcha delay block" json:"measure"`
+}
+
+// MatchReputation whether the reputation is in the band of the rule
+func (r *ActionPolicyRule) MatchReputation(reputation int) bool {
+	if reputation < r.MinReputation {
+		return false
+	}
+	return r.MaxReputation == 0 || reputation < r.MaxReputation
+}
+
+// SiteActionPolicyReq site action policy request
+type SiteActionPolicyReq struct {
+	Rules []*ActionPolicyRule `validate:"dive" json:"rules"`
+}
+
+// SiteActionPolicyResp site action policy response
+type SiteActionPolicyResp SiteActionPolicyReq
+
+// GetRule get the first rule that matches the action and reputation
+func (s *SiteActionPolicyResp) GetRule(action string, reputation int) *ActionPolicyRule {
+	for _, rule := range s.Rules {
+		if rule.Action == action && rule.MatchReputation(reputation) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// DefaultActionPolicyRules the rules used when the admin has never saved the policy
+var DefaultActionPolicyRules = []*ActionPolicyRule{
+	{Action: entity.CaptchaActionEmail, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionPassword, Limit: 3, Window: 1800, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionEditUserinfo, Limit: 3, Window: 1800, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionQuestion, Interval: 5, Limit: 10, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionAnswer, Interval: 5, Limit: 10, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionComment, Interval: 1, Limit: 30, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionEdit, Limit: 10, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionInvitationAnswer, Limit: 30, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionSearch, Limit: 20, Window: 60, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionReport, Interval: 1, Limit: 30, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionDelete, Interval: 5, Limit: 5, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionVote, Limit: 40, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionArticle, Interval: 5, Limit: 10, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionQuote, Interval: 5, Limit: 10, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionQuoteAuthor, Interval: 5, Limit: 10, Window: 360, Measure: ActionMeasureCaptcha},
+	{Action: entity.CaptchaActionQuotePiece, Interval: 5, Limit: 10, Window: 360, Measure: ActionMeasureCaptcha},
+}
+
+// GetActionPolicyReq get action policy request
+type GetActionPolicyReq struct {
+	UserID  string `json:"-"`
+	IP      string `json:"-"`
+	IsAdmin bool   `json:"-"`
+}
+
+// GetActionPolicyResp the measure currently applied to the action for the user
+type GetActionPolicyResp struct {
+	Action  string `json:"action"`
+	Measure string `json:"measure"`
+	// RetryAfter seconds to wait when the measure is delay
+	RetryAfter int64 `json:"retry_after"`
+}
+
+// ActionPolicyTrTplData template data for the action policy messages
+type ActionPolicyTrTplData struct {
+	Seconds int64
+}
@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteActionPolicyResp_GetRule(t *testing.T) {
+	policy := &SiteActionPolicyResp{Rules: []*ActionPolicyRule{
+		{Action: "question", MaxReputation: 100, Interval: 60, Measure: ActionMeasureCaptcha},
+		{Action: "question", MinReputation: 100, MaxReputation: 1000, Interval: 5, Measure: ActionMeasureDelay},
+		{Action: "question", MinReputation: 1000, Measure: ActionMeasureNone},
+	}}
+
+	assert.Equal(t, ActionMeasureCaptcha, policy.GetRule("question", 0).Measure)
+	assert.Equal(t, ActionMeasureDelay, policy.GetRule("question", 100).Measure)
+	assert.Equal(t, ActionMeasureDelay, policy.GetRule("question", 999).Measure)
+	assert.Equal(t, ActionMeasureNone, policy.GetRule("question", 5000).Measure)
+	assert.Nil(t, policy.GetRule("answer", 0))
+}
@@ -116,6 +116,8 @@ type AnswerInfo struct {
 	VoteCount      int               `json:"vote_count"`
 	QuestionInfo   *QuestionInfoResp `json:"question_info,omitempty"`
 	Status         int               `json:"status"`
+	// whether the author is muted by the login user, the answer should be collapsed
+	Muted bool `json:"muted"`
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
@@ -96,7 +96,7 @@ type ArticleAdd struct {
 
 func (req *ArticleAdd) Check() (errFields []*validator.FormErrorField, err error) {
 	if req.ContentFormat == ArticleContentFormat_HTML {
-		req.HTML = req.Content
+		req.HTML = converter.SanitizeHTML(req.Content)
 		//req.Content = "" //清空
 	} else {
 		req.HTML = converter.Markdown2HTML(req.Content)
@@ -217,7 +217,7 @@ func (req *ArticleUpdate) Check() (errFields []*validator.FormErrorField, err er
 	if req.ContentFormat == ArticleContentFormat_MARKDOWN { //@cws
 		req.HTML = converter.Markdown2HTML(req.Content)
 	} else {
-		req.HTML = req.Content
+		req.HTML = converter.SanitizeHTML(req.Content)
 	}
 
 	return nil, nil
@@ -379,6 +379,8 @@ type ArticlePageReq struct {
 	Tag       string `validate:"omitempty,gt=0,lte=100" form:"tag"`
 	Username  string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	InDays    int    `validate:"omitempty,min=1" form:"in_days"`
+	// include the content of all descendant tags of the tag
+	IncludeDescendants bool `form:"include_descendants"`
 
 	LoginUserID      string `json:"-"`
 	UserIDBeSearched string `json:"-"`
@@ -427,6 +429,9 @@ type ArticlePageResp struct {
 	Operator      *ArticlePageRespOperator `json:"operator"`
 	OperationType string                   `json:"operation_type"`
 
+	// whether the author is muted by the login user, the item should be collapsed
+	Muted bool `json:"muted"`
+
 	Thumbnails []ArticleThumbnail `json:"thumbnails"`
 }
 
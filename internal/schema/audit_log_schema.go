@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+)
+
+// DefaultAuditLogRetentionDays the retention days used when the admin has never saved it
+const DefaultAuditLogRetentionDays = 180
+
+// AuditLogRecord the admin or moderator action to be recorded
+type AuditLogRecord struct {
+	// UserID the actor, the login user in the context is used if it is empty
+	UserID     string
+	Action     string
+	ObjectType string
+	ObjectID   string
+	// Before and After are marshalled to json, nil means no value
+	Before interface{}
+	After  interface{}
+}
+
+// GetAuditLogPageReq get audit log page request
+type GetAuditLogPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	AuditLogFilter
+}
+
+// AuditLogFilter the filter of audit log
+type AuditLogFilter struct {
+	// filter by actor user id
+	UserID     string `validate:"omitempty" form:"user_id"`
+	Action     string `validate:"omitempty,lte=64" form:"action"`
+	ObjectType string `validate:"omitempty,lte=64" form:"object_type"`
+	ObjectID   string `validate:"omitempty,lte=128" form:"object_id"`
+	// StartTime and EndTime are unix timestamps in seconds, 0 means no limit
+	StartTime int64 `validate:"omitempty,min=0" form:"start_time"`
+	EndTime   int64 `validate:"omitempty,min=0" form:"end_time"`
+}
+
+// ExportAuditLogReq export audit log request
+type ExportAuditLogReq struct {
+	AuditLogFilter
+}
+
+// Cond convert to the condition of audit log
+func (f *AuditLogFilter) Cond() (cond *entity.AuditLog, startTime, endTime time.Time) {
+	cond = &entity.AuditLog{
+		UserID:     f.UserID,
+		Action:     f.Action,
+		ObjectType: f.ObjectType,
+		ObjectID:   f.ObjectID,
+	}
+	if f.StartTime > 0 {
+		startTime = time.Unix(f.StartTime, 0)
+	}
+	if f.EndTime > 0 {
+		endTime = time.Unix(f.EndTime, 0)
+	}
+	return cond, startTime, endTime
+}
+
+// AuditLogResp audit log response
+type AuditLogResp struct {
+	ID          string          `json:"id"`
+	CreatedAt   int64           `json:"created_at"`
+	UserID      string          `json:"user_id"`
+	Username    string          `json:"username"`
+	DisplayName string          `json:"display_name"`
+	Action      string          `json:"action"`
+	ObjectType  string          `json:"object_type"`
+	ObjectID    string          `json:"object_id"`
+	Before      json.RawMessage `json:"before"`
+	After       json.RawMessage `json:"after"`
+	IP          string          `json:"ip"`
+}
+
+// NewAuditLogResp new audit log response
+func NewAuditLogResp(auditLog *entity.AuditLog) *AuditLogResp {
+	resp := &AuditLogResp{
+		ID:         auditLog.ID,
+		CreatedAt:  auditLog.CreatedAt.Unix(),
+		UserID:     auditLog.UserID,
+		Action:     auditLog.Action,
+		ObjectType: auditLog.ObjectType,
+		ObjectID:   auditLog.ObjectID,
+		IP:         auditLog.IP,
+	}
+	if resp.UserID == "0" {
+		resp.UserID = ""
+	}
+	if len(auditLog.BeforeValue) > 0 {
+		resp.Before = json.RawMessage(auditLog.BeforeValue)
+	}
+	if len(auditLog.AfterValue) > 0 {
+		resp.After = json.RawMessage(auditLog.AfterValue)
+	}
+	return resp
+}
+
+// SiteAuditLogReq site audit log request
+type SiteAuditLogReq struct {
+	// RetentionDays the audit log older than it is removed every day, 0 means keep forever
+	RetentionDays int `validate:"omitempty,min=0,max=3650" json:"retention_days"`
+}
+
+// SiteAuditLogResp site audit log response
+type SiteAuditLogResp SiteAuditLogReq
@@ -192,6 +192,9 @@ type GetCommentResp struct {
 	// reply user status
 	ReplyUserStatus string `json:"reply_user_status"`
 
+	// whether the comment user is muted by the current user, the comment should be collapsed
+	Muted bool `json:"muted"`
+
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
 }
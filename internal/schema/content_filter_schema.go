@@ -0,0 +1,264 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// The field name of the content checked by the content filter
+const (
+	ContentFilterFieldTitle       = "title"
+	ContentFilterFieldContent     = "content"
+	ContentFilterFieldTag         = "tag"
+	ContentFilterFieldUsername    = "username"
+	ContentFilterFieldDisplayName = "display_name"
+	ContentFilterFieldBio         = "bio"
+)
+
+// ContentFilterField the text to be checked by the content filter
+type ContentFilterField struct {
+	// Name the field name, such as title or content
+	Name string
+	// Text the original text, the matched words are masked in place if the severity is mask
+	Text *string
+	// HTML the parsed html of Text, re-rendered after Text is masked. It's optional.
+	HTML *string
+	// IsHTML whether the Text is written in html format rather than markdown
+	IsHTML bool
+	// RejectOnMask reject the content instead of masking it, such as username or tag slug name
+	RejectOnMask bool
+}
+
+// ContentFilterReq content filter request
+type ContentFilterReq struct {
+	UserID     string
+	ObjectType string
+	// ObjectID is empty when the object is not created yet
+	ObjectID string
+	Fields   []*ContentFilterField
+}
+
+// NewContentFilterReq new content filter request
+func NewContentFilterReq(userID, objectType, objectID string) *ContentFilterReq {
+	return &ContentFilterReq{
+		UserID:     userID,
+		ObjectType: objectType,
+		ObjectID:   objectID,
+	}
+}
+
+// AddField add the field to be checked, empty text is ignored
+func (r *ContentFilterReq) AddField(field *ContentFilterField) *ContentFilterReq {
+	if field.Text != nil && len(*field.Text) > 0 {
+		r.Fields = append(r.Fields, field)
+	}
+	return r
+}
+
+// AddText add the plain text field, such as title or bio
+func (r *ContentFilterReq) AddText(name string, text *string) *ContentFilterReq {
+	return r.AddField(&ContentFilterField{Name: name, Text: text})
+}
+
+// AddContent add the content field with its parsed html
+func (r *ContentFilterReq) AddContent(text, html *string, isHTML bool) *ContentFilterReq {
+	return r.AddField(&ContentFilterField{Name: ContentFilterFieldContent, Text: text, HTML: html, IsHTML: isHTML})
+}
+
+// AddName add the name field which can not be masked, such as username
+func (r *ContentFilterReq) AddName(name string, text *string) *ContentFilterReq {
+	return r.AddField(&ContentFilterField{Name: name, Text: text, RejectOnMask: true})
+}
+
+// AddTags add the slug name, display name and description of the tags
+func (r *ContentFilterReq) AddTags(tags []*TagItem) *ContentFilterReq {
+	for _, tag := range tags {
+		r.AddName(ContentFilterFieldTag, &tag.SlugName)
+		r.AddName(ContentFilterFieldTag, &tag.DisplayName)
+		r.AddField(&ContentFilterField{Name: ContentFilterFieldTag, Text: &tag.OriginalText, HTML: &tag.ParsedText})
+	}
+	return r
+}
+
+// ContentFilterRejectedTrTplData the template data of content filter rejected error message
+type ContentFilterRejectedTrTplData struct {
+	Words string
+}
+
+// ContentFilterReviewReasonTrTplData the template data of the review reason submitted by the content filter
+type ContentFilterReviewReasonTrTplData struct {
+	Pattern string
+}
+
+// AddContentFilterRuleReq add content filter rule request
+type AddContentFilterRuleReq struct {
+	// rule type: word, regex or domain
+	RuleType string `validate:"required,oneof=word regex domain" json:"rule_type"`
+	// add several rules with the same type and severity at once
+	Patterns []string `validate:"required,min=1,max=1000,dive,required,notblank,lte=255" json:"patterns"`
+	// severity: mask, review or reject
+	Severity string `validate:"required,oneof=mask review reject" json:"severity"`
+	UserID   string `json:"-"`
+}
+
+func (r *AddContentFilterRuleReq) Check() (errFields []*validator.FormErrorField, err error) {
+	for i, pattern := range r.Patterns {
+		r.Patterns[i], errFields, err = checkContentFilterPattern(r.RuleType, pattern, "patterns")
+		if err != nil {
+			return errFields, err
+		}
+	}
+	return nil, nil
+}
+
+// UpdateContentFilterRuleReq update content filter rule request
+type UpdateContentFilterRuleReq struct {
+	ID       string `validate:"required" json:"id"`
+	RuleType string `validate:"required,oneof=word regex domain" json:"rule_type"`
+	Pattern  string `validate:"required,notblank,lte=255" json:"pattern"`
+	Severity string `validate:"required,oneof=mask review reject" json:"severity"`
+}
+
+func (r *UpdateContentFilterRuleReq) Check() (errFields []*validator.FormErrorField, err error) {
+	r.Pattern, errFields, err = checkContentFilterPattern(r.RuleType, r.Pattern, "pattern")
+	return errFields, err
+}
+
+// checkContentFilterPattern trim the pattern and check whether the regex can be compiled
+func checkContentFilterPattern(ruleType, pattern, fieldName string) (
+	trimmed string, errFields []*validator.FormErrorField, err error) {
+	trimmed = strings.TrimSpace(pattern)
+	switch ruleType {
+	case "regex":
+		if _, err = regexp.Compile(trimmed); err != nil {
+			return trimmed, append(errFields, &validator.FormErrorField{
+				ErrorField: fieldName,
+				ErrorMsg:   reason.ContentFilterRegexInvalid,
+			}), errors.BadRequest(reason.ContentFilterRegexInvalid)
+		}
+	case "domain":
+		trimmed = strings.TrimPrefix(strings.ToLower(trimmed), "*.")
+	}
+	return trimmed, nil, nil
+}
+
+// RemoveContentFilterRuleReq remove content filter rule request
+type RemoveContentFilterRuleReq struct {
+	ID string `validate:"required" json:"id"`
+}
+
+// GetContentFilterRulePageReq get content filter rule page request
+type GetContentFilterRulePageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// filter by rule type
+	RuleType string `validate:"omitempty,oneof=word regex domain" form:"rule_type"`
+	// filter by severity
+	Severity string `validate:"omitempty,oneof=mask review reject" form:"severity"`
+	// search the pattern
+	Query string `validate:"omitempty,lte=100" form:"query"`
+}
+
+// ContentFilterRuleResp content filter rule response
+type ContentFilterRuleResp struct {
+	ID        string `json:"id"`
+	RuleType  string `json:"rule_type"`
+	Pattern   string `json:"pattern"`
+	Severity  string `json:"severity"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// NewContentFilterRuleResp new content filter rule response
+func NewContentFilterRuleResp(rule *entity.ContentFilterRule) *ContentFilterRuleResp {
+	return &ContentFilterRuleResp{
+		ID:        rule.ID,
+		RuleType:  entity.ContentFilterRuleTypeIntToString[rule.RuleType],
+		Pattern:   rule.Pattern,
+		Severity:  entity.ContentFilterSeverityIntToString[rule.Severity],
+		CreatedAt: rule.CreatedAt.Unix(),
+		UpdatedAt: rule.UpdatedAt.Unix(),
+	}
+}
+
+// GetContentFilterLogPageReq get content filter log page request
+type GetContentFilterLogPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// filter by user id
+	UserID string `validate:"omitempty" form:"user_id"`
+	// filter by object type, such as question, article or tq_quote
+	ObjectType string `validate:"omitempty,lte=32" form:"object_type"`
+	// filter by severity
+	Severity string `validate:"omitempty,oneof=mask review reject" form:"severity"`
+}
+
+// ContentFilterLogResp content filter log response
+type ContentFilterLogResp struct {
+	ID          string `json:"id"`
+	CreatedAt   int64  `json:"created_at"`
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	ObjectType  string `json:"object_type"`
+	ObjectID    string `json:"object_id"`
+	Field       string `json:"field"`
+	RuleID      string `json:"rule_id"`
+	RuleType    string `json:"rule_type"`
+	Pattern     string `json:"pattern"`
+	Severity    string `json:"severity"`
+	Matched     string `json:"matched"`
+	Excerpt     string `json:"excerpt"`
+}
+
+// NewContentFilterLogResp new content filter log response
+func NewContentFilterLogResp(filterLog *entity.ContentFilterLog) *ContentFilterLogResp {
+	resp := &ContentFilterLogResp{
+		ID:         filterLog.ID,
+		CreatedAt:  filterLog.CreatedAt.Unix(),
+		UserID:     filterLog.UserID,
+		ObjectType: filterLog.ObjectType,
+		ObjectID:   filterLog.ObjectID,
+		Field:      filterLog.Field,
+		RuleID:     filterLog.RuleID,
+		RuleType:   entity.ContentFilterRuleTypeIntToString[filterLog.RuleType],
+		Pattern:    filterLog.Pattern,
+		Severity:   entity.ContentFilterSeverityIntToString[filterLog.Severity],
+		Matched:    filterLog.Matched,
+		Excerpt:    filterLog.Excerpt,
+	}
+	if resp.UserID == "0" {
+		resp.UserID = ""
+	}
+	if resp.ObjectID == "0" {
+		resp.ObjectID = ""
+	}
+	if resp.RuleID == "0" {
+		resp.RuleID = ""
+	}
+	return resp
+}
@@ -142,3 +142,51 @@ type NewQuestionTemplateData struct {
 	Tags           string
 	UnsubscribeUrl string
 }
+
+type SavedSearchAlertTemplateRawData struct {
+	SavedSearchName string
+	Query           string
+	NewCount        int64
+	Titles          []string
+	UnsubscribeCode string
+}
+
+type SavedSearchAlertTemplateData struct {
+	SiteName        string
+	SavedSearchName string
+	NewCount        int64
+	Titles          string
+	SearchUrl       string
+	UnsubscribeUrl  string
+}
+
+type MentionTemplateRawData struct {
+	TriggerUserDisplayName string
+	Title                  string
+	QuestionID             string
+	AnswerID               string
+	CommentID              string
+	Summary                string
+	UnsubscribeCode        string
+}
+
+type MentionTemplateData struct {
+	SiteName       string
+	DisplayName    string
+	Title          string
+	MentionUrl     string
+	Summary        string
+	UnsubscribeUrl string
+}
+
+type DataExportReadyTemplateData struct {
+	SiteName    string
+	DownloadUrl string
+	ExpiredAt   string
+}
+
+type LoginLockoutTemplateData struct {
+	SiteName    string
+	IP          string
+	LockedUntil string
+}
@@ -25,6 +25,8 @@ type FollowReq struct {
 	ObjectID string `validate:"required" form:"object_id" json:"object_id"`
 	// is cancel
 	IsCancel bool `validate:"omitempty" form:"is_cancel" json:"is_cancel"`
+	// if the object is a tag, also follow or cancel follow all its descendant tags
+	IncludeDescendants bool `validate:"omitempty" form:"include_descendants" json:"include_descendants"`
 }
 
 // FollowResp response object's follows and current user follow status
@@ -40,6 +42,8 @@ type FollowDTO struct {
 	ObjectID string
 	// is cancel
 	IsCancel bool
+	// also follow or cancel follow all descendant tags
+	IncludeDescendants bool
 	// user TagID
 	UserID string
 }
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/incubator-answer/pkg/converter"
+
+// SiteHTMLSanitizerReq the allowlist of html-format content, on top of the basic policy of markdown content.
+// The unsafe entries, such as script element or event handler attributes, are ignored by the sanitizer.
+type SiteHTMLSanitizerReq struct {
+	Elements   []string `validate:"omitempty,dive,gt=0,lte=32" json:"elements"`
+	Attributes []string `validate:"omitempty,dive,gt=0,lte=32" json:"attributes"`
+	// the schemes allowed in href and src, "data" only permits inline images
+	URLSchemes []string `validate:"omitempty,dive,gt=0,lte=32" json:"url_schemes"`
+	// the hosts allowed to be embedded by https iframe, such as www.youtube.com
+	IframeHosts []string `validate:"omitempty,dive,gt=0,lte=255" json:"iframe_hosts"`
+}
+
+// SiteHTMLSanitizerResp the allowlist of html-format content
+type SiteHTMLSanitizerResp SiteHTMLSanitizerReq
+
+// HTMLAllowlist convert to the allowlist of converter
+func (r *SiteHTMLSanitizerResp) HTMLAllowlist() *converter.HTMLAllowlist {
+	return &converter.HTMLAllowlist{
+		Elements:    r.Elements,
+		Attributes:  r.Attributes,
+		URLSchemes:  r.URLSchemes,
+		IframeHosts: r.IframeHosts,
+	}
+}
+
+// NewDefaultSiteHTMLSanitizer the allowlist used when the admin has never saved it
+func NewDefaultSiteHTMLSanitizer() *SiteHTMLSanitizerResp {
+	allowlist := converter.DefaultHTMLAllowlist()
+	return &SiteHTMLSanitizerResp{
+		Elements:    allowlist.Elements,
+		Attributes:  allowlist.Attributes,
+		URLSchemes:  allowlist.URLSchemes,
+		IframeHosts: allowlist.IframeHosts,
+	}
+}
@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// MentionNotificationReq the content that mentions users
+type MentionNotificationReq struct {
+	// ObjectID the id of the question, answer, article, quote or comment that mentions users
+	ObjectID   string
+	ObjectType string
+	Title      string
+	// QuestionID the id of the question, article or quote the object belongs to
+	QuestionID    string
+	AnswerID      string
+	CommentID     string
+	TriggerUserID string
+	ParsedText    string
+	// OldParsedText the content before edit, the users mentioned in it are not notified again
+	OldParsedText string
+	// MentionUsernameList the usernames mentioned by the client besides the content
+	MentionUsernameList []string
+	// SkipUserIDs the users already notified by other notifications
+	SkipUserIDs map[string]bool
+}
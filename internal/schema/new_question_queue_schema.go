@@ -29,10 +29,12 @@ type ExternalNotificationMsg struct {
 	ReceiverEmail  string `json:"receiver_email"`
 	ReceiverLang   string `json:"receiver_lang"`
 
-	NewAnswerTemplateRawData       *NewAnswerTemplateRawData       `json:"new_answer_template_raw_data,omitempty"`
-	NewInviteAnswerTemplateRawData *NewInviteAnswerTemplateRawData `json:"new_invite_answer_template_raw_data,omitempty"`
-	NewCommentTemplateRawData      *NewCommentTemplateRawData      `json:"new_comment_template_raw_data,omitempty"`
-	NewQuestionTemplateRawData     *NewQuestionTemplateRawData     `json:"new_question_template_raw_data,omitempty"`
+	NewAnswerTemplateRawData       *NewAnswerTemplateRawData        `json:"new_answer_template_raw_data,omitempty"`
+	NewInviteAnswerTemplateRawData *NewInviteAnswerTemplateRawData  `json:"new_invite_answer_template_raw_data,omitempty"`
+	NewCommentTemplateRawData      *NewCommentTemplateRawData       `json:"new_comment_template_raw_data,omitempty"`
+	NewQuestionTemplateRawData     *NewQuestionTemplateRawData      `json:"new_question_template_raw_data,omitempty"`
+	SavedSearchAlertRawData        *SavedSearchAlertTemplateRawData `json:"saved_search_alert_raw_data,omitempty"`
+	MentionTemplateRawData         *MentionTemplateRawData          `json:"mention_template_raw_data,omitempty"`
 }
 
 func CreateNewQuestionNotificationMsg(
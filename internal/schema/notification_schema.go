@@ -38,6 +38,9 @@ const (
 	NotificationInboxTypeInvites = 3
 )
 
+// NotificationExtraRejectReason the extra info key of the reason why the post is rejected by the reviewer
+const NotificationExtraRejectReason = "reject_reason"
+
 var NotificationType = map[string]int{
 	"inbox":       NotificationTypeInbox,
 	"achievement": NotificationTypeAchievement,
@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/entity"
+)
+
+// AddPersonalAccessTokenReq add personal access token request
+type AddPersonalAccessTokenReq struct {
+	// token name
+	Name string `validate:"required,notblank,gte=1,lte=100" json:"name"`
+	// token scopes
+	Scopes []string `validate:"required,min=1,dive,oneof=read write admin" json:"scopes" enums:"read,write,admin"`
+	// expired time, unix timestamp in seconds, 0 means never expire
+	ExpiredAt int64 `validate:"omitempty,min=0" json:"expired_at"`
+	// user id
+	UserID string `json:"-"`
+	// whether the user is admin
+	IsAdmin bool `json:"-"`
+}
+
+// AddPersonalAccessTokenResp add personal access token response, the token is only shown once
+type AddPersonalAccessTokenResp struct {
+	*PersonalAccessTokenResp
+	Token string `json:"token"`
+}
+
+// RevokePersonalAccessTokenReq revoke personal access token request
+type RevokePersonalAccessTokenReq struct {
+	// token id
+	ID string `validate:"required" json:"id"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// PersonalAccessTokenResp personal access token response
+type PersonalAccessTokenResp struct {
+	ID string `json:"id"`
+	// token name
+	Name string `json:"name"`
+	// the beginning of the token, used to recognize the token
+	TokenPrefix string   `json:"token_prefix"`
+	Scopes      []string `json:"scopes"`
+	Status      string   `json:"status"`
+	CreatedAt   int64    `json:"created_at"`
+	// 0 means never expire
+	ExpiredAt int64 `json:"expired_at"`
+	// 0 means never used
+	LastUsedAt int64 `json:"last_used_at"`
+}
+
+// NewPersonalAccessTokenResp convert personal access token entity to response
+func NewPersonalAccessTokenResp(token *entity.PersonalAccessToken) *PersonalAccessTokenResp {
+	resp := &PersonalAccessTokenResp{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      strings.Split(token.Scopes, ","),
+		Status:      PersonalAccessTokenStatusDisplayMapping[token.Status],
+		CreatedAt:   token.CreatedAt.Unix(),
+	}
+	if !token.ExpiredAt.IsZero() {
+		resp.ExpiredAt = token.ExpiredAt.Unix()
+	}
+	if !token.LastUsedAt.IsZero() {
+		resp.LastUsedAt = token.LastUsedAt.Unix()
+	}
+	return resp
+}
+
+// PersonalAccessTokenStatusDisplayMapping personal access token status display
+var PersonalAccessTokenStatusDisplayMapping = map[int]string{
+	entity.PersonalAccessTokenStatusAvailable: "available",
+	entity.PersonalAccessTokenStatusRevoked:   "revoked",
+}
+
+// GetPersonalAccessTokenPageReq get personal access token page request
+type GetPersonalAccessTokenPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// filter by user id
+	UserID string `validate:"omitempty" form:"user_id"`
+	// filter by status
+	Status string `validate:"omitempty,oneof=available revoked" form:"status"`
+}
+
+// GetPersonalAccessTokenPageResp get personal access token page response
+type GetPersonalAccessTokenPageResp struct {
+	*PersonalAccessTokenResp
+	// the owner of the token
+	UserInfo *UserBasicInfo `json:"user_info"`
+}
+
+// AdminRevokePersonalAccessTokenReq admin revoke personal access token request
+type AdminRevokePersonalAccessTokenReq struct {
+	// token id
+	ID string `validate:"required" json:"id"`
+	// operator user id
+	UserID string `json:"-"`
+}
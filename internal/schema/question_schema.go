@@ -359,6 +359,8 @@ type QuestionPageReq struct {
 	Tag       string `validate:"omitempty,gt=0,lte=100" form:"tag"`
 	Username  string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	InDays    int    `validate:"omitempty,min=1" form:"in_days"`
+	// include the content of all descendant tags of the tag
+	IncludeDescendants bool `form:"include_descendants"`
 
 	LoginUserID      string `json:"-"`
 	UserIDBeSearched string `json:"-"`
@@ -401,6 +403,9 @@ type QuestionPageResp struct {
 	OperatedAt    int64                     `json:"operated_at"`
 	Operator      *QuestionPageRespOperator `json:"operator"`
 	OperationType string                    `json:"operation_type"`
+
+	// whether the author is muted by the login user, the item should be collapsed
+	Muted bool `json:"muted"`
 }
 
 type QuestionPageRespOperator struct {
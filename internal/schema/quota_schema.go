@@ -103,7 +103,7 @@ type QuoteAdd struct {
 
 func (req *QuoteAdd) Check() (errFields []*validator.FormErrorField, err error) {
 	if req.ContentFormat == QuoteContentFormat_HTML {
-		req.HTML = req.Content
+		req.HTML = converter.SanitizeHTML(req.Content)
 		//req.Content = "" //清空
 	} else {
 		req.HTML = converter.Markdown2HTML(req.Content)
@@ -224,7 +224,7 @@ func (req *QuoteUpdate) Check() (errFields []*validator.FormErrorField, err erro
 	if req.ContentFormat == QuoteContentFormat_MARKDOWN { //@cws
 		req.HTML = converter.Markdown2HTML(req.Content)
 	} else {
-		req.HTML = req.Content
+		req.HTML = converter.SanitizeHTML(req.Content)
 	}
 
 	return nil, nil
@@ -403,6 +403,8 @@ type QuotePageReq struct {
 	Tag       string `validate:"omitempty,gt=0,lte=100" form:"tag"`
 	Username  string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	InDays    int    `validate:"omitempty,min=1" form:"in_days"`
+	// include the content of all descendant tags of the tag
+	IncludeDescendants bool `form:"include_descendants"`
 
 	LoginUserID      string `json:"-"`
 	UserIDBeSearched string `json:"-"`
@@ -451,6 +453,9 @@ type QuotePageResp struct {
 	Operator      *QuotePageRespOperator `json:"operator"`
 	OperationType string                 `json:"operation_type"`
 
+	// whether the author is muted by the login user, the item should be collapsed
+	Muted bool `json:"muted"`
+
 	Thumbnails []QuoteThumbnail `json:"thumbnails"`
 
 	QuoteAuthorId string `json:"quote_author_id"`
@@ -96,7 +96,7 @@ type QuoteAuthorAdd struct {
 
 func (req *QuoteAuthorAdd) Check() (errFields []*validator.FormErrorField, err error) {
 	if req.ContentFormat == QuoteAuthorContentFormat_HTML {
-		req.HTML = req.Content
+		req.HTML = converter.SanitizeHTML(req.Content)
 		//req.Content = "" //清空
 	} else {
 		req.HTML = converter.Markdown2HTML(req.Content)
@@ -110,6 +110,14 @@ func (req *QuoteAuthorAdd) Check() (errFields []*validator.FormErrorField, err e
 	return nil, nil
 }
 
+// BioText the bio to be saved, the html-format one is sanitized
+func (req *QuoteAuthorAdd) BioText() string {
+	if req.ContentFormat == QuoteAuthorContentFormat_HTML {
+		return req.HTML
+	}
+	return req.Content
+}
+
 type QuoteAuthorAddByAnswer struct {
 	// question title
 	AuthorName string `validate:"required,notblank,gte=6,lte=150" json:"author_name"`
@@ -217,12 +225,20 @@ func (req *QuoteAuthorUpdate) Check() (errFields []*validator.FormErrorField, er
 	if req.ContentFormat == QuoteAuthorContentFormat_MARKDOWN { //@cws
 		req.HTML = converter.Markdown2HTML(req.Content)
 	} else {
-		req.HTML = req.Content
+		req.HTML = converter.SanitizeHTML(req.Content)
 	}
 
 	return nil, nil
 }
 
+// BioText the bio to be saved, the html-format one is sanitized
+func (req *QuoteAuthorUpdate) BioText() string {
+	if req.ContentFormat != QuoteAuthorContentFormat_MARKDOWN {
+		return req.HTML
+	}
+	return req.Content
+}
+
 type QuoteAuthorBaseInfo struct {
 	ID              string `json:"id" `
 	AuthorName      string `json:"author_name"`
@@ -427,6 +443,9 @@ type QuoteAuthorPageResp struct {
 	Operator      *QuoteAuthorPageRespOperator `json:"operator"`
 	OperationType string                       `json:"operation_type"`
 
+	// whether the author is muted by the login user, the item should be collapsed
+	Muted bool `json:"muted"`
+
 	Thumbnails []QuoteAuthorThumbnail `json:"thumbnails"`
 }
 
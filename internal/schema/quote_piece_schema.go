@@ -96,7 +96,7 @@ type QuotePieceAdd struct {
 
 func (req *QuotePieceAdd) Check() (errFields []*validator.FormErrorField, err error) {
 	if req.ContentFormat == QuotePieceContentFormat_HTML {
-		req.HTML = req.Content
+		req.HTML = converter.SanitizeHTML(req.Content)
 		//req.Content = "" //清空
 	} else {
 		req.HTML = converter.Markdown2HTML(req.Content)
@@ -217,7 +217,7 @@ func (req *QuotePieceUpdate) Check() (errFields []*validator.FormErrorField, err
 	if req.ContentFormat == QuotePieceContentFormat_MARKDOWN { //@cws
 		req.HTML = converter.Markdown2HTML(req.Content)
 	} else {
-		req.HTML = req.Content
+		req.HTML = converter.SanitizeHTML(req.Content)
 	}
 
 	return nil, nil
@@ -427,6 +427,9 @@ type QuotePiecePageResp struct {
 	Operator      *QuotePiecePageRespOperator `json:"operator"`
 	OperationType string                      `json:"operation_type"`
 
+	// whether the author is muted by the login user, the item should be collapsed
+	Muted bool `json:"muted"`
+
 	Thumbnails []QuotePieceThumbnail `json:"thumbnails"`
 }
 
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// RateLimitGroupAuto the group is decided by the request method and path
+	RateLimitGroupAuto   = ""
+	RateLimitGroupRead   = "read"
+	RateLimitGroupWrite  = "write"
+	RateLimitGroupSearch = "search"
+	RateLimitGroupAuth   = "auth"
+
+	// RateLimitScopeUser the requests of the login user are limited by user id
+	RateLimitScopeUser = "user"
+	// RateLimitScopeIP the requests without login are limited by client ip
+	RateLimitScopeIP = "ip"
+)
+
+// RateLimitGroupList all the route groups that can be limited
+var RateLimitGroupList = []string{RateLimitGroupRead, RateLimitGroupWrite, RateLimitGroupSearch, RateLimitGroupAuth}
+
+// RateLimitRule the token bucket of the route group for the users whose reputation is in [MinReputation, MaxReputation).
+// The bucket holds at most Capacity tokens and is refilled by RefillPerMinute tokens per minute, each request takes one.
+type RateLimitRule struct {
+	Group         string `validate:"required,oneof=read write search auth" json:"group"`
+	Scope         string `validate:"required,oneof=user ip" json:"scope"`
+	MinReputation int    `validate:"gte=0" json:"min_reputation"`
+	// MaxReputation 0 means no upper limit
+	MaxReputation   int   `validate:"gte=0" json:"max_reputation"`
+	Capacity        int64 `validate:"required,gte=1" json:"capacity"`
+	RefillPerMinute int64 `validate:"required,gte=1" json:"refill_per_minute"`
+}
+
+// MatchReputation whether the reputation is in the band of the rule
+func (r *RateLimitRule) MatchReputation(reputation int) bool {
+	if reputation < r.MinReputation {
+		return false
+	}
+	return r.MaxReputation == 0 || reputation < r.MaxReputation
+}
+
+// SiteRateLimitReq site rate limit request
+type SiteRateLimitReq struct {
+	Enabled bool             `json:"enabled"`
+	Rules   []*RateLimitRule `validate:"dive" json:"rules"`
+	// TrustedUserIDs the requests with the personal access token of these users are never limited
+	TrustedUserIDs []string `validate:"dive,required" json:"trusted_user_ids"`
+}
+
+// SiteRateLimitResp site rate limit response
+type SiteRateLimitResp SiteRateLimitReq
+
+// GetRule get the first rule that matches the group, scope and reputation
+func (s *SiteRateLimitResp) GetRule(group, scope string, reputation int) *RateLimitRule {
+	for _, rule := range s.Rules {
+		if rule.Group == group && rule.Scope == scope && rule.MatchReputation(reputation) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// IsTrustedUser whether the user is in the allowlist
+func (s *SiteRateLimitResp) IsTrustedUser(userID string) bool {
+	for _, id := range s.TrustedUserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultSiteRateLimit the config used when the admin has never saved it.
+// It is disabled, so the upgraded sites are not limited until the admin checks the rules and saves them.
+var DefaultSiteRateLimit = &SiteRateLimitResp{
+	Enabled: false,
+	Rules: []*RateLimitRule{
+		{Group: RateLimitGroupAuth, Scope: RateLimitScopeIP, Capacity: 20, RefillPerMinute: 10},
+		{Group: RateLimitGroupAuth, Scope: RateLimitScopeUser, Capacity: 20, RefillPerMinute: 10},
+		{Group: RateLimitGroupWrite, Scope: RateLimitScopeIP, Capacity: 30, RefillPerMinute: 15},
+		{Group: RateLimitGroupWrite, Scope: RateLimitScopeUser, MaxReputation: 100, Capacity: 30, RefillPerMinute: 15},
+		{Group: RateLimitGroupWrite, Scope: RateLimitScopeUser, MinReputation: 100, Capacity: 120, RefillPerMinute: 60},
+		{Group: RateLimitGroupSearch, Scope: RateLimitScopeIP, Capacity: 30, RefillPerMinute: 20},
+		{Group: RateLimitGroupSearch, Scope: RateLimitScopeUser, Capacity: 60, RefillPerMinute: 40},
+		{Group: RateLimitGroupRead, Scope: RateLimitScopeIP, Capacity: 300, RefillPerMinute: 300},
+		{Group: RateLimitGroupRead, Scope: RateLimitScopeUser, Capacity: 600, RefillPerMinute: 600},
+	},
+	TrustedUserIDs: []string{},
+}
+
+// GetRateLimitStatsResp the requests allowed and rejected by the rate limit today
+type GetRateLimitStatsResp struct {
+	Group    string `json:"group"`
+	Allowed  int64  `json:"allowed"`
+	Rejected int64  `json:"rejected"`
+}
@@ -28,8 +28,10 @@ import (
 type UpdateReviewReq struct {
 	ReviewID int    `validate:"required" json:"review_id"`
 	Status   string `validate:"required,oneof=approve reject" json:"status"`
-	UserID   string `json:"-"`
-	IsAdmin  bool   `json:"-"`
+	// RejectReason tell the author why the post is rejected
+	RejectReason string `validate:"omitempty,lte=500" json:"reject_reason"`
+	UserID       string `json:"-"`
+	IsAdmin      bool   `json:"-"`
 }
 
 func (r *UpdateReviewReq) IsApprove() bool {
@@ -65,7 +67,7 @@ type GetUnreviewedPostPageResp struct {
 	QuestionID           string        `json:"question_id"`
 	AnswerID             string        `json:"answer_id"`
 	CommentID            string        `json:"comment_id"`
-	ObjectType           string        `json:"object_type" enums:"question,answer,comment"`
+	ObjectType           string        `json:"object_type" enums:"question,answer,comment,article,tq_quote,tq_quote_author,tq_quote_piece"`
 	Title                string        `json:"title"`
 	UrlTitle             string        `json:"url_title"`
 	OriginalText         string        `json:"original_text"`
@@ -77,4 +79,11 @@ type GetUnreviewedPostPageResp struct {
 	SubmitAt             int64         `json:"submit_at"`
 	SubmitterDisplayName string        `json:"submitter_display_name"`
 	Reason               string        `json:"reason"`
+	// SubmittedTitle and SubmittedContent are what was sent to review
+	SubmittedTitle   string `json:"submitted_title"`
+	SubmittedContent string `json:"submitted_content"`
+	// BaseTitle and BaseContent are the last approved version, diff them with the title and parsed text
+	BaseTitle      string `json:"base_title"`
+	BaseContent    string `json:"base_content"`
+	ContentChanged bool   `json:"content_changed"`
 }
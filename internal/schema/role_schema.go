@@ -24,4 +24,44 @@ type GetRoleResp struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// built-in roles can not be deleted
+	BuiltIn bool `json:"built_in"`
+}
+
+// GetRoleInfoReq get role info request
+type GetRoleInfoReq struct {
+	ID int `validate:"required" form:"id"`
+}
+
+// GetRoleInfoResp get role info response
+type GetRoleInfoResp struct {
+	GetRoleResp
+	Powers []string `json:"powers"`
+}
+
+// AddRoleReq add role request
+type AddRoleReq struct {
+	Name        string   `validate:"required,notblank,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	Powers      []string `validate:"omitempty,dive,required" json:"powers"`
+}
+
+// UpdateRoleReq update role request
+type UpdateRoleReq struct {
+	ID          int      `validate:"required" json:"id"`
+	Name        string   `validate:"required,notblank,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	Powers      []string `validate:"omitempty,dive,required" json:"powers"`
+}
+
+// RemoveRoleReq remove role request
+type RemoveRoleReq struct {
+	ID int `validate:"required" json:"id"`
+}
+
+// GetPowerResp get power response
+type GetPowerResp struct {
+	PowerType string `json:"power_type"`
+	// the object type the power acts on, such as question, article and quote
+	Group string `json:"group"`
 }
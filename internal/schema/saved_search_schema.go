@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/incubator-answer/internal/entity"
+)
+
+// AddSavedSearchReq add saved search request
+type AddSavedSearchReq struct {
+	// saved search name
+	Name string `validate:"required,notblank,gte=1,lte=100" json:"name"`
+	// search query, the same as the query of search
+	Query string `validate:"required,notblank,gte=1,lte=255" json:"query"`
+	// whether to alert the new matching contents
+	Alert bool `json:"alert"`
+	// alert frequency
+	AlertFrequency string `validate:"omitempty,oneof=hourly daily weekly" json:"alert_frequency" enums:"hourly,daily,weekly"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// UpdateSavedSearchReq update saved search request
+type UpdateSavedSearchReq struct {
+	// saved search id
+	ID string `validate:"required" json:"id"`
+	// saved search name
+	Name string `validate:"required,notblank,gte=1,lte=100" json:"name"`
+	// search query, the same as the query of search
+	Query string `validate:"required,notblank,gte=1,lte=255" json:"query"`
+	// whether to alert the new matching contents
+	Alert bool `json:"alert"`
+	// alert frequency
+	AlertFrequency string `validate:"omitempty,oneof=hourly daily weekly" json:"alert_frequency" enums:"hourly,daily,weekly"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// RemoveSavedSearchReq remove saved search request
+type RemoveSavedSearchReq struct {
+	// saved search id
+	ID string `validate:"required" json:"id"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// SavedSearchResp saved search response
+type SavedSearchResp struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Query          string `json:"query"`
+	Alert          bool   `json:"alert"`
+	AlertFrequency string `json:"alert_frequency"`
+	CreatedAt      int64  `json:"created_at"`
+	// the created time of the newest content that has been alerted
+	LastSeenAt int64 `json:"last_seen_at"`
+}
+
+// NewSavedSearchResp convert saved search entity to response
+func NewSavedSearchResp(savedSearch *entity.SavedSearch) *SavedSearchResp {
+	resp := &SavedSearchResp{
+		ID:             savedSearch.ID,
+		Name:           savedSearch.Name,
+		Query:          savedSearch.Query,
+		Alert:          savedSearch.Alert,
+		AlertFrequency: savedSearch.AlertFrequency,
+		CreatedAt:      savedSearch.CreatedAt.Unix(),
+	}
+	if !savedSearch.LastSeenAt.IsZero() {
+		resp.LastSeenAt = savedSearch.LastSeenAt.Unix()
+	}
+	return resp
+}
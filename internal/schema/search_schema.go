@@ -22,6 +22,7 @@ package schema
 import (
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/validator"
@@ -35,7 +36,9 @@ type SearchDTO struct {
 	Order       string `validate:"required,oneof=newest active score relevance" form:"order,default=relevance" enums:"newest,active,score,relevance"`
 	CaptchaID   string `form:"captcha_id"`
 	CaptchaCode string `form:"captcha_code"`
-	UserID      string `json:"-"`
+	// whether to count the facets of the search result
+	Facets bool   `form:"facets"`
+	UserID string `json:"-"`
 }
 
 func (s *SearchDTO) Check() (errField []*validator.FormErrorField, err error) {
@@ -50,23 +53,37 @@ func (s *SearchDTO) Check() (errField []*validator.FormErrorField, err error) {
 func ReplaceSearchContent(content string) (string, []string) {
 	// Define the regular expressions for key:value pairs and [tag]
 	keyValueRegex := regexp.MustCompile(`\w+:\S+`)
-	tagRegex := regexp.MustCompile(`\[\w+\]`)
+	tagRegex := regexp.MustCompile(`-?\[\w+\]`)
+	// Define the regular expression for negated word, the minus sign must be at the beginning of the word
+	negatedWordRegex := regexp.MustCompile(`(^|\s)-[^\s\-\[]\S*`)
 	// Define the pattern for characters to replace
 	replaceCharsPattern := regexp.MustCompile(`[+#.<>\-_()*]`)
 
 	// Extract key:value pairs
 	keyValues := keyValueRegex.FindAllString(content, -1)
-	// Extract [tag]
+	// Extract [tag] and -[tag]
 	tags := tagRegex.FindAllString(content, -1)
 
 	// Replace key:value pairs and [tag] with empty string
 	contentWithoutPatterns := keyValueRegex.ReplaceAllString(content, "")
 	contentWithoutPatterns = tagRegex.ReplaceAllString(contentWithoutPatterns, "")
 
+	// Extract -word, the special characters in the word will be replaced too
+	negatedWords := make([]string, 0)
+	for _, word := range negatedWordRegex.FindAllString(contentWithoutPatterns, -1) {
+		word = strings.TrimSpace(replaceCharsPattern.ReplaceAllString(strings.TrimSpace(word)[1:], " "))
+		if len(word) > 0 {
+			negatedWords = append(negatedWords, "-"+strings.Fields(word)[0])
+		}
+	}
+	contentWithoutPatterns = negatedWordRegex.ReplaceAllString(contentWithoutPatterns, " ")
+
 	// Replace characters with pattern [+#.<>_()*] with space
 	replacedContent := replaceCharsPattern.ReplaceAllString(contentWithoutPatterns, " ")
 
-	return strings.TrimSpace(replacedContent), append(keyValues, tags...)
+	patterns := append(keyValues, tags...)
+	patterns = append(patterns, negatedWords...)
+	return strings.TrimSpace(replacedContent), patterns
 }
 
 type SearchCondition struct {
@@ -90,6 +107,38 @@ type SearchCondition struct {
 	Tags [][]string
 	// search query keywords
 	Words []string
+	// the tags that the result should not be related
+	ExcludedTags [][]string
+	// the keywords that the result should not contain
+	ExcludedWords []string
+	// created time range
+	Created *SearchTimeRange
+	// updated time range
+	Updated *SearchTimeRange
+	// last active time range
+	Active *SearchTimeRange
+	// the order specified in query by `sort:`, it will override the order of request
+	Order string
+}
+
+// SearchTimeRange time range of search condition, the zero start or end means unlimited
+type SearchTimeRange struct {
+	// include
+	Start time.Time
+	// exclude
+	End time.Time
+}
+
+// IsEmpty check if the time range is not limited
+func (r *SearchTimeRange) IsEmpty() bool {
+	return r == nil || (r.Start.IsZero() && r.End.IsZero())
+}
+
+func (r *SearchTimeRange) convert2PluginCond() (cond plugin.SearchTimeRangeCond) {
+	if r == nil {
+		return cond
+	}
+	return plugin.SearchTimeRangeCond{Start: r.Start, End: r.End}
 }
 
 // SearchAll check if search all
@@ -107,6 +156,16 @@ func (s *SearchCondition) SearchAnswer() bool {
 	return s.TargetType == constant.AnswerObjectType
 }
 
+// SearchArticle check if search only need article
+func (s *SearchCondition) SearchArticle() bool {
+	return s.TargetType == constant.ArticleObjectType
+}
+
+// SearchQuote check if search only need quote
+func (s *SearchCondition) SearchQuote() bool {
+	return s.TargetType == constant.QuoteObjectType
+}
+
 // Convert2PluginSearchCond convert to plugin search condition
 func (s *SearchCondition) Convert2PluginSearchCond(page, pageSize int, order string) *plugin.SearchBasicCond {
 	basic := &plugin.SearchBasicCond{
@@ -120,6 +179,12 @@ func (s *SearchCondition) Convert2PluginSearchCond(page, pageSize int, order str
 		VoteAmount:   s.VoteAmount,
 		ViewAmount:   s.Views,
 		AnswerAmount: s.AnswerAmount,
+
+		ExcludedWords:  s.ExcludedWords,
+		ExcludedTagIDs: s.ExcludedTags,
+		Created:        s.Created.convert2PluginCond(),
+		Updated:        s.Updated.convert2PluginCond(),
+		Active:         s.Active.convert2PluginCond(),
 	}
 	if s.Accepted {
 		basic.AnswerAccepted = plugin.AcceptedCondTrue
@@ -182,6 +247,114 @@ type SearchResp struct {
 	Total int64 `json:"count"`
 	// search response
 	SearchResults []*SearchResult `json:"list"`
+	// search facets, only returned when requested
+	Facets *SearchFacets `json:"facets,omitempty"`
+}
+
+// SearchFacets the counts of search result grouped by different dimensions
+type SearchFacets struct {
+	ObjectTypes []*SearchFacetItem `json:"object_types"`
+	Tags        []*SearchFacetItem `json:"tags"`
+	Authors     []*SearchFacetItem `json:"authors"`
+	TimeBuckets []*SearchFacetItem `json:"time_buckets"`
+}
+
+// SearchFacetItem one facet value
+type SearchFacetItem struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+	// the query that refine current search by this facet
+	Query string `json:"query"`
+}
+
+// SearchFacetTimeBucket created time bucket of search facets
+type SearchFacetTimeBucket struct {
+	Key      string
+	Duration time.Duration
+	// if true, count the contents created before the duration, otherwise within the duration
+	Before bool
+	// the relative value of `created:` operator
+	Operand string
+}
+
+// Query get the `created:` operator query of this bucket
+func (b *SearchFacetTimeBucket) Query(now time.Time) string {
+	if b.Before {
+		return "created:.." + now.Add(-b.Duration).Format("2006-01-02")
+	}
+	return "created:" + b.Operand
+}
+
+var (
+	// SearchFacetObjectTypes the object types that can be counted in search facets
+	SearchFacetObjectTypes = []string{
+		constant.QuestionObjectType,
+		constant.AnswerObjectType,
+		constant.ArticleObjectType,
+		constant.QuoteObjectType,
+	}
+	// SearchFacetObjectTypeOperator the value of `is:` operator for each object type
+	SearchFacetObjectTypeOperator = map[string]string{
+		constant.QuestionObjectType: "question",
+		constant.AnswerObjectType:   "answer",
+		constant.ArticleObjectType:  "article",
+		constant.QuoteObjectType:    "quote",
+	}
+	SearchFacetTimeBuckets = []*SearchFacetTimeBucket{
+		{Key: "day", Duration: 24 * time.Hour, Operand: "1d"},
+		{Key: "week", Duration: 7 * 24 * time.Hour, Operand: "7d"},
+		{Key: "month", Duration: 30 * 24 * time.Hour, Operand: "30d"},
+		{Key: "year", Duration: 365 * 24 * time.Hour, Operand: "1y"},
+		{Key: "older", Duration: 365 * 24 * time.Hour, Before: true},
+	}
+)
+
+// RefineQuery make every facet query based on the current search query
+func (f *SearchFacets) RefineQuery(query string) {
+	for _, items := range [][]*SearchFacetItem{f.ObjectTypes, f.Tags, f.Authors, f.TimeBuckets} {
+		for _, item := range items {
+			switch {
+			case len(item.Query) == 0:
+			case strings.Contains(query, item.Query):
+				item.Query = query
+			default:
+				item.Query = strings.TrimSpace(query + " " + item.Query)
+			}
+		}
+	}
+}
+
+// SearchSuggestReq search suggestion request
+type SearchSuggestReq struct {
+	Query string `validate:"required,gte=1,lte=60" form:"q"`
+	Size  int    `validate:"omitempty,min=1,max=10" form:"size,default=5"`
+}
+
+func (s *SearchSuggestReq) Check() (errField []*validator.FormErrorField, err error) {
+	s.Query = strings.TrimSpace(s.Query)
+	if s.Size == 0 {
+		s.Size = 5
+	}
+	return nil, nil
+}
+
+// SearchSuggestResp search suggestion response
+type SearchSuggestResp struct {
+	Titles []*SearchSuggestTitle `json:"titles"`
+	Tags   []*TagResp            `json:"tags"`
+	Users  []*UserBasicInfo      `json:"users"`
+}
+
+// SearchSuggestTitle the title of question, article or quote that matched the suggestion
+type SearchSuggestTitle struct {
+	ID         string `json:"id"`
+	ObjectType string `json:"object_type"`
+	Title      string `json:"title"`
+	UrlTitle   string `json:"url_title"`
+	HotScore   int    `json:"-"`
+	// whether the title starts with the query
+	ExactPrefix bool `json:"-"`
 }
 
 type SearchDescResp struct {
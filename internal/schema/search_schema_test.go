@@ -38,4 +38,25 @@ func TestReplaceSearchContent(t *testing.T) {
 	ret = strings.Join(append(patterns, replacedContent), " ")
 
 	assert.Equal(t, "user:aaa-sss score:3 [tag1] [tag2] ssssfdfdf as fsadf", ret)
+
+	content = "go-lang -[java] -python created:2024..2025 sort:votes"
+	replacedContent, patterns = ReplaceSearchContent(content)
+	ret = strings.Join(append(patterns, replacedContent), " ")
+
+	assert.Equal(t, "created:2024..2025 sort:votes -[java] -python go lang", ret)
+}
+
+func TestSearchFacetsRefineQuery(t *testing.T) {
+	facets := &SearchFacets{
+		ObjectTypes: []*SearchFacetItem{{Key: "question", Query: "is:question"}},
+		Tags:        []*SearchFacetItem{{Key: "go", Query: "[go]"}},
+		Authors:     []*SearchFacetItem{{Key: "admin", Query: "user:admin"}},
+		TimeBuckets: []*SearchFacetItem{{Key: "day"}},
+	}
+	facets.RefineQuery("[go] hello")
+
+	assert.Equal(t, "[go] hello is:question", facets.ObjectTypes[0].Query)
+	assert.Equal(t, "[go] hello", facets.Tags[0].Query)
+	assert.Equal(t, "[go] hello user:admin", facets.Authors[0].Query)
+	assert.Equal(t, "", facets.TimeBuckets[0].Query)
 }
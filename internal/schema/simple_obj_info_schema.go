@@ -49,6 +49,14 @@ func (s *SimpleObjectInfo) IsDeleted() bool {
 		return s.AnswerStatus == entity.AnswerStatusDeleted
 	case constant.CommentObjectType:
 		return s.CommentStatus == entity.CommentStatusDeleted
+	case constant.ArticleObjectType:
+		return s.QuestionStatus == entity.ArticleStatusDeleted
+	case constant.QuoteObjectType:
+		return s.QuestionStatus == entity.QuoteStatusDeleted
+	case constant.QuoteAuthorObjectType:
+		return s.QuestionStatus == entity.QuoteAuthorStatusDeleted
+	case constant.QuotePieceObjectType:
+		return s.QuestionStatus == entity.QuotePieceStatusDeleted
 	}
 	return false
 }
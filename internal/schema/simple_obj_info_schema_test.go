@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimpleObjectInfo_IsDeleted(t *testing.T) {
+	for _, objectType := range []string{
+		constant.ArticleObjectType,
+		constant.QuoteObjectType,
+		constant.QuoteAuthorObjectType,
+		constant.QuotePieceObjectType,
+	} {
+		deleted := &SimpleObjectInfo{ObjectType: objectType, QuestionStatus: 10}
+		assert.True(t, deleted.IsDeleted(), objectType)
+		available := &SimpleObjectInfo{ObjectType: objectType, QuestionStatus: 1}
+		assert.False(t, available.IsDeleted(), objectType)
+	}
+
+	comment := &SimpleObjectInfo{ObjectType: constant.CommentObjectType, CommentStatus: entity.CommentStatusDeleted}
+	assert.True(t, comment.IsDeleted())
+}
@@ -134,6 +134,35 @@ type SiteLoginReq struct {
 	AllowPasswordLogin      bool     `json:"allow_password_login"`
 	LoginRequired           bool     `json:"login_required"`
 	AllowEmailDomains       []string `json:"allow_email_domains"`
+	// the staff (admin and moderator) must enroll two-factor authentication to login
+	RequireTwoFactorForStaff bool `json:"require_two_factor_for_staff"`
+	// the consecutive failed attempts of an account before the exponential backoff starts, 0 means disabled
+	LoginBackoffThreshold int `validate:"gte=0,lte=1000" json:"login_backoff_threshold"`
+	// the delay after the first backoff failure, doubles on each failure after that
+	LoginBackoffBaseSeconds int `validate:"gte=0,lte=3600" json:"login_backoff_base_seconds"`
+	// the consecutive failed attempts of an account before it is locked, 0 means disabled
+	LoginLockoutThreshold int `validate:"gte=0,lte=1000" json:"login_lockout_threshold"`
+	// the failed attempts from an ip before it is locked, 0 means disabled
+	LoginIPLockoutThreshold int `validate:"gte=0,lte=100000" json:"login_ip_lockout_threshold"`
+	// the duration of the first lockout, doubles on each repeated lockout of the account
+	LoginLockoutMinutes int `validate:"gte=0,lte=10080" json:"login_lockout_minutes"`
+}
+
+const (
+	DefaultLoginBackoffThreshold   = 3
+	DefaultLoginBackoffBaseSeconds = 2
+	DefaultLoginLockoutThreshold   = 10
+	DefaultLoginIPLockoutThreshold = 50
+	DefaultLoginLockoutMinutes     = 15
+)
+
+// SetDefaultLoginLockout set the default brute-force protection thresholds
+func (r *SiteLoginReq) SetDefaultLoginLockout() {
+	r.LoginBackoffThreshold = DefaultLoginBackoffThreshold
+	r.LoginBackoffBaseSeconds = DefaultLoginBackoffBaseSeconds
+	r.LoginLockoutThreshold = DefaultLoginLockoutThreshold
+	r.LoginIPLockoutThreshold = DefaultLoginIPLockoutThreshold
+	r.LoginLockoutMinutes = DefaultLoginLockoutMinutes
 }
 
 // SiteCustomCssHTMLReq site custom css html
@@ -22,6 +22,7 @@ package schema
 import (
 	"strings"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/validator"
 	"github.com/apache/incubator-answer/pkg/converter"
 )
@@ -80,6 +81,10 @@ type GetTagResp struct {
 	Description   string                    `json:"description"`
 	FollowCount   int                       `json:"follow_count"`
 	QuestionCount int                       `json:"question_count"`
+	ArticleCount  int                       `json:"article_count"`
+	QuoteCount    int                       `json:"quote_count"`
+	AuthorCount   int                       `json:"author_count"`
+	PieceCount    int                       `json:"piece_count"`
 	IsFollower    bool                      `json:"is_follower"`
 	Status        string                    `json:"status"`
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
@@ -90,6 +95,29 @@ type GetTagResp struct {
 
 	ParentTagId       string `json:"parent_tag_id"`
 	ParentTagSlugName string `json:"parent_tag_slug_name"`
+	// the tags which are often used together with this tag
+	RelatedTags []*RelatedTag `json:"related_tags"`
+}
+
+// RelatedTag the tag which is often used together with other tags
+type RelatedTag struct {
+	TagID       string `json:"tag_id"`
+	SlugName    string `json:"slug_name"`
+	DisplayName string `json:"display_name"`
+	Recommend   bool   `json:"recommend"`
+	Reserved    bool   `json:"reserved"`
+	// the number of contents tagged with both tags
+	CoCount int `json:"co_count"`
+	// the jaccard similarity of the contents, summed up if there are several picked tags
+	Strength float64 `json:"strength"`
+}
+
+// GetRelatedTagsReq get the related tags of the picked tags
+type GetRelatedTagsReq struct {
+	// slug name list split by ','
+	Tags string `validate:"required" form:"tags"`
+	// max number of related tags
+	Limit int `validate:"omitempty,min=1,max=20" form:"limit"`
 }
 
 func (tr *GetTagResp) GetExcerpt() {
@@ -121,6 +149,14 @@ type GetTagPageResp struct {
 	FollowCount int `json:"follow_count"`
 	// question amount
 	QuestionCount int `json:"question_count"`
+	// article amount
+	ArticleCount int `json:"article_count"`
+	// quote amount
+	QuoteCount int `json:"quote_count"`
+	// quote author amount
+	AuthorCount int `json:"author_count"`
+	// quote piece amount
+	PieceCount int `json:"piece_count"`
 	// is follower
 	IsFollower bool `json:"is_follower"`
 	// created time
@@ -168,8 +204,8 @@ type RemoveTagReq struct {
 
 // AddTagReq add tag request
 type AddTagReq struct {
-	// slug_name
-	SlugName string `validate:"required,gt=0,lte=35" json:"slug_name"`
+	// slug_name, generated from the display name if it is empty
+	SlugName string `validate:"omitempty,gt=0,lte=35" json:"slug_name"`
 	// display_name
 	DisplayName string `validate:"required,gt=0,lte=35" json:"display_name"`
 	// original text
@@ -242,6 +278,8 @@ type GetTagWithPageReq struct {
 	DisplayName string `validate:"omitempty,gt=0,lte=35" form:"display_name"`
 	// query condition
 	QueryCond string `validate:"omitempty,oneof=popular name newest" form:"query_cond"`
+	// content type, only return the tags which have the content of this type, and popular sorts by its count
+	ContentType string `validate:"omitempty,oneof=question article quote author piece" form:"content_type"`
 	// user id
 	UserID string `json:"-"`
 
@@ -253,6 +291,17 @@ type TagSearchCond struct {
 	TagType             int   `validate:"omitempty" form:"tag_type"`
 	ParentTagId         int64 `validate:"" form:"parent_tag_id"` //@cws omitempty
 	IsArticleModuleMenu int8  `validate:"omitempty" form:"is_article_module_menu"`
+	// the object type of the content, see TagContentTypeObjectTypeMapping
+	ContentObjectType string `json:"-"`
+}
+
+// TagContentTypeObjectTypeMapping the object type of the tag content type
+var TagContentTypeObjectTypeMapping = map[string]string{
+	"question": constant.QuestionObjectType,
+	"article":  constant.ArticleObjectType,
+	"quote":    constant.QuoteObjectType,
+	"author":   constant.QuoteAuthorObjectType,
+	"piece":    constant.QuotePieceObjectType,
 }
 
 // GetTagSynonymsReq get tag synonyms request
@@ -294,6 +343,32 @@ type UpdateTagSynonymReq struct {
 	UserID string `json:"-"`
 }
 
+// MergeTagReq merge the source tag into the target tag
+type MergeTagReq struct {
+	// the tag to be merged, it will become a synonym of the target tag
+	SourceTagID string `validate:"required" json:"source_tag_id"`
+	// the tag to merge into
+	TargetTagID string `validate:"required" json:"target_tag_id"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// TagSlugProposal the pinyin slug name proposed for the tag whose slug name is chinese
+type TagSlugProposal struct {
+	TagID            string `json:"tag_id"`
+	DisplayName      string `json:"display_name"`
+	SlugName         string `json:"slug_name"`
+	ProposedSlugName string `json:"proposed_slug_name"`
+}
+
+// ApplyTagSlugProposalsReq apply the proposed slug names
+type ApplyTagSlugProposalsReq struct {
+	// only apply the proposals of these tags, apply all if it is empty
+	TagIDs []string `validate:"omitempty" json:"tag_ids"`
+	// user id
+	UserID string `json:"-"`
+}
+
 func (req *UpdateTagSynonymReq) Format() {
 	for _, item := range req.SynonymTagList {
 		item.SlugName = strings.ToLower(item.SlugName)
@@ -321,3 +396,43 @@ type GetTagBasicResp struct {
 	Recommend   bool   `json:"recommend"`
 	Reserved    bool   `json:"reserved"`
 }
+
+// GetTagTreeReq get tag tree request
+type GetTagTreeReq struct {
+	// the root tag id, if empty, return the whole tag tree
+	TagID string `validate:"omitempty" form:"tag_id"`
+}
+
+// TagTreeNode tag tree node
+type TagTreeNode struct {
+	TagID         string `json:"tag_id"`
+	SlugName      string `json:"slug_name"`
+	DisplayName   string `json:"display_name"`
+	ParentTagID   string `json:"parent_tag_id"`
+	TagSort       int64  `json:"tag_sort"`
+	FollowCount   int    `json:"follow_count"`
+	QuestionCount int    `json:"question_count"`
+	// the sum of question count of this tag and all descendant tags
+	TotalQuestionCount int            `json:"total_question_count"`
+	Children           []*TagTreeNode `json:"children"`
+}
+
+// MoveTagReq move tag and its subtree to another parent tag
+type MoveTagReq struct {
+	// tag_id
+	TagID string `validate:"required" json:"tag_id"`
+	// the new parent tag id, 0 means move to the root
+	ParentTagID string `validate:"required" json:"parent_tag_id"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// ReorderTagsReq reorder the sibling tags
+type ReorderTagsReq struct {
+	// the parent tag id of the sibling tags, 0 means the root tags
+	ParentTagID string `validate:"required" json:"parent_tag_id"`
+	// the sibling tag ids in the new order
+	TagIDs []string `validate:"required,gt=0,dive,required" json:"tag_ids"`
+	// user id
+	UserID string `json:"-"`
+}
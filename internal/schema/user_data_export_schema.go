@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/incubator-answer/internal/entity"
+)
+
+// UserDataExportResp user data export response
+type UserDataExportResp struct {
+	ID string `json:"id"`
+	// status: pending, processing, completed, failed, expired
+	Status string `json:"status" enums:"pending,processing,completed,failed,expired"`
+	// the percentage of the export job, from 0 to 100
+	Progress  int   `json:"progress"`
+	FileSize  int64 `json:"file_size"`
+	CreatedAt int64 `json:"created_at"`
+	ExpiredAt int64 `json:"expired_at"`
+	// the signed download link, only available when the export is completed
+	DownloadURL string `json:"download_url"`
+	// the time when the user can request a new export
+	NextRequestAt int64 `json:"next_request_at"`
+}
+
+// NewUserDataExportResp convert user data export entity to response
+func NewUserDataExportResp(export *entity.UserDataExport) *UserDataExportResp {
+	resp := &UserDataExportResp{
+		ID:        export.ID,
+		Status:    entity.UserDataExportStatusMapping[export.Status],
+		Progress:  export.Progress,
+		FileSize:  export.FileSize,
+		CreatedAt: export.CreatedAt.Unix(),
+	}
+	if !export.ExpiredAt.IsZero() {
+		resp.ExpiredAt = export.ExpiredAt.Unix()
+	}
+	return resp
+}
+
+// DownloadUserDataExportReq download user data export request
+type DownloadUserDataExportReq struct {
+	// export id
+	ID string `validate:"required" form:"id"`
+	// the unix time when the link expires
+	Expires int64 `validate:"required" form:"expires"`
+	// the signature of the link
+	Sign string `validate:"required" form:"sign"`
+}
+
+// UserDataExportProfile the profile of the user in the export archive
+type UserDataExportProfile struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	DisplayName   string `json:"display_name"`
+	EMail         string `json:"e_mail"`
+	MailStatus    int    `json:"mail_status"`
+	Avatar        string `json:"avatar"`
+	Bio           string `json:"bio"`
+	Website       string `json:"website"`
+	Location      string `json:"location"`
+	Language      string `json:"language"`
+	ColorScheme   string `json:"color_scheme"`
+	Rank          int    `json:"rank"`
+	Status        string `json:"status"`
+	IPInfo        string `json:"ip_info"`
+	CreatedAt     int64  `json:"created_at"`
+	LastLoginDate int64  `json:"last_login_date"`
+}
+
+// UserDataExportFile the uploaded file referenced by the user in the export archive
+type UserDataExportFile struct {
+	URL string `json:"url"`
+	// the path of the file in the archive, empty if the file is not stored locally
+	Path string `json:"path"`
+}
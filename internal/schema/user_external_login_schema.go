@@ -23,6 +23,10 @@ package schema
 type UserExternalLoginResp struct {
 	BindingKey  string `json:"binding_key"`
 	AccessToken string `json:"access_token"`
+	// TwoFactorToken if not empty, the login must be completed by the second factor
+	TwoFactorToken string `json:"two_factor_token"`
+	// TwoFactorEnrollRequired the user must enroll two-factor authentication to complete the login
+	TwoFactorEnrollRequired bool `json:"two_factor_enroll_required"`
 	// ErrMsg error message, if not empty, means login failed and this message should be displayed.
 	ErrMsg   string `json:"-"`
 	ErrTitle string `json:"-"`
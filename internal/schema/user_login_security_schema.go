@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/incubator-answer/internal/entity"
+)
+
+// UserLoginAttempt the password login attempt
+type UserLoginAttempt struct {
+	// UserID is empty if no user is found by the email
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+}
+
+// LoginLockoutTrTplData the template data of login lockout error message
+type LoginLockoutTrTplData struct {
+	Seconds int64
+}
+
+// GetLockedUserPageReq get locked user page request
+type GetLockedUserPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+}
+
+// LockedUserResp locked user response
+type LockedUserResp struct {
+	UserID       string `json:"user_id"`
+	Username     string `json:"username"`
+	DisplayName  string `json:"display_name"`
+	EMail        string `json:"e_mail"`
+	LockoutCount int    `json:"lockout_count"`
+	LastFailedAt int64  `json:"last_failed_at"`
+	LastFailedIP string `json:"last_failed_ip"`
+	LockedUntil  int64  `json:"locked_until"`
+}
+
+// UnlockUserReq unlock user request
+type UnlockUserReq struct {
+	UserID string `validate:"required" json:"user_id"`
+}
+
+// GetUserLoginLogPageReq get user login log page request
+type GetUserLoginLogPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// filter by user id
+	UserID string `validate:"omitempty" form:"user_id"`
+	// filter by ip
+	IP string `validate:"omitempty,lte=64" form:"ip"`
+	// filter by status: success or failed
+	Status string `validate:"omitempty,oneof=success failed" form:"status"`
+}
+
+// UserLoginLogResp user login log response
+type UserLoginLogResp struct {
+	ID        string `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+}
+
+// UserLoginLogStatusMapping user login log status mapping
+var UserLoginLogStatusMapping = map[string]int{
+	"success": entity.UserLoginLogStatusSuccess,
+	"failed":  entity.UserLoginLogStatusFailed,
+}
+
+// NewUserLoginLogResp new user login log response
+func NewUserLoginLogResp(loginLog *entity.UserLoginLog) *UserLoginLogResp {
+	resp := &UserLoginLogResp{
+		ID:        loginLog.ID,
+		CreatedAt: loginLog.CreatedAt.Unix(),
+		UserID:    loginLog.UserID,
+		Email:     loginLog.Email,
+		IP:        loginLog.IP,
+		UserAgent: loginLog.UserAgent,
+		Reason:    loginLog.Reason,
+	}
+	if resp.UserID == "0" {
+		resp.UserID = ""
+	}
+	for status, value := range UserLoginLogStatusMapping {
+		if value == loginLog.Status {
+			resp.Status = status
+		}
+	}
+	return resp
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// AddUserRelationReq block or mute a user request
+type AddUserRelationReq struct {
+	// the username of the user to be blocked or muted
+	Username string `validate:"required,gt=0,lte=50" json:"username"`
+	// relation type
+	Type   string `validate:"required,oneof=block mute" json:"type" enums:"block,mute"`
+	UserID string `json:"-"`
+}
+
+// RemoveUserRelationReq unblock or unmute a user request
+type RemoveUserRelationReq struct {
+	// the username of the user to be unblocked or unmuted
+	Username string `validate:"required,gt=0,lte=50" json:"username"`
+	// relation type
+	Type   string `validate:"required,oneof=block mute" json:"type" enums:"block,mute"`
+	UserID string `json:"-"`
+}
+
+// GetUserRelationPageReq get blocked or muted users request
+type GetUserRelationPageReq struct {
+	// relation type
+	Type string `validate:"required,oneof=block mute" form:"type" enums:"block,mute"`
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+	UserID   string `json:"-"`
+}
+
+// UserRelationResp blocked or muted user response
+type UserRelationResp struct {
+	UserInfo  *UserBasicInfo `json:"user_info"`
+	Type      string         `json:"type"`
+	CreatedAt int64          `json:"created_at"`
+}
@@ -96,6 +96,10 @@ type UserLoginResp struct {
 	HavePassword bool `json:"have_password"`
 	// visit token
 	VisitToken string `json:"visit_token"`
+	// two-factor token, if not empty, the login must be completed by the second factor with this token
+	TwoFactorToken string `json:"two_factor_token,omitempty"`
+	// the user must enroll two-factor authentication before login
+	TwoFactorEnrollRequired bool `json:"two_factor_enroll_required,omitempty"`
 }
 
 func (r *UserLoginResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -194,6 +198,8 @@ type UserEmailLoginReq struct {
 	Pass        string `validate:"required,gte=8,lte=32" json:"pass"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
+	IP          string `json:"-"`
+	UserAgent   string `json:"-"`
 }
 
 // UserRegisterReq user register request
@@ -336,7 +342,7 @@ func (u *UserRePassWordRequest) Check() (errFields []*validator.FormErrorField,
 }
 
 type ActionRecordReq struct {
-	Action string `validate:"required,oneof=email password edit_userinfo question answer comment edit invitation_answer search report delete vote" form:"action"`
+	Action string `validate:"required,oneof=email password edit_userinfo question answer comment edit invitation_answer search report delete vote article quote quote_author quote_piece" form:"action"`
 	IP     string `json:"-"`
 	UserID string `json:"-"`
 }
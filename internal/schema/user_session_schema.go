@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// UserSessionResp user login session response
+type UserSessionResp struct {
+	SessionID string `json:"session_id"`
+	// login time, 0 means unknown
+	CreatedAt int64 `json:"created_at"`
+	// last seen time, 0 means unknown
+	LastSeenAt int64  `json:"last_seen_at"`
+	IP         string `json:"ip"`
+	UserAgent  string `json:"user_agent"`
+	// readable device parsed from user agent, such as "Chrome on Windows"
+	Device string `json:"device"`
+	// device type: desktop, mobile, tablet, bot or unknown
+	DeviceType string `json:"device_type"`
+	// whether the session is the one of the current request
+	Current bool `json:"current"`
+}
+
+// RevokeUserSessionReq revoke user session request
+type RevokeUserSessionReq struct {
+	SessionID string `validate:"required,lte=64" json:"session_id"`
+	UserID    string `json:"-"`
+}
+
+// AdminGetUserSessionListReq admin get user session list request
+type AdminGetUserSessionListReq struct {
+	UserID string `validate:"required" form:"user_id"`
+}
+
+// AdminRevokeUserSessionReq admin revoke user session request
+type AdminRevokeUserSessionReq struct {
+	UserID    string `validate:"required" json:"user_id"`
+	SessionID string `validate:"required,lte=64" json:"session_id"`
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// TwoFactorPendingLogin the login which is waiting for the second factor, cached by the two-factor token
+type TwoFactorPendingLogin struct {
+	UserID     string `json:"user_id"`
+	ExternalID string `json:"external_id"`
+	// EnrollRequired the user must enroll two-factor authentication to complete the login
+	EnrollRequired bool `json:"enroll_required"`
+	// UserCenterLogin the login is from the user center plugin, which must be notified after login
+	UserCenterLogin bool `json:"user_center_login"`
+	// PasswordLoginAttempt the password login which is succeeded only after the second factor is passed,
+	// it is nil if the login is not from password
+	PasswordLoginAttempt *UserLoginAttempt `json:"password_login_attempt,omitempty"`
+}
+
+// GetUserTwoFactorResp get user two-factor authentication status response
+type GetUserTwoFactorResp struct {
+	// whether the two-factor authentication is enabled
+	Enabled bool `json:"enabled"`
+	// whether the two-factor authentication is required by site policy, it can not be disabled if required
+	Required bool `json:"required"`
+	// the number of unused recovery codes
+	RecoveryCodesRemaining int `json:"recovery_codes_remaining"`
+}
+
+// UserTwoFactorEnrollResp enroll two-factor authentication response
+type UserTwoFactorEnrollResp struct {
+	// base32 encoded secret, for entering into the authenticator app manually
+	Secret string `json:"secret"`
+	// otpauth uri, shown as QR code
+	KeyURI string `json:"key_uri"`
+}
+
+// UserTwoFactorCodeReq the request confirmed by a totp code or recovery code
+type UserTwoFactorCodeReq struct {
+	// totp code or recovery code
+	Code   string `validate:"required,gte=6,lte=32" json:"code"`
+	UserID string `json:"-"`
+}
+
+// UserTwoFactorRecoveryCodesResp recovery codes response, the codes are only shown once
+type UserTwoFactorRecoveryCodesResp struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// UserTwoFactorLoginEnrollReq enroll two-factor authentication during login request
+type UserTwoFactorLoginEnrollReq struct {
+	// two-factor token returned by login
+	Token string `validate:"required,lte=100" json:"token"`
+}
+
+// UserTwoFactorLoginReq complete the login by the second factor request
+type UserTwoFactorLoginReq struct {
+	// two-factor token returned by login
+	Token string `validate:"required,lte=100" json:"token"`
+	// totp code or recovery code
+	Code string `validate:"required,gte=6,lte=32" json:"code"`
+}
+
+// UserTwoFactorLoginResp complete the login by the second factor response
+type UserTwoFactorLoginResp struct {
+	*UserLoginResp
+	// recovery codes, only returned when the two-factor authentication is enrolled during login
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// AdminResetUserTwoFactorReq admin reset user two-factor authentication request
+type AdminResetUserTwoFactorReq struct {
+	UserID     string `validate:"required" json:"user_id"`
+	OperatorID string `json:"-"`
+}
@@ -22,10 +22,16 @@ package action
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -41,69 +47,121 @@ type CaptchaRepo interface {
 
 // CaptchaService kit service
 type CaptchaService struct {
-	captchaRepo CaptchaRepo
+	captchaRepo           CaptchaRepo
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	userRepo              usercommon.UserRepo
 }
 
 // NewCaptchaService captcha service
-func NewCaptchaService(captchaRepo CaptchaRepo) *CaptchaService {
+func NewCaptchaService(
+	captchaRepo CaptchaRepo,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	userRepo usercommon.UserRepo,
+) *CaptchaService {
 	return &CaptchaService{
-		captchaRepo: captchaRepo,
+		captchaRepo:           captchaRepo,
+		siteInfoCommonService: siteInfoCommonService,
+		userRepo:              userRepo,
 	}
 }
 
 // ActionRecord action record
 func (cs *CaptchaService) ActionRecord(ctx context.Context, req *schema.ActionRecordReq) (resp *schema.ActionRecordResp, err error) {
 	resp = &schema.ActionRecordResp{}
-	unit := req.IP
-	switch req.Action {
+	unit := getActionUnit(req.Action, req.UserID, req.IP)
+	result := cs.checkActionPolicy(ctx, unit, req.Action)
+	if result.Measure == schema.ActionMeasureCaptcha && plugin.CaptchaEnabled() {
+		resp.Verify = true
+		resp.CaptchaID, resp.CaptchaImg, err = cs.GenerateCaptcha(ctx, result.Level)
+		if err != nil {
+			log.Errorf("GenerateCaptcha error: %v", err)
+		}
+	}
+	return
+}
+
+// GetActionPolicy get the measures currently applied to all the actions for the user
+func (cs *CaptchaService) GetActionPolicy(ctx context.Context, req *schema.GetActionPolicyReq) (
+	resp []*schema.GetActionPolicyResp) {
+	resp = make([]*schema.GetActionPolicyResp, 0, len(actionList))
+	for _, action := range actionList {
+		// the actions of admin and moderator are never limited
+		if req.IsAdmin {
+			resp = append(resp, &schema.GetActionPolicyResp{Action: action, Measure: schema.ActionMeasureNone})
+			continue
+		}
+		result := cs.checkActionPolicy(ctx, getActionUnit(action, req.UserID, req.IP), action)
+		if result.Measure == schema.ActionMeasureCaptcha && !plugin.CaptchaEnabled() {
+			result.Measure = schema.ActionMeasureNone
+		}
+		resp = append(resp, &schema.GetActionPolicyResp{
+			Action:     action,
+			Measure:    result.Measure,
+			RetryAfter: result.RetryAfter,
+		})
+	}
+	return resp
+}
+
+// getActionUnit the actions of the user are recorded by user id, the others are recorded by ip
+func getActionUnit(action, userID, ip string) (unit string) {
+	unit = ip
+	switch action {
 	case entity.CaptchaActionEditUserinfo:
-		unit = req.UserID
+		unit = userID
 	case entity.CaptchaActionQuestion:
-		unit = req.UserID
+		unit = userID
 	case entity.CaptchaActionAnswer:
-		unit = req.UserID
+		unit = userID
 	case entity.CaptchaActionComment:
-		unit = req.UserID
+		unit = userID
 	case entity.CaptchaActionEdit:
-		unit = req.UserID
+		unit = userID
 	case entity.CaptchaActionInvitationAnswer:
-		unit = req.UserID
+		unit = userID
 	case entity.CaptchaActionSearch:
-		if req.UserID != "" {
-			unit = req.UserID
+		if userID != "" {
+			unit = userID
 		}
 	case entity.CaptchaActionReport:
-		unit = req.UserID
+		unit = userID
 	case entity.CaptchaActionDelete:
-		unit = req.UserID
+		unit = userID
 	case entity.CaptchaActionVote:
-		unit = req.UserID
+		unit = userID
+	case entity.CaptchaActionArticle, entity.CaptchaActionQuote,
+		entity.CaptchaActionQuoteAuthor, entity.CaptchaActionQuotePiece:
+		unit = userID
 	}
-	verificationResult := cs.ValidationStrategy(ctx, unit, req.Action)
-	if !verificationResult {
-		resp.Verify = true
-		resp.CaptchaID, resp.CaptchaImg, err = cs.GenerateCaptcha(ctx)
-		if err != nil {
-			log.Errorf("GenerateCaptcha error: %v", err)
-		}
-	}
-	return
+	return unit
 }
 
 // ActionRecordVerifyCaptcha
-// Verify that you need to enter a CAPTCHA, and that the CAPTCHA is correct
+// Verify that you need to enter a CAPTCHA, and that the CAPTCHA is correct.
+// The error is returned when the action is delayed or blocked by the action policy.
 func (cs *CaptchaService) ActionRecordVerifyCaptcha(
 	ctx context.Context, actionType string, unit string, captchaID string, captchaCode string,
-) bool {
-	verificationResult := cs.ValidationStrategy(ctx, unit, actionType)
-	if verificationResult {
-		return true
-	}
-	pass, err := cs.VerifyCaptcha(ctx, captchaID, captchaCode)
-	if err != nil {
-		return false
+) (pass bool, err error) {
+	result := cs.checkActionPolicy(ctx, unit, actionType)
+	switch result.Measure {
+	case schema.ActionMeasureBlock:
+		return false, errors.Forbidden(reason.ActionBlocked)
+	case schema.ActionMeasureDelay:
+		msg := translator.TrWithData(handler.GetLangByCtx(ctx), reason.ActionTooFrequent,
+			&schema.ActionPolicyTrTplData{Seconds: result.RetryAfter})
+		return false, errors.BadRequest(reason.ActionTooFrequent).WithMsg(msg)
+	case schema.ActionMeasureCaptcha:
+		// If the captcha is not enabled, the verification is passed directly
+		if !plugin.CaptchaEnabled() {
+			return true, nil
+		}
+		pass, err = cs.VerifyCaptcha(ctx, captchaID, captchaCode)
+		if err != nil {
+			return false, nil
+		}
+		return pass, nil
 	}
-	return pass
+	return true, nil
 }
 
 func (cs *CaptchaService) ActionRecordAdd(ctx context.Context, actionType string, unit string) (int, error) {
@@ -130,12 +188,18 @@ func (cs *CaptchaService) ActionRecordDel(ctx context.Context, actionType string
 	}
 }
 
-// GenerateCaptcha generate captcha
-func (cs *CaptchaService) GenerateCaptcha(ctx context.Context) (key, captchaBase64 string, err error) {
+// GenerateCaptcha generate captcha, the level is passed to the plugin that can scale the difficulty
+func (cs *CaptchaService) GenerateCaptcha(ctx context.Context, level int) (key, captchaBase64 string, err error) {
 	realCaptcha := ""
 	key = token.GenerateToken()
 	_ = plugin.CallCaptcha(func(fn plugin.Captcha) error {
-		if captcha, code := fn.Create(); len(code) > 0 {
+		var captcha, code string
+		if levelCaptcha, ok := fn.(plugin.CaptchaLevel); ok {
+			captcha, code = levelCaptcha.CreateWithLevel(level)
+		} else {
+			captcha, code = fn.Create()
+		}
+		if len(code) > 0 {
 			captchaBase64 = captcha
 			realCaptcha = code
 		}
@@ -23,203 +23,122 @@ import (
 	"context"
 	"time"
 
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/segmentfault/pacman/log"
-
-	"github.com/apache/incubator-answer/internal/entity"
 )
 
-// ValidationStrategy
-// true pass
-// false need captcha
-func (cs *CaptchaService) ValidationStrategy(ctx context.Context, unit, actionType string) bool {
-	// If the captcha is not enabled, the verification is passed directly
-	if !plugin.CaptchaEnabled() {
-		return true
-	}
-	info, err := cs.captchaRepo.GetActionType(ctx, unit, actionType)
-	if err != nil {
-		log.Error(err)
-		return false
-	}
-	switch actionType {
-	case entity.CaptchaActionEmail:
-		return cs.CaptchaActionEmail(ctx, unit, info)
-	case entity.CaptchaActionPassword:
-		return cs.CaptchaActionPassword(ctx, unit, info)
-	case entity.CaptchaActionEditUserinfo:
-		return cs.CaptchaActionEditUserinfo(ctx, unit, info)
-	case entity.CaptchaActionQuestion:
-		return cs.CaptchaActionQuestion(ctx, unit, info)
-	case entity.CaptchaActionAnswer:
-		return cs.CaptchaActionAnswer(ctx, unit, info)
-	case entity.CaptchaActionComment:
-		return cs.CaptchaActionComment(ctx, unit, info)
-	case entity.CaptchaActionEdit:
-		return cs.CaptchaActionEdit(ctx, unit, info)
-	case entity.CaptchaActionInvitationAnswer:
-		return cs.CaptchaActionInvitationAnswer(ctx, unit, info)
-	case entity.CaptchaActionSearch:
-		return cs.CaptchaActionSearch(ctx, unit, info)
-	case entity.CaptchaActionReport:
-		return cs.CaptchaActionReport(ctx, unit, info)
-	case entity.CaptchaActionDelete:
-		return cs.CaptchaActionDelete(ctx, unit, info)
-	case entity.CaptchaActionVote:
-		return cs.CaptchaActionVote(ctx, unit, info)
-
-	}
-	//actionType not found
-	return false
-}
-
-func (cs *CaptchaService) CaptchaActionEmail(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	// You need a verification code every time
-	return false
-}
-
-func (cs *CaptchaService) CaptchaActionPassword(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 3
-	setTime := int64(60 * 30) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime && actionInfo.Num >= setNum {
-		return false
-	}
-	if now-actionInfo.LastTime != 0 && now-actionInfo.LastTime > setTime {
-		cs.captchaRepo.SetActionType(ctx, unit, entity.CaptchaActionPassword, "", 0)
-	}
-	return true
+// actionList all the actions that are limited by the action policy
+var actionList = []string{
+	entity.CaptchaActionEmail,
+	entity.CaptchaActionPassword,
+	entity.CaptchaActionEditUserinfo,
+	entity.CaptchaActionQuestion,
+	entity.CaptchaActionAnswer,
+	entity.CaptchaActionComment,
+	entity.CaptchaActionEdit,
+	entity.CaptchaActionInvitationAnswer,
+	entity.CaptchaActionSearch,
+	entity.CaptchaActionReport,
+	entity.CaptchaActionDelete,
+	entity.CaptchaActionVote,
+	entity.CaptchaActionArticle,
+	entity.CaptchaActionQuote,
+	entity.CaptchaActionQuoteAuthor,
+	entity.CaptchaActionQuotePiece,
 }
 
-func (cs *CaptchaService) CaptchaActionEditUserinfo(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 3
-	setTime := int64(60 * 30) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime && actionInfo.Num >= setNum {
-		return false
-	}
-	if now-actionInfo.LastTime != 0 && now-actionInfo.LastTime > setTime {
-		cs.captchaRepo.SetActionType(ctx, unit, entity.CaptchaActionEditUserinfo, "", 0)
-	}
-	return true
+// ipUnitActions the actions are recorded by ip, so the reputation of the unit is always 0
+var ipUnitActions = map[string]bool{
+	entity.CaptchaActionEmail:    true,
+	entity.CaptchaActionPassword: true,
 }
 
-func (cs *CaptchaService) CaptchaActionQuestion(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 10
-	setTime := int64(5) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime || actionInfo.Num >= setNum {
-		return false
-	}
-	return true
+// actionPolicyResult the measure applied to the action
+type actionPolicyResult struct {
+	Measure    string
+	RetryAfter int64
+	// Level the anti-abuse signal, 0 is normal, it increases with how far the action exceeds the rule
+	Level int
 }
 
-func (cs *CaptchaService) CaptchaActionAnswer(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
+// ValidationStrategy
+// true pass
+// false need captcha
+func (cs *CaptchaService) ValidationStrategy(ctx context.Context, unit, actionType string) bool {
+	result := cs.checkActionPolicy(ctx, unit, actionType)
+	switch result.Measure {
+	case schema.ActionMeasureNone:
 		return true
+	case schema.ActionMeasureCaptcha:
+		// If the captcha is not enabled, the verification is passed directly
+		return !plugin.CaptchaEnabled()
 	}
-	setNum := 10
-	setTime := int64(5) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime || actionInfo.Num >= setNum {
-		return false
-	}
-	return true
+	return false
 }
 
-func (cs *CaptchaService) CaptchaActionComment(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
+// checkActionPolicy find the rule of the action for the unit and check whether it is triggered by the action record
+func (cs *CaptchaService) checkActionPolicy(ctx context.Context, unit, actionType string) (result *actionPolicyResult) {
+	result = &actionPolicyResult{Measure: schema.ActionMeasureNone}
+	policy, err := cs.siteInfoCommonService.GetSiteActionPolicy(ctx)
+	if err != nil {
+		log.Error(err)
+		policy = &schema.SiteActionPolicyResp{Rules: schema.DefaultActionPolicyRules}
 	}
-	setNum := 30
-	setTime := int64(1) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime || actionInfo.Num >= setNum {
-		return false
+	rule := policy.GetRule(actionType, cs.getUnitReputation(ctx, unit, actionType))
+	if rule == nil || rule.Measure == schema.ActionMeasureNone {
+		return result
 	}
-	return true
-}
 
-func (cs *CaptchaService) CaptchaActionEdit(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 10
-	if actionInfo.Num >= setNum {
-		return false
+	// the rule without any threshold is always triggered
+	if rule.Interval == 0 && rule.Limit == 0 {
+		result.Measure = rule.Measure
+		return result
 	}
-	return true
-}
 
-func (cs *CaptchaService) CaptchaActionInvitationAnswer(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 30
-	if actionInfo.Num >= setNum {
-		return false
-	}
-	return true
-}
-
-func (cs *CaptchaService) CaptchaActionSearch(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
+	info, err := cs.captchaRepo.GetActionType(ctx, unit, actionType)
+	if err != nil {
+		log.Error(err)
+		result.Measure = rule.Measure
+		return result
 	}
-	now := time.Now().Unix()
-	setNum := 20
-	setTime := int64(60) //seconds
-	if now-int64(actionInfo.LastTime) <= setTime && actionInfo.Num >= setNum {
-		return false
+	if info == nil {
+		return result
 	}
-	if now-actionInfo.LastTime > setTime {
-		cs.captchaRepo.SetActionType(ctx, unit, entity.CaptchaActionSearch, "", 0)
+	elapsed := time.Now().Unix() - info.LastTime
+	if rule.Window > 0 && elapsed > rule.Window {
+		cs.ActionRecordDel(ctx, actionType, unit)
+		return result
 	}
-	return true
-}
 
-func (cs *CaptchaService) CaptchaActionReport(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
+	if rule.Interval > 0 && elapsed <= rule.Interval {
+		result.Measure = rule.Measure
+		result.RetryAfter = rule.Interval - elapsed + 1
+		result.Level = 1
 	}
-	setNum := 30
-	setTime := int64(1) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime || actionInfo.Num >= setNum {
-		return false
+	if rule.Limit > 0 && info.Num >= rule.Limit {
+		result.Measure = rule.Measure
+		if rule.Window > 0 {
+			result.RetryAfter = rule.Window - elapsed + 1
+		}
+		result.Level = 1 + (info.Num-rule.Limit)/rule.Limit
 	}
-	return true
+	return result
 }
 
-func (cs *CaptchaService) CaptchaActionDelete(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
-	}
-	setNum := 5
-	setTime := int64(5) //seconds
-	now := time.Now().Unix()
-	if now-actionInfo.LastTime <= setTime || actionInfo.Num >= setNum {
-		return false
+// getUnitReputation get the reputation of the user who does the action, the anonymous unit is 0
+func (cs *CaptchaService) getUnitReputation(ctx context.Context, unit, actionType string) int {
+	if ipUnitActions[actionType] {
+		return 0
 	}
-	return true
-}
-
-func (cs *CaptchaService) CaptchaActionVote(ctx context.Context, unit string, actionInfo *entity.ActionRecordInfo) bool {
-	if actionInfo == nil {
-		return true
+	userInfo, exist, err := cs.userRepo.GetByUserID(ctx, unit)
+	if err != nil {
+		log.Error(err)
+		return 0
 	}
-	setNum := 40
-	if actionInfo.Num >= setNum {
-		return false
+	if !exist {
+		return 0
 	}
-	return true
+	return userInfo.Rank
 }
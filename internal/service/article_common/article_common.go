@@ -50,6 +50,7 @@ import (
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -97,6 +98,7 @@ type ArticleCommon struct {
 	activityQueueService activity_queue.ActivityQueueService
 	revisionRepo         revision.RevisionRepo
 	data                 *data.Data
+	userRelationService  *user_relation.UserRelationService
 }
 
 func NewArticleCommon(articleRepo ArticleRepo,
@@ -112,6 +114,7 @@ func NewArticleCommon(articleRepo ArticleRepo,
 	activityQueueService activity_queue.ActivityQueueService,
 	revisionRepo revision.RevisionRepo,
 	data *data.Data,
+	userRelationService *user_relation.UserRelationService,
 ) *ArticleCommon {
 	return &ArticleCommon{
 		articleRepo:          articleRepo,
@@ -127,6 +130,7 @@ func NewArticleCommon(articleRepo ArticleRepo,
 		activityQueueService: activityQueueService,
 		revisionRepo:         revisionRepo,
 		data:                 data,
+		userRelationService:  userRelationService,
 	}
 }
 
@@ -425,6 +429,11 @@ func (qs *ArticleCommon) FormatArticlesPage(
 	ctx context.Context, articleList []*entity.Article, loginUserID string, orderCond string) (
 	formattedArticles []*schema.ArticlePageResp, err error) {
 	formattedArticles = make([]*schema.ArticlePageResp, 0)
+	// the content of muted users is collapsed for the login user
+	mutedUserIDs, err := qs.userRelationService.GetMutedUserIDs(ctx, loginUserID)
+	if err != nil {
+		return formattedArticles, err
+	}
 	articleIDs := make([]string, 0)
 	userIDs := make([]string, 0)
 
@@ -453,6 +462,7 @@ func (qs *ArticleCommon) FormatArticlesPage(
 			Thumbnails: thumbnails,
 		}
 
+		t.Muted = mutedUserIDs[articleInfo.UserID]
 		articleIDs = append(articleIDs, articleInfo.ID)
 		userIDs = append(userIDs, articleInfo.UserID)
 		haveEdited, haveAnswered := false, false
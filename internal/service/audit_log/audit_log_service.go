@@ -0,0 +1,233 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package audit_log
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// exportAuditLogBatchSize the number of audit log read from database at a time when exporting
+	exportAuditLogBatchSize = 1000
+	// maxExportAuditLogRows the upper limit of the exported rows, narrow the filter to export the older ones
+	maxExportAuditLogRows = 100000
+)
+
+// AuditLogRepo audit log repository
+type AuditLogRepo interface {
+	AddAuditLog(ctx context.Context, auditLog *entity.AuditLog) (err error)
+	GetAuditLogPage(ctx context.Context, page, pageSize int, cond *entity.AuditLog, startTime, endTime time.Time) (
+		auditLogList []*entity.AuditLog, total int64, err error)
+	GetAuditLogListBeforeID(ctx context.Context, cond *entity.AuditLog, startTime, endTime time.Time,
+		beforeID int64, limit int) (auditLogList []*entity.AuditLog, err error)
+	RemoveAuditLogBefore(ctx context.Context, before time.Time) (affected int64, err error)
+}
+
+// AuditLogUserRepo get the actors of audit log.
+// The user common service is not used, because the role service which it depends on records audit log.
+type AuditLogUserRepo interface {
+	BatchGetByID(ctx context.Context, ids []string) ([]*entity.User, error)
+}
+
+// AuditLogService audit log service
+type AuditLogService struct {
+	auditLogRepo          AuditLogRepo
+	userRepo              AuditLogUserRepo
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+}
+
+// NewAuditLogService new audit log service
+func NewAuditLogService(
+	auditLogRepo AuditLogRepo,
+	userRepo AuditLogUserRepo,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+) *AuditLogService {
+	return &AuditLogService{
+		auditLogRepo:          auditLogRepo,
+		userRepo:              userRepo,
+		siteInfoCommonService: siteInfoCommonService,
+	}
+}
+
+// AddAuditLog record the admin or moderator action, the ip is taken from the context.
+// It never returns an error, so that the action is not blocked by the failure of recording.
+func (as *AuditLogService) AddAuditLog(ctx context.Context, record *schema.AuditLogRecord) {
+	auditLog := &entity.AuditLog{
+		UserID:      record.UserID,
+		Action:      record.Action,
+		ObjectType:  record.ObjectType,
+		ObjectID:    record.ObjectID,
+		BeforeValue: marshalAuditValue(record.Before),
+		AfterValue:  marshalAuditValue(record.After),
+		IP:          handler.GetClientIPByCtx(ctx),
+	}
+	if len(auditLog.UserID) == 0 {
+		auditLog.UserID = handler.GetLoginUserIDByCtx(ctx)
+	}
+	if len(auditLog.UserID) == 0 {
+		auditLog.UserID = "0"
+	}
+	if err := as.auditLogRepo.AddAuditLog(ctx, auditLog); err != nil {
+		log.Errorf("add audit log %s of %s %s failed: %v", auditLog.Action, auditLog.ObjectType, auditLog.ObjectID, err)
+	}
+}
+
+// marshalAuditValue marshal the value to json, json.RawMessage is kept as it is
+func marshalAuditValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		log.Errorf("marshal audit log value failed: %v", err)
+		return ""
+	}
+	return string(content)
+}
+
+// GetAuditLogPage get audit log page
+func (as *AuditLogService) GetAuditLogPage(ctx context.Context, req *schema.GetAuditLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	cond, startTime, endTime := req.Cond()
+	auditLogList, total, err := as.auditLogRepo.GetAuditLogPage(ctx, req.Page, req.PageSize, cond, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return pager.NewPageModel(total, as.formatAuditLogList(ctx, auditLogList)), nil
+}
+
+// ExportAuditLog export the filtered audit log as csv, the latest ones come first
+func (as *AuditLogService) ExportAuditLog(ctx context.Context, req *schema.ExportAuditLogReq) (
+	content []byte, err error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	_ = w.Write([]string{"id", "created_at", "user_id", "username", "action",
+		"object_type", "object_id", "before", "after", "ip"})
+
+	// the batches are read by the id of the last row instead of the page, so that the new audit log added
+	// during the export does not shift the rows into the next batch
+	cond, startTime, endTime := req.Cond()
+	var lastID int64
+	for rows := 0; rows < maxExportAuditLogRows; {
+		auditLogList, err := as.auditLogRepo.GetAuditLogListBeforeID(ctx, cond, startTime, endTime,
+			lastID, exportAuditLogBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, auditLog := range as.formatAuditLogList(ctx, auditLogList) {
+			_ = w.Write(escapeCSVRecord([]string{
+				auditLog.ID,
+				time.Unix(auditLog.CreatedAt, 0).UTC().Format(time.RFC3339),
+				auditLog.UserID,
+				auditLog.Username,
+				auditLog.Action,
+				auditLog.ObjectType,
+				auditLog.ObjectID,
+				string(auditLog.Before),
+				string(auditLog.After),
+				auditLog.IP,
+			}))
+		}
+		rows += len(auditLogList)
+		if len(auditLogList) < exportAuditLogBatchSize {
+			break
+		}
+		lastID = converter.StringToInt64(auditLogList[len(auditLogList)-1].ID)
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// escapeCSVRecord prefix the cells which may be taken as a formula by the spreadsheet with a single quote
+func escapeCSVRecord(record []string) []string {
+	for i, cell := range record {
+		if len(cell) > 0 && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			record[i] = "'" + cell
+		}
+	}
+	return record
+}
+
+func (as *AuditLogService) formatAuditLogList(ctx context.Context, auditLogList []*entity.AuditLog) (
+	resp []*schema.AuditLogResp) {
+	resp = make([]*schema.AuditLogResp, 0, len(auditLogList))
+	userIDs := make([]string, 0, len(auditLogList))
+	for _, auditLog := range auditLogList {
+		item := schema.NewAuditLogResp(auditLog)
+		if len(item.UserID) > 0 {
+			userIDs = append(userIDs, item.UserID)
+		}
+		resp = append(resp, item)
+	}
+	if len(userIDs) == 0 {
+		return resp
+	}
+	userList, err := as.userRepo.BatchGetByID(ctx, userIDs)
+	if err != nil {
+		log.Error(err)
+		return resp
+	}
+	userInfoMapping := make(map[string]*entity.User, len(userList))
+	for _, userInfo := range userList {
+		userInfoMapping[userInfo.ID] = userInfo
+	}
+	for _, item := range resp {
+		if userInfo, ok := userInfoMapping[item.UserID]; ok {
+			item.Username = userInfo.Username
+			item.DisplayName = userInfo.DisplayName
+		}
+	}
+	return resp
+}
+
+// CleanAuditLogCron remove the audit log older than the retention days
+func (as *AuditLogService) CleanAuditLogCron(ctx context.Context) {
+	siteAuditLog, err := as.siteInfoCommonService.GetSiteAuditLog(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if siteAuditLog.RetentionDays <= 0 {
+		return
+	}
+	before := time.Now().AddDate(0, 0, -siteAuditLog.RetentionDays)
+	affected, err := as.auditLogRepo.RemoveAuditLogBefore(ctx, before)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	log.Infof("removed %d audit log created before %s", affected, before.Format(time.RFC3339))
+}
@@ -42,6 +42,10 @@ type AuthRepo interface {
 	RemoveAdminUserCacheInfo(ctx context.Context, accessToken string) (err error)
 	AddUserTokenMapping(ctx context.Context, userID, accessToken string) (err error)
 	RemoveUserTokens(ctx context.Context, userID string, remainToken string)
+	GetUserTokens(ctx context.Context, userID string) (tokens []string, err error)
+	RemoveUserToken(ctx context.Context, userID, accessToken string) (err error)
+	GetUserSessionInfo(ctx context.Context, accessToken string) (sessionInfo *entity.UserSessionInfo, err error)
+	SetUserSessionInfo(ctx context.Context, accessToken string, sessionInfo *entity.UserSessionInfo) (err error)
 }
 
 // AuthService kit service
@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/device"
+	"github.com/apache/incubator-answer/pkg/encryption"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// sessionLastSeenInterval the last seen time of session is only updated once in this interval
+	sessionLastSeenInterval = time.Minute
+	// sessionIDLength the session id is the prefix of the hash of access token, the access token is never exposed
+	sessionIDLength = 32
+)
+
+// TouchUserSession record the metadata of the session when it is used
+func (as *AuthService) TouchUserSession(ctx context.Context, accessToken, ip, userAgent string) {
+	now := time.Now()
+	sessionInfo, err := as.authRepo.GetUserSessionInfo(ctx, accessToken)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if sessionInfo == nil {
+		sessionInfo = &entity.UserSessionInfo{CreatedAt: now.Unix()}
+	} else if now.Sub(time.Unix(sessionInfo.LastSeenAt, 0)) < sessionLastSeenInterval &&
+		sessionInfo.IP == ip && sessionInfo.UserAgent == userAgent {
+		return
+	}
+	sessionInfo.LastSeenAt = now.Unix()
+	sessionInfo.IP = ip
+	sessionInfo.UserAgent = userAgent
+	if err = as.authRepo.SetUserSessionInfo(ctx, accessToken, sessionInfo); err != nil {
+		log.Error(err)
+	}
+}
+
+// GetUserSessionList get the active sessions of the user, the latest used first
+func (as *AuthService) GetUserSessionList(ctx context.Context, userID, currentAccessToken string) (
+	resp []*schema.UserSessionResp, err error) {
+	tokens, err := as.authRepo.GetUserTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserSessionResp, 0, len(tokens))
+	for _, accessToken := range tokens {
+		// the token is expired or logged out
+		userCacheInfo, err := as.authRepo.GetUserCacheInfo(ctx, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		if userCacheInfo == nil {
+			continue
+		}
+		session := &schema.UserSessionResp{
+			SessionID: sessionID(accessToken),
+			Current:   accessToken == currentAccessToken,
+		}
+		sessionInfo, err := as.authRepo.GetUserSessionInfo(ctx, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		if sessionInfo != nil {
+			d := device.Parse(sessionInfo.UserAgent)
+			session.CreatedAt = sessionInfo.CreatedAt
+			session.LastSeenAt = sessionInfo.LastSeenAt
+			session.IP = sessionInfo.IP
+			session.UserAgent = sessionInfo.UserAgent
+			session.Device = d.String()
+			session.DeviceType = d.Type
+		}
+		resp = append(resp, session)
+	}
+	sort.SliceStable(resp, func(i, j int) bool {
+		return resp[i].LastSeenAt > resp[j].LastSeenAt
+	})
+	return resp, nil
+}
+
+// RevokeUserSession log out the session of the user
+func (as *AuthService) RevokeUserSession(ctx context.Context, userID, revokeSessionID string) (err error) {
+	tokens, err := as.authRepo.GetUserTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, accessToken := range tokens {
+		if sessionID(accessToken) == revokeSessionID {
+			return as.authRepo.RemoveUserToken(ctx, userID, accessToken)
+		}
+	}
+	return errors.BadRequest(reason.UserSessionNotFound)
+}
+
+func sessionID(accessToken string) string {
+	return encryption.SHA256(accessToken)[:sessionIDLength]
+}
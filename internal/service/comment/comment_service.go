@@ -32,11 +32,14 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/mention_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -88,6 +91,9 @@ type CommentService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	activityQueueService             activity_queue.ActivityQueueService
 	eventQueueService                event_queue.EventQueueService
+	userRelationService              *user_relation.UserRelationService
+	mentionCommon                    *mention_common.MentionCommon
+	contentFilterService             *content_filter.ContentFilterService
 }
 
 // NewCommentService new comment service
@@ -103,6 +109,9 @@ func NewCommentService(
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService,
 	activityQueueService activity_queue.ActivityQueueService,
 	eventQueueService event_queue.EventQueueService,
+	userRelationService *user_relation.UserRelationService,
+	mentionCommon *mention_common.MentionCommon,
+	contentFilterService *content_filter.ContentFilterService,
 ) *CommentService {
 	return &CommentService{
 		commentRepo:                      commentRepo,
@@ -116,12 +125,21 @@ func NewCommentService(
 		externalNotificationQueueService: externalNotificationQueueService,
 		activityQueueService:             activityQueueService,
 		eventQueueService:                eventQueueService,
+		userRelationService:              userRelationService,
+		mentionCommon:                    mentionCommon,
+		contentFilterService:             contentFilterService,
 	}
 }
 
 // AddComment add comment
 func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddCommentReq) (
 	resp *schema.GetCommentResp, err error) {
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.CommentObjectType, "").
+		AddContent(&req.OriginalText, &req.ParsedText, false)
+	if err = cs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
+
 	comment := &entity.Comment{}
 	_ = copier.Copy(comment, req)
 	comment.Status = entity.CommentStatusAvailable
@@ -141,6 +159,9 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 	} else if objInfo.ObjectType == constant.ArticleObjectType {
 		comment.QuestionID = objInfo.ObjectID
 	}
+	if err = cs.userRelationService.CheckBlocked(ctx, req.UserID, objInfo.ObjectCreatorUserID); err != nil {
+		return nil, err
+	}
 
 	if len(req.ReplyCommentID) > 0 {
 		replyComment, exist, err := cs.commentCommonRepo.GetComment(ctx, req.ReplyCommentID)
@@ -150,12 +171,16 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 		if !exist {
 			return nil, errors.BadRequest(reason.CommentNotFound)
 		}
+		if err = cs.userRelationService.CheckBlocked(ctx, req.UserID, replyComment.UserID); err != nil {
+			return nil, err
+		}
 		comment.SetReplyUserID(replyComment.UserID)
 		comment.SetReplyCommentID(replyComment.ID)
 	} else {
 		comment.SetReplyUserID("")
 		comment.SetReplyCommentID("")
 	}
+	comment.ParsedText = cs.mentionCommon.LinkMentions(ctx, comment.ParsedText)
 
 	err = cs.commentRepo.AddComment(ctx, comment)
 	if err != nil {
@@ -234,15 +259,24 @@ func (cs *CommentService) addCommentNotification(
 		cs.notificationCommentReply(ctx, replyUser.ID, comment.ID, req.UserID,
 			objInfo.QuestionID, objInfo.Title, htmltext.FetchExcerpt(comment.ParsedText, "...", 240))
 		alreadyNotifiedUserID[replyUser.ID] = true
-		return nil, nil
 	}
 
-	if len(req.MentionUsernameList) > 0 {
-		alreadyNotifiedUserIDs := cs.notificationMention(
-			ctx, req.MentionUsernameList, comment.ID, req.UserID, alreadyNotifiedUserID)
-		for _, userID := range alreadyNotifiedUserIDs {
-			alreadyNotifiedUserID[userID] = true
-		}
+	notifiedUserIDs := cs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+		ObjectID:            comment.ID,
+		ObjectType:          constant.CommentObjectType,
+		Title:               objInfo.Title,
+		QuestionID:          objInfo.QuestionID,
+		AnswerID:            objInfo.AnswerID,
+		CommentID:           comment.ID,
+		TriggerUserID:       req.UserID,
+		ParsedText:          comment.ParsedText,
+		MentionUsernameList: req.MentionUsernameList,
+		SkipUserIDs:         alreadyNotifiedUserID,
+	})
+	for _, userID := range notifiedUserIDs {
+		alreadyNotifiedUserID[userID] = true
+	}
+	if len(alreadyNotifiedUserID) > 0 {
 		return nil, nil
 	}
 
@@ -288,9 +322,17 @@ func (cs *CommentService) UpdateComment(ctx context.Context, req *schema.UpdateC
 		return nil, errors.BadRequest(reason.CommentCannotEditAfterDeadline)
 	}
 
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.CommentObjectType, old.ID).
+		AddContent(&req.OriginalText, &req.ParsedText, false)
+	if err = cs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
+
+	req.ParsedText = cs.mentionCommon.LinkMentions(ctx, req.ParsedText)
 	if err = cs.commentRepo.UpdateCommentContent(ctx, old.ID, req.OriginalText, req.ParsedText); err != nil {
 		return nil, err
 	}
+	cs.notificationEditMention(ctx, old, req.UserID, req.ParsedText)
 	resp = &schema.UpdateCommentResp{
 		CommentID:    old.ID,
 		OriginalText: req.OriginalText,
@@ -402,6 +444,15 @@ func (cs *CommentService) GetCommentWithPage(ctx context.Context, req *schema.Ge
 			}
 		}
 	}
+
+	// the comments of muted users are collapsed for the current user
+	mutedUserIDs, err := cs.userRelationService.GetMutedUserIDs(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, commentResp := range resp {
+		commentResp.Muted = mutedUserIDs[commentResp.UserID]
+	}
 	return pager.NewPageModel(total, resp), nil
 }
 
@@ -650,27 +701,23 @@ func (cs *CommentService) notificationCommentReply(ctx context.Context, replyUse
 	cs.externalNotificationQueueService.Send(ctx, externalNotificationMsg)
 }
 
-func (cs *CommentService) notificationMention(
-	ctx context.Context, mentionUsernameList []string, commentID, commentUserID string,
-	alreadyNotifiedUserID map[string]bool) (alreadyNotifiedUserIDs []string) {
-	for _, username := range mentionUsernameList {
-		userInfo, exist, err := cs.userCommon.GetUserBasicInfoByUserName(ctx, username)
-		if err != nil {
-			log.Error(err)
-			continue
-		}
-		if exist && !alreadyNotifiedUserID[userInfo.ID] {
-			msg := &schema.NotificationMsg{
-				ReceiverUserID: userInfo.ID,
-				TriggerUserID:  commentUserID,
-				Type:           schema.NotificationTypeInbox,
-				ObjectID:       commentID,
-			}
-			msg.ObjectType = constant.CommentObjectType
-			msg.NotificationAction = constant.NotificationMentionYou
-			cs.notificationQueueService.Send(ctx, msg)
-			alreadyNotifiedUserIDs = append(alreadyNotifiedUserIDs, userInfo.ID)
-		}
+// notificationEditMention notify the users newly mentioned by the comment edit
+func (cs *CommentService) notificationEditMention(ctx context.Context, old *entity.Comment, editUserID, parsedText string) {
+	req := &schema.MentionNotificationReq{
+		ObjectID:      old.ID,
+		ObjectType:    constant.CommentObjectType,
+		QuestionID:    old.QuestionID,
+		CommentID:     old.ID,
+		TriggerUserID: editUserID,
+		ParsedText:    parsedText,
+		OldParsedText: old.ParsedText,
+	}
+	objInfo, err := cs.objectInfoService.GetInfo(ctx, old.ID)
+	if err != nil {
+		log.Error(err)
+	} else {
+		req.Title = objInfo.Title
+		req.AnswerID = uid.DeShortID(objInfo.AnswerID)
 	}
-	return alreadyNotifiedUserIDs
+	cs.mentionCommon.NotifyMentions(ctx, req)
 }
@@ -33,8 +33,11 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/mention_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
@@ -42,6 +45,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/role"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/token"
@@ -69,6 +73,10 @@ type AnswerService struct {
 	activityQueueService             activity_queue.ActivityQueueService
 	reviewService                    *review.ReviewService
 	eventQueueService                event_queue.EventQueueService
+	userRelationService              *user_relation.UserRelationService
+	mentionCommon                    *mention_common.MentionCommon
+	contentFilterService             *content_filter.ContentFilterService
+	auditLogService                  *audit_log.AuditLogService
 }
 
 func NewAnswerService(
@@ -89,6 +97,10 @@ func NewAnswerService(
 	activityQueueService activity_queue.ActivityQueueService,
 	reviewService *review.ReviewService,
 	eventQueueService event_queue.EventQueueService,
+	userRelationService *user_relation.UserRelationService,
+	mentionCommon *mention_common.MentionCommon,
+	contentFilterService *content_filter.ContentFilterService,
+	auditLogService *audit_log.AuditLogService,
 ) *AnswerService {
 	return &AnswerService{
 		answerRepo:                       answerRepo,
@@ -108,6 +120,10 @@ func NewAnswerService(
 		activityQueueService:             activityQueueService,
 		reviewService:                    reviewService,
 		eventQueueService:                eventQueueService,
+		userRelationService:              userRelationService,
+		mentionCommon:                    mentionCommon,
+		contentFilterService:             contentFilterService,
+		auditLogService:                  auditLogService,
 	}
 }
 
@@ -234,10 +250,15 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 		err = errors.BadRequest(reason.AnswerCannotAddByClosedQuestion)
 		return "", err
 	}
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.AnswerObjectType, "").
+		AddContent(&req.Content, &req.HTML, false)
+	if err = as.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return "", err
+	}
 	insertData := &entity.Answer{}
 	insertData.UserID = req.UserID
 	insertData.OriginalText = req.Content
-	insertData.ParsedText = req.HTML
+	insertData.ParsedText = as.mentionCommon.LinkMentions(ctx, req.HTML)
 	insertData.Accepted = schema.AnswerAcceptedFailed
 	insertData.QuestionID = req.QuestionID
 	insertData.RevisionID = "0"
@@ -286,6 +307,16 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 	if insertData.Status == entity.AnswerStatusAvailable {
 		as.notificationAnswerTheQuestion(ctx, questionInfo.UserID, questionInfo.ID, insertData.ID, req.UserID, questionInfo.Title,
 			htmltext.FetchExcerpt(insertData.ParsedText, "...", 240))
+		as.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+			ObjectID:      insertData.ID,
+			ObjectType:    constant.AnswerObjectType,
+			Title:         questionInfo.Title,
+			QuestionID:    questionInfo.ID,
+			AnswerID:      insertData.ID,
+			TriggerUserID: req.UserID,
+			ParsedText:    insertData.ParsedText,
+			SkipUserIDs:   map[string]bool{questionInfo.UserID: true},
+		})
 	}
 
 	as.activityQueueService.Send(ctx, &schema.ActivityMsg{
@@ -336,6 +367,12 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 		return "", errors.BadRequest(reason.AnswerCannotUpdate)
 	}
 
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.AnswerObjectType, req.ID).
+		AddContent(&req.Content, &req.HTML, false)
+	if err = as.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return "", err
+	}
+
 	//If the content is the same, ignore it
 	if answerInfo.OriginalText == req.Content {
 		return "", nil
@@ -346,7 +383,7 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 	insertData.UserID = answerInfo.UserID
 	insertData.QuestionID = req.QuestionID
 	insertData.OriginalText = req.Content
-	insertData.ParsedText = req.HTML
+	insertData.ParsedText = as.mentionCommon.LinkMentions(ctx, req.HTML)
 	insertData.UpdatedAt = time.Now()
 	insertData.LastEditUserID = "0"
 	if answerInfo.UserID != req.UserID {
@@ -374,6 +411,18 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 			return insertData.ID, err
 		}
 		as.notificationUpdateAnswer(ctx, questionInfo.UserID, insertData.ID, req.UserID)
+		if answerInfo.Status != entity.AnswerStatusPending {
+			as.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+				ObjectID:      insertData.ID,
+				ObjectType:    constant.AnswerObjectType,
+				Title:         questionInfo.Title,
+				QuestionID:    questionInfo.ID,
+				AnswerID:      insertData.ID,
+				TriggerUserID: req.UserID,
+				ParsedText:    insertData.ParsedText,
+				OldParsedText: answerInfo.ParsedText,
+			})
+		}
 		revisionDTO.Status = entity.RevisionReviewPassStatus
 	}
 
@@ -579,6 +628,14 @@ func (as *AnswerService) AdminSetAnswerStatus(ctx context.Context, req *schema.A
 			return err
 		}
 	}
+	as.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentUpdateStatus,
+		ObjectType: constant.AnswerObjectType,
+		ObjectID:   answerInfo.ID,
+		Before:     map[string]int{"status": answerInfo.Status},
+		After:      map[string]int{"status": setStatus},
+	})
 	return nil
 }
 
@@ -626,6 +683,12 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 		return list, nil
 	}
 
+	// the answers of muted users are collapsed for the login user
+	mutedUserIDs, err := as.userRelationService.GetMutedUserIDs(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
 	collectedMap, err := as.collectionCommon.SearchObjectCollected(ctx, req.UserID, objectIDs)
 	if err != nil {
 		return nil, err
@@ -633,6 +696,7 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 	for _, item := range list {
 		item.VoteStatus = as.voteRepo.GetVoteStatus(ctx, item.ID, req.UserID)
 		item.Collected = collectedMap[item.ID]
+		item.Muted = mutedUserIDs[item.UserID]
 		item.MemberActions = permission.GetAnswerPermission(ctx,
 			req.UserID,
 			item.UserID,
@@ -38,9 +38,12 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/mention_common"
 	metacommon "github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
@@ -53,6 +56,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/tag"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/htmltext"
@@ -90,6 +94,10 @@ type QuestionService struct {
 	reviewService                    *review.ReviewService
 	configService                    *config.ConfigService
 	eventQueueService                event_queue.EventQueueService
+	userRelationService              *user_relation.UserRelationService
+	mentionCommon                    *mention_common.MentionCommon
+	contentFilterService             *content_filter.ContentFilterService
+	auditLogService                  *audit_log.AuditLogService
 }
 
 func NewQuestionService(
@@ -115,6 +123,10 @@ func NewQuestionService(
 	reviewService *review.ReviewService,
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
+	userRelationService *user_relation.UserRelationService,
+	mentionCommon *mention_common.MentionCommon,
+	contentFilterService *content_filter.ContentFilterService,
+	auditLogService *audit_log.AuditLogService,
 ) *QuestionService {
 	return &QuestionService{
 		activityRepo:                     activityRepo,
@@ -139,6 +151,10 @@ func NewQuestionService(
 		reviewService:                    reviewService,
 		configService:                    configService,
 		eventQueueService:                eventQueueService,
+		userRelationService:              userRelationService,
+		mentionCommon:                    mentionCommon,
+		contentFilterService:             contentFilterService,
+		auditLogService:                  auditLogService,
 	}
 }
 
@@ -159,6 +175,7 @@ func (qs *QuestionService) CloseQuestion(ctx context.Context, req *schema.CloseQ
 		return errors.BadRequest(reason.InvalidURLError)
 	}
 
+	oldStatus := questionInfo.Status
 	questionInfo.Status = entity.QuestionStatusClosed
 	err = qs.questionRepo.UpdateQuestionStatus(ctx, questionInfo.ID, questionInfo.Status)
 	if err != nil {
@@ -173,6 +190,17 @@ func (qs *QuestionService) CloseQuestion(ctx context.Context, req *schema.CloseQ
 	if err != nil {
 		return err
 	}
+	// the author closing the own question is not a moderator action
+	if questionInfo.UserID != req.UserID {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionContentClose,
+			ObjectType: constant.QuestionObjectType,
+			ObjectID:   questionInfo.ID,
+			Before:     map[string]int{"status": oldStatus},
+			After:      json.RawMessage(closeMeta),
+		})
+	}
 
 	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
@@ -275,6 +303,13 @@ func (qs *QuestionService) HasNewTag(ctx context.Context, tags []*schema.TagItem
 
 // AddQuestion add question
 func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.QuestionAdd) (questionInfo any, err error) {
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.QuestionObjectType, "").
+		AddText(schema.ContentFilterFieldTitle, &req.Title).
+		AddContent(&req.Content, &req.HTML, false).
+		AddTags(req.Tags)
+	if err = qs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
 	if len(req.Tags) == 0 {
 		errorlist := make([]*validator.FormErrorField, 0)
 		errorlist = append(errorlist, &validator.FormErrorField{
@@ -327,7 +362,7 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	question.UserID = req.UserID
 	question.Title = req.Title
 	question.OriginalText = req.Content
-	question.ParsedText = req.HTML
+	question.ParsedText = qs.mentionCommon.LinkMentions(ctx, req.HTML)
 	question.AcceptedAnswerID = "0"
 	question.LastAnswerID = "0"
 	question.LastEditUserID = "0"
@@ -396,6 +431,14 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	if question.Status == entity.QuestionStatusAvailable {
 		qs.externalNotificationQueueService.Send(ctx,
 			schema.CreateNewQuestionNotificationMsg(question.ID, question.Title, question.UserID, tags))
+		qs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+			ObjectID:      question.ID,
+			ObjectType:    constant.QuestionObjectType,
+			Title:         question.Title,
+			QuestionID:    question.ID,
+			TriggerUserID: question.UserID,
+			ParsedText:    question.ParsedText,
+		})
 	}
 	qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, req.UserID).TID(question.ID).
 		QID(question.ID, question.UserID))
@@ -421,6 +464,7 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	if questionInfo.Pin == entity.QuestionPin && req.Operation == schema.QuestionOperationHide {
 		return nil
 	}
+	before := map[string]int{"show": questionInfo.Show, "pin": questionInfo.Pin}
 
 	switch req.Operation {
 	case schema.QuestionOperationHide:
@@ -453,6 +497,14 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentOperate,
+		ObjectType: constant.QuestionObjectType,
+		ObjectID:   questionInfo.ID,
+		Before:     before,
+		After:      map[string]int{"show": questionInfo.Show, "pin": questionInfo.Pin},
+	})
 
 	actMap := make(map[string]constant.ActivityTypeKey)
 	actMap[schema.QuestionOperationPin] = constant.ActQuestionPin
@@ -512,11 +564,23 @@ func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.Remov
 		}
 	}
 
+	oldStatus := questionInfo.Status
 	questionInfo.Status = entity.QuestionStatusDeleted
 	err = qs.questionRepo.UpdateQuestionStatusWithOutUpdateTime(ctx, questionInfo)
 	if err != nil {
 		return err
 	}
+	// the author removing the own question is not a moderator action
+	if questionInfo.UserID != req.UserID {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionContentRemove,
+			ObjectType: constant.QuestionObjectType,
+			ObjectID:   questionInfo.ID,
+			Before:     map[string]int{"status": oldStatus},
+			After:      map[string]int{"status": questionInfo.Status},
+		})
+	}
 
 	userQuestionCount, err := qs.questioncommon.GetUserQuestionCount(ctx, questionInfo.UserID)
 	if err != nil {
@@ -709,6 +773,9 @@ func (qs *QuestionService) UpdateQuestionInviteUser(ctx context.Context, req *sc
 			inviteUserIDs = append(inviteUserIDs, inviteUserInfoList[item].ID)
 		}
 	}
+	if err = qs.userRelationService.CheckBlocked(ctx, req.UserID, inviteUserIDs...); err != nil {
+		return err
+	}
 	inviteUserStr := ""
 	inviteUserByte, err := json.Marshal(inviteUserIDs)
 	if err != nil {
@@ -821,11 +888,19 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 		return nil, err
 	}
 
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.QuestionObjectType, req.ID).
+		AddText(schema.ContentFilterFieldTitle, &req.Title).
+		AddContent(&req.Content, &req.HTML, false).
+		AddTags(req.Tags)
+	if err = qs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	question := &entity.Question{}
 	question.Title = req.Title
 	question.OriginalText = req.Content
-	question.ParsedText = req.HTML
+	question.ParsedText = qs.mentionCommon.LinkMentions(ctx, req.HTML)
 	question.ID = uid.DeShortID(req.ID)
 	question.UpdatedAt = now
 	question.PostUpdateTime = now
@@ -955,6 +1030,17 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 		})
 		qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionUpdate, req.UserID).TID(question.ID).
 			QID(question.ID, question.UserID))
+		if dbinfo.Status != entity.QuestionStatusPending {
+			qs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+				ObjectID:      question.ID,
+				ObjectType:    constant.QuestionObjectType,
+				Title:         question.Title,
+				QuestionID:    question.ID,
+				TriggerUserID: req.UserID,
+				ParsedText:    question.ParsedText,
+				OldParsedText: dbinfo.ParsedText,
+			})
+		}
 	}
 
 	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
@@ -1338,6 +1424,15 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 		}
 	}
 
+	// include the content of all descendant tags, the last one of tag ids is the main tag
+	if req.IncludeDescendants && len(tagIDs) > 0 {
+		descendantTagIDs, err := qs.tagCommon.GetDescendantTagIDsWithSynonyms(ctx, tagIDs[len(tagIDs)-1])
+		if err != nil {
+			return nil, 0, err
+		}
+		tagIDs = append(tagIDs, descendantTagIDs...)
+	}
+
 	// query by user condition
 	if req.Username != "" {
 		userinfo, exist, err := qs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
@@ -1423,6 +1518,14 @@ func (qs *QuestionService) AdminSetQuestionStatus(ctx context.Context, req *sche
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentUpdateStatus,
+		ObjectType: constant.QuestionObjectType,
+		ObjectID:   questionInfo.ID,
+		Before:     map[string]int{"status": questionInfo.Status},
+		After:      map[string]int{"status": setStatus},
+	})
 
 	msg := &schema.NotificationMsg{}
 	if setStatus == entity.QuestionStatusDeleted {
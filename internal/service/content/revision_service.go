@@ -35,6 +35,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/mention_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
@@ -73,6 +74,7 @@ type RevisionService struct {
 	reportRepo               report_common.ReportRepo
 	reviewService            *review.ReviewService
 	reviewActivity           activity.ReviewActivityRepo
+	mentionCommon            *mention_common.MentionCommon
 }
 
 func NewRevisionService(
@@ -92,7 +94,7 @@ func NewRevisionService(
 	reviewActivity activity.ReviewActivityRepo,
 
 	articleCommon *articlecommon.ArticleCommon,
-
+	mentionCommon *mention_common.MentionCommon,
 ) *RevisionService {
 	return &RevisionService{
 		revisionRepo:             revisionRepo,
@@ -111,6 +113,7 @@ func NewRevisionService(
 		reviewActivity:           reviewActivity,
 
 		articleCommon: articleCommon,
+		mentionCommon: mentionCommon,
 	}
 }
 
@@ -215,6 +218,17 @@ func (rs *RevisionService) revisionAuditQuestion(ctx context.Context, revisionit
 		if saveerr != nil {
 			return saveerr
 		}
+		if dbquestion.Status != entity.QuestionStatusPending {
+			rs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+				ObjectID:      question.ID,
+				ObjectType:    constant.QuestionObjectType,
+				Title:         question.Title,
+				QuestionID:    question.ID,
+				TriggerUserID: revisionitem.UserID,
+				ParsedText:    question.ParsedText,
+				OldParsedText: dbquestion.ParsedText,
+			})
+		}
 		objectTagTags := make([]*schema.TagItem, 0)
 		for _, tag := range questioninfo.Tags {
 			item := &schema.TagItem{}
@@ -254,6 +268,11 @@ func (rs *RevisionService) revisionAuditAnswer(ctx context.Context, revisionitem
 			PostUpdateTime = dbquestion.PostUpdateTime
 		}
 
+		dbanswer, exist, dberr := rs.answerRepo.GetAnswer(ctx, answerinfo.ID)
+		if dberr != nil || !exist {
+			return
+		}
+
 		insertData := new(entity.Answer)
 		insertData.ID = answerinfo.ID
 		insertData.OriginalText = answerinfo.Content
@@ -284,6 +303,18 @@ func (rs *RevisionService) revisionAuditAnswer(ctx context.Context, revisionitem
 		msg.ObjectType = constant.AnswerObjectType
 		msg.NotificationAction = constant.NotificationUpdateAnswer
 		rs.notificationQueueService.Send(ctx, msg)
+		if dbanswer.Status != entity.AnswerStatusPending {
+			rs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+				ObjectID:      insertData.ID,
+				ObjectType:    constant.AnswerObjectType,
+				Title:         questionInfo.Title,
+				QuestionID:    questionInfo.ID,
+				AnswerID:      insertData.ID,
+				TriggerUserID: revisionitem.UserID,
+				ParsedText:    insertData.ParsedText,
+				OldParsedText: dbanswer.ParsedText,
+			})
+		}
 
 		rs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           revisionitem.UserID,
@@ -21,13 +21,22 @@ package content
 
 import (
 	"context"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/search_common"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
 	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/log"
 )
 
+// searchSuggestTimeout the latency budget of search suggestion,
+// the sources that can not be finished in time will be ignored.
+const searchSuggestTimeout = 300 * time.Millisecond
+
 type SearchService struct {
 	searchParser *search_parser.SearchParser
 	searchRepo   search_common.SearchRepo
@@ -57,6 +66,10 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 
 	// search type
 	cond := ss.searchParser.ParseStructure(ctx, dto)
+	// the sort operator in query overrides the order param
+	if len(cond.Order) > 0 {
+		dto.Order = cond.Order
+	}
 
 	// check search plugin
 	var finder plugin.Search
@@ -66,23 +79,58 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 	})
 
 	resp = &schema.SearchResp{}
-	// search plugin is not found, call system search
-	if finder == nil {
-		if cond.SearchAll() {
-			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchContents(ctx, cond.Words, cond.Tags, cond.UserID, cond.VoteAmount, dto.Page, dto.Size, dto.Order)
-		} else if cond.SearchQuestion() {
-			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchQuestions(ctx, cond.Words, cond.Tags, cond.NotAccepted, cond.Views, cond.AnswerAmount, dto.Page, dto.Size, dto.Order)
-		} else if cond.SearchAnswer() {
-			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchAnswers(ctx, cond.Words, cond.Tags, cond.Accepted, cond.QuestionID, dto.Page, dto.Size, dto.Order)
+	// search plugin only syncs questions and answers, so articles and quotes are always searched by system search
+	if finder == nil || cond.SearchArticle() || cond.SearchQuote() {
+		resp.SearchResults, resp.Total, err = ss.searchBySystem(ctx, cond, dto.Page, dto.Size, dto.Order)
+		if err != nil || !dto.Facets {
+			return resp, err
+		}
+		resp.Facets, err = ss.searchRepo.SearchFacets(ctx, cond)
+		if err != nil {
+			return resp, err
 		}
-		return
+		resp.Facets.RefineQuery(dto.Query)
+		return resp, nil
 	}
 	return ss.searchByPlugin(ctx, finder, cond, dto)
 }
 
+// SearchSince search the contents created since the time by the query of userID, the newest first.
+// It always uses the system search, so that the time condition is exact.
+func (ss *SearchService) SearchSince(ctx context.Context, query, userID string, since time.Time, size int) (
+	resp []*schema.SearchResult, total int64, err error) {
+	dto := &schema.SearchDTO{Query: query, UserID: userID}
+	// the query should be formatted the same as the search request
+	if _, err = dto.Check(); err != nil {
+		return nil, 0, err
+	}
+	cond := ss.searchParser.ParseStructure(ctx, dto)
+	if cond.Created == nil {
+		cond.Created = &schema.SearchTimeRange{}
+	}
+	if cond.Created.Start.Before(since) {
+		cond.Created.Start = since
+	}
+	return ss.searchBySystem(ctx, cond, 1, size, "newest")
+}
+
+func (ss *SearchService) searchBySystem(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (
+	resp []*schema.SearchResult, total int64, err error) {
+	switch {
+	case cond.SearchAll():
+		return ss.searchRepo.SearchContents(ctx, cond, page, size, order)
+	case cond.SearchQuestion():
+		return ss.searchRepo.SearchQuestions(ctx, cond, page, size, order)
+	case cond.SearchAnswer():
+		return ss.searchRepo.SearchAnswers(ctx, cond, page, size, order)
+	case cond.SearchArticle():
+		return ss.searchRepo.SearchArticles(ctx, cond, page, size, order)
+	case cond.SearchQuote():
+		return ss.searchRepo.SearchQuotes(ctx, cond, page, size, order)
+	}
+	return make([]*schema.SearchResult, 0), 0, nil
+}
+
 func (ss *SearchService) searchByPlugin(ctx context.Context, finder plugin.Search, cond *schema.SearchCondition, dto *schema.SearchDTO) (resp *schema.SearchResp, err error) {
 	var res []plugin.SearchResult
 	resp = &schema.SearchResp{}
@@ -95,5 +143,109 @@ func (ss *SearchService) searchByPlugin(ctx context.Context, finder plugin.Searc
 	}
 
 	resp.SearchResults, err = ss.searchRepo.ParseSearchPluginResult(ctx, res, cond.Words)
-	return resp, err
+	if err != nil || !dto.Facets {
+		return resp, err
+	}
+
+	// the facets are optional for search plugin
+	facetFinder, ok := finder.(plugin.SearchFacetFinder)
+	if !ok {
+		return resp, nil
+	}
+	pluginFacets, err := facetFinder.SearchFacets(ctx, cond.Convert2PluginSearchCond(dto.Page, dto.Size, dto.Order))
+	if err != nil {
+		return resp, err
+	}
+	resp.Facets, err = ss.searchRepo.ParseSearchPluginFacets(ctx, pluginFacets)
+	if err != nil || resp.Facets == nil {
+		return resp, err
+	}
+	resp.Facets.RefineQuery(dto.Query)
+	return resp, nil
+}
+
+// Suggest search suggestions as the user types, including titles, tags and users
+func (ss *SearchService) Suggest(ctx context.Context, req *schema.SearchSuggestReq) (resp *schema.SearchSuggestResp, err error) {
+	resp = &schema.SearchSuggestResp{
+		Titles: make([]*schema.SearchSuggestTitle, 0),
+		Tags:   make([]*schema.TagResp, 0),
+		Users:  make([]*schema.UserBasicInfo, 0),
+	}
+	if len(req.Query) == 0 {
+		return resp, nil
+	}
+
+	cacheResp, exist, err := ss.searchRepo.GetSuggestCache(ctx, req.Query, req.Size)
+	if err != nil {
+		log.Error(err)
+	}
+	if exist {
+		return cacheResp, nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, searchSuggestTimeout)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		complete = true
+	)
+	handleErr := func(err error) {
+		if err == nil {
+			return
+		}
+		log.Warnf("search suggest failed: %v", err)
+		mu.Lock()
+		complete = false
+		mu.Unlock()
+	}
+	for _, objectType := range []string{constant.QuestionObjectType, constant.ArticleObjectType, constant.QuoteObjectType} {
+		wg.Add(1)
+		go func(objectType string) {
+			defer wg.Done()
+			titles, err := ss.searchRepo.SuggestTitles(timeoutCtx, objectType, req.Query, req.Size)
+			handleErr(err)
+			mu.Lock()
+			resp.Titles = append(resp.Titles, titles...)
+			mu.Unlock()
+		}(objectType)
+	}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		tags, err := ss.searchRepo.SuggestTags(timeoutCtx, req.Query, req.Size)
+		handleErr(err)
+		if tags != nil {
+			resp.Tags = tags
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		users, err := ss.searchRepo.SuggestUsers(timeoutCtx, req.Query, req.Size)
+		handleErr(err)
+		if users != nil {
+			resp.Users = users
+		}
+	}()
+	wg.Wait()
+
+	// exact prefix matches first, then the hottest
+	sort.SliceStable(resp.Titles, func(i, j int) bool {
+		if resp.Titles[i].ExactPrefix != resp.Titles[j].ExactPrefix {
+			return resp.Titles[i].ExactPrefix
+		}
+		return resp.Titles[i].HotScore > resp.Titles[j].HotScore
+	})
+	if len(resp.Titles) > req.Size {
+		resp.Titles = resp.Titles[:req.Size]
+	}
+
+	// only cache the complete result, otherwise the timeout result will be cached
+	if complete {
+		if err := ss.searchRepo.SetSuggestCache(ctx, req.Query, req.Size, resp); err != nil {
+			log.Error(err)
+		}
+	}
+	return resp, nil
 }
@@ -39,11 +39,14 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity"
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/internal/service/user_login_security"
+	"github.com/apache/incubator-answer/internal/service/user_two_factor"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/google/uuid"
@@ -67,6 +70,9 @@ type UserService struct {
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
 	questionService               *questioncommon.QuestionCommon
 	eventQueueService             event_queue.EventQueueService
+	userTwoFactorService          *user_two_factor.UserTwoFactorService
+	userLoginSecurityService      *user_login_security.UserLoginSecurityService
+	contentFilterService          *content_filter.ContentFilterService
 }
 
 func NewUserService(userRepo usercommon.UserRepo,
@@ -82,6 +88,9 @@ func NewUserService(userRepo usercommon.UserRepo,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
 	questionService *questioncommon.QuestionCommon,
 	eventQueueService event_queue.EventQueueService,
+	userTwoFactorService *user_two_factor.UserTwoFactorService,
+	userLoginSecurityService *user_login_security.UserLoginSecurityService,
+	contentFilterService *content_filter.ContentFilterService,
 ) *UserService {
 	return &UserService{
 		userCommonService:             userCommonService,
@@ -97,6 +106,9 @@ func NewUserService(userRepo usercommon.UserRepo,
 		userNotificationConfigService: userNotificationConfigService,
 		questionService:               questionService,
 		eventQueueService:             eventQueueService,
+		userTwoFactorService:          userTwoFactorService,
+		userLoginSecurityService:      userLoginSecurityService,
+		contentFilterService:          contentFilterService,
 	}
 }
 
@@ -161,12 +173,27 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 	if err != nil {
 		return nil, err
 	}
-	if !exist || userInfo.Status == entity.UserStatusDeleted {
+	if exist && userInfo.Status == entity.UserStatusDeleted {
+		exist = false
+	}
+
+	// the ip and the account are checked before the password, so that a locked account can not be guessed
+	attempt := &schema.UserLoginAttempt{Email: req.Email, IP: req.IP, UserAgent: req.UserAgent}
+	if exist {
+		attempt.UserID = userInfo.ID
+	}
+	if err = us.userLoginSecurityService.CheckLoginAllowed(ctx, siteLogin, attempt); err != nil {
+		return nil, err
+	}
+	if !exist {
+		us.userLoginSecurityService.LoginFailed(ctx, siteLogin, attempt, nil)
 		return nil, errors.BadRequest(reason.EmailOrPasswordWrong)
 	}
 	if !us.verifyPassword(ctx, req.Pass, userInfo.Pass) {
+		us.userLoginSecurityService.LoginFailed(ctx, siteLogin, attempt, userInfo)
 		return nil, errors.BadRequest(reason.EmailOrPasswordWrong)
 	}
+
 	ok, externalID, err := us.userExternalLoginService.CheckUserStatusInUserCenter(ctx, userInfo.ID)
 	if err != nil {
 		return nil, err
@@ -175,6 +202,59 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 		return nil, errors.BadRequest(reason.EmailOrPasswordWrong)
 	}
 
+	// the login is completed by TwoFactorLogin if the second factor is required,
+	// and the failed state of the account is not reset until then
+	pendingLogin := &schema.TwoFactorPendingLogin{
+		UserID:               userInfo.ID,
+		ExternalID:           externalID,
+		PasswordLoginAttempt: attempt,
+	}
+	twoFactorToken, err := us.userTwoFactorService.NewPendingLoginIfRequired(ctx, pendingLogin)
+	if err != nil {
+		return nil, err
+	}
+	if len(twoFactorToken) > 0 {
+		return &schema.UserLoginResp{
+			TwoFactorToken:          twoFactorToken,
+			TwoFactorEnrollRequired: pendingLogin.EnrollRequired,
+		}, nil
+	}
+	us.userLoginSecurityService.LoginSucceeded(ctx, attempt)
+	return us.login(ctx, userInfo, externalID)
+}
+
+// TwoFactorLogin complete the login by the second factor
+func (us *UserService) TwoFactorLogin(ctx context.Context, req *schema.UserTwoFactorLoginReq) (
+	resp *schema.UserTwoFactorLoginResp, err error) {
+	pendingLogin, recoveryCodes, err := us.userTwoFactorService.VerifyPendingLogin(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, pendingLogin.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	if pendingLogin.PasswordLoginAttempt != nil {
+		us.userLoginSecurityService.LoginSucceeded(ctx, pendingLogin.PasswordLoginAttempt)
+	}
+	loginResp, err := us.login(ctx, userInfo, pendingLogin.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	if pendingLogin.UserCenterLogin {
+		if userCenter, ok := plugin.GetUserCenter(); ok {
+			userCenter.AfterLogin(pendingLogin.ExternalID, loginResp.AccessToken)
+		}
+	}
+	return &schema.UserTwoFactorLoginResp{UserLoginResp: loginResp, RecoveryCodes: recoveryCodes}, nil
+}
+
+// login set the user login cache and returns the tokens
+func (us *UserService) login(ctx context.Context, userInfo *entity.User, externalID string) (
+	resp *schema.UserLoginResp, err error) {
 	err = us.userRepo.UpdateLastLoginDate(ctx, userInfo.ID)
 	if err != nil {
 		log.Errorf("update last login data failed, err: %v", err)
@@ -314,6 +394,14 @@ func (us *UserService) UpdateInfo(ctx context.Context, req *schema.UpdateInfoReq
 		return nil, err
 	}
 
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.UserObjectType, req.UserID).
+		AddName(schema.ContentFilterFieldUsername, &req.Username).
+		AddName(schema.ContentFilterFieldDisplayName, &req.DisplayName).
+		AddField(&schema.ContentFilterField{Name: schema.ContentFilterFieldBio, Text: &req.Bio, HTML: &req.BioHTML})
+	if err = us.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
+
 	if siteUsers.AllowUpdateUsername && len(req.Username) > 0 {
 		if checker.IsInvalidUsername(req.Username) {
 			return append(errFields, &validator.FormErrorField{
@@ -420,6 +508,12 @@ func (us *UserService) UserRegisterByEmail(ctx context.Context, registerUserInfo
 		return nil, errFields, errors.BadRequest(reason.EmailDuplicate)
 	}
 
+	filterReq := schema.NewContentFilterReq("", constant.UserObjectType, "").
+		AddName(schema.ContentFilterFieldDisplayName, &registerUserInfo.Name)
+	if err = us.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, nil, err
+	}
+
 	userInfo := &entity.User{}
 	userInfo.EMail = registerUserInfo.Email
 	userInfo.DisplayName = registerUserInfo.Name
@@ -546,21 +640,43 @@ func (us *UserService) UserVerifyEmail(ctx context.Context, req *schema.UserVeri
 		}
 	}
 
-	accessToken, userCacheInfo, err := us.userCommonService.CacheLoginUserInfo(
-		ctx, userInfo.ID, userInfo.MailStatus, userInfo.Status, "")
-	if err != nil {
+	// User verified email will update user email status. So user status cache should be updated.
+	if err = us.setVerifiedUserStatus(ctx, userInfo); err != nil {
 		return nil, err
 	}
+	return us.verifiedEmailLogin(ctx, userInfo)
+}
 
-	resp = &schema.UserLoginResp{}
-	resp.ConvertFromUserEntity(userInfo)
-	resp.Avatar = us.siteInfoService.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status).GetURL()
-	resp.AccessToken = accessToken
-	// User verified email will update user email status. So user status cache should be updated.
-	if err = us.authService.SetUserStatus(ctx, userCacheInfo); err != nil {
+// setVerifiedUserStatus update the user status cache after the email is verified
+func (us *UserService) setVerifiedUserStatus(ctx context.Context, userInfo *entity.User) (err error) {
+	roleID, err := us.userRoleService.GetUserRole(ctx, userInfo.ID)
+	if err != nil {
+		log.Error(err)
+	}
+	return us.authService.SetUserStatus(ctx, &entity.UserCacheInfo{
+		UserID:      userInfo.ID,
+		EmailStatus: userInfo.MailStatus,
+		UserStatus:  userInfo.Status,
+		RoleID:      roleID,
+	})
+}
+
+// verifiedEmailLogin login the user by the verified email link,
+// the login is completed by TwoFactorLogin if the second factor is required, the same as EmailLogin
+func (us *UserService) verifiedEmailLogin(ctx context.Context, userInfo *entity.User) (
+	resp *schema.UserLoginResp, err error) {
+	pendingLogin := &schema.TwoFactorPendingLogin{UserID: userInfo.ID}
+	twoFactorToken, err := us.userTwoFactorService.NewPendingLoginIfRequired(ctx, pendingLogin)
+	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	if len(twoFactorToken) > 0 {
+		return &schema.UserLoginResp{
+			TwoFactorToken:          twoFactorToken,
+			TwoFactorEnrollRequired: pendingLogin.EnrollRequired,
+		}, nil
+	}
+	return us.login(ctx, userInfo, "")
 }
 
 // verifyPassword
@@ -673,36 +789,14 @@ func (us *UserService) UserChangeEmailVerify(ctx context.Context, content string
 		}
 	}
 
-	roleID, err := us.userRoleService.GetUserRole(ctx, userInfo.ID)
-	if err != nil {
-		log.Error(err)
-	}
+	userInfo.EMail = data.Email
+	userInfo.MailStatus = entity.EmailStatusAvailable
 
-	resp = &schema.UserLoginResp{}
-	resp.ConvertFromUserEntity(userInfo)
-	resp.Avatar = us.siteInfoService.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status).GetURL()
-	userCacheInfo := &entity.UserCacheInfo{
-		UserID:      userInfo.ID,
-		EmailStatus: entity.EmailStatusAvailable,
-		UserStatus:  userInfo.Status,
-		RoleID:      roleID,
-	}
-	resp.AccessToken, resp.VisitToken, err = us.authService.SetUserCacheInfo(ctx, userCacheInfo)
-	if err != nil {
-		return nil, err
-	}
 	// User verified email will update user email status. So user status cache should be updated.
-	if err = us.authService.SetUserStatus(ctx, userCacheInfo); err != nil {
+	if err = us.setVerifiedUserStatus(ctx, userInfo); err != nil {
 		return nil, err
 	}
-	resp.RoleID = userCacheInfo.RoleID
-	if resp.RoleID == role.RoleAdminID {
-		err = us.authService.SetAdminUserCacheInfo(ctx, resp.AccessToken, &entity.UserCacheInfo{UserID: userInfo.ID})
-		if err != nil {
-			return nil, err
-		}
-	}
-	return resp, nil
+	return us.verifiedEmailLogin(ctx, userInfo)
 }
 
 // getSiteUrl get site url
@@ -43,6 +43,7 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
 	"github.com/segmentfault/pacman/errors"
 )
 
@@ -57,14 +58,15 @@ type VoteRepo interface {
 
 // VoteService user service
 type VoteService struct {
-	voteRepo          VoteRepo
-	configService     *config.ConfigService
-	questionRepo      questioncommon.QuestionRepo
-	answerRepo        answercommon.AnswerRepo
-	commentCommonRepo comment_common.CommentCommonRepo
-	objectService     *object_info.ObjService
-	activityRepo      activity_common.ActivityRepo
-	eventQueueService event_queue.EventQueueService
+	voteRepo            VoteRepo
+	configService       *config.ConfigService
+	questionRepo        questioncommon.QuestionRepo
+	answerRepo          answercommon.AnswerRepo
+	commentCommonRepo   comment_common.CommentCommonRepo
+	objectService       *object_info.ObjService
+	activityRepo        activity_common.ActivityRepo
+	eventQueueService   event_queue.EventQueueService
+	userRelationService *user_relation.UserRelationService
 }
 
 func NewVoteService(
@@ -75,15 +77,17 @@ func NewVoteService(
 	commentCommonRepo comment_common.CommentCommonRepo,
 	objectService *object_info.ObjService,
 	eventQueueService event_queue.EventQueueService,
+	userRelationService *user_relation.UserRelationService,
 ) *VoteService {
 	return &VoteService{
-		voteRepo:          voteRepo,
-		configService:     configService,
-		questionRepo:      questionRepo,
-		answerRepo:        answerRepo,
-		commentCommonRepo: commentCommonRepo,
-		objectService:     objectService,
-		eventQueueService: eventQueueService,
+		voteRepo:            voteRepo,
+		configService:       configService,
+		questionRepo:        questionRepo,
+		answerRepo:          answerRepo,
+		commentCommonRepo:   commentCommonRepo,
+		objectService:       objectService,
+		eventQueueService:   eventQueueService,
+		userRelationService: userRelationService,
 	}
 }
 
@@ -103,6 +107,12 @@ func (vs *VoteService) VoteUp(ctx context.Context, req *schema.VoteReq) (resp *s
 	if objectInfo.ObjectCreatorUserID == req.UserID {
 		return nil, errors.BadRequest(reason.DisallowVoteYourSelf)
 	}
+	// the vote can still be cancelled after blocked
+	if !req.IsCancel {
+		if err = vs.userRelationService.CheckBlocked(ctx, req.UserID, objectInfo.ObjectCreatorUserID); err != nil {
+			return nil, err
+		}
+	}
 
 	voteUpOperationInfo := vs.createVoteOperationInfo(ctx, req.UserID, true, objectInfo)
 
@@ -154,6 +164,12 @@ func (vs *VoteService) VoteDown(ctx context.Context, req *schema.VoteReq) (resp
 	if objectInfo.ObjectCreatorUserID == req.UserID {
 		return nil, errors.BadRequest(reason.DisallowVoteYourSelf)
 	}
+	// the vote can still be cancelled after blocked
+	if !req.IsCancel {
+		if err = vs.userRelationService.CheckBlocked(ctx, req.UserID, objectInfo.ObjectCreatorUserID); err != nil {
+			return nil, err
+		}
+	}
 
 	// vote operation
 	voteDownOperationInfo := vs.createVoteOperationInfo(ctx, req.UserID, false, objectInfo)
@@ -0,0 +1,455 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_filter
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/textfilter"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// filterRefreshInterval the rules are reloaded after the interval, so the changes made by other instances are applied
+	filterRefreshInterval = time.Minute
+	// excerptRunes the number of runes kept on each side of the matched text in the log
+	excerptRunes = 40
+)
+
+// ContentFilterRepo content filter repository
+type ContentFilterRepo interface {
+	AddContentFilterRules(ctx context.Context, rules []*entity.ContentFilterRule) (err error)
+	UpdateContentFilterRule(ctx context.Context, rule *entity.ContentFilterRule) (err error)
+	RemoveContentFilterRule(ctx context.Context, id string) (err error)
+	GetContentFilterRule(ctx context.Context, id string) (rule *entity.ContentFilterRule, exist bool, err error)
+	GetContentFilterRulePage(ctx context.Context, page, pageSize int, cond *entity.ContentFilterRule, query string) (
+		rules []*entity.ContentFilterRule, total int64, err error)
+	GetAllContentFilterRules(ctx context.Context) (rules []*entity.ContentFilterRule, err error)
+	AddContentFilterLogs(ctx context.Context, logs []*entity.ContentFilterLog) (err error)
+	GetContentFilterLogPage(ctx context.Context, page, pageSize int, cond *entity.ContentFilterLog) (
+		logs []*entity.ContentFilterLog, total int64, err error)
+}
+
+// ContentFilterService check the user content against the banned words, regexes and domains
+type ContentFilterService struct {
+	contentFilterRepo ContentFilterRepo
+	userRepo          usercommon.UserRepo
+	auditLogService   *audit_log.AuditLogService
+
+	lock     sync.Mutex
+	filter   *compiledFilter
+	loadedAt time.Time
+}
+
+// NewContentFilterService new content filter service
+func NewContentFilterService(
+	contentFilterRepo ContentFilterRepo,
+	userRepo usercommon.UserRepo,
+	auditLogService *audit_log.AuditLogService,
+) *ContentFilterService {
+	return &ContentFilterService{
+		contentFilterRepo: contentFilterRepo,
+		userRepo:          userRepo,
+		auditLogService:   auditLogService,
+	}
+}
+
+// FilterContent check the fields of the content. If any rule with reject severity is matched, the content is rejected.
+// The text matched by mask rules is masked in place. All the matches are logged for moderator review.
+// The rules with review severity are checked again by NeedReview when the content is sent to review.
+func (cs *ContentFilterService) FilterContent(ctx context.Context, req *schema.ContentFilterReq) (err error) {
+	filter := cs.getFilter(ctx)
+	filterLogs := make([]*entity.ContentFilterLog, 0)
+	rejectedWords := make([]string, 0)
+	for _, field := range req.Fields {
+		text := *field.Text
+		maskRanges := make([]textfilter.Range, 0)
+		loggedRules := make(map[*entity.ContentFilterRule]bool)
+		for _, m := range filter.match(text) {
+			severity := m.rule.Severity
+			if severity == entity.ContentFilterSeverityMask && field.RejectOnMask {
+				severity = entity.ContentFilterSeverityReject
+			}
+			matched := text[m.Start:m.End]
+			switch severity {
+			case entity.ContentFilterSeverityReject:
+				rejectedWords = append(rejectedWords, matched)
+			case entity.ContentFilterSeverityMask:
+				maskRanges = append(maskRanges, m.Range)
+			}
+			if loggedRules[m.rule] {
+				continue
+			}
+			loggedRules[m.rule] = true
+			filterLog := newContentFilterLog(req, field, text, m.Range)
+			filterLog.RuleID = m.rule.ID
+			filterLog.RuleType = m.rule.RuleType
+			filterLog.Pattern = m.rule.Pattern
+			filterLog.Severity = severity
+			filterLogs = append(filterLogs, filterLog)
+		}
+
+		_ = plugin.CallFilter(func(fn plugin.Filter) error {
+			if filterErr := fn.FilterText(text); filterErr != nil {
+				rejectedWords = append(rejectedWords, filterErr.Error())
+				filterLog := newContentFilterLog(req, field, text, textfilter.Range{})
+				filterLog.RuleID = "0"
+				filterLog.Pattern = fn.Info().SlugName
+				filterLog.Severity = entity.ContentFilterSeverityReject
+				filterLog.Matched = truncate(filterErr.Error(), 255)
+				filterLogs = append(filterLogs, filterLog)
+			}
+			return nil
+		})
+
+		if len(maskRanges) > 0 {
+			*field.Text = textfilter.Mask(text, maskRanges)
+			if field.HTML != nil {
+				if field.IsHTML {
+					*field.HTML = converter.SanitizeHTML(*field.Text)
+				} else {
+					*field.HTML = converter.Markdown2HTML(*field.Text)
+				}
+			}
+		}
+	}
+
+	if len(filterLogs) > 0 {
+		if err := cs.contentFilterRepo.AddContentFilterLogs(ctx, filterLogs); err != nil {
+			log.Error(err)
+		}
+	}
+	if len(rejectedWords) > 0 {
+		msg := translator.TrWithData(handler.GetLangByCtx(ctx), reason.ContentFilterRejected,
+			&schema.ContentFilterRejectedTrTplData{Words: strings.Join(uniqueStrings(rejectedWords), ", ")})
+		return errors.BadRequest(reason.ContentFilterRejected).WithMsg(msg)
+	}
+	return nil
+}
+
+// NeedReview check whether the texts match any rule which sends the content to review.
+// It returns the first matched rule, nil if no rule is matched.
+func (cs *ContentFilterService) NeedReview(ctx context.Context, texts ...string) (rule *entity.ContentFilterRule) {
+	filter := cs.getFilter(ctx)
+	for _, text := range texts {
+		for _, m := range filter.match(text) {
+			if m.rule.Severity >= entity.ContentFilterSeverityReview {
+				return m.rule
+			}
+		}
+	}
+	return nil
+}
+
+// AddContentFilterRules add content filter rules
+func (cs *ContentFilterService) AddContentFilterRules(ctx context.Context, req *schema.AddContentFilterRuleReq) (err error) {
+	rules := make([]*entity.ContentFilterRule, 0, len(req.Patterns))
+	for _, pattern := range uniqueStrings(req.Patterns) {
+		rules = append(rules, &entity.ContentFilterRule{
+			RuleType: entity.ContentFilterRuleTypeMapping[req.RuleType],
+			Pattern:  pattern,
+			Severity: entity.ContentFilterSeverityMapping[req.Severity],
+			UserID:   req.UserID,
+		})
+	}
+	if err = cs.contentFilterRepo.AddContentFilterRules(ctx, rules); err != nil {
+		return err
+	}
+	cs.resetFilter()
+	after := make([]*schema.ContentFilterRuleResp, 0, len(rules))
+	for _, rule := range rules {
+		after = append(after, schema.NewContentFilterRuleResp(rule))
+	}
+	cs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentFilterAdd,
+		ObjectType: entity.AuditLogObjectTypeContentFilterRule,
+		After:      after,
+	})
+	return nil
+}
+
+// UpdateContentFilterRule update content filter rule
+func (cs *ContentFilterService) UpdateContentFilterRule(ctx context.Context, req *schema.UpdateContentFilterRuleReq) (err error) {
+	rule, exist, err := cs.contentFilterRepo.GetContentFilterRule(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.ContentFilterRuleNotFound)
+	}
+	before := schema.NewContentFilterRuleResp(rule)
+	rule.RuleType = entity.ContentFilterRuleTypeMapping[req.RuleType]
+	rule.Pattern = req.Pattern
+	rule.Severity = entity.ContentFilterSeverityMapping[req.Severity]
+	if err = cs.contentFilterRepo.UpdateContentFilterRule(ctx, rule); err != nil {
+		return err
+	}
+	cs.resetFilter()
+	cs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionContentFilterUpdate,
+		ObjectType: entity.AuditLogObjectTypeContentFilterRule,
+		ObjectID:   rule.ID,
+		Before:     before,
+		After:      schema.NewContentFilterRuleResp(rule),
+	})
+	return nil
+}
+
+// RemoveContentFilterRule remove content filter rule
+func (cs *ContentFilterService) RemoveContentFilterRule(ctx context.Context, req *schema.RemoveContentFilterRuleReq) (err error) {
+	rule, exist, err := cs.contentFilterRepo.GetContentFilterRule(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return nil
+	}
+	if err = cs.contentFilterRepo.RemoveContentFilterRule(ctx, req.ID); err != nil {
+		return err
+	}
+	cs.resetFilter()
+	cs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionContentFilterRemove,
+		ObjectType: entity.AuditLogObjectTypeContentFilterRule,
+		ObjectID:   rule.ID,
+		Before:     schema.NewContentFilterRuleResp(rule),
+	})
+	return nil
+}
+
+// GetContentFilterRulePage get content filter rule page
+func (cs *ContentFilterService) GetContentFilterRulePage(ctx context.Context, req *schema.GetContentFilterRulePageReq) (
+	pageModel *pager.PageModel, err error) {
+	cond := &entity.ContentFilterRule{
+		RuleType: entity.ContentFilterRuleTypeMapping[req.RuleType],
+		Severity: entity.ContentFilterSeverityMapping[req.Severity],
+	}
+	rules, total, err := cs.contentFilterRepo.GetContentFilterRulePage(ctx, req.Page, req.PageSize, cond, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*schema.ContentFilterRuleResp, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, schema.NewContentFilterRuleResp(rule))
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// GetContentFilterLogPage get the log of the content matched by the filter
+func (cs *ContentFilterService) GetContentFilterLogPage(ctx context.Context, req *schema.GetContentFilterLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	cond := &entity.ContentFilterLog{
+		UserID:     req.UserID,
+		ObjectType: req.ObjectType,
+		Severity:   entity.ContentFilterSeverityMapping[req.Severity],
+	}
+	filterLogs, total, err := cs.contentFilterRepo.GetContentFilterLogPage(ctx, req.Page, req.PageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(filterLogs))
+	for _, filterLog := range filterLogs {
+		userIDs = append(userIDs, filterLog.UserID)
+	}
+	userList, err := cs.userRepo.BatchGetByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userMapping := make(map[string]*entity.User, len(userList))
+	for _, user := range userList {
+		userMapping[user.ID] = user
+	}
+	resp := make([]*schema.ContentFilterLogResp, 0, len(filterLogs))
+	for _, filterLog := range filterLogs {
+		item := schema.NewContentFilterLogResp(filterLog)
+		if user := userMapping[filterLog.UserID]; user != nil {
+			item.Username = user.Username
+			item.DisplayName = user.DisplayName
+		}
+		resp = append(resp, item)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// getFilter get the compiled rules, reload them if they are expired
+func (cs *ContentFilterService) getFilter(ctx context.Context) *compiledFilter {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+	if cs.filter != nil && time.Since(cs.loadedAt) < filterRefreshInterval {
+		return cs.filter
+	}
+	rules, err := cs.contentFilterRepo.GetAllContentFilterRules(ctx)
+	if err != nil {
+		log.Errorf("load content filter rules failed: %v", err)
+		if cs.filter == nil {
+			return newCompiledFilter(nil)
+		}
+		return cs.filter
+	}
+	cs.filter = newCompiledFilter(rules)
+	cs.loadedAt = time.Now()
+	return cs.filter
+}
+
+// resetFilter reload the rules on next use
+func (cs *ContentFilterService) resetFilter() {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+	cs.filter = nil
+}
+
+// compiledFilter the rules which are ready to match
+type compiledFilter struct {
+	wordRules   []*entity.ContentFilterRule
+	wordMatcher *textfilter.Matcher
+	regexRules  []*entity.ContentFilterRule
+	regexes     []*regexp.Regexp
+	domainRules []*entity.ContentFilterRule
+	domains     []string
+}
+
+type ruleMatch struct {
+	rule *entity.ContentFilterRule
+	textfilter.Range
+}
+
+func newCompiledFilter(rules []*entity.ContentFilterRule) *compiledFilter {
+	f := &compiledFilter{}
+	words := make([]string, 0)
+	for _, rule := range rules {
+		switch rule.RuleType {
+		case entity.ContentFilterRuleTypeWord:
+			f.wordRules = append(f.wordRules, rule)
+			words = append(words, rule.Pattern)
+		case entity.ContentFilterRuleTypeRegex:
+			re, err := regexp.Compile("(?i)" + rule.Pattern)
+			if err != nil {
+				log.Warnf("content filter rule %s is not a valid regex: %v", rule.ID, err)
+				continue
+			}
+			f.regexRules = append(f.regexRules, rule)
+			f.regexes = append(f.regexes, re)
+		case entity.ContentFilterRuleTypeDomain:
+			f.domainRules = append(f.domainRules, rule)
+			f.domains = append(f.domains, rule.Pattern)
+		}
+	}
+	f.wordMatcher = textfilter.NewMatcher(words)
+	return f
+}
+
+// match find all the rules matched by the text
+func (f *compiledFilter) match(text string) (matches []*ruleMatch) {
+	if len(text) == 0 {
+		return nil
+	}
+	for _, m := range f.wordMatcher.FindAll(text) {
+		matches = append(matches, &ruleMatch{rule: f.wordRules[m.Pattern], Range: m.Range})
+	}
+	for i, re := range f.regexes {
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			matches = append(matches, &ruleMatch{rule: f.regexRules[i], Range: textfilter.Range{Start: loc[0], End: loc[1]}})
+		}
+	}
+	if len(f.domains) > 0 {
+		for _, m := range textfilter.FindDomains(text, f.domains) {
+			matches = append(matches, &ruleMatch{rule: f.domainRules[m.Pattern], Range: m.Range})
+		}
+	}
+	return matches
+}
+
+func newContentFilterLog(req *schema.ContentFilterReq, field *schema.ContentFilterField,
+	text string, matched textfilter.Range) *entity.ContentFilterLog {
+	filterLog := &entity.ContentFilterLog{
+		UserID:     req.UserID,
+		ObjectType: req.ObjectType,
+		ObjectID:   uid.DeShortID(req.ObjectID),
+		Field:      field.Name,
+		Matched:    truncate(text[matched.Start:matched.End], 255),
+		Excerpt:    excerpt(text, matched),
+	}
+	if len(filterLog.UserID) == 0 {
+		filterLog.UserID = "0"
+	}
+	if len(filterLog.ObjectID) == 0 {
+		filterLog.ObjectID = "0"
+	}
+	return filterLog
+}
+
+// excerpt get the text around the matched range
+func excerpt(text string, matched textfilter.Range) string {
+	start, end := matched.Start, matched.End
+	for i := 0; i < excerptRunes && start > 0; i++ {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+	for i := 0; i < excerptRunes && end < len(text); i++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+	return truncate(text[start:end], 1024)
+}
+
+// truncate cut the text to at most maxBytes bytes without breaking a rune
+func truncate(text string, maxBytes int) string {
+	if len(text) <= maxBytes {
+		return text
+	}
+	for maxBytes > 0 && !utf8.RuneStart(text[maxBytes]) {
+		maxBytes--
+	}
+	return text[:maxBytes]
+}
+
+func uniqueStrings(list []string) []string {
+	seen := make(map[string]bool, len(list))
+	result := make([]string, 0, len(list))
+	for _, s := range list {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		result = append(result, s)
+	}
+	return result
+}
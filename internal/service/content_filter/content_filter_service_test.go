@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_filter
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/pkg/textfilter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompiledFilter_match(t *testing.T) {
+	filter := newCompiledFilter([]*entity.ContentFilterRule{
+		{ID: "1", RuleType: entity.ContentFilterRuleTypeWord, Pattern: "spam", Severity: entity.ContentFilterSeverityMask},
+		{ID: "2", RuleType: entity.ContentFilterRuleTypeRegex, Pattern: `buy\s+now`, Severity: entity.ContentFilterSeverityReview},
+		{ID: "3", RuleType: entity.ContentFilterRuleTypeDomain, Pattern: "bad.example", Severity: entity.ContentFilterSeverityReject},
+		{ID: "4", RuleType: entity.ContentFilterRuleTypeRegex, Pattern: `(`, Severity: entity.ContentFilterSeverityReject},
+	})
+
+	matches := filter.match("S P A M here, BUY  now at https://shop.bad.example/x")
+	ruleIDs := make([]string, 0, len(matches))
+	for _, m := range matches {
+		ruleIDs = append(ruleIDs, m.rule.ID)
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, ruleIDs)
+	assert.Empty(t, filter.match("a spammer is not matched"))
+	assert.Empty(t, filter.match(""))
+}
+
+func TestExcerpt(t *testing.T) {
+	text := "前面的内容 spam 后面的内容"
+	start := len("前面的内容 ")
+	got := excerpt(text, textfilter.Range{Start: start, End: start + len("spam")})
+	assert.Equal(t, text, got)
+	assert.Equal(t, "前", truncate("前面", 4))
+}
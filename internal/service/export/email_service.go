@@ -24,7 +24,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-answer/pkg/display"
+	"html"
 	"mime"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -36,6 +38,8 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 	"golang.org/x/net/context"
@@ -327,6 +331,113 @@ func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.New
 	return title, body, nil
 }
 
+// SavedSearchAlertTemplate saved search alert template
+func (es *EmailService) SavedSearchAlertTemplate(ctx context.Context, raw *schema.SavedSearchAlertTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	titles := make([]string, 0, len(raw.Titles))
+	for _, t := range raw.Titles {
+		titles = append(titles, html.EscapeString(t))
+	}
+	templateData := &schema.SavedSearchAlertTemplateData{
+		SiteName:        siteInfo.Name,
+		SavedSearchName: html.EscapeString(raw.SavedSearchName),
+		NewCount:        raw.NewCount,
+		Titles:          strings.Join(titles, "<br>\n"),
+		SearchUrl:       fmt.Sprintf("%s/search?q=%s", siteInfo.SiteUrl, url.QueryEscape(raw.Query)),
+		UnsubscribeUrl:  fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeySavedSearchAlertTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeySavedSearchAlertBody, templateData)
+	return title, body, nil
+}
+
+// MentionTemplate mention template
+func (es *EmailService) MentionTemplate(ctx context.Context, raw *schema.MentionTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.MentionTemplateData{
+		SiteName:       siteInfo.Name,
+		DisplayName:    raw.TriggerUserDisplayName,
+		Title:          raw.Title,
+		Summary:        raw.Summary,
+		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+	}
+	// the question id is the id of the article or quote when the mention is in them
+	objectType, _ := obj.GetObjectTypeStrByObjectID(uid.DeShortID(raw.QuestionID))
+	switch objectType {
+	case constant.ArticleObjectType:
+		templateData.MentionUrl = display.ArticleURL(siteInfo.SiteUrl, raw.QuestionID)
+	case constant.QuoteObjectType:
+		templateData.MentionUrl = display.QuoteURL(siteInfo.SiteUrl, raw.QuestionID)
+	default:
+		if len(raw.AnswerID) > 0 {
+			templateData.MentionUrl = display.AnswerURL(seoInfo.Permalink,
+				siteInfo.SiteUrl, raw.QuestionID, raw.Title, raw.AnswerID)
+		} else {
+			templateData.MentionUrl = display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, raw.QuestionID, raw.Title)
+		}
+	}
+	if len(raw.CommentID) > 0 {
+		templateData.MentionUrl += "?commentId=" + raw.CommentID
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyMentionTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyMentionBody, templateData)
+	return title, body, nil
+}
+
+// DataExportReadyTemplate data export ready template
+func (es *EmailService) DataExportReadyTemplate(ctx context.Context, downloadUrl string, expiredAt time.Time) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.DataExportReadyTemplateData{
+		SiteName:    siteInfo.Name,
+		DownloadUrl: downloadUrl,
+		ExpiredAt:   expiredAt.UTC().Format("2006-01-02 15:04 MST"),
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyDataExportReadyTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyDataExportReadyBody, templateData)
+	return title, body, nil
+}
+
+// LoginLockoutTemplate login lockout template
+func (es *EmailService) LoginLockoutTemplate(ctx context.Context, ip string, lockedUntil time.Time) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.LoginLockoutTemplateData{
+		SiteName:    siteInfo.Name,
+		IP:          ip,
+		LockedUntil: lockedUntil.UTC().Format("2006-01-02 15:04 MST"),
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyLoginLockoutTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyLoginLockoutBody, templateData)
+	return title, body, nil
+}
+
 func (es *EmailService) GetEmailConfig(ctx context.Context) (ec *EmailConfig, err error) {
 	emailConf, err := es.configService.GetStringValue(ctx, constant.EmailConfigKey)
 	if err != nil {
@@ -22,10 +22,12 @@ package follow
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/pkg/obj"
 )
 
 type FollowRepo interface {
@@ -37,17 +39,20 @@ type FollowService struct {
 	tagRepo          tagcommon.TagCommonRepo
 	followRepo       FollowRepo
 	followCommonRepo activity_common.FollowRepo
+	tagCommonService *tagcommon.TagCommonService
 }
 
 func NewFollowService(
 	followRepo FollowRepo,
 	followCommonRepo activity_common.FollowRepo,
 	tagRepo tagcommon.TagCommonRepo,
+	tagCommonService *tagcommon.TagCommonService,
 ) *FollowService {
 	return &FollowService{
 		followRepo:       followRepo,
 		followCommonRepo: followCommonRepo,
 		tagRepo:          tagRepo,
+		tagCommonService: tagCommonService,
 	}
 }
 
@@ -61,6 +66,11 @@ func (fs *FollowService) Follow(ctx context.Context, dto *schema.FollowDTO) (res
 	if err != nil {
 		return resp, err
 	}
+	if dto.IncludeDescendants {
+		if err = fs.followDescendantTags(ctx, dto); err != nil {
+			return resp, err
+		}
+	}
 	follows, err := fs.followCommonRepo.GetFollowAmount(ctx, dto.ObjectID)
 	if err != nil {
 		return resp, err
@@ -71,6 +81,29 @@ func (fs *FollowService) Follow(ctx context.Context, dto *schema.FollowDTO) (res
 	return resp, nil
 }
 
+// followDescendantTags follow or cancel follow all descendant tags if the object is a tag
+func (fs *FollowService) followDescendantTags(ctx context.Context, dto *schema.FollowDTO) (err error) {
+	objectType, err := obj.GetObjectTypeStrByObjectID(dto.ObjectID)
+	if err != nil || objectType != constant.TagObjectType {
+		return nil
+	}
+	tagIDs, err := fs.tagCommonService.GetDescendantTagIDs(ctx, dto.ObjectID)
+	if err != nil {
+		return err
+	}
+	for _, tagID := range tagIDs {
+		if dto.IsCancel {
+			err = fs.followRepo.FollowCancel(ctx, tagID, dto.UserID)
+		} else {
+			err = fs.followRepo.Follow(ctx, tagID, dto.UserID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // UpdateFollowTags update user follow tags
 func (fs *FollowService) UpdateFollowTags(ctx context.Context, req *schema.UpdateFollowTagsReq) (err error) {
 	objIDs, err := fs.followCommonRepo.GetFollowIDs(ctx, req.UserID, entity.Tag{}.TableName())
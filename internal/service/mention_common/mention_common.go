@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mention_common
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/mention"
+	"github.com/apache/incubator-answer/pkg/token"
+	"github.com/segmentfault/pacman/log"
+)
+
+// MentionCommon parse the @username mentions in the content and notify the mentioned users
+type MentionCommon struct {
+	userCommon                       *usercommon.UserCommon
+	userRepo                         usercommon.UserRepo
+	siteInfoService                  siteinfo_common.SiteInfoCommonService
+	notificationQueueService         notice_queue.NotificationQueueService
+	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
+	userRelationService              *user_relation.UserRelationService
+}
+
+// NewMentionCommon new mention common
+func NewMentionCommon(
+	userCommon *usercommon.UserCommon,
+	userRepo usercommon.UserRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	notificationQueueService notice_queue.NotificationQueueService,
+	externalNotificationQueueService notice_queue.ExternalNotificationQueueService,
+	userRelationService *user_relation.UserRelationService,
+) *MentionCommon {
+	return &MentionCommon{
+		userCommon:                       userCommon,
+		userRepo:                         userRepo,
+		siteInfoService:                  siteInfoService,
+		notificationQueueService:         notificationQueueService,
+		externalNotificationQueueService: externalNotificationQueueService,
+		userRelationService:              userRelationService,
+	}
+}
+
+// LinkMentions link the mentions of the existing users in the parsed html to their profile pages
+func (mc *MentionCommon) LinkMentions(ctx context.Context, parsedText string) string {
+	usernames := mention.Parse(parsedText)
+	if len(usernames) == 0 {
+		return parsedText
+	}
+	userMapping, err := mc.getMentionedUsers(ctx, usernames)
+	if err != nil {
+		log.Error(err)
+		return parsedText
+	}
+	if len(userMapping) == 0 {
+		return parsedText
+	}
+	siteInfo, err := mc.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return parsedText
+	}
+	return mention.Link(parsedText, func(username string) (string, bool) {
+		if _, ok := userMapping[username]; !ok {
+			return "", false
+		}
+		return display.UserURL(siteInfo.SiteUrl, username), true
+	})
+}
+
+// NotifyMentions notify the users mentioned in the content, the users already mentioned before edit are skipped.
+func (mc *MentionCommon) NotifyMentions(ctx context.Context, req *schema.MentionNotificationReq) (
+	notifiedUserIDs []string) {
+	oldMentioned := make(map[string]bool)
+	for _, username := range mention.Parse(req.OldParsedText) {
+		oldMentioned[username] = true
+	}
+	usernames := make([]string, 0)
+	for _, username := range append(mention.Parse(req.ParsedText), req.MentionUsernameList...) {
+		if !oldMentioned[username] {
+			oldMentioned[username] = true
+			usernames = append(usernames, username)
+		}
+	}
+	if len(usernames) == 0 {
+		return nil
+	}
+	userMapping, err := mc.getMentionedUsers(ctx, usernames)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	var triggerUserDisplayName string
+	triggerUser, exist, err := mc.userCommon.GetUserBasicInfoByID(ctx, req.TriggerUserID)
+	if err != nil {
+		log.Error(err)
+	} else if exist {
+		triggerUserDisplayName = triggerUser.DisplayName
+	}
+	summary := htmltext.FetchExcerpt(req.ParsedText, "...", 240)
+
+	for _, username := range usernames {
+		userInfo, ok := userMapping[username]
+		if !ok || userInfo.ID == req.TriggerUserID || req.SkipUserIDs[userInfo.ID] {
+			continue
+		}
+		// the users who blocked the trigger user receive neither the inbox nor the email notification
+		blocked, err := mc.userRelationService.IsBlocked(ctx, userInfo.ID, req.TriggerUserID)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		if blocked {
+			continue
+		}
+		msg := &schema.NotificationMsg{
+			ReceiverUserID: userInfo.ID,
+			TriggerUserID:  req.TriggerUserID,
+			Type:           schema.NotificationTypeInbox,
+			ObjectID:       req.ObjectID,
+			ObjectType:     req.ObjectType,
+			Title:          req.Title,
+		}
+		msg.NotificationAction = constant.NotificationMentionYou
+		mc.notificationQueueService.Send(ctx, msg)
+		notifiedUserIDs = append(notifiedUserIDs, userInfo.ID)
+
+		// Send external notification.
+		receiverUserInfo, exist, err := mc.userRepo.GetByUserID(ctx, userInfo.ID)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		if !exist {
+			continue
+		}
+		externalNotificationMsg := &schema.ExternalNotificationMsg{
+			ReceiverUserID: receiverUserInfo.ID,
+			ReceiverEmail:  receiverUserInfo.EMail,
+			ReceiverLang:   receiverUserInfo.Language,
+		}
+		externalNotificationMsg.MentionTemplateRawData = &schema.MentionTemplateRawData{
+			TriggerUserDisplayName: triggerUserDisplayName,
+			Title:                  req.Title,
+			QuestionID:             req.QuestionID,
+			AnswerID:               req.AnswerID,
+			CommentID:              req.CommentID,
+			Summary:                summary,
+			UnsubscribeCode:        token.GenerateToken(),
+		}
+		mc.externalNotificationQueueService.Send(ctx, externalNotificationMsg)
+	}
+	return notifiedUserIDs
+}
+
+// getMentionedUsers get the users that can be mentioned by the usernames, the deleted users are excluded
+func (mc *MentionCommon) getMentionedUsers(ctx context.Context, usernames []string) (
+	userMapping map[string]*schema.UserBasicInfo, err error) {
+	userMapping, err = mc.userCommon.BatchGetUserBasicInfoByUserNames(ctx, usernames)
+	if err != nil {
+		return nil, err
+	}
+	for username, userInfo := range userMapping {
+		if userInfo.Status == constant.UserDeleted {
+			delete(userMapping, username)
+		}
+	}
+	return userMapping, nil
+}
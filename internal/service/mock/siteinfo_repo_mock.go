@@ -240,6 +240,66 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLogin(ctx interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLogin", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLogin), ctx)
 }
 
+// GetSiteActionPolicy mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteActionPolicy(ctx context.Context) (*schema.SiteActionPolicyResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteActionPolicy", ctx)
+	ret0, _ := ret[0].(*schema.SiteActionPolicyResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteActionPolicy indicates an expected call of GetSiteActionPolicy.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteActionPolicy(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteActionPolicy", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteActionPolicy), ctx)
+}
+
+// GetSiteAuditLog mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteAuditLog(ctx context.Context) (*schema.SiteAuditLogResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteAuditLog", ctx)
+	ret0, _ := ret[0].(*schema.SiteAuditLogResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteAuditLog indicates an expected call of GetSiteAuditLog.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteAuditLog(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteAuditLog", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteAuditLog), ctx)
+}
+
+// GetSiteHTMLSanitizer mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteHTMLSanitizer(ctx context.Context) (*schema.SiteHTMLSanitizerResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteHTMLSanitizer", ctx)
+	ret0, _ := ret[0].(*schema.SiteHTMLSanitizerResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteHTMLSanitizer indicates an expected call of GetSiteHTMLSanitizer.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteHTMLSanitizer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteHTMLSanitizer", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteHTMLSanitizer), ctx)
+}
+
+// GetSiteRateLimit mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteRateLimit(ctx context.Context) (*schema.SiteRateLimitResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteRateLimit", ctx)
+	ret0, _ := ret[0].(*schema.SiteRateLimitResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteRateLimit indicates an expected call of GetSiteRateLimit.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteRateLimit(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteRateLimit", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteRateLimit), ctx)
+}
+
 // GetSiteSeo mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteSeo(ctx context.Context) (*schema.SiteSeoResp, error) {
 	m.ctrl.T.Helper()
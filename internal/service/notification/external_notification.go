@@ -91,6 +91,12 @@ func (ns *ExternalNotificationService) Handler(ctx context.Context, msg *schema.
 	if msg.NewInviteAnswerTemplateRawData != nil {
 		return ns.handleInviteAnswerNotification(ctx, msg)
 	}
+	if msg.SavedSearchAlertRawData != nil {
+		return ns.handleSavedSearchAlertNotification(ctx, msg)
+	}
+	if msg.MentionTemplateRawData != nil {
+		return ns.handleMentionNotification(ctx, msg)
+	}
 	log.Errorf("unknown notification message: %+v", msg)
 	return nil
 }
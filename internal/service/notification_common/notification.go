@@ -38,6 +38,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/goccy/go-json"
@@ -67,6 +68,7 @@ type NotificationCommon struct {
 	notificationQueueService notice_queue.NotificationQueueService
 	userExternalLoginRepo    user_external_login.UserExternalLoginRepo
 	siteInfoService          siteinfo_common.SiteInfoCommonService
+	userRelationService      *user_relation.UserRelationService
 }
 
 func NewNotificationCommon(
@@ -79,6 +81,7 @@ func NewNotificationCommon(
 	notificationQueueService notice_queue.NotificationQueueService,
 	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRelationService *user_relation.UserRelationService,
 ) *NotificationCommon {
 	notification := &NotificationCommon{
 		data:                     data,
@@ -90,6 +93,7 @@ func NewNotificationCommon(
 		notificationQueueService: notificationQueueService,
 		userExternalLoginRepo:    userExternalLoginRepo,
 		siteInfoService:          siteInfoService,
+		userRelationService:      userRelationService,
 	}
 	notificationQueueService.RegisterHandler(notification.AddNotification)
 	return notification
@@ -139,6 +143,12 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 			objectMap["comment"] = objInfo.CommentID
 			req.ObjectInfo.ObjectMap = objectMap
 		}
+		if rejectReason := msg.ExtraInfo[schema.NotificationExtraRejectReason]; len(rejectReason) > 0 {
+			if req.ObjectInfo.ObjectMap == nil {
+				req.ObjectInfo.ObjectMap = make(map[string]string)
+			}
+			req.ObjectInfo.ObjectMap[schema.NotificationExtraRejectReason] = rejectReason
+		}
 	}
 
 	if msg.Type == schema.NotificationTypeAchievement {
@@ -169,6 +179,18 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 		}
 	}
 
+	// the notifications triggered by the blocked user are suppressed, the followers are still notified
+	if msg.Type == schema.NotificationTypeInbox {
+		blocked, err := ns.userRelationService.IsBlocked(ctx, req.ReceiverUserID, req.TriggerUserID)
+		if err != nil {
+			log.Error(err)
+		} else if blocked {
+			log.Debugf("notification from %s to %s is suppressed by block", req.TriggerUserID, req.ReceiverUserID)
+			go ns.SendNotificationToAllFollower(ctx, msg, questionID)
+			return nil
+		}
+	}
+
 	info := &entity.Notification{}
 	now := time.Now()
 	info.UserID = req.ReceiverUserID
@@ -207,7 +229,7 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 
 	go ns.SendNotificationToAllFollower(ctx, msg, questionID)
 
-	if msg.Type == schema.NotificationTypeInbox {
+	if msg.Type == schema.NotificationTypeInbox && objInfo != nil {
 		ns.syncNotificationToPlugin(ctx, objInfo, msg)
 	}
 	return nil
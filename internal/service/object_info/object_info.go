@@ -29,7 +29,9 @@ import (
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	quotecommon "github.com/apache/incubator-answer/internal/service_quote/quote_common"
 	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/segmentfault/pacman/errors"
 )
@@ -42,7 +44,10 @@ type ObjService struct {
 	tagRepo      tagcommon.TagCommonRepo
 	tagCommon    *tagcommon.TagCommonService
 
-	articleRepo articlecommon.ArticleRepo
+	articleRepo     articlecommon.ArticleRepo
+	quoteRepo       quotecommon.QuoteRepo
+	quoteAuthorRepo quotecommon.QuoteAuthorRepo
+	quotePieceRepo  quotecommon.QuotePieceRepo
 }
 
 // NewObjService new object service
@@ -53,16 +58,23 @@ func NewObjService(
 	tagRepo tagcommon.TagCommonRepo,
 	tagCommon *tagcommon.TagCommonService,
 	articleRepo articlecommon.ArticleRepo,
+	quoteRepo quotecommon.QuoteRepo,
+	quoteAuthorRepo quotecommon.QuoteAuthorRepo,
+	quotePieceRepo quotecommon.QuotePieceRepo,
 ) *ObjService {
 	return &ObjService{
-		answerRepo:   answerRepo,
-		questionRepo: questionRepo,
-		commentRepo:  commentRepo,
-		tagRepo:      tagRepo,
-		tagCommon:    tagCommon,
-		articleRepo:  articleRepo,
+		answerRepo:      answerRepo,
+		questionRepo:    questionRepo,
+		commentRepo:     commentRepo,
+		tagRepo:         tagRepo,
+		tagCommon:       tagCommon,
+		articleRepo:     articleRepo,
+		quoteRepo:       quoteRepo,
+		quoteAuthorRepo: quoteAuthorRepo,
+		quotePieceRepo:  quotePieceRepo,
 	}
 }
+
 func (os *ObjService) GetUnreviewedRevisionInfo(ctx context.Context, objectID string) (objInfo *schema.UnreviewedRevisionInfoInfo, err error) {
 	objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
 	if err != nil {
@@ -101,6 +113,96 @@ func (os *ObjService) GetUnreviewedRevisionInfo(ctx context.Context, objectID st
 			Status:              questionInfo.Status,
 			ShowStatus:          questionInfo.Show,
 		}
+	case constant.ArticleObjectType:
+		articleInfo, exist, err := os.articleRepo.GetArticle(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			break
+		}
+		tags, err := os.getObjectTags(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		objInfo = &schema.UnreviewedRevisionInfoInfo{
+			CreatedAt:           articleInfo.CreatedAt.Unix(),
+			ObjectID:            articleInfo.ID,
+			QuestionID:          articleInfo.ID,
+			ObjectType:          objectType,
+			ObjectCreatorUserID: articleInfo.UserID,
+			Title:               articleInfo.Title,
+			Content:             articleInfo.OriginalText,
+			Html:                articleInfo.ParsedText,
+			Tags:                tags,
+			Status:              articleInfo.Status,
+			ShowStatus:          articleInfo.Show,
+		}
+	case constant.QuoteObjectType:
+		quoteInfo, exist, err := os.quoteRepo.GetQuote(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			break
+		}
+		tags, err := os.getObjectTags(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		objInfo = &schema.UnreviewedRevisionInfoInfo{
+			CreatedAt:           quoteInfo.CreatedAt.Unix(),
+			ObjectID:            quoteInfo.ID,
+			QuestionID:          quoteInfo.ID,
+			ObjectType:          objectType,
+			ObjectCreatorUserID: quoteInfo.UserID,
+			Title:               quoteInfo.Title,
+			Content:             quoteInfo.OriginalText,
+			Html:                quoteInfo.ParsedText,
+			Tags:                tags,
+			Status:              quoteInfo.Status,
+			ShowStatus:          quoteInfo.Show,
+		}
+	case constant.QuoteAuthorObjectType:
+		authorInfo, exist, err := os.quoteAuthorRepo.GetQuoteAuthor(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			break
+		}
+		objInfo = &schema.UnreviewedRevisionInfoInfo{
+			CreatedAt:           authorInfo.CreatedAt.Unix(),
+			ObjectID:            authorInfo.ID,
+			QuestionID:          authorInfo.ID,
+			ObjectType:          objectType,
+			ObjectCreatorUserID: authorInfo.UserID,
+			Title:               authorInfo.AuthorName,
+			Content:             authorInfo.Bio,
+			Html:                converter.SanitizeHTML(authorInfo.Bio),
+			Status:              authorInfo.Status,
+			ShowStatus:          authorInfo.Show,
+		}
+	case constant.QuotePieceObjectType:
+		pieceInfo, exist, err := os.quotePieceRepo.GetQuotePiece(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			break
+		}
+		objInfo = &schema.UnreviewedRevisionInfoInfo{
+			CreatedAt:           pieceInfo.CreatedAt.Unix(),
+			ObjectID:            pieceInfo.ID,
+			QuestionID:          pieceInfo.ID,
+			ObjectType:          objectType,
+			ObjectCreatorUserID: pieceInfo.UserID,
+			Title:               pieceInfo.Title,
+			Content:             pieceInfo.OriginalText,
+			Html:                pieceInfo.ParsedText,
+			Status:              pieceInfo.Status,
+			ShowStatus:          pieceInfo.Show,
+		}
 	case constant.AnswerObjectType:
 		answerInfo, exist, err := os.answerRepo.GetAnswer(ctx, objectID)
 		if err != nil {
@@ -229,6 +331,57 @@ func (os *ObjService) GetInfo(ctx context.Context, objectID string) (objInfo *sc
 			Title:               articleInfo.Title,
 			Content:             articleInfo.ParsedText, // todo trim
 		}
+	case constant.QuoteObjectType:
+		quoteInfo, exist, err := os.quoteRepo.GetQuote(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			break
+		}
+		objInfo = &schema.SimpleObjectInfo{
+			ObjectID:            quoteInfo.ID,
+			ObjectCreatorUserID: quoteInfo.UserID,
+			QuestionID:          quoteInfo.ID,
+			QuestionStatus:      quoteInfo.Status,
+			ObjectType:          objectType,
+			Title:               quoteInfo.Title,
+			Content:             quoteInfo.ParsedText,
+		}
+	case constant.QuoteAuthorObjectType:
+		authorInfo, exist, err := os.quoteAuthorRepo.GetQuoteAuthor(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			break
+		}
+		objInfo = &schema.SimpleObjectInfo{
+			ObjectID:            authorInfo.ID,
+			ObjectCreatorUserID: authorInfo.UserID,
+			QuestionID:          authorInfo.ID,
+			QuestionStatus:      authorInfo.Status,
+			ObjectType:          objectType,
+			Title:               authorInfo.AuthorName,
+			Content:             authorInfo.Bio,
+		}
+	case constant.QuotePieceObjectType:
+		pieceInfo, exist, err := os.quotePieceRepo.GetQuotePiece(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			break
+		}
+		objInfo = &schema.SimpleObjectInfo{
+			ObjectID:            pieceInfo.ID,
+			ObjectCreatorUserID: pieceInfo.UserID,
+			QuestionID:          pieceInfo.ID,
+			QuestionStatus:      pieceInfo.Status,
+			ObjectType:          objectType,
+			Title:               pieceInfo.Title,
+			Content:             pieceInfo.ParsedText,
+		}
 	case constant.AnswerObjectType:
 		answerInfo, exist, err := os.answerRepo.GetAnswer(ctx, objectID)
 		if err != nil {
@@ -310,3 +463,13 @@ func (os *ObjService) GetInfo(ctx context.Context, objectID string) (objInfo *sc
 	}
 	return objInfo, err
 }
+
+// getObjectTags get the formatted tags of the object
+func (os *ObjService) getObjectTags(ctx context.Context, objectID string) (tags []*schema.TagResp, err error) {
+	taglist, err := os.tagCommon.GetObjectEntityTag(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	os.tagCommon.TagsFormatRecommendAndReserved(ctx, taglist)
+	return os.tagCommon.TagFormat(ctx, taglist)
+}
@@ -143,3 +143,111 @@ const (
 
 	QuotePieceUnDelete = "quote_piece.undeleted"
 )
+
+// PowerList the powers that can be assigned to a role.
+// AdminAccess is not in the list, the admin api is only open to the admin role.
+var PowerList = []string{
+	QuestionAdd,
+	QuestionEdit,
+	QuestionEditWithoutReview,
+	QuestionDelete,
+	QuestionClose,
+	QuestionReopen,
+	QuestionVoteUp,
+	QuestionVoteDown,
+	QuestionPin,
+	QuestionUnPin,
+	QuestionHide,
+	QuestionShow,
+	AnswerAdd,
+	AnswerEdit,
+	AnswerEditWithoutReview,
+	AnswerDelete,
+	AnswerAccept,
+	AnswerVoteUp,
+	AnswerVoteDown,
+	AnswerInviteSomeoneToAnswer,
+	CommentAdd,
+	CommentEdit,
+	CommentDelete,
+	CommentVoteUp,
+	CommentVoteDown,
+	ReportAdd,
+	TagAdd,
+	TagEdit,
+	TagEditSlugName,
+	TagEditWithoutReview,
+	TagDelete,
+	TagSynonym,
+	LinkUrlLimit,
+	VoteDetail,
+	AnswerAudit,
+	QuestionAudit,
+	TagAudit,
+	TagUseReservedTag,
+	AnswerUnDelete,
+	QuestionUnDelete,
+	TagUnDelete,
+	ArticleAdd,
+	ArticleEdit,
+	ArticleEditWithoutReview,
+	ArticleDelete,
+	ArticleClose,
+	ArticleReopen,
+	ArticleVoteUp,
+	ArticleVoteDown,
+	ArticlePin,
+	ArticleUnPin,
+	ArticleHide,
+	ArticleShow,
+	ArticleUnDelete,
+	QuoteAdd,
+	QuoteEdit,
+	QuoteEditWithoutReview,
+	QuoteDelete,
+	QuoteClose,
+	QuoteReopen,
+	QuoteVoteUp,
+	QuoteVoteDown,
+	QuotePin,
+	QuoteUnPin,
+	QuoteHide,
+	QuoteShow,
+	QuoteUnDelete,
+	QuoteAuthorAdd,
+	QuoteAuthorEdit,
+	QuoteAuthorEditWithoutReview,
+	QuoteAuthorDelete,
+	QuoteAuthorClose,
+	QuoteAuthorReopen,
+	QuoteAuthorVoteUp,
+	QuoteAuthorVoteDown,
+	QuoteAuthorPin,
+	QuoteAuthorUnPin,
+	QuoteAuthorHide,
+	QuoteAuthorShow,
+	QuoteAuthorUnDelete,
+	QuotePieceAdd,
+	QuotePieceEdit,
+	QuotePieceEditWithoutReview,
+	QuotePieceDelete,
+	QuotePieceClose,
+	QuotePieceReopen,
+	QuotePieceVoteUp,
+	QuotePieceVoteDown,
+	QuotePiecePin,
+	QuotePieceUnPin,
+	QuotePieceHide,
+	QuotePieceShow,
+	QuotePieceUnDelete,
+}
+
+// IsValidPower check whether the power can be assigned to a role
+func IsValidPower(power string) bool {
+	for _, p := range PowerList {
+		if p == power {
+			return true
+		}
+	}
+	return false
+}
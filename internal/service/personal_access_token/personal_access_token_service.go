@@ -0,0 +1,299 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package personal_access_token
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/apache/incubator-answer/internal/service/role"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/encryption"
+	"github.com/apache/incubator-answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// TokenPrefix all personal access tokens start with this prefix, so they can be told apart from session tokens
+	TokenPrefix = "answer_pat_"
+	// tokenRandomBytes the number of random bytes of the token
+	tokenRandomBytes = 20
+	// tokenDisplayPrefixLength the length of the token prefix shown to the user
+	tokenDisplayPrefixLength = len(TokenPrefix) + 6
+	// tokenMaxPerUser the maximum number of available personal access tokens of each user
+	tokenMaxPerUser = 20
+	// tokenLastUsedInterval the last used time is only updated once in this interval
+	tokenLastUsedInterval = time.Minute
+)
+
+// PersonalAccessTokenRepo personal access token repository
+type PersonalAccessTokenRepo interface {
+	AddPersonalAccessToken(ctx context.Context, token *entity.PersonalAccessToken) (err error)
+	GetPersonalAccessToken(ctx context.Context, id string) (token *entity.PersonalAccessToken, exist bool, err error)
+	GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (token *entity.PersonalAccessToken, exist bool, err error)
+	GetPersonalAccessTokenListByUserID(ctx context.Context, userID string) (tokenList []*entity.PersonalAccessToken, err error)
+	CountPersonalAccessTokenByUserID(ctx context.Context, userID string) (count int64, err error)
+	GetPersonalAccessTokenPage(ctx context.Context, page, pageSize int, cond *entity.PersonalAccessToken) (
+		tokenList []*entity.PersonalAccessToken, total int64, err error)
+	UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) (err error)
+	RevokePersonalAccessToken(ctx context.Context, id string) (err error)
+}
+
+// PersonalAccessTokenService personal access token service
+type PersonalAccessTokenService struct {
+	personalAccessTokenRepo PersonalAccessTokenRepo
+	userRepo                usercommon.UserRepo
+	userCommon              *usercommon.UserCommon
+	userRoleRelService      *role.UserRoleRelService
+	auditLogService         *audit_log.AuditLogService
+}
+
+// NewPersonalAccessTokenService new personal access token service
+func NewPersonalAccessTokenService(
+	personalAccessTokenRepo PersonalAccessTokenRepo,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+	userRoleRelService *role.UserRoleRelService,
+	auditLogService *audit_log.AuditLogService,
+) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		personalAccessTokenRepo: personalAccessTokenRepo,
+		userRepo:                userRepo,
+		userCommon:              userCommon,
+		userRoleRelService:      userRoleRelService,
+		auditLogService:         auditLogService,
+	}
+}
+
+// IsPersonalAccessToken whether the token is a personal access token
+func IsPersonalAccessToken(accessToken string) bool {
+	return strings.HasPrefix(accessToken, TokenPrefix)
+}
+
+// GetPersonalAccessTokenList get the available personal access tokens of the user
+func (ps *PersonalAccessTokenService) GetPersonalAccessTokenList(ctx context.Context, userID string) (
+	resp []*schema.PersonalAccessTokenResp, err error) {
+	tokenList, err := ps.personalAccessTokenRepo.GetPersonalAccessTokenListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.PersonalAccessTokenResp, 0, len(tokenList))
+	for _, item := range tokenList {
+		resp = append(resp, schema.NewPersonalAccessTokenResp(item))
+	}
+	return resp, nil
+}
+
+// AddPersonalAccessToken add personal access token, the plain token is only returned here
+func (ps *PersonalAccessTokenService) AddPersonalAccessToken(ctx context.Context, req *schema.AddPersonalAccessTokenReq) (
+	resp *schema.AddPersonalAccessTokenResp, err error) {
+	scopes := make([]string, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if scope == entity.PersonalAccessTokenScopeAdmin && !req.IsAdmin {
+			return nil, errors.Forbidden(reason.PersonalAccessTokenAdminDenied)
+		}
+		if !containsScope(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	var expiredAt time.Time
+	if req.ExpiredAt > 0 {
+		expiredAt = time.Unix(req.ExpiredAt, 0)
+		if !expiredAt.After(time.Now()) {
+			return nil, errors.BadRequest(reason.PersonalAccessTokenExpiredAt)
+		}
+	}
+	count, err := ps.personalAccessTokenRepo.CountPersonalAccessTokenByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= tokenMaxPerUser {
+		return nil, errors.BadRequest(reason.PersonalAccessTokenExceedLimit)
+	}
+
+	randomToken, err := token.GenerateSecureToken(tokenRandomBytes)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	plainToken := TokenPrefix + randomToken
+	accessToken := &entity.PersonalAccessToken{
+		UserID:      req.UserID,
+		Name:        req.Name,
+		TokenHash:   encryption.SHA256(plainToken),
+		TokenPrefix: plainToken[:tokenDisplayPrefixLength],
+		Scopes:      strings.Join(scopes, ","),
+		Status:      entity.PersonalAccessTokenStatusAvailable,
+		ExpiredAt:   expiredAt,
+	}
+	if err = ps.personalAccessTokenRepo.AddPersonalAccessToken(ctx, accessToken); err != nil {
+		return nil, err
+	}
+	return &schema.AddPersonalAccessTokenResp{
+		PersonalAccessTokenResp: schema.NewPersonalAccessTokenResp(accessToken),
+		Token:                   plainToken,
+	}, nil
+}
+
+// RevokePersonalAccessToken revoke the personal access token of the user
+func (ps *PersonalAccessTokenService) RevokePersonalAccessToken(ctx context.Context,
+	req *schema.RevokePersonalAccessTokenReq) (err error) {
+	accessToken, exist, err := ps.personalAccessTokenRepo.GetPersonalAccessToken(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist || accessToken.UserID != req.UserID || accessToken.Status != entity.PersonalAccessTokenStatusAvailable {
+		return errors.BadRequest(reason.PersonalAccessTokenNotFound)
+	}
+	return ps.personalAccessTokenRepo.RevokePersonalAccessToken(ctx, req.ID)
+}
+
+// AdminGetPersonalAccessTokenPage get the personal access tokens of all users
+func (ps *PersonalAccessTokenService) AdminGetPersonalAccessTokenPage(ctx context.Context,
+	req *schema.GetPersonalAccessTokenPageReq) (pageModel *pager.PageModel, err error) {
+	cond := &entity.PersonalAccessToken{UserID: req.UserID}
+	for status, display := range schema.PersonalAccessTokenStatusDisplayMapping {
+		if display == req.Status {
+			cond.Status = status
+		}
+	}
+	tokenList, total, err := ps.personalAccessTokenRepo.GetPersonalAccessTokenPage(ctx, req.Page, req.PageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(tokenList))
+	for _, item := range tokenList {
+		userIDs = append(userIDs, item.UserID)
+	}
+	userInfoMapping, err := ps.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*schema.GetPersonalAccessTokenPageResp, 0, len(tokenList))
+	for _, item := range tokenList {
+		resp = append(resp, &schema.GetPersonalAccessTokenPageResp{
+			PersonalAccessTokenResp: schema.NewPersonalAccessTokenResp(item),
+			UserInfo:                userInfoMapping[item.UserID],
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// AdminRevokePersonalAccessToken revoke the personal access token of any user
+func (ps *PersonalAccessTokenService) AdminRevokePersonalAccessToken(ctx context.Context,
+	req *schema.AdminRevokePersonalAccessTokenReq) (err error) {
+	accessToken, exist, err := ps.personalAccessTokenRepo.GetPersonalAccessToken(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.PersonalAccessTokenNotFound)
+	}
+	if accessToken.Status == entity.PersonalAccessTokenStatusRevoked {
+		return nil
+	}
+	log.Infof("admin %s revoke personal access token %s of user %s", req.UserID, accessToken.ID, accessToken.UserID)
+	if err = ps.personalAccessTokenRepo.RevokePersonalAccessToken(ctx, req.ID); err != nil {
+		return err
+	}
+	ps.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionTokenRevoke,
+		ObjectType: entity.AuditLogObjectTypeToken,
+		ObjectID:   accessToken.ID,
+		Before:     map[string]string{"user_id": accessToken.UserID, "name": accessToken.Name},
+	})
+	return nil
+}
+
+// GetUserCacheInfoByToken get the user info and scopes of the personal access token,
+// returns nil if the token is not available or expired
+func (ps *PersonalAccessTokenService) GetUserCacheInfoByToken(ctx context.Context, plainToken string) (
+	userInfo *entity.UserCacheInfo, scopes []string, err error) {
+	accessToken, exist, err := ps.personalAccessTokenRepo.GetPersonalAccessTokenByHash(ctx, encryption.SHA256(plainToken))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exist || accessToken.Status != entity.PersonalAccessTokenStatusAvailable {
+		return nil, nil, nil
+	}
+	now := time.Now()
+	if !accessToken.ExpiredAt.IsZero() && accessToken.ExpiredAt.Before(now) {
+		return nil, nil, nil
+	}
+
+	user, exist, err := ps.userRepo.GetByUserID(ctx, accessToken.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exist {
+		return nil, nil, nil
+	}
+	roleID, err := ps.userRoleRelService.GetUserRole(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if now.Sub(accessToken.LastUsedAt) >= tokenLastUsedInterval {
+		if err := ps.personalAccessTokenRepo.UpdateLastUsedAt(ctx, accessToken.ID, now); err != nil {
+			log.Error(err)
+		}
+	}
+	userInfo = &entity.UserCacheInfo{
+		UserID:      user.ID,
+		UserStatus:  user.Status,
+		EmailStatus: user.MailStatus,
+		RoleID:      roleID,
+	}
+	return userInfo, strings.Split(accessToken.Scopes, ","), nil
+}
+
+// CheckScope check whether the scopes allow the request, the admin api needs the admin scope,
+// the read-only requests need the read or write scope and the others need the write scope.
+func CheckScope(scopes []string, method string, isAdminAPI bool) bool {
+	if isAdminAPI {
+		return containsScope(scopes, entity.PersonalAccessTokenScopeAdmin)
+	}
+	if containsScope(scopes, entity.PersonalAccessTokenScopeWrite) {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return containsScope(scopes, entity.PersonalAccessTokenScopeRead)
+	}
+	return false
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, item := range scopes {
+		if item == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/plugin"
 )
@@ -56,6 +57,7 @@ type PluginCommonService struct {
 	pluginConfigRepo     PluginConfigRepo
 	pluginUserConfigRepo PluginUserConfigRepo
 	data                 *data.Data
+	auditLogService      *audit_log.AuditLogService
 }
 
 // NewPluginCommonService new report service
@@ -64,6 +66,7 @@ func NewPluginCommonService(
 	pluginUserConfigRepo PluginUserConfigRepo,
 	configService *config.ConfigService,
 	data *data.Data,
+	auditLogService *audit_log.AuditLogService,
 ) *PluginCommonService {
 
 	p := &PluginCommonService{
@@ -71,27 +74,51 @@ func NewPluginCommonService(
 		pluginConfigRepo:     pluginConfigRepo,
 		pluginUserConfigRepo: pluginUserConfigRepo,
 		data:                 data,
+		auditLogService:      auditLogService,
 	}
 	p.initPluginData()
 	return p
 }
 
 // UpdatePluginStatus update plugin status
-func (ps *PluginCommonService) UpdatePluginStatus(ctx context.Context) (err error) {
+func (ps *PluginCommonService) UpdatePluginStatus(ctx context.Context, req *schema.UpdatePluginStatusReq) (err error) {
+	enabled := plugin.StatusManager.IsEnabled(req.PluginSlugName)
+	plugin.StatusManager.Enable(req.PluginSlugName, req.Enabled)
 	content, err := plugin.StatusManager.MarshalJSON()
 	if err != nil {
 		return errors.InternalServer(reason.UnknownError).WithError(err)
 	}
-	return ps.configService.UpdateConfig(ctx, constant.PluginStatus, string(content))
+	if err = ps.configService.UpdateConfig(ctx, constant.PluginStatus, string(content)); err != nil {
+		return err
+	}
+	ps.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionPluginUpdateStatus,
+		ObjectType: entity.AuditLogObjectTypePlugin,
+		ObjectID:   req.PluginSlugName,
+		Before:     map[string]bool{"enabled": enabled},
+		After:      map[string]bool{"enabled": req.Enabled},
+	})
+	return nil
 }
 
 // UpdatePluginConfig update plugin config
 func (ps *PluginCommonService) UpdatePluginConfig(ctx context.Context, req *schema.UpdatePluginConfigReq) (err error) {
+	before, err := ps.getPluginConfigFields(ctx, req.PluginSlugName)
+	if err != nil {
+		return err
+	}
 	configValue, _ := json.Marshal(req.ConfigFields)
 	err = ps.pluginConfigRepo.SavePluginConfig(ctx, req.PluginSlugName, string(configValue))
 	if err != nil {
 		return err
 	}
+	ps.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionPluginUpdateConfig,
+		ObjectType: entity.AuditLogObjectTypePlugin,
+		ObjectID:   req.PluginSlugName,
+		Before:     maskPluginPasswordFields(req.PluginSlugName, before),
+		After:      maskPluginPasswordFields(req.PluginSlugName, req.ConfigFields),
+	})
 
 	_ = plugin.CallSearch(func(search plugin.Search) error {
 		if search.Info().SlugName == req.PluginSlugName {
@@ -102,6 +129,49 @@ func (ps *PluginCommonService) UpdatePluginConfig(ctx context.Context, req *sche
 	return nil
 }
 
+// getPluginConfigFields get the saved config fields of the plugin, nil if it has never been saved
+func (ps *PluginCommonService) getPluginConfigFields(ctx context.Context, pluginSlugName string) (
+	configFields map[string]any, err error) {
+	pluginConfigs, err := ps.pluginConfigRepo.GetPluginConfigAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, pluginConfig := range pluginConfigs {
+		if pluginConfig.PluginSlugName == pluginSlugName {
+			_ = json.Unmarshal([]byte(pluginConfig.Value), &configFields)
+		}
+	}
+	return configFields, nil
+}
+
+// maskPluginPasswordFields copy the config fields with the values of password inputs masked,
+// so that the secrets are never recorded in the audit log
+func maskPluginPasswordFields(pluginSlugName string, configFields map[string]any) map[string]any {
+	if configFields == nil {
+		return nil
+	}
+	passwordFields := make(map[string]bool)
+	_ = plugin.CallConfig(func(fn plugin.Config) error {
+		if fn.Info().SlugName != pluginSlugName {
+			return nil
+		}
+		for _, field := range fn.ConfigFields() {
+			if field.UIOptions.InputType == plugin.InputTypePassword {
+				passwordFields[field.Name] = true
+			}
+		}
+		return nil
+	})
+	masked := make(map[string]any, len(configFields))
+	for name, value := range configFields {
+		if passwordFields[name] {
+			value = "******"
+		}
+		masked[name] = value
+	}
+	return masked
+}
+
 // UpdatePluginUserConfig update plugin config
 func (ps *PluginCommonService) UpdatePluginUserConfig(ctx context.Context, req *schema.UpdateUserPluginConfigReq) (err error) {
 	configValue, _ := json.Marshal(req.ConfigFields)
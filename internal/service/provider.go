@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
 	articlecommon "github.com/apache/incubator-answer/internal/service/article_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/auth"
 	"github.com/apache/incubator-answer/internal/service/badge"
 	"github.com/apache/incubator-answer/internal/service/collection"
@@ -34,16 +35,19 @@ import (
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/dashboard"
 	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/follow"
+	"github.com/apache/incubator-answer/internal/service/mention_common"
 	"github.com/apache/incubator-answer/internal/service/meta"
 	metacommon "github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
 	notficationcommon "github.com/apache/incubator-answer/internal/service/notification_common"
 	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/personal_access_token"
 	"github.com/apache/incubator-answer/internal/service/plugin_common"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/rank"
@@ -53,6 +57,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/saved_search"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
@@ -61,8 +66,12 @@ import (
 	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_data_export"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/internal/service/user_login_security"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
+	"github.com/apache/incubator-answer/internal/service/user_two_factor"
 	"github.com/google/wire"
 )
 
@@ -93,6 +102,16 @@ var ProviderSetService = wire.NewSet(
 	rank.NewRankService,
 	search_parser.NewSearchParser,
 	content.NewSearchService,
+	saved_search.NewSavedSearchService,
+	personal_access_token.NewPersonalAccessTokenService,
+	user_two_factor.NewUserTwoFactorService,
+	user_data_export.NewUserDataExportService,
+	user_relation.NewUserRelationService,
+	user_login_security.NewUserLoginSecurityService,
+	content_filter.NewContentFilterService,
+	audit_log.NewAuditLogService,
+	wire.Bind(new(audit_log.AuditLogUserRepo), new(usercommon.UserRepo)),
+	mention_common.NewMentionCommon,
 	metacommon.NewMetaCommonService,
 	object_info.NewObjService,
 	report_handle.NewReportHandle,
@@ -45,6 +45,7 @@ import (
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -92,6 +93,7 @@ type QuestionCommon struct {
 	activityQueueService activity_queue.ActivityQueueService
 	revisionRepo         revision.RevisionRepo
 	data                 *data.Data
+	userRelationService  *user_relation.UserRelationService
 }
 
 func NewQuestionCommon(questionRepo QuestionRepo,
@@ -107,6 +109,7 @@ func NewQuestionCommon(questionRepo QuestionRepo,
 	activityQueueService activity_queue.ActivityQueueService,
 	revisionRepo revision.RevisionRepo,
 	data *data.Data,
+	userRelationService *user_relation.UserRelationService,
 ) *QuestionCommon {
 	return &QuestionCommon{
 		questionRepo:         questionRepo,
@@ -122,6 +125,7 @@ func NewQuestionCommon(questionRepo QuestionRepo,
 		activityQueueService: activityQueueService,
 		revisionRepo:         revisionRepo,
 		data:                 data,
+		userRelationService:  userRelationService,
 	}
 }
 
@@ -348,6 +352,11 @@ func (qs *QuestionCommon) FormatQuestionsPage(
 	ctx context.Context, questionList []*entity.Question, loginUserID string, orderCond string) (
 	formattedQuestions []*schema.QuestionPageResp, err error) {
 	formattedQuestions = make([]*schema.QuestionPageResp, 0)
+	// the content of muted users is collapsed for the login user
+	mutedUserIDs, err := qs.userRelationService.GetMutedUserIDs(ctx, loginUserID)
+	if err != nil {
+		return formattedQuestions, err
+	}
 	questionIDs := make([]string, 0)
 	userIDs := make([]string, 0)
 	for _, questionInfo := range questionList {
@@ -370,6 +379,7 @@ func (qs *QuestionCommon) FormatQuestionsPage(
 			Show:             questionInfo.Show,
 		}
 
+		t.Muted = mutedUserIDs[questionInfo.UserID]
 		questionIDs = append(questionIDs, questionInfo.ID)
 		userIDs = append(userIDs, questionInfo.UserID)
 		haveEdited, haveAnswered := false, false
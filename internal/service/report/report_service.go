@@ -30,6 +30,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/object_info"
@@ -57,6 +58,7 @@ type ReportService struct {
 	reportHandle      *report_handle.ReportHandle
 	configService     *config.ConfigService
 	eventQueueService event_queue.EventQueueService
+	auditLogService   *audit_log.AuditLogService
 }
 
 // NewReportService new report service
@@ -70,6 +72,7 @@ func NewReportService(
 	reportHandle *report_handle.ReportHandle,
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
+	auditLogService *audit_log.AuditLogService,
 ) *ReportService {
 	return &ReportService{
 		reportRepo:        reportRepo,
@@ -81,6 +84,7 @@ func NewReportService(
 		reportHandle:      reportHandle,
 		configService:     configService,
 		eventQueueService: eventQueueService,
+		auditLogService:   auditLogService,
 	}
 }
 
@@ -216,16 +220,36 @@ func (rs *ReportService) ReviewReport(ctx context.Context, req *schema.ReviewRep
 		return nil
 	}
 
+	status := entity.ReportStatusCompleted
 	// ignore this report
 	if req.OperationType == constant.ReportOperationIgnoreReport {
-		return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusIgnore)
-	}
-
-	if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
+		status = entity.ReportStatusIgnore
+	} else if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
 		return
 	}
 
-	return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusCompleted)
+	if err = rs.reportRepo.UpdateStatus(ctx, report.ID, status); err != nil {
+		return err
+	}
+	rs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionReportHandle,
+		ObjectType: constant.ReportObjectType,
+		ObjectID:   report.ID,
+		Before: map[string]interface{}{
+			"status":           report.Status,
+			"object_type":      constant.ObjectTypeNumberMapping[report.ObjectType],
+			"object_id":        report.ObjectID,
+			"reported_user_id": report.ReportedUserID,
+		},
+		After: map[string]interface{}{
+			"status":         status,
+			"operation_type": req.OperationType,
+			"close_type":     req.CloseType,
+			"close_msg":      req.CloseMsg,
+		},
+	})
+	return nil
 }
 
 func (rs *ReportService) sendEvent(ctx context.Context,
@@ -241,6 +265,18 @@ func (rs *ReportService) sendEvent(ctx context.Context,
 	case constant.CommentObjectType:
 		event = schema.NewEvent(constant.EventCommentFlag, report.UserID).TID(objectInfo.CommentID).
 			CID(objectInfo.CommentID, objectInfo.ObjectCreatorUserID)
+	case constant.ArticleObjectType:
+		event = schema.NewEvent(constant.EventArticleFlag, report.UserID).TID(objectInfo.ObjectID).
+			QID(objectInfo.ObjectID, objectInfo.ObjectCreatorUserID)
+	case constant.QuoteObjectType:
+		event = schema.NewEvent(constant.EventQuoteFlag, report.UserID).TID(objectInfo.ObjectID).
+			QID(objectInfo.ObjectID, objectInfo.ObjectCreatorUserID)
+	case constant.QuoteAuthorObjectType:
+		event = schema.NewEvent(constant.EventQuoteAuthorFlag, report.UserID).TID(objectInfo.ObjectID).
+			QID(objectInfo.ObjectID, objectInfo.ObjectCreatorUserID)
+	case constant.QuotePieceObjectType:
+		event = schema.NewEvent(constant.EventQuotePieceFlag, report.UserID).TID(objectInfo.ObjectID).
+			QID(objectInfo.ObjectID, objectInfo.ObjectCreatorUserID)
 	default:
 		return
 	}
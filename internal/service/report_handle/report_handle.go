@@ -27,25 +27,43 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/comment"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	"github.com/apache/incubator-answer/internal/service_article"
+	"github.com/apache/incubator-answer/internal/service_quote"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/obj"
 )
 
 type ReportHandle struct {
-	questionService *content.QuestionService
-	answerService   *content.AnswerService
-	commentService  *comment.CommentService
+	questionService          *content.QuestionService
+	answerService            *content.AnswerService
+	commentService           *comment.CommentService
+	articleService           *service_article.ArticleService
+	quoteService             *service_quote.QuoteService
+	quoteAuthorService       *service_quote.QuoteAuthorService
+	quotePieceService        *service_quote.QuotePieceService
+	notificationQueueService notice_queue.NotificationQueueService
 }
 
 func NewReportHandle(
 	questionService *content.QuestionService,
 	answerService *content.AnswerService,
 	commentService *comment.CommentService,
+	articleService *service_article.ArticleService,
+	quoteService *service_quote.QuoteService,
+	quoteAuthorService *service_quote.QuoteAuthorService,
+	quotePieceService *service_quote.QuotePieceService,
+	notificationQueueService notice_queue.NotificationQueueService,
 ) *ReportHandle {
 	return &ReportHandle{
-		questionService: questionService,
-		answerService:   answerService,
-		commentService:  commentService,
+		questionService:          questionService,
+		answerService:            answerService,
+		commentService:           commentService,
+		articleService:           articleService,
+		quoteService:             quoteService,
+		quoteAuthorService:       quoteAuthorService,
+		quotePieceService:        quotePieceService,
+		notificationQueueService: notificationQueueService,
 	}
 }
 
@@ -63,6 +81,14 @@ func (rh *ReportHandle) UpdateReportedObject(ctx context.Context,
 		err = rh.updateReportedAnswerReport(ctx, report, req)
 	case constant.CommentObjectType:
 		err = rh.updateReportedCommentReport(ctx, report, req)
+	case constant.ArticleObjectType:
+		err = rh.updateReportedArticleReport(ctx, report, req)
+	case constant.QuoteObjectType:
+		err = rh.updateReportedQuoteReport(ctx, report, req)
+	case constant.QuoteAuthorObjectType:
+		err = rh.updateReportedQuoteAuthorReport(ctx, report, req)
+	case constant.QuotePieceObjectType:
+		err = rh.updateReportedQuotePieceReport(ctx, report, req)
 	}
 	return
 }
@@ -130,3 +156,163 @@ func (rh *ReportHandle) updateReportedCommentReport(ctx context.Context, report
 	}
 	return nil
 }
+
+func (rh *ReportHandle) updateReportedArticleReport(ctx context.Context,
+	report *entity.Report, req *schema.ReviewReportReq) (err error) {
+	var notificationAction string
+	switch req.OperationType {
+	case constant.ReportOperationUnlistPost:
+		err = rh.articleService.OperationArticle(ctx, &schema.OperationArticleReq{
+			ID: report.ObjectID, Operation: schema.ArticleOperationHide, UserID: req.UserID})
+	case constant.ReportOperationDeletePost:
+		err = rh.articleService.RemoveArticle(ctx, &schema.RemoveArticleReq{
+			ID: report.ObjectID, UserID: req.UserID, IsAdmin: true})
+		notificationAction = constant.NotificationYourArticleWasDeleted
+	case constant.ReportOperationClosePost:
+		err = rh.articleService.CloseArticle(ctx, &schema.CloseArticleReq{
+			ID:        report.ObjectID,
+			CloseType: req.CloseType,
+			CloseMsg:  req.CloseMsg,
+			UserID:    req.UserID,
+		})
+		notificationAction = constant.NotificationYourArticleIsClosed
+	case constant.ReportOperationEditPost:
+		_, err = rh.articleService.UpdateArticle(ctx, &schema.ArticleUpdate{
+			ID:           report.ObjectID,
+			Title:        req.Title,
+			Content:      req.Content,
+			HTML:         converter.Markdown2HTML(req.Content),
+			Tags:         req.Tags,
+			UserID:       req.UserID,
+			NoNeedReview: true,
+		})
+	}
+	if err == nil && len(notificationAction) > 0 {
+		rh.sendNotification(ctx, report, req, constant.ArticleObjectType, notificationAction)
+	}
+	return
+}
+
+func (rh *ReportHandle) updateReportedQuoteReport(ctx context.Context,
+	report *entity.Report, req *schema.ReviewReportReq) (err error) {
+	var notificationAction string
+	switch req.OperationType {
+	case constant.ReportOperationUnlistPost:
+		err = rh.quoteService.OperationQuote(ctx, &schema.OperationQuoteReq{
+			ID: report.ObjectID, Operation: schema.QuoteOperationHide, UserID: req.UserID})
+	case constant.ReportOperationDeletePost:
+		err = rh.quoteService.RemoveQuote(ctx, &schema.RemoveQuoteReq{
+			ID: report.ObjectID, UserID: req.UserID, IsAdmin: true})
+		notificationAction = constant.NotificationYourQuoteWasDeleted
+	case constant.ReportOperationClosePost:
+		err = rh.quoteService.CloseQuote(ctx, &schema.CloseQuoteReq{
+			ID:        report.ObjectID,
+			CloseType: req.CloseType,
+			CloseMsg:  req.CloseMsg,
+			UserID:    req.UserID,
+		})
+		notificationAction = constant.NotificationYourQuoteIsClosed
+	case constant.ReportOperationEditPost:
+		_, err = rh.quoteService.UpdateQuote(ctx, &schema.QuoteUpdate{
+			ID:           report.ObjectID,
+			Title:        req.Title,
+			Content:      req.Content,
+			HTML:         converter.Markdown2HTML(req.Content),
+			Tags:         req.Tags,
+			UserID:       req.UserID,
+			NoNeedReview: true,
+		})
+	}
+	if err == nil && len(notificationAction) > 0 {
+		rh.sendNotification(ctx, report, req, constant.QuoteObjectType, notificationAction)
+	}
+	return
+}
+
+func (rh *ReportHandle) updateReportedQuoteAuthorReport(ctx context.Context,
+	report *entity.Report, req *schema.ReviewReportReq) (err error) {
+	var notificationAction string
+	switch req.OperationType {
+	case constant.ReportOperationUnlistPost:
+		err = rh.quoteAuthorService.OperationQuoteAuthor(ctx, &schema.OperationQuoteAuthorReq{
+			ID: report.ObjectID, Operation: schema.QuoteAuthorOperationHide, UserID: req.UserID})
+	case constant.ReportOperationDeletePost:
+		err = rh.quoteAuthorService.RemoveQuoteAuthor(ctx, &schema.RemoveQuoteAuthorReq{
+			ID: report.ObjectID, UserID: req.UserID, IsAdmin: true})
+		notificationAction = constant.NotificationYourQuoteAuthorWasDeleted
+	case constant.ReportOperationClosePost:
+		err = rh.quoteAuthorService.CloseQuoteAuthor(ctx, &schema.CloseQuoteAuthorReq{
+			ID:        report.ObjectID,
+			CloseType: req.CloseType,
+			CloseMsg:  req.CloseMsg,
+			UserID:    req.UserID,
+		})
+		notificationAction = constant.NotificationYourQuoteAuthorIsClosed
+	case constant.ReportOperationEditPost:
+		_, err = rh.quoteAuthorService.UpdateQuoteAuthor(ctx, &schema.QuoteAuthorUpdate{
+			ID:           report.ObjectID,
+			AuthorName:   req.Title,
+			Content:      req.Content,
+			HTML:         converter.Markdown2HTML(req.Content),
+			Tags:         req.Tags,
+			UserID:       req.UserID,
+			NoNeedReview: true,
+		})
+	}
+	if err == nil && len(notificationAction) > 0 {
+		rh.sendNotification(ctx, report, req, constant.QuoteAuthorObjectType, notificationAction)
+	}
+	return
+}
+
+func (rh *ReportHandle) updateReportedQuotePieceReport(ctx context.Context,
+	report *entity.Report, req *schema.ReviewReportReq) (err error) {
+	var notificationAction string
+	switch req.OperationType {
+	case constant.ReportOperationUnlistPost:
+		err = rh.quotePieceService.OperationQuotePiece(ctx, &schema.OperationQuotePieceReq{
+			ID: report.ObjectID, Operation: schema.QuotePieceOperationHide, UserID: req.UserID})
+	case constant.ReportOperationDeletePost:
+		err = rh.quotePieceService.RemoveQuotePiece(ctx, &schema.RemoveQuotePieceReq{
+			ID: report.ObjectID, UserID: req.UserID, IsAdmin: true})
+		notificationAction = constant.NotificationYourQuotePieceWasDeleted
+	case constant.ReportOperationClosePost:
+		err = rh.quotePieceService.CloseQuotePiece(ctx, &schema.CloseQuotePieceReq{
+			ID:        report.ObjectID,
+			CloseType: req.CloseType,
+			CloseMsg:  req.CloseMsg,
+			UserID:    req.UserID,
+		})
+		notificationAction = constant.NotificationYourQuotePieceIsClosed
+	case constant.ReportOperationEditPost:
+		_, err = rh.quotePieceService.UpdateQuotePiece(ctx, &schema.QuotePieceUpdate{
+			ID:           report.ObjectID,
+			Title:        req.Title,
+			Content:      req.Content,
+			HTML:         converter.Markdown2HTML(req.Content),
+			Tags:         req.Tags,
+			UserID:       req.UserID,
+			NoNeedReview: true,
+		})
+	}
+	if err == nil && len(notificationAction) > 0 {
+		rh.sendNotification(ctx, report, req, constant.QuotePieceObjectType, notificationAction)
+	}
+	return
+}
+
+// sendNotification tell the author that the reported object has been handled
+func (rh *ReportHandle) sendNotification(ctx context.Context, report *entity.Report, req *schema.ReviewReportReq,
+	objectType, notificationAction string) {
+	if len(report.ReportedUserID) == 0 || report.ReportedUserID == req.UserID {
+		return
+	}
+	rh.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		ObjectID:           report.ObjectID,
+		Type:               schema.NotificationTypeInbox,
+		ReceiverUserID:     report.ReportedUserID,
+		TriggerUserID:      req.UserID,
+		ObjectType:         objectType,
+		NotificationAction: notificationAction,
+	})
+}
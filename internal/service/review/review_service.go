@@ -21,33 +21,46 @@ package review
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	articlecommon "github.com/apache/incubator-answer/internal/service/article_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
+	"github.com/apache/incubator-answer/internal/service/mention_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/revision"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	quotecommon "github.com/apache/incubator-answer/internal/service_quote/quote_common"
 	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 )
 
 // ReviewRepo review repository
 type ReviewRepo interface {
 	AddReview(ctx context.Context, review *entity.Review) (err error)
-	UpdateReviewStatus(ctx context.Context, reviewID int, reviewerUserID string, status int) (err error)
+	UpdateReviewStatus(ctx context.Context, reviewID int, reviewerUserID string, status int, rejectReason string) (err error)
 	GetReview(ctx context.Context, reviewID int) (review *entity.Review, exist bool, err error)
 	GetReviewCount(ctx context.Context, status int) (count int64, err error)
 	GetReviewPage(ctx context.Context, page, pageSize int, cond *entity.Review) (reviewList []*entity.Review, total int64, err error)
@@ -69,6 +82,14 @@ type ReviewService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	notificationQueueService         notice_queue.NotificationQueueService
 	siteInfoService                  siteinfo_common.SiteInfoCommonService
+	contentFilterService             *content_filter.ContentFilterService
+	articleRepo                      articlecommon.ArticleRepo
+	quoteRepo                        quotecommon.QuoteRepo
+	quoteAuthorRepo                  quotecommon.QuoteAuthorRepo
+	quotePieceRepo                   quotecommon.QuotePieceRepo
+	auditLogService                  *audit_log.AuditLogService
+	revisionRepo                     revision.RevisionRepo
+	mentionCommon                    *mention_common.MentionCommon
 }
 
 // NewReviewService new review service
@@ -85,6 +106,14 @@ func NewReviewService(
 	questionCommon *questioncommon.QuestionCommon,
 	notificationQueueService notice_queue.NotificationQueueService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	contentFilterService *content_filter.ContentFilterService,
+	articleRepo articlecommon.ArticleRepo,
+	quoteRepo quotecommon.QuoteRepo,
+	quoteAuthorRepo quotecommon.QuoteAuthorRepo,
+	quotePieceRepo quotecommon.QuotePieceRepo,
+	auditLogService *audit_log.AuditLogService,
+	revisionRepo revision.RevisionRepo,
+	mentionCommon *mention_common.MentionCommon,
 ) *ReviewService {
 	return &ReviewService{
 		reviewRepo:                       reviewRepo,
@@ -99,6 +128,14 @@ func NewReviewService(
 		questionCommon:                   questionCommon,
 		notificationQueueService:         notificationQueueService,
 		siteInfoService:                  siteInfoService,
+		contentFilterService:             contentFilterService,
+		articleRepo:                      articleRepo,
+		quoteRepo:                        quoteRepo,
+		quoteAuthorRepo:                  quoteAuthorRepo,
+		quotePieceRepo:                   quotePieceRepo,
+		auditLogService:                  auditLogService,
+		revisionRepo:                     revisionRepo,
+		mentionCommon:                    mentionCommon,
 	}
 }
 
@@ -129,109 +166,117 @@ func (cs *ReviewService) AddQuestionReview(ctx context.Context,
 	}
 	return questionStatus
 }
+
+// AddArticleReview add review for article if needed
 func (cs *ReviewService) AddArticleReview(ctx context.Context,
-	question *entity.Article, tags []*schema.TagItem, ip, ua string) (questionStatus int) {
-	//reviewContent := &plugin.ReviewContent{
-	//	ObjectType: constant.QuestionObjectType,
-	//	Title:      question.Title,
-	//	Content:    question.ParsedText,
-	//	IP:         ip,
-	//	UserAgent:  ua,
-	//}
-	//for _, tag := range tags {
-	//	reviewContent.Tags = append(reviewContent.Tags, tag.SlugName)
-	//}
-	//reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, question.UserID)
-	//reviewStatus := cs.callPluginToReview(ctx, question.UserID, question.ID, reviewContent)
-	//switch reviewStatus {
-	//case plugin.ReviewStatusApproved:
-	//	questionStatus = entity.QuestionStatusAvailable
-	//case plugin.ReviewStatusNeedReview:
-	//	questionStatus = entity.QuestionStatusPending
-	//case plugin.ReviewStatusDeleteDirectly:
-	//	questionStatus = entity.QuestionStatusDeleted
-	//default:
-	//	questionStatus = entity.QuestionStatusAvailable
-	//}
-	return entity.ArticleStatusAvailable
+	article *entity.Article, tags []*schema.TagItem, ip, ua string) (status int) {
+	reviewContent := &plugin.ReviewContent{
+		ObjectType: constant.ArticleObjectType,
+		Title:      article.Title,
+		Content:    article.ParsedText,
+		IP:         ip,
+		UserAgent:  ua,
+	}
+	for _, tag := range tags {
+		reviewContent.Tags = append(reviewContent.Tags, tag.SlugName)
+	}
+	reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, article.UserID)
+	reviewStatus := cs.callPluginToReview(ctx, article.UserID, article.ID, reviewContent)
+	switch reviewStatus {
+	case plugin.ReviewStatusApproved:
+		status = entity.ArticleStatusAvailable
+	case plugin.ReviewStatusNeedReview:
+		status = entity.ArticleStatusPending
+	case plugin.ReviewStatusDeleteDirectly:
+		status = entity.ArticleStatusDeleted
+	default:
+		status = entity.ArticleStatusAvailable
+	}
+	return status
 }
+
+// AddQuoteReview add review for quote if needed
 func (cs *ReviewService) AddQuoteReview(ctx context.Context,
-	question *entity.Quote, tags []*schema.TagItem, ip, ua string) (questionStatus int) {
-	//reviewContent := &plugin.ReviewContent{
-	//	ObjectType: constant.QuestionObjectType,
-	//	Title:      question.Title,
-	//	Content:    question.ParsedText,
-	//	IP:         ip,
-	//	UserAgent:  ua,
-	//}
-	//for _, tag := range tags {
-	//	reviewContent.Tags = append(reviewContent.Tags, tag.SlugName)
-	//}
-	//reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, question.UserID)
-	//reviewStatus := cs.callPluginToReview(ctx, question.UserID, question.ID, reviewContent)
-	//switch reviewStatus {
-	//case plugin.ReviewStatusApproved:
-	//	questionStatus = entity.QuestionStatusAvailable
-	//case plugin.ReviewStatusNeedReview:
-	//	questionStatus = entity.QuestionStatusPending
-	//case plugin.ReviewStatusDeleteDirectly:
-	//	questionStatus = entity.QuestionStatusDeleted
-	//default:
-	//	questionStatus = entity.QuestionStatusAvailable
-	//}
-	return entity.QuoteStatusAvailable
+	quote *entity.Quote, tags []*schema.TagItem, ip, ua string) (status int) {
+	reviewContent := &plugin.ReviewContent{
+		ObjectType: constant.QuoteObjectType,
+		Title:      quote.Title,
+		Content:    quote.ParsedText,
+		IP:         ip,
+		UserAgent:  ua,
+	}
+	for _, tag := range tags {
+		reviewContent.Tags = append(reviewContent.Tags, tag.SlugName)
+	}
+	reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, quote.UserID)
+	reviewStatus := cs.callPluginToReview(ctx, quote.UserID, quote.ID, reviewContent)
+	switch reviewStatus {
+	case plugin.ReviewStatusApproved:
+		status = entity.QuoteStatusAvailable
+	case plugin.ReviewStatusNeedReview:
+		status = entity.QuoteStatusPending
+	case plugin.ReviewStatusDeleteDirectly:
+		status = entity.QuoteStatusDeleted
+	default:
+		status = entity.QuoteStatusAvailable
+	}
+	return status
 }
+
+// AddQuoteAuthorReview add review for quote author if needed
 func (cs *ReviewService) AddQuoteAuthorReview(ctx context.Context,
-	question *entity.QuoteAuthor, tags []*schema.TagItem, ip, ua string) (questionStatus int) {
-	//reviewContent := &plugin.ReviewContent{
-	//	ObjectType: constant.QuestionObjectType,
-	//	Title:      question.Title,
-	//	Content:    question.ParsedText,
-	//	IP:         ip,
-	//	UserAgent:  ua,
-	//}
-	//for _, tag := range tags {
-	//	reviewContent.Tags = append(reviewContent.Tags, tag.SlugName)
-	//}
-	//reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, question.UserID)
-	//reviewStatus := cs.callPluginToReview(ctx, question.UserID, question.ID, reviewContent)
-	//switch reviewStatus {
-	//case plugin.ReviewStatusApproved:
-	//	questionStatus = entity.QuestionStatusAvailable
-	//case plugin.ReviewStatusNeedReview:
-	//	questionStatus = entity.QuestionStatusPending
-	//case plugin.ReviewStatusDeleteDirectly:
-	//	questionStatus = entity.QuestionStatusDeleted
-	//default:
-	//	questionStatus = entity.QuestionStatusAvailable
-	//}
-	return entity.QuoteAuthorStatusAvailable
+	author *entity.QuoteAuthor, tags []*schema.TagItem, ip, ua string) (status int) {
+	reviewContent := &plugin.ReviewContent{
+		ObjectType: constant.QuoteAuthorObjectType,
+		Title:      author.AuthorName,
+		Content:    author.Bio,
+		IP:         ip,
+		UserAgent:  ua,
+	}
+	for _, tag := range tags {
+		reviewContent.Tags = append(reviewContent.Tags, tag.SlugName)
+	}
+	reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, author.UserID)
+	reviewStatus := cs.callPluginToReview(ctx, author.UserID, author.ID, reviewContent)
+	switch reviewStatus {
+	case plugin.ReviewStatusApproved:
+		status = entity.QuoteAuthorStatusAvailable
+	case plugin.ReviewStatusNeedReview:
+		status = entity.QuoteAuthorStatusPending
+	case plugin.ReviewStatusDeleteDirectly:
+		status = entity.QuoteAuthorStatusDeleted
+	default:
+		status = entity.QuoteAuthorStatusAvailable
+	}
+	return status
 }
+
+// AddQuotePieceReview add review for quote piece if needed
 func (cs *ReviewService) AddQuotePieceReview(ctx context.Context,
-	question *entity.QuotePiece, tags []*schema.TagItem, ip, ua string) (questionStatus int) {
-	//reviewContent := &plugin.ReviewContent{
-	//	ObjectType: constant.QuestionObjectType,
-	//	Title:      question.Title,
-	//	Content:    question.ParsedText,
-	//	IP:         ip,
-	//	UserAgent:  ua,
-	//}
-	//for _, tag := range tags {
-	//	reviewContent.Tags = append(reviewContent.Tags, tag.SlugName)
-	//}
-	//reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, question.UserID)
-	//reviewStatus := cs.callPluginToReview(ctx, question.UserID, question.ID, reviewContent)
-	//switch reviewStatus {
-	//case plugin.ReviewStatusApproved:
-	//	questionStatus = entity.QuestionStatusAvailable
-	//case plugin.ReviewStatusNeedReview:
-	//	questionStatus = entity.QuestionStatusPending
-	//case plugin.ReviewStatusDeleteDirectly:
-	//	questionStatus = entity.QuestionStatusDeleted
-	//default:
-	//	questionStatus = entity.QuestionStatusAvailable
-	//}
-	return entity.QuoteAuthorStatusAvailable
+	piece *entity.QuotePiece, tags []*schema.TagItem, ip, ua string) (status int) {
+	reviewContent := &plugin.ReviewContent{
+		ObjectType: constant.QuotePieceObjectType,
+		Title:      piece.Title,
+		Content:    piece.ParsedText,
+		IP:         ip,
+		UserAgent:  ua,
+	}
+	for _, tag := range tags {
+		reviewContent.Tags = append(reviewContent.Tags, tag.SlugName)
+	}
+	reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, piece.UserID)
+	reviewStatus := cs.callPluginToReview(ctx, piece.UserID, piece.ID, reviewContent)
+	switch reviewStatus {
+	case plugin.ReviewStatusApproved:
+		status = entity.QuotePieceStatusAvailable
+	case plugin.ReviewStatusNeedReview:
+		status = entity.QuotePieceStatusPending
+	case plugin.ReviewStatusDeleteDirectly:
+		status = entity.QuotePieceStatusDeleted
+	default:
+		status = entity.QuotePieceStatusAvailable
+	}
+	return status
 }
 
 // AddAnswerReview add review for answer if needed
@@ -276,6 +321,37 @@ func (cs *ReviewService) getReviewContentAuthorInfo(ctx context.Context, userID
 	return
 }
 
+// AddEditReview add review for the pending edit of article or quote, the edit is applied when the review is approved.
+// The current content of the object is kept as the diff base of the review.
+func (cs *ReviewService) AddEditReview(ctx context.Context, userID, objectID, revisionID, title, content string) {
+	objectID = uid.DeShortID(objectID)
+	objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil {
+		log.Errorf("get object type failed, err: %v", err)
+		return
+	}
+	r := &entity.Review{
+		UserID:           userID,
+		ObjectID:         objectID,
+		ObjectType:       constant.ObjectTypeStrMapping[objectType],
+		RevisionID:       revisionID,
+		ReviewerUserID:   "0",
+		Submitter:        constant.ReviewSubmitterSuggestedEdit,
+		Status:           entity.ReviewStatusPending,
+		SubmittedTitle:   title,
+		SubmittedContent: content,
+	}
+	info, err := cs.objectInfoService.GetUnreviewedRevisionInfo(ctx, objectID)
+	if err != nil {
+		log.Errorf("get object info failed, err: %v", err)
+	} else {
+		r.BaseTitle, r.BaseContent = info.Title, info.Html
+	}
+	if err = cs.reviewRepo.AddReview(ctx, r); err != nil {
+		log.Errorf("add review failed, err: %v", err)
+	}
+}
+
 // call plugin to review
 func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectID string,
 	reviewContent *plugin.ReviewContent) (reviewStatus plugin.ReviewStatus) {
@@ -284,16 +360,27 @@ func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectI
 	objectID = uid.DeShortID(objectID)
 
 	r := &entity.Review{
-		UserID:         userID,
-		ObjectID:       objectID,
-		ObjectType:     constant.ObjectTypeStrMapping[reviewContent.ObjectType],
-		ReviewerUserID: "0",
-		Status:         entity.ReviewStatusPending,
+		UserID:           userID,
+		ObjectID:         objectID,
+		ObjectType:       constant.ObjectTypeStrMapping[reviewContent.ObjectType],
+		ReviewerUserID:   "0",
+		Status:           entity.ReviewStatusPending,
+		SubmittedTitle:   reviewContent.Title,
+		SubmittedContent: reviewContent.Content,
 	}
 	if siteInterface, _ := cs.siteInfoService.GetSiteInterface(ctx); siteInterface != nil {
 		reviewContent.Language = siteInterface.Language
 	}
 
+	// The built-in content filter goes first, the rules with review severity always send the content to review
+	if rule := cs.contentFilterService.NeedReview(ctx, reviewContent.Title, reviewContent.Content,
+		strings.Join(reviewContent.Tags, " ")); rule != nil {
+		reviewStatus = plugin.ReviewStatusNeedReview
+		r.Reason = translator.TrWithData(i18n.Language(reviewContent.Language), constant.ReviewContentFilterReason,
+			&schema.ContentFilterReviewReasonTrTplData{Pattern: rule.Pattern})
+		r.Submitter = constant.ReviewSubmitterContentFilter
+	}
+
 	_ = plugin.CallReviewer(func(reviewer plugin.Reviewer) error {
 		// If one of the reviewer plugin return false, then the review is not approved
 		if reviewStatus != plugin.ReviewStatusApproved {
@@ -328,16 +415,122 @@ func (cs *ReviewService) UpdateReview(ctx context.Context, req *schema.UpdateRev
 		return nil
 	}
 
-	if err = cs.updateObjectStatus(ctx, review, req.IsApprove()); err != nil {
+	if review.IsEdit() {
+		err = cs.updateEditRevision(ctx, review, req.UserID, req.IsApprove())
+	} else {
+		err = cs.updateObjectStatus(ctx, review, req.IsApprove())
+	}
+	if err != nil {
 		return err
 	}
 
-	if req.IsApprove() {
-		err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, entity.ReviewStatusApproved)
-	} else {
-		err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, entity.ReviewStatusRejected)
+	status, rejectReason := entity.ReviewStatusApproved, ""
+	if !req.IsApprove() {
+		status, rejectReason = entity.ReviewStatusRejected, req.RejectReason
 	}
-	return
+	if err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, status, rejectReason); err != nil {
+		return err
+	}
+	cs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionReviewHandle,
+		ObjectType: entity.AuditLogObjectTypeReview,
+		ObjectID:   strconv.Itoa(review.ID),
+		Before: map[string]interface{}{
+			"status":      review.Status,
+			"object_type": constant.ObjectTypeNumberMapping[review.ObjectType],
+			"object_id":   review.ObjectID,
+		},
+		After: map[string]interface{}{
+			"status":        status,
+			"reject_reason": rejectReason,
+		},
+	})
+	cs.notificationReviewResult(ctx, review, req)
+	return nil
+}
+
+// updateEditRevision apply the revision of the pending edit to the object if approved, otherwise reject the revision
+func (cs *ReviewService) updateEditRevision(ctx context.Context, review *entity.Review, reviewerUserID string,
+	isApprove bool) (err error) {
+	revision, exist, err := cs.revisionRepo.GetRevisionByID(ctx, review.RevisionID)
+	if err != nil {
+		return err
+	}
+	if !exist || revision.Status != entity.RevisionUnreviewedStatus {
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+	if !isApprove {
+		return cs.revisionRepo.UpdateStatus(ctx, revision.ID, entity.RevisionReviewRejectStatus, reviewerUserID)
+	}
+
+	now := time.Now()
+	var (
+		tags       []*entity.TagSimpleInfoForRevision
+		mentionReq *schema.MentionNotificationReq
+	)
+	objectType := constant.ObjectTypeNumberMapping[review.ObjectType]
+	switch objectType {
+	case constant.ArticleObjectType:
+		data := &entity.ArticleWithTagsRevision{}
+		if err = json.Unmarshal([]byte(revision.Content), data); err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		article := &entity.Article{
+			ID:             review.ObjectID,
+			Title:          data.Title,
+			OriginalText:   data.OriginalText,
+			ParsedText:     data.ParsedText,
+			UpdatedAt:      now,
+			PostUpdateTime: now,
+		}
+		err = cs.articleRepo.UpdateArticle(ctx, article,
+			[]string{"title", "original_text", "parsed_text", "updated_at", "post_update_time"})
+		tags = data.Tags
+		mentionReq = &schema.MentionNotificationReq{Title: data.Title, ParsedText: data.ParsedText}
+	case constant.QuoteObjectType:
+		data := &entity.QuoteWithTagsRevision{}
+		if err = json.Unmarshal([]byte(revision.Content), data); err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		quote := &entity.Quote{
+			ID:             review.ObjectID,
+			Title:          data.Title,
+			OriginalText:   data.OriginalText,
+			ParsedText:     data.ParsedText,
+			UpdatedAt:      now,
+			PostUpdateTime: now,
+		}
+		err = cs.quoteRepo.UpdateQuote(ctx, quote,
+			[]string{"title", "original_text", "parsed_text", "updated_at", "post_update_time"})
+		tags = data.Tags
+		mentionReq = &schema.MentionNotificationReq{Title: data.Title, ParsedText: data.ParsedText}
+	default:
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+	if err != nil {
+		return err
+	}
+
+	tagChange := &schema.TagChange{ObjectID: review.ObjectID, UserID: revision.UserID}
+	for _, tag := range tags {
+		tagChange.Tags = append(tagChange.Tags, &schema.TagItem{SlugName: tag.SlugName})
+	}
+	if err = cs.tagCommon.ObjectChangeTag(ctx, tagChange); err != nil {
+		return err
+	}
+	if err = cs.revisionRepo.UpdateStatus(ctx, revision.ID, entity.RevisionReviewPassStatus, reviewerUserID); err != nil {
+		return err
+	}
+
+	// the users newly mentioned by the edit are notified only after it is approved
+	mentionReq.ObjectID = review.ObjectID
+	mentionReq.ObjectType = objectType
+	mentionReq.QuestionID = review.ObjectID
+	mentionReq.TriggerUserID = revision.UserID
+	mentionReq.OldParsedText = review.BaseContent
+	cs.mentionCommon.NotifyMentions(ctx, mentionReq)
+	return nil
 }
 
 // update object status
@@ -367,6 +560,14 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 			}
 			cs.externalNotificationQueueService.Send(ctx,
 				schema.CreateNewQuestionNotificationMsg(questionInfo.ID, questionInfo.Title, questionInfo.UserID, tags))
+			cs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+				ObjectID:      questionInfo.ID,
+				ObjectType:    constant.QuestionObjectType,
+				Title:         questionInfo.Title,
+				QuestionID:    questionInfo.ID,
+				TriggerUserID: questionInfo.UserID,
+				ParsedText:    questionInfo.ParsedText,
+			})
 		}
 		userQuestionCount, err := cs.questionRepo.GetUserQuestionCount(ctx, questionInfo.UserID, 0)
 		if err != nil {
@@ -403,6 +604,16 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 		if isApprove {
 			cs.notificationAnswerTheQuestion(ctx, questionInfo.UserID, questionInfo.ID, answerInfo.ID,
 				answerInfo.UserID, questionInfo.Title, answerInfo.OriginalText)
+			cs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+				ObjectID:      answerInfo.ID,
+				ObjectType:    constant.AnswerObjectType,
+				Title:         questionInfo.Title,
+				QuestionID:    questionInfo.ID,
+				AnswerID:      answerInfo.ID,
+				TriggerUserID: answerInfo.UserID,
+				ParsedText:    answerInfo.ParsedText,
+				SkipUserIDs:   map[string]bool{questionInfo.UserID: true},
+			})
 		}
 		if err := cs.questionCommon.UpdateAnswerCount(ctx, answerInfo.QuestionID); err != nil {
 			log.Errorf("update question answer count failed, err: %v", err)
@@ -419,10 +630,129 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 				log.Errorf("update user answer count failed, err: %v", err)
 			}
 		}
+	case constant.ArticleObjectType:
+		articleInfo, exist, err := cs.articleRepo.GetArticle(ctx, review.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.ObjectNotFound)
+		}
+		status := entity.ArticleStatusDeleted
+		if isApprove {
+			status = entity.ArticleStatusAvailable
+		}
+		if err := cs.articleRepo.UpdateArticleStatus(ctx, articleInfo.ID, status); err != nil {
+			return err
+		}
+		if isApprove {
+			cs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+				ObjectID:      articleInfo.ID,
+				ObjectType:    constant.ArticleObjectType,
+				Title:         articleInfo.Title,
+				QuestionID:    articleInfo.ID,
+				TriggerUserID: articleInfo.UserID,
+				ParsedText:    articleInfo.ParsedText,
+			})
+		}
+		userArticleCount, err := cs.articleRepo.GetUserArticleCount(ctx, articleInfo.UserID, 0)
+		if err != nil {
+			log.Errorf("get user article count failed, err: %v", err)
+		} else if err = cs.userCommon.UpdateArticleCount(ctx, articleInfo.UserID, userArticleCount); err != nil {
+			log.Errorf("update user article count failed, err: %v", err)
+		}
+	case constant.QuoteObjectType:
+		quoteInfo, exist, err := cs.quoteRepo.GetQuote(ctx, review.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.ObjectNotFound)
+		}
+		status := entity.QuoteStatusDeleted
+		if isApprove {
+			status = entity.QuoteStatusAvailable
+		}
+		if err := cs.quoteRepo.UpdateQuoteStatus(ctx, quoteInfo.ID, status); err != nil {
+			return err
+		}
+		if isApprove {
+			cs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+				ObjectID:      quoteInfo.ID,
+				ObjectType:    constant.QuoteObjectType,
+				Title:         quoteInfo.Title,
+				QuestionID:    quoteInfo.ID,
+				TriggerUserID: quoteInfo.UserID,
+				ParsedText:    quoteInfo.ParsedText,
+			})
+		}
+		userQuoteCount, err := cs.quoteRepo.GetUserQuoteCount(ctx, quoteInfo.UserID, 0)
+		if err != nil {
+			log.Errorf("get user quote count failed, err: %v", err)
+		} else if err = cs.userCommon.UpdateQuoteCount(ctx, quoteInfo.UserID, userQuoteCount); err != nil {
+			log.Errorf("update user quote count failed, err: %v", err)
+		}
+	case constant.QuoteAuthorObjectType:
+		authorInfo, exist, err := cs.quoteAuthorRepo.GetQuoteAuthor(ctx, review.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.ObjectNotFound)
+		}
+		status := entity.QuoteAuthorStatusDeleted
+		if isApprove {
+			status = entity.QuoteAuthorStatusAvailable
+		}
+		if err := cs.quoteAuthorRepo.UpdateQuoteAuthorStatus(ctx, authorInfo.ID, status); err != nil {
+			return err
+		}
+	case constant.QuotePieceObjectType:
+		pieceInfo, exist, err := cs.quotePieceRepo.GetQuotePiece(ctx, review.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.ObjectNotFound)
+		}
+		status := entity.QuotePieceStatusDeleted
+		if isApprove {
+			status = entity.QuotePieceStatusAvailable
+		}
+		if err := cs.quotePieceRepo.UpdateQuotePieceStatus(ctx, pieceInfo.ID, status); err != nil {
+			return err
+		}
 	}
 	return
 }
 
+// notificationReviewResult tell the author of the article or quote whether the post passed the review
+func (cs *ReviewService) notificationReviewResult(ctx context.Context, review *entity.Review, req *schema.UpdateReviewReq) {
+	objectType := constant.ObjectTypeNumberMapping[review.ObjectType]
+	switch objectType {
+	case constant.ArticleObjectType, constant.QuoteObjectType,
+		constant.QuoteAuthorObjectType, constant.QuotePieceObjectType:
+	default:
+		return
+	}
+	if review.UserID == req.UserID {
+		return
+	}
+	msg := &schema.NotificationMsg{
+		TriggerUserID:      req.UserID,
+		ReceiverUserID:     review.UserID,
+		Type:               schema.NotificationTypeInbox,
+		ObjectID:           review.ObjectID,
+		ObjectType:         objectType,
+		NotificationAction: constant.NotificationYourPostWasApproved,
+	}
+	if req.IsReject() {
+		msg.NotificationAction = constant.NotificationYourPostWasRejected
+		msg.ExtraInfo = map[string]string{schema.NotificationExtraRejectReason: req.RejectReason}
+	}
+	cs.notificationQueueService.Send(ctx, msg)
+}
+
 func (cs *ReviewService) notificationAnswerTheQuestion(ctx context.Context,
 	questionUserID, questionID, answerID, answerUserID, questionTitle, answerSummary string) {
 	// If the question is answered by me, there is no notification for myself.
@@ -515,6 +845,16 @@ func (cs *ReviewService) GetUnreviewedPostPage(ctx context.Context, req *schema.
 			SubmitAt:             review.CreatedAt.Unix(),
 			SubmitterDisplayName: req.ReviewerMapping[review.Submitter],
 			Reason:               review.Reason,
+			SubmittedTitle:       review.SubmittedTitle,
+			SubmittedContent:     review.SubmittedContent,
+			BaseTitle:            review.BaseTitle,
+			BaseContent:          review.BaseContent,
+		}
+		// the pending edit is not applied yet, so show what was submitted and diff it with the current content
+		if review.IsEdit() {
+			r.Title, r.UrlTitle, r.ParsedText = review.SubmittedTitle, htmltext.UrlTitle(review.SubmittedTitle), review.SubmittedContent
+			r.ContentChanged = review.BaseContent != review.SubmittedContent ||
+				(len(review.BaseTitle) > 0 && review.BaseTitle != review.SubmittedTitle)
 		}
 
 		// get user info
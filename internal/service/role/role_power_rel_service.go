@@ -26,6 +26,8 @@ import (
 // RolePowerRelRepo rolePowerRel repository
 type RolePowerRelRepo interface {
 	GetRolePowerTypeList(ctx context.Context, roleID int) (powers []string, err error)
+	SaveRolePowers(ctx context.Context, roleID int, powers []string) (err error)
+	RemoveRolePowers(ctx context.Context, roleID int) (err error)
 }
 
 // RolePowerRelService user service
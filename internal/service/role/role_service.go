@@ -21,18 +21,23 @@ package role
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/apache/incubator-answer/internal/service/permission"
 	"github.com/jinzhu/copier"
+	"github.com/segmentfault/pacman/errors"
 )
 
 const (
-	// Since there is currently no need to edit roles to add roles and other operations,
-	// the current role information is translated directly.
-	// Later on, when the relevant ability is available, it can be adjusted by the user himself.
+	// The built-in role information is translated directly,
+	// the roles added by the admin are displayed as they are.
 
 	RoleUserID      = 1
 	RoleAdminID     = 2
@@ -55,19 +60,40 @@ const (
 type RoleRepo interface {
 	GetRoleAllList(ctx context.Context) (roles []*entity.Role, err error)
 	GetRoleAllMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error)
+	AddRole(ctx context.Context, role *entity.Role) (err error)
+	UpdateRole(ctx context.Context, role *entity.Role) (err error)
+	RemoveRole(ctx context.Context, roleID int) (err error)
+	GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error)
+	GetRoleByName(ctx context.Context, name string) (role *entity.Role, exist bool, err error)
 }
 
 // RoleService user service
 type RoleService struct {
-	roleRepo RoleRepo
+	roleRepo         RoleRepo
+	rolePowerRelRepo RolePowerRelRepo
+	userRoleRelRepo  UserRoleRelRepo
+	auditLogService  *audit_log.AuditLogService
 }
 
-func NewRoleService(roleRepo RoleRepo) *RoleService {
+func NewRoleService(
+	roleRepo RoleRepo,
+	rolePowerRelRepo RolePowerRelRepo,
+	userRoleRelRepo UserRoleRelRepo,
+	auditLogService *audit_log.AuditLogService,
+) *RoleService {
 	return &RoleService{
-		roleRepo: roleRepo,
+		roleRepo:         roleRepo,
+		rolePowerRelRepo: rolePowerRelRepo,
+		userRoleRelRepo:  userRoleRelRepo,
+		auditLogService:  auditLogService,
 	}
 }
 
+// IsBuiltInRole the built-in roles are created by installation and can not be deleted
+func IsBuiltInRole(roleID int) bool {
+	return roleID == RoleUserID || roleID == RoleAdminID || roleID == RoleModeratorID
+}
+
 // GetRoleList get role list all
 func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleResp, err error) {
 	roles, err := rs.roleRepo.GetRoleAllList(ctx)
@@ -81,9 +107,198 @@ func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleR
 
 	resp = []*schema.GetRoleResp{}
 	_ = copier.Copy(&resp, roles)
+	for _, r := range resp {
+		r.BuiltIn = IsBuiltInRole(r.ID)
+	}
 	return
 }
 
+// GetRoleInfo get role info with its powers
+func (rs *RoleService) GetRoleInfo(ctx context.Context, req *schema.GetRoleInfoReq) (
+	resp *schema.GetRoleInfoResp, err error) {
+	role, exist, err := rs.roleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.RoleNotFound)
+	}
+	rs.translateRole(ctx, role)
+
+	resp = &schema.GetRoleInfoResp{}
+	_ = copier.Copy(&resp.GetRoleResp, role)
+	resp.BuiltIn = IsBuiltInRole(role.ID)
+	resp.Powers, err = rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, role.ID)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetPowerList get all the powers that can be assigned to a role
+func (rs *RoleService) GetPowerList(ctx context.Context) (resp []*schema.GetPowerResp) {
+	resp = make([]*schema.GetPowerResp, 0, len(permission.PowerList))
+	for _, power := range permission.PowerList {
+		group, _, _ := strings.Cut(power, ".")
+		resp = append(resp, &schema.GetPowerResp{PowerType: power, Group: group})
+	}
+	return resp
+}
+
+// AddRole add role with powers
+func (rs *RoleService) AddRole(ctx context.Context, req *schema.AddRoleReq) (
+	resp *schema.GetRoleInfoResp, err error) {
+	powers, err := rs.checkPowers(req.Powers)
+	if err != nil {
+		return nil, err
+	}
+	if err = rs.checkRoleName(ctx, 0, req.Name); err != nil {
+		return nil, err
+	}
+
+	role := &entity.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if err = rs.roleRepo.AddRole(ctx, role); err != nil {
+		return nil, err
+	}
+	if err = rs.rolePowerRelRepo.SaveRolePowers(ctx, role.ID, powers); err != nil {
+		return nil, err
+	}
+	rs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionRoleAdd,
+		ObjectType: entity.AuditLogObjectTypeRole,
+		ObjectID:   strconv.Itoa(role.ID),
+		After:      roleAuditValue(role, powers),
+	})
+	return rs.GetRoleInfo(ctx, &schema.GetRoleInfoReq{ID: role.ID})
+}
+
+// UpdateRole update role and replace its powers.
+// The name and description of the built-in roles are translated, so only their powers can be changed.
+func (rs *RoleService) UpdateRole(ctx context.Context, req *schema.UpdateRoleReq) (err error) {
+	role, exist, err := rs.roleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	// the admin role always has all the powers
+	if role.ID == RoleAdminID {
+		return errors.BadRequest(reason.RoleCannotUpdateAdmin)
+	}
+	powers, err := rs.checkPowers(req.Powers)
+	if err != nil {
+		return err
+	}
+	oldPowers, err := rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, role.ID)
+	if err != nil {
+		return err
+	}
+	before := roleAuditValue(role, oldPowers)
+
+	if !IsBuiltInRole(role.ID) {
+		if err = rs.checkRoleName(ctx, role.ID, req.Name); err != nil {
+			return err
+		}
+		role.Name = req.Name
+		role.Description = req.Description
+		if err = rs.roleRepo.UpdateRole(ctx, role); err != nil {
+			return err
+		}
+	}
+	if err = rs.rolePowerRelRepo.SaveRolePowers(ctx, role.ID, powers); err != nil {
+		return err
+	}
+	rs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionRoleUpdate,
+		ObjectType: entity.AuditLogObjectTypeRole,
+		ObjectID:   strconv.Itoa(role.ID),
+		Before:     before,
+		After:      roleAuditValue(role, powers),
+	})
+	return nil
+}
+
+// RemoveRole remove the role that is not assigned to any user
+func (rs *RoleService) RemoveRole(ctx context.Context, req *schema.RemoveRoleReq) (err error) {
+	if IsBuiltInRole(req.ID) {
+		return errors.BadRequest(reason.RoleCannotDeleteBuiltIn)
+	}
+	role, exist, err := rs.roleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	userRoleRelList, err := rs.userRoleRelRepo.GetUserRoleRelListByRoleID(ctx, []int{req.ID})
+	if err != nil {
+		return err
+	}
+	if len(userRoleRelList) > 0 {
+		return errors.BadRequest(reason.RoleInUse)
+	}
+
+	powers, err := rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = rs.roleRepo.RemoveRole(ctx, req.ID); err != nil {
+		return err
+	}
+	if err = rs.rolePowerRelRepo.RemoveRolePowers(ctx, req.ID); err != nil {
+		return err
+	}
+	rs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionRoleRemove,
+		ObjectType: entity.AuditLogObjectTypeRole,
+		ObjectID:   strconv.Itoa(req.ID),
+		Before:     roleAuditValue(role, powers),
+	})
+	return nil
+}
+
+// roleAuditValue the role value recorded by audit log
+func roleAuditValue(role *entity.Role, powers []string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        role.Name,
+		"description": role.Description,
+		"powers":      powers,
+	}
+}
+
+// checkPowers check the powers are all defined and remove the duplicated ones
+func (rs *RoleService) checkPowers(powers []string) (checked []string, err error) {
+	checked = make([]string, 0, len(powers))
+	exist := make(map[string]bool)
+	for _, power := range powers {
+		if !permission.IsValidPower(power) {
+			return nil, errors.BadRequest(reason.RolePowerInvalid)
+		}
+		if !exist[power] {
+			exist[power] = true
+			checked = append(checked, power)
+		}
+	}
+	return checked, nil
+}
+
+// checkRoleName check the role name is not used by other roles
+func (rs *RoleService) checkRoleName(ctx context.Context, roleID int, name string) (err error) {
+	role, exist, err := rs.roleRepo.GetRoleByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if exist && role.ID != roleID {
+		return errors.BadRequest(reason.RoleNameDuplicate)
+	}
+	return nil
+}
+
 func (rs *RoleService) GetRoleMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error) {
 	return rs.roleRepo.GetRoleAllMapping(ctx)
 }
@@ -0,0 +1,293 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package saved_search
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// savedSearchMaxPerUser the maximum number of saved searches of each user
+	savedSearchMaxPerUser = 20
+	// savedSearchAlertBatchSize the number of saved searches checked in one batch
+	savedSearchAlertBatchSize = 100
+	// savedSearchAlertMaxTitles the maximum number of new content titles in alert email
+	savedSearchAlertMaxTitles = 5
+	// savedSearchAlertTolerance the tolerance of the alert interval, so that the alert will not be
+	// delayed to the next round because the cron job is triggered a little earlier than the last time
+	savedSearchAlertTolerance = 5 * time.Minute
+)
+
+// SavedSearchRepo saved search repository
+type SavedSearchRepo interface {
+	AddSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (err error)
+	UpdateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch, cols []string) (err error)
+	RemoveSavedSearch(ctx context.Context, id, userID string) (err error)
+	GetSavedSearch(ctx context.Context, id string) (savedSearch *entity.SavedSearch, exist bool, err error)
+	GetSavedSearchListByUserID(ctx context.Context, userID string) (savedSearchList []*entity.SavedSearch, err error)
+	CountSavedSearchByUserID(ctx context.Context, userID string) (count int64, err error)
+	GetAlertSavedSearchList(ctx context.Context, startID string, limit int) (savedSearchList []*entity.SavedSearch, err error)
+}
+
+// SavedSearchService saved search service
+type SavedSearchService struct {
+	data                             *data.Data
+	savedSearchRepo                  SavedSearchRepo
+	searchService                    *content.SearchService
+	userRepo                         usercommon.UserRepo
+	notificationQueueService         notice_queue.NotificationQueueService
+	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
+}
+
+// NewSavedSearchService new saved search service
+func NewSavedSearchService(
+	data *data.Data,
+	savedSearchRepo SavedSearchRepo,
+	searchService *content.SearchService,
+	userRepo usercommon.UserRepo,
+	notificationQueueService notice_queue.NotificationQueueService,
+	externalNotificationQueueService notice_queue.ExternalNotificationQueueService,
+) *SavedSearchService {
+	return &SavedSearchService{
+		data:                             data,
+		savedSearchRepo:                  savedSearchRepo,
+		searchService:                    searchService,
+		userRepo:                         userRepo,
+		notificationQueueService:         notificationQueueService,
+		externalNotificationQueueService: externalNotificationQueueService,
+	}
+}
+
+// GetSavedSearchList get saved searches of the user
+func (ss *SavedSearchService) GetSavedSearchList(ctx context.Context, userID string) (
+	resp []*schema.SavedSearchResp, err error) {
+	savedSearchList, err := ss.savedSearchRepo.GetSavedSearchListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.SavedSearchResp, 0, len(savedSearchList))
+	for _, savedSearch := range savedSearchList {
+		resp = append(resp, schema.NewSavedSearchResp(savedSearch))
+	}
+	return resp, nil
+}
+
+// AddSavedSearch add saved search
+func (ss *SavedSearchService) AddSavedSearch(ctx context.Context, req *schema.AddSavedSearchReq) (
+	resp *schema.SavedSearchResp, err error) {
+	count, err := ss.savedSearchRepo.CountSavedSearchByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= savedSearchMaxPerUser {
+		return nil, errors.BadRequest(reason.SavedSearchExceedLimit)
+	}
+
+	now := time.Now()
+	savedSearch := &entity.SavedSearch{
+		UserID:         req.UserID,
+		Name:           req.Name,
+		Query:          req.Query,
+		Alert:          req.Alert,
+		AlertFrequency: formatAlertFrequency(req.AlertFrequency),
+		// only the contents created after saving will be alerted
+		LastSeenAt:    now,
+		LastCheckedAt: now,
+	}
+	if err = ss.savedSearchRepo.AddSavedSearch(ctx, savedSearch); err != nil {
+		return nil, err
+	}
+	return schema.NewSavedSearchResp(savedSearch), nil
+}
+
+// UpdateSavedSearch update saved search
+func (ss *SavedSearchService) UpdateSavedSearch(ctx context.Context, req *schema.UpdateSavedSearchReq) (
+	resp *schema.SavedSearchResp, err error) {
+	savedSearch, exist, err := ss.savedSearchRepo.GetSavedSearch(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || savedSearch.UserID != req.UserID {
+		return nil, errors.BadRequest(reason.SavedSearchNotFound)
+	}
+
+	cols := []string{"name", "query", "alert", "alert_frequency"}
+	// the contents matched the old query are not seen by the user, so reset the high-water mark
+	if savedSearch.Query != req.Query || (!savedSearch.Alert && req.Alert) {
+		savedSearch.LastSeenAt = time.Now()
+		cols = append(cols, "last_seen_at")
+	}
+	savedSearch.Name = req.Name
+	savedSearch.Query = req.Query
+	savedSearch.Alert = req.Alert
+	savedSearch.AlertFrequency = formatAlertFrequency(req.AlertFrequency)
+	if err = ss.savedSearchRepo.UpdateSavedSearch(ctx, savedSearch, cols); err != nil {
+		return nil, err
+	}
+	return schema.NewSavedSearchResp(savedSearch), nil
+}
+
+// RemoveSavedSearch remove saved search
+func (ss *SavedSearchService) RemoveSavedSearch(ctx context.Context, req *schema.RemoveSavedSearchReq) (err error) {
+	return ss.savedSearchRepo.RemoveSavedSearch(ctx, req.ID, req.UserID)
+}
+
+// AlertCron re-run the saved searches which enable alert, and notify the user if new contents match
+func (ss *SavedSearchService) AlertCron(ctx context.Context) {
+	startID := "0"
+	for {
+		savedSearchList, err := ss.savedSearchRepo.GetAlertSavedSearchList(ctx, startID, savedSearchAlertBatchSize)
+		if err != nil {
+			log.Errorf("get alert saved search list failed: %v", err)
+			return
+		}
+		now := time.Now()
+		for _, savedSearch := range savedSearchList {
+			startID = savedSearch.ID
+			interval := entity.SavedSearchAlertIntervalMapping[formatAlertFrequency(savedSearch.AlertFrequency)]
+			if now.Sub(savedSearch.LastCheckedAt)+savedSearchAlertTolerance < interval {
+				continue
+			}
+			if err := ss.checkSavedSearchAlert(ctx, savedSearch, now); err != nil {
+				log.Errorf("check saved search %s alert failed: %v", savedSearch.ID, err)
+			}
+		}
+		if len(savedSearchList) < savedSearchAlertBatchSize {
+			return
+		}
+	}
+}
+
+func (ss *SavedSearchService) checkSavedSearchAlert(ctx context.Context, savedSearch *entity.SavedSearch, now time.Time) (err error) {
+	userInfo, exist, err := ss.userRepo.GetByUserID(ctx, savedSearch.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable {
+		return nil
+	}
+	if ss.checkAlertLimit(ctx, savedSearch.UserID) {
+		return nil
+	}
+
+	// the created time is accurate to the second, so the contents created at the same second as the
+	// high-water mark have been seen already
+	since := savedSearch.LastSeenAt.Truncate(time.Second).Add(time.Second)
+	results, total, err := ss.searchService.SearchSince(ctx, savedSearch.Query, savedSearch.UserID, since, savedSearchAlertMaxTitles)
+	if err != nil {
+		return err
+	}
+
+	savedSearch.LastCheckedAt = now
+	cols := []string{"last_checked_at"}
+	if total > 0 && len(results) > 0 {
+		ss.increaseAlertLimit(ctx, savedSearch.UserID)
+		ss.sendAlert(ctx, savedSearch, userInfo, results, total)
+		// the results are sorted by created time desc, so the first one is the newest
+		savedSearch.LastSeenAt = time.Unix(results[0].Object.CreatedAtParsed, 0)
+		cols = append(cols, "last_seen_at")
+	}
+	return ss.savedSearchRepo.UpdateSavedSearch(ctx, savedSearch, cols)
+}
+
+func (ss *SavedSearchService) sendAlert(ctx context.Context, savedSearch *entity.SavedSearch, userInfo *entity.User,
+	results []*schema.SearchResult, total int64) {
+	newest := results[0]
+	// inbox notification, it will be synced to the notification plugins too
+	msg := &schema.NotificationMsg{
+		TriggerUserID:       savedSearch.UserID,
+		ReceiverUserID:      savedSearch.UserID,
+		Type:                schema.NotificationTypeInbox,
+		ObjectID:            newest.Object.ID,
+		ObjectType:          newest.ObjectType,
+		NotificationAction:  constant.NotificationSavedSearchNewResult,
+		NoNeedPushAllFollow: true,
+	}
+	if newest.Object.UserInfo != nil && len(newest.Object.UserInfo.ID) > 0 {
+		msg.TriggerUserID = newest.Object.UserInfo.ID
+	}
+	ss.notificationQueueService.Send(ctx, msg)
+
+	titles := make([]string, 0, len(results))
+	for _, result := range results {
+		titles = append(titles, result.Object.Title)
+	}
+	ss.externalNotificationQueueService.Send(ctx, &schema.ExternalNotificationMsg{
+		ReceiverUserID: userInfo.ID,
+		ReceiverEmail:  userInfo.EMail,
+		ReceiverLang:   userInfo.Language,
+		SavedSearchAlertRawData: &schema.SavedSearchAlertTemplateRawData{
+			SavedSearchName: savedSearch.Name,
+			Query:           savedSearch.Query,
+			NewCount:        total,
+			Titles:          titles,
+		},
+	})
+}
+
+// checkAlertLimit check whether the user has reached the alert limit in the period
+func (ss *SavedSearchService) checkAlertLimit(ctx context.Context, userID string) (reached bool) {
+	key := constant.SavedSearchAlertLimitCacheKeyPrefix + userID
+	count, exist, err := ss.data.Cache.GetInt64(ctx, key)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	if exist && count >= constant.SavedSearchAlertLimitMax {
+		log.Debugf("%s user reach saved search alert limit", userID)
+		return true
+	}
+	return false
+}
+
+func (ss *SavedSearchService) increaseAlertLimit(ctx context.Context, userID string) {
+	key := constant.SavedSearchAlertLimitCacheKeyPrefix + userID
+	_, exist, err := ss.data.Cache.GetInt64(ctx, key)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist {
+		err = ss.data.Cache.SetInt64(ctx, key, 1, constant.SavedSearchAlertLimitCacheTime)
+	} else {
+		_, err = ss.data.Cache.Increase(ctx, key, 1)
+	}
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func formatAlertFrequency(frequency string) string {
+	if _, ok := entity.SavedSearchAlertIntervalMapping[frequency]; ok {
+		return frequency
+	}
+	return entity.SavedSearchAlertDaily
+}
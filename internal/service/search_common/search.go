@@ -27,8 +27,17 @@ import (
 )
 
 type SearchRepo interface {
-	SearchContents(ctx context.Context, words []string, tagIDs [][]string, userID string, votes, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
-	SearchQuestions(ctx context.Context, words []string, tagIDs [][]string, notAccepted bool, views, answers int, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
-	SearchAnswers(ctx context.Context, words []string, tagIDs [][]string, accepted bool, questionID string, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
+	SearchContents(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
+	SearchQuestions(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
+	SearchAnswers(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
+	SearchArticles(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
+	SearchQuotes(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
+	SearchFacets(ctx context.Context, cond *schema.SearchCondition) (facets *schema.SearchFacets, err error)
 	ParseSearchPluginResult(ctx context.Context, sres []plugin.SearchResult, words []string) (resp []*schema.SearchResult, err error)
+	ParseSearchPluginFacets(ctx context.Context, pluginFacets *plugin.SearchFacets) (facets *schema.SearchFacets, err error)
+	SuggestTitles(ctx context.Context, objectType, prefix string, limit int) (resp []*schema.SearchSuggestTitle, err error)
+	SuggestTags(ctx context.Context, prefix string, limit int) (resp []*schema.TagResp, err error)
+	SuggestUsers(ctx context.Context, prefix string, limit int) (resp []*schema.UserBasicInfo, err error)
+	GetSuggestCache(ctx context.Context, prefix string, limit int) (resp *schema.SearchSuggestResp, exist bool, err error)
+	SetSuggestCache(ctx context.Context, prefix string, limit int, resp *schema.SearchSuggestResp) (err error)
 }
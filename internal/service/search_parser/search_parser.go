@@ -25,6 +25,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/tag_common"
@@ -53,13 +54,19 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 		limitWords = 5
 	)
 
-	// match tags
+	// match tags, the excluded tags must be parsed first
+	cond.ExcludedTags = sp.parseExcludedTags(ctx, &query)
 	cond.Tags = sp.parseTags(ctx, &query)
 
 	// match all
 	cond.UserID = sp.parseUserID(ctx, &query, dto.UserID)
 	cond.VoteAmount = sp.parseVotes(&query)
+	cond.Created = sp.parseTimeRange(&query, "created", time.Now())
+	cond.Updated = sp.parseTimeRange(&query, "updated", time.Now())
+	cond.Active = sp.parseTimeRange(&query, "active", time.Now())
+	cond.Order = sp.parseSort(&query)
 	cond.Words = sp.parseWithin(&query)
+	cond.ExcludedWords = sp.parseExcludedWords(&query)
 
 	// match questions
 	cond.NotAccepted = sp.parseNotAccepted(&query)
@@ -91,9 +98,15 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 	if sp.parseIsAnswer(&query) {
 		cond.TargetType = constant.AnswerObjectType
 	}
+	if sp.parseIsArticle(&query) {
+		cond.TargetType = constant.ArticleObjectType
+	}
+	if sp.parseIsQuote(&query) {
+		cond.TargetType = constant.QuoteObjectType
+	}
 
 	if len(strings.TrimSpace(query)) > 0 {
-		words := strings.Split(strings.TrimSpace(query), " ")
+		words := strings.Fields(query)
 		cond.Words = append(cond.Words, words...)
 	}
 
@@ -101,16 +114,27 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 	if len(cond.Words) > limitWords {
 		cond.Words = cond.Words[:limitWords]
 	}
+	if len(cond.ExcludedWords) > limitWords {
+		cond.ExcludedWords = cond.ExcludedWords[:limitWords]
+	}
 	return
 }
 
 // parseTags parse search tags, return tag ids array
 func (sp *SearchParser) parseTags(ctx context.Context, query *string) (tags [][]string) {
+	return sp.parseTagsByExpr(ctx, query, `\[(.*?)\]`)
+}
+
+// parseExcludedTags parse search excluded tags like: -[tag], return tag ids array
+func (sp *SearchParser) parseExcludedTags(ctx context.Context, query *string) (tags [][]string) {
+	return sp.parseTagsByExpr(ctx, query, `(?:^|\s)-\[(.*?)\]`)
+}
+
+// parseTagsByExpr parse the tags matched the expression, the first sub match of expression must be the tag slug name
+func (sp *SearchParser) parseTagsByExpr(ctx context.Context, query *string, exprTag string) (tags [][]string) {
 	var (
-		// expire tag pattern
-		exprTag = `\[(.*?)\]`
-		q       = *query
-		limit   = 5
+		q     = *query
+		limit = 5
 	)
 
 	re := regexp.MustCompile(exprTag)
@@ -145,7 +169,7 @@ func (sp *SearchParser) parseTags(ctx context.Context, query *string) (tags [][]
 		tags = tags[:limit]
 	}
 
-	q = strings.TrimSpace(re.ReplaceAllString(q, ""))
+	q = strings.TrimSpace(re.ReplaceAllString(q, " "))
 	*query = q
 	return
 }
@@ -194,6 +218,136 @@ func (sp *SearchParser) parseVotes(query *string) (votes int) {
 	return
 }
 
+// parseTimeRange parse the time range of the operator like `created:7d`, `updated:2024-01..2024-03`, `active:2023..`.
+// The value can be a relative duration ([0-9]+[hdwmy]) to now or an absolute date in the format of
+// YYYY, YYYY-MM or YYYY-MM-DD, and two absolute dates can be joined by `..` to specify a range.
+func (sp *SearchParser) parseTimeRange(query *string, operator string, now time.Time) (timeRange *schema.SearchTimeRange) {
+	// the operator must start a word, so that `uncreated:7d` is not taken as `created:7d`
+	var (
+		q    = *query
+		expr = `(?:^|\s)` + operator + `:(\S+)`
+	)
+
+	re := regexp.MustCompile(expr)
+	res := re.FindStringSubmatch(q)
+	if len(res) < 2 {
+		return nil
+	}
+	*query = strings.TrimSpace(re.ReplaceAllString(q, ""))
+
+	value := res[1]
+	if start, ok := parseRelativeTime(value, now); ok {
+		return &schema.SearchTimeRange{Start: start}
+	}
+
+	startValue, endValue, isRange := strings.Cut(value, "..")
+	timeRange = &schema.SearchTimeRange{}
+	if len(startValue) > 0 {
+		start, end, ok := parseAbsoluteDate(startValue)
+		if !ok {
+			return nil
+		}
+		timeRange.Start = start
+		// a single date means the whole period of the date
+		if !isRange {
+			timeRange.End = end
+		}
+	}
+	if len(endValue) > 0 {
+		_, end, ok := parseAbsoluteDate(endValue)
+		if !ok {
+			return nil
+		}
+		timeRange.End = end
+	}
+	if timeRange.IsEmpty() {
+		return nil
+	}
+	return timeRange
+}
+
+// parseRelativeTime parse relative duration like 12h, 7d, 2w, 3m, 1y, return the start time
+func parseRelativeTime(value string, now time.Time) (start time.Time, ok bool) {
+	res := regexp.MustCompile(`^(\d+)([hdwmy])$`).FindStringSubmatch(value)
+	if len(res) != 3 {
+		return start, false
+	}
+	amount := converter.StringToInt(res[1])
+	switch res[2] {
+	case "h":
+		return now.Add(-time.Duration(amount) * time.Hour), true
+	case "d":
+		return now.AddDate(0, 0, -amount), true
+	case "w":
+		return now.AddDate(0, 0, -amount*7), true
+	case "m":
+		return now.AddDate(0, -amount, 0), true
+	default:
+		return now.AddDate(-amount, 0, 0), true
+	}
+}
+
+// parseAbsoluteDate parse date in the format of YYYY, YYYY-MM or YYYY-MM-DD,
+// return the start time of the period and the start time of the next period
+func parseAbsoluteDate(value string) (start, end time.Time, ok bool) {
+	layouts := []struct {
+		layout string
+		years  int
+		months int
+		days   int
+	}{
+		{"2006", 1, 0, 0},
+		{"2006-01", 0, 1, 0},
+		{"2006-01-02", 0, 0, 1},
+	}
+	for _, l := range layouts {
+		t, err := time.ParseInLocation(l.layout, value, time.Local)
+		if err != nil {
+			continue
+		}
+		return t, t.AddDate(l.years, l.months, l.days), true
+	}
+	return start, end, false
+}
+
+// parseSort parse the sort operator like `sort:newest`, return the order of search
+func (sp *SearchParser) parseSort(query *string) (order string) {
+	var (
+		q    = *query
+		expr = `(?:^|\s)sort:(newest|active|votes|relevance)\b`
+	)
+
+	re := regexp.MustCompile(expr)
+	res := re.FindStringSubmatch(q)
+	if len(res) == 2 {
+		order = res[1]
+		if order == "votes" {
+			order = "score"
+		}
+		q = re.ReplaceAllString(q, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseExcludedWords parse the words that should not be contained like: -word
+func (sp *SearchParser) parseExcludedWords(query *string) (words []string) {
+	var (
+		q    = *query
+		expr = `(?:^|\s)-(\S+)`
+	)
+
+	re := regexp.MustCompile(expr)
+	words = []string{}
+	for _, match := range re.FindAllStringSubmatch(q, -1) {
+		words = append(words, match[1])
+	}
+	q = re.ReplaceAllString(q, " ")
+	*query = strings.TrimSpace(q)
+	return
+}
+
 // parseWithin parse quotes within words like: "hello world"
 func (sp *SearchParser) parseWithin(query *string) (words []string) {
 	var (
@@ -332,3 +486,35 @@ func (sp *SearchParser) parseIsAnswer(query *string) (isAnswer bool) {
 	*query = strings.TrimSpace(q)
 	return
 }
+
+// parseIsArticle check the result if only limit article or not
+func (sp *SearchParser) parseIsArticle(query *string) (isArticle bool) {
+	var (
+		q    = *query
+		expr = `is:article`
+	)
+
+	if strings.Contains(q, expr) {
+		isArticle = true
+		q = strings.ReplaceAll(q, expr, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseIsQuote check the result if only limit quote or not
+func (sp *SearchParser) parseIsQuote(query *string) (isQuote bool) {
+	var (
+		q    = *query
+		expr = `is:quote`
+	)
+
+	if strings.Contains(q, expr) {
+		isQuote = true
+		q = strings.ReplaceAll(q, expr, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
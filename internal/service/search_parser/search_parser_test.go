@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchParser_parseTimeRange(t *testing.T) {
+	sp := &SearchParser{}
+	now := time.Date(2024, 5, 20, 12, 0, 0, 0, time.Local)
+
+	query := "hello created:7d"
+	timeRange := sp.parseTimeRange(&query, "created", now)
+	assert.Equal(t, "hello", query)
+	assert.Equal(t, now.AddDate(0, 0, -7), timeRange.Start)
+	assert.True(t, timeRange.End.IsZero())
+
+	query = "updated:2024-02 hello"
+	timeRange = sp.parseTimeRange(&query, "updated", now)
+	assert.Equal(t, "hello", query)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local), timeRange.Start)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), timeRange.End)
+
+	query = "active:2023..2024-01-15"
+	timeRange = sp.parseTimeRange(&query, "active", now)
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local), timeRange.Start)
+	assert.Equal(t, time.Date(2024, 1, 16, 0, 0, 0, 0, time.Local), timeRange.End)
+
+	query = "created:..2023"
+	timeRange = sp.parseTimeRange(&query, "created", now)
+	assert.True(t, timeRange.Start.IsZero())
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), timeRange.End)
+
+	query = "created:yesterday"
+	assert.Nil(t, sp.parseTimeRange(&query, "created", now))
+	assert.Empty(t, query)
+
+	// the operator in the middle of a word is not parsed
+	query = "uncreated:7d hello"
+	assert.Nil(t, sp.parseTimeRange(&query, "created", now))
+	assert.Equal(t, "uncreated:7d hello", query)
+
+	query = "hello uncreated:7d created:7d world"
+	timeRange = sp.parseTimeRange(&query, "created", now)
+	assert.Equal(t, now.AddDate(0, 0, -7), timeRange.Start)
+	assert.Equal(t, "hello uncreated:7d world", query)
+}
+
+func TestSearchParser_parseSort(t *testing.T) {
+	sp := &SearchParser{}
+
+	query := "hello sort:votes world"
+	assert.Equal(t, "score", sp.parseSort(&query))
+	assert.Equal(t, "hello world", query)
+
+	query = "resort:newest sort:newestfoo"
+	assert.Empty(t, sp.parseSort(&query))
+	assert.Equal(t, "resort:newest sort:newestfoo", query)
+}
+
+func TestSearchParser_parseExcludedWords(t *testing.T) {
+	sp := &SearchParser{}
+	query := "-java golang non-blocking -python"
+	words := sp.parseExcludedWords(&query)
+	assert.Equal(t, []string{"java", "python"}, words)
+	assert.Equal(t, "golang non-blocking", query)
+}
@@ -31,11 +31,13 @@ import (
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/export"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
@@ -49,6 +51,7 @@ type SiteInfoService struct {
 	tagCommonService      *tagcommon.TagCommonService
 	configService         *config.ConfigService
 	questioncommon        *questioncommon.QuestionCommon
+	auditLogService       *audit_log.AuditLogService
 }
 
 func NewSiteInfoService(
@@ -58,7 +61,7 @@ func NewSiteInfoService(
 	tagCommonService *tagcommon.TagCommonService,
 	configService *config.ConfigService,
 	questioncommon *questioncommon.QuestionCommon,
-
+	auditLogService *audit_log.AuditLogService,
 ) *SiteInfoService {
 	plugin.RegisterGetSiteURLFunc(func() string {
 		generalSiteInfo, err := siteInfoCommonService.GetSiteGeneral(context.Background())
@@ -69,14 +72,58 @@ func NewSiteInfoService(
 		return generalSiteInfo.SiteUrl
 	})
 
-	return &SiteInfoService{
+	s := &SiteInfoService{
 		siteInfoRepo:          siteInfoRepo,
 		siteInfoCommonService: siteInfoCommonService,
 		emailService:          emailService,
 		tagCommonService:      tagCommonService,
 		configService:         configService,
 		questioncommon:        questioncommon,
+		auditLogService:       auditLogService,
+	}
+	s.initHTMLSanitizer()
+	return s
+}
+
+// saveByType save the site info and record the change to the audit log
+func (s *SiteInfoService) saveByType(ctx context.Context, siteType string, data *entity.SiteInfo) (err error) {
+	var before interface{}
+	oldSiteInfo, exist, err := s.siteInfoRepo.GetByType(ctx, siteType)
+	if err != nil {
+		return err
+	}
+	if exist {
+		before = siteInfoAuditValue(oldSiteInfo.Content)
+	}
+	if err = s.siteInfoRepo.SaveByType(ctx, siteType, data); err != nil {
+		return err
 	}
+	s.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionSiteInfoUpdate,
+		ObjectType: entity.AuditLogObjectTypeSiteInfo,
+		ObjectID:   siteType,
+		Before:     before,
+		After:      siteInfoAuditValue(data.Content),
+	})
+	return nil
+}
+
+// siteInfoAuditValue keep the json content as it is in the audit log, the others are recorded as string
+func siteInfoAuditValue(content string) interface{} {
+	if json.Valid([]byte(content)) {
+		return json.RawMessage(content)
+	}
+	return content
+}
+
+// initHTMLSanitizer load the allowlist of html-format content saved by admin
+func (s *SiteInfoService) initHTMLSanitizer() {
+	htmlSanitizer, err := s.siteInfoCommonService.GetSiteHTMLSanitizer(context.Background())
+	if err != nil {
+		log.Errorf("load html sanitizer allowlist failed, the default one is used: %v", err)
+		return
+	}
+	converter.SetHTMLAllowlist(htmlSanitizer.HTMLAllowlist())
 }
 
 // GetSiteGeneral get site info general
@@ -150,7 +197,7 @@ func (s *SiteInfoService) SaveSiteGeneral(ctx context.Context, req schema.SiteGe
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeGeneral, data)
+	return s.saveByType(ctx, constant.SiteTypeGeneral, data)
 }
 
 func (s *SiteInfoService) SaveSiteInterface(ctx context.Context, req schema.SiteInterfaceReq) (err error) {
@@ -165,7 +212,7 @@ func (s *SiteInfoService) SaveSiteInterface(ctx context.Context, req schema.Site
 		Type:    constant.SiteTypeInterface,
 		Content: string(content),
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeInterface, &data)
+	return s.saveByType(ctx, constant.SiteTypeInterface, &data)
 }
 
 // SaveSiteBranding save site branding information
@@ -176,7 +223,7 @@ func (s *SiteInfoService) SaveSiteBranding(ctx context.Context, req *schema.Site
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeBranding, data)
+	return s.saveByType(ctx, constant.SiteTypeBranding, data)
 }
 
 // SaveSiteWrite save site configuration about write
@@ -211,7 +258,7 @@ func (s *SiteInfoService) SaveSiteWrite(ctx context.Context, req *schema.SiteWri
 		Content: string(content),
 		Status:  1,
 	}
-	return nil, s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeWrite, data)
+	return nil, s.saveByType(ctx, constant.SiteTypeWrite, data)
 }
 
 // SaveSiteLegal save site legal configuration
@@ -222,7 +269,7 @@ func (s *SiteInfoService) SaveSiteLegal(ctx context.Context, req *schema.SiteLeg
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeLegal, data)
+	return s.saveByType(ctx, constant.SiteTypeLegal, data)
 }
 
 // SaveSiteLogin save site legal configuration
@@ -233,7 +280,7 @@ func (s *SiteInfoService) SaveSiteLogin(ctx context.Context, req *schema.SiteLog
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeLogin, data)
+	return s.saveByType(ctx, constant.SiteTypeLogin, data)
 }
 
 // SaveSiteCustomCssHTML save site custom html configuration
@@ -244,7 +291,7 @@ func (s *SiteInfoService) SaveSiteCustomCssHTML(ctx context.Context, req *schema
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeCustomCssHTML, data)
+	return s.saveByType(ctx, constant.SiteTypeCustomCssHTML, data)
 }
 
 // SaveSiteTheme save site custom html configuration
@@ -255,7 +302,7 @@ func (s *SiteInfoService) SaveSiteTheme(ctx context.Context, req *schema.SiteThe
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeTheme, data)
+	return s.saveByType(ctx, constant.SiteTypeTheme, data)
 }
 
 // SaveSiteUsers save site users
@@ -266,7 +313,98 @@ func (s *SiteInfoService) SaveSiteUsers(ctx context.Context, req *schema.SiteUse
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeUsers, data)
+	return s.saveByType(ctx, constant.SiteTypeUsers, data)
+}
+
+// GetSiteActionPolicy get site action policy
+func (s *SiteInfoService) GetSiteActionPolicy(ctx context.Context) (resp *schema.SiteActionPolicyResp, err error) {
+	return s.siteInfoCommonService.GetSiteActionPolicy(ctx)
+}
+
+// SaveSiteActionPolicy save site action policy
+func (s *SiteInfoService) SaveSiteActionPolicy(ctx context.Context, req *schema.SiteActionPolicyReq) (err error) {
+	// the empty rules means no limit, it should be saved as it is rather than fall back to the default rules
+	if req.Rules == nil {
+		req.Rules = make([]*schema.ActionPolicyRule, 0)
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeActionPolicy,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveByType(ctx, constant.SiteTypeActionPolicy, data)
+}
+
+// GetSiteRateLimit get site rate limit
+func (s *SiteInfoService) GetSiteRateLimit(ctx context.Context) (resp *schema.SiteRateLimitResp, err error) {
+	return s.siteInfoCommonService.GetSiteRateLimit(ctx)
+}
+
+// SaveSiteRateLimit save site rate limit
+func (s *SiteInfoService) SaveSiteRateLimit(ctx context.Context, req *schema.SiteRateLimitReq) (err error) {
+	if req.Rules == nil {
+		req.Rules = make([]*schema.RateLimitRule, 0)
+	}
+	if req.TrustedUserIDs == nil {
+		req.TrustedUserIDs = make([]string, 0)
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeRateLimit,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveByType(ctx, constant.SiteTypeRateLimit, data)
+}
+
+// GetSiteHTMLSanitizer get the allowlist of html-format content
+func (s *SiteInfoService) GetSiteHTMLSanitizer(ctx context.Context) (resp *schema.SiteHTMLSanitizerResp, err error) {
+	return s.siteInfoCommonService.GetSiteHTMLSanitizer(ctx)
+}
+
+// SaveSiteHTMLSanitizer save the allowlist of html-format content, it takes effect on the new content immediately
+func (s *SiteInfoService) SaveSiteHTMLSanitizer(ctx context.Context, req *schema.SiteHTMLSanitizerReq) (err error) {
+	if req.Elements == nil {
+		req.Elements = make([]string, 0)
+	}
+	if req.Attributes == nil {
+		req.Attributes = make([]string, 0)
+	}
+	if req.URLSchemes == nil {
+		req.URLSchemes = make([]string, 0)
+	}
+	if req.IframeHosts == nil {
+		req.IframeHosts = make([]string, 0)
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeHTMLSanitizer,
+		Content: string(content),
+		Status:  1,
+	}
+	if err = s.saveByType(ctx, constant.SiteTypeHTMLSanitizer, data); err != nil {
+		return err
+	}
+	resp := schema.SiteHTMLSanitizerResp(*req)
+	converter.SetHTMLAllowlist(resp.HTMLAllowlist())
+	return nil
+}
+
+// GetSiteAuditLog get the audit log config
+func (s *SiteInfoService) GetSiteAuditLog(ctx context.Context) (resp *schema.SiteAuditLogResp, err error) {
+	return s.siteInfoCommonService.GetSiteAuditLog(ctx)
+}
+
+// SaveSiteAuditLog save the audit log config, the retention takes effect on the next daily cleanup
+func (s *SiteInfoService) SaveSiteAuditLog(ctx context.Context, req *schema.SiteAuditLogReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeAuditLog,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveByType(ctx, constant.SiteTypeAuditLog, data)
 }
 
 // GetSMTPConfig get smtp config
@@ -299,6 +437,13 @@ func (s *SiteInfoService) UpdateSMTPConfig(ctx context.Context, req *schema.Upda
 	if err != nil {
 		return err
 	}
+	s.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionSiteInfoUpdate,
+		ObjectType: entity.AuditLogObjectTypeSiteInfo,
+		ObjectID:   "smtp",
+		Before:     maskEmailConfigPassword(*emailConfig),
+		After:      maskEmailConfigPassword(*ec),
+	})
 	if len(req.TestEmailRecipient) > 0 {
 		title, body, err := s.emailService.TestTemplate(ctx)
 		if err != nil {
@@ -309,6 +454,14 @@ func (s *SiteInfoService) UpdateSMTPConfig(ctx context.Context, req *schema.Upda
 	return nil
 }
 
+// maskEmailConfigPassword the smtp password is never recorded in the audit log
+func maskEmailConfigPassword(ec export.EmailConfig) export.EmailConfig {
+	if len(ec.SMTPPassword) > 0 {
+		ec.SMTPPassword = strings.Repeat("*", len(ec.SMTPPassword))
+	}
+	return ec
+}
+
 func (s *SiteInfoService) GetSeo(ctx context.Context) (resp *schema.SiteSeoReq, err error) {
 	resp = &schema.SiteSeoReq{}
 	if err = s.siteInfoCommonService.GetSiteInfoByType(ctx, constant.SiteTypeSeo, resp); err != nil {
@@ -333,7 +486,7 @@ func (s *SiteInfoService) SaveSeo(ctx context.Context, req schema.SiteSeoReq) (e
 		Type:    constant.SiteTypeSeo,
 		Content: string(content),
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeSeo, &data)
+	return s.saveByType(ctx, constant.SiteTypeSeo, &data)
 }
 
 func (s *SiteInfoService) GetPrivilegesConfig(ctx context.Context) (resp *schema.GetPrivilegesConfigResp, err error) {
@@ -424,7 +577,7 @@ func (s *SiteInfoService) UpdatePrivilegesConfig(ctx context.Context, req *schem
 		Content: string(content),
 		Status:  1,
 	}
-	err = s.siteInfoRepo.SaveByType(ctx, constant.SiteTypePrivileges, data)
+	err = s.saveByType(ctx, constant.SiteTypePrivileges, data)
 	if err != nil {
 		return err
 	}
@@ -55,6 +55,10 @@ type SiteInfoCommonService interface {
 	GetSiteCustomCssHTML(ctx context.Context) (resp *schema.SiteCustomCssHTMLResp, err error)
 	GetSiteTheme(ctx context.Context) (resp *schema.SiteThemeResp, err error)
 	GetSiteSeo(ctx context.Context) (resp *schema.SiteSeoResp, err error)
+	GetSiteActionPolicy(ctx context.Context) (resp *schema.SiteActionPolicyResp, err error)
+	GetSiteRateLimit(ctx context.Context) (resp *schema.SiteRateLimitResp, err error)
+	GetSiteHTMLSanitizer(ctx context.Context) (resp *schema.SiteHTMLSanitizerResp, err error)
+	GetSiteAuditLog(ctx context.Context) (resp *schema.SiteAuditLogResp, err error)
 	GetSiteInfoByType(ctx context.Context, siteType string, resp interface{}) (err error)
 
 	GetSiteValByType(ctx context.Context, siteType string, val *string) (err error)
@@ -206,6 +210,51 @@ func (s *siteInfoCommonService) GetSiteTheme(ctx context.Context) (resp *schema.
 	return resp, nil
 }
 
+// GetSiteActionPolicy get site action policy, the default rules are used if the admin has never saved it
+func (s *siteInfoCommonService) GetSiteActionPolicy(ctx context.Context) (resp *schema.SiteActionPolicyResp, err error) {
+	resp = &schema.SiteActionPolicyResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeActionPolicy, resp); err != nil {
+		return nil, err
+	}
+	if resp.Rules == nil {
+		resp.Rules = schema.DefaultActionPolicyRules
+	}
+	return resp, nil
+}
+
+// GetSiteRateLimit get site rate limit, the default config is used if the admin has never saved it
+func (s *siteInfoCommonService) GetSiteRateLimit(ctx context.Context) (resp *schema.SiteRateLimitResp, err error) {
+	resp = &schema.SiteRateLimitResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeRateLimit, resp); err != nil {
+		return nil, err
+	}
+	if resp.Rules == nil {
+		return schema.DefaultSiteRateLimit, nil
+	}
+	return resp, nil
+}
+
+// GetSiteHTMLSanitizer get the allowlist of html-format content, the default one is used if the admin has never saved it
+func (s *siteInfoCommonService) GetSiteHTMLSanitizer(ctx context.Context) (resp *schema.SiteHTMLSanitizerResp, err error) {
+	resp = &schema.SiteHTMLSanitizerResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeHTMLSanitizer, resp); err != nil {
+		return nil, err
+	}
+	if resp.URLSchemes == nil {
+		return schema.NewDefaultSiteHTMLSanitizer(), nil
+	}
+	return resp, nil
+}
+
+// GetSiteAuditLog get the audit log config, the default retention is used if the admin has never saved it
+func (s *siteInfoCommonService) GetSiteAuditLog(ctx context.Context) (resp *schema.SiteAuditLogResp, err error) {
+	resp = &schema.SiteAuditLogResp{RetentionDays: schema.DefaultAuditLogRetentionDays}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeAuditLog, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetSiteSeo get site seo
 func (s *siteInfoCommonService) GetSiteSeo(ctx context.Context) (resp *schema.SiteSeoResp, err error) {
 	resp = &schema.SiteSeoResp{}
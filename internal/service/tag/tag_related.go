@@ -0,0 +1,221 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// relatedTagMinCoCount the pairs of tags used together fewer times are treated as noise
+	relatedTagMinCoCount = 2
+	// relatedTagMaxCount the max number of related tags kept for each tag
+	relatedTagMaxCount = 10
+)
+
+// TagRelatedRepo tag related repository
+type TagRelatedRepo interface {
+	GetTagCooccurrenceList(ctx context.Context, minCoCount int) (cooccurrenceList []*entity.TagRelated, err error)
+	GetTagRelCountMapping(ctx context.Context) (countMapping map[string]int, err error)
+	ReplaceTagRelatedList(ctx context.Context, tagRelatedList []*entity.TagRelated) (err error)
+	GetRelatedTagList(ctx context.Context, tagIDs []string) (tagRelatedList []*entity.TagRelated, err error)
+}
+
+// RefreshRelatedTagsCron compute the co-occurrence of tags across all content types and refresh the related tags
+func (ts *TagService) RefreshRelatedTagsCron(ctx context.Context) {
+	cooccurrenceList, err := ts.tagRelatedRepo.GetTagCooccurrenceList(ctx, relatedTagMinCoCount)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	countMapping, err := ts.tagRelatedRepo.GetTagRelCountMapping(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	// only the available main tags can be related, synonyms and deleted tags are skipped
+	tagIDs := make([]string, 0, len(countMapping))
+	for tagID := range countMapping {
+		tagIDs = append(tagIDs, tagID)
+	}
+	tagList, err := ts.tagCommonService.GetTagListByIDs(ctx, tagIDs)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	mainTagIDs := make(map[string]bool, len(tagList))
+	for _, tag := range tagList {
+		if tag.MainTagID == 0 {
+			mainTagIDs[tag.ID] = true
+		}
+	}
+
+	relatedMapping := make(map[string][]*entity.TagRelated)
+	for _, item := range cooccurrenceList {
+		if !mainTagIDs[item.TagID] || !mainTagIDs[item.RelatedTagID] {
+			continue
+		}
+		strength := tagRelatedStrength(item.CoCount, countMapping[item.TagID], countMapping[item.RelatedTagID])
+		relatedMapping[item.TagID] = append(relatedMapping[item.TagID], &entity.TagRelated{
+			TagID: item.TagID, RelatedTagID: item.RelatedTagID, CoCount: item.CoCount, Strength: strength,
+		})
+		relatedMapping[item.RelatedTagID] = append(relatedMapping[item.RelatedTagID], &entity.TagRelated{
+			TagID: item.RelatedTagID, RelatedTagID: item.TagID, CoCount: item.CoCount, Strength: strength,
+		})
+	}
+
+	tagRelatedList := make([]*entity.TagRelated, 0)
+	for _, relatedList := range relatedMapping {
+		sortTagRelatedList(relatedList)
+		if len(relatedList) > relatedTagMaxCount {
+			relatedList = relatedList[:relatedTagMaxCount]
+		}
+		tagRelatedList = append(tagRelatedList, relatedList...)
+	}
+	if err = ts.tagRelatedRepo.ReplaceTagRelatedList(ctx, tagRelatedList); err != nil {
+		log.Error(err)
+		return
+	}
+	log.Infof("refresh related tags done, %d tags have related tags", len(relatedMapping))
+}
+
+// GetRelatedTags get the related tags of the tags to suggest in the editor, the picked tags are excluded
+func (ts *TagService) GetRelatedTags(ctx context.Context, req *schema.GetRelatedTagsReq) (
+	resp []*schema.RelatedTag, err error) {
+	tagSlugNames := make([]string, 0)
+	for _, slugName := range strings.Split(req.Tags, ",") {
+		if slugName = strings.TrimSpace(strings.ToLower(slugName)); len(slugName) > 0 {
+			tagSlugNames = append(tagSlugNames, slugName)
+		}
+	}
+	if len(tagSlugNames) == 0 {
+		return make([]*schema.RelatedTag, 0), nil
+	}
+	tagList, err := ts.tagCommonService.GetTagListByNames(ctx, tagSlugNames)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs := make([]string, 0, len(tagList))
+	for _, tag := range tagList {
+		if tag.MainTagID > 0 {
+			tagIDs = append(tagIDs, converter.IntToString(tag.MainTagID))
+		} else {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = relatedTagMaxCount
+	}
+	return ts.getRelatedTags(ctx, tagIDs, limit)
+}
+
+// getRelatedTags merge the related tags of the tags by summing up the strength
+func (ts *TagService) getRelatedTags(ctx context.Context, tagIDs []string, limit int) (
+	resp []*schema.RelatedTag, err error) {
+	resp = make([]*schema.RelatedTag, 0)
+	if len(tagIDs) == 0 {
+		return resp, nil
+	}
+	relatedList, err := ts.tagRelatedRepo.GetRelatedTagList(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeTagIDs := make(map[string]bool, len(tagIDs))
+	for _, tagID := range tagIDs {
+		excludeTagIDs[tagID] = true
+	}
+	mergedMapping := make(map[string]*entity.TagRelated)
+	mergedList := make([]*entity.TagRelated, 0)
+	for _, item := range relatedList {
+		if excludeTagIDs[item.RelatedTagID] {
+			continue
+		}
+		merged, ok := mergedMapping[item.RelatedTagID]
+		if !ok {
+			merged = &entity.TagRelated{RelatedTagID: item.RelatedTagID}
+			mergedMapping[item.RelatedTagID] = merged
+			mergedList = append(mergedList, merged)
+		}
+		merged.CoCount += item.CoCount
+		merged.Strength += item.Strength
+	}
+	sortTagRelatedList(mergedList)
+	if len(mergedList) > limit {
+		mergedList = mergedList[:limit]
+	}
+
+	relatedTagIDs := make([]string, 0, len(mergedList))
+	for _, item := range mergedList {
+		relatedTagIDs = append(relatedTagIDs, item.RelatedTagID)
+	}
+	tagList, err := ts.tagCommonService.GetTagListByIDs(ctx, relatedTagIDs)
+	if err != nil {
+		return nil, err
+	}
+	tagMapping := make(map[string]*entity.Tag, len(tagList))
+	for _, tag := range tagList {
+		tagMapping[tag.ID] = tag
+	}
+	for _, item := range mergedList {
+		tag, ok := tagMapping[item.RelatedTagID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.RelatedTag{
+			TagID:       tag.ID,
+			SlugName:    tag.SlugName,
+			DisplayName: tag.DisplayName,
+			Recommend:   tag.Recommend,
+			Reserved:    tag.Reserved,
+			CoCount:     item.CoCount,
+			Strength:    math.Round(item.Strength*10000) / 10000,
+		})
+	}
+	return resp, nil
+}
+
+// tagRelatedStrength the jaccard similarity of the contents of two tags
+func tagRelatedStrength(coCount, count, relatedCount int) float64 {
+	union := count + relatedCount - coCount
+	if union <= 0 {
+		return 0
+	}
+	return math.Round(float64(coCount)/float64(union)*10000) / 10000
+}
+
+func sortTagRelatedList(relatedList []*entity.TagRelated) {
+	sort.SliceStable(relatedList, func(i, j int) bool {
+		if relatedList[i].Strength != relatedList[j].Strength {
+			return relatedList[i].Strength > relatedList[j].Strength
+		}
+		return relatedList[i].CoCount > relatedList[j].CoCount
+	})
+}
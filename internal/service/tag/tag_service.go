@@ -22,6 +22,7 @@ package tag
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
@@ -30,6 +31,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tagcommonser "github.com/apache/incubator-answer/internal/service/tag_common"
 	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/jinzhu/copier"
 
 	"github.com/apache/incubator-answer/internal/base/pager"
@@ -37,7 +39,9 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -51,6 +55,9 @@ type TagService struct {
 	followCommon         activity_common.FollowRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activity_queue.ActivityQueueService
+	activityRepo         activity_common.ActivityRepo
+	tagRelatedRepo       TagRelatedRepo
+	auditLogService      *audit_log.AuditLogService
 }
 
 // NewTagService new tag service
@@ -61,6 +68,9 @@ func NewTagService(
 	followCommon activity_common.FollowRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activity_queue.ActivityQueueService,
+	activityRepo activity_common.ActivityRepo,
+	tagRelatedRepo TagRelatedRepo,
+	auditLogService *audit_log.AuditLogService,
 ) *TagService {
 	return &TagService{
 		tagRepo:              tagRepo,
@@ -69,6 +79,9 @@ func NewTagService(
 		followCommon:         followCommon,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
+		activityRepo:         activityRepo,
+		tagRelatedRepo:       tagRelatedRepo,
+		auditLogService:      auditLogService,
 	}
 }
 
@@ -115,6 +128,157 @@ func (ts *TagService) RemoveTag(ctx context.Context, req *schema.RemoveTagReq) (
 	return nil
 }
 
+// MergeTag merge the source tag into the target tag, the source tag becomes a synonym of the target tag
+func (ts *TagService) MergeTag(ctx context.Context, req *schema.MergeTagReq) (err error) {
+	if req.SourceTagID == req.TargetTagID {
+		return errors.BadRequest(reason.TagCannotMergeIntoItself)
+	}
+	sourceTag, exist, err := ts.tagCommonService.GetTagByID(ctx, req.SourceTagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	targetTag, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TargetTagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	if sourceTag.MainTagID != 0 || targetTag.MainTagID != 0 {
+		return errors.BadRequest(reason.TagCannotMergeSynonym)
+	}
+
+	// keep the wiki text of the source tag if the target tag has none
+	if len(targetTag.OriginalText) == 0 {
+		targetTag.OriginalText = sourceTag.OriginalText
+		targetTag.ParsedText = sourceTag.ParsedText
+	}
+	// the children of the source tag are moved under the target tag, so if the target tag is one of
+	// the descendants of the source tag, it takes the place of the source tag to avoid a parent cycle
+	descendantTagIDs, err := ts.tagCommonService.GetDescendantTagIDs(ctx, sourceTag.ID)
+	if err != nil {
+		return err
+	}
+	for _, id := range descendantTagIDs {
+		if id == targetTag.ID {
+			targetTag.ParentTagId = sourceTag.ParentTagId
+			targetTag.ParentTagSlugName = sourceTag.ParentTagSlugName
+			break
+		}
+	}
+
+	followActivityType, err := ts.activityRepo.GetActivityTypeByObjectType(ctx, constant.TagObjectType, "follow")
+	if err != nil {
+		return err
+	}
+	objectTypeNumber, err := obj.GetObjectTypeNumberByObjectID(targetTag.ID)
+	if err != nil {
+		return err
+	}
+	tagInfoJson, _ := json.Marshal(targetTag)
+	revision := &entity.Revision{
+		UserID:     req.UserID,
+		ObjectType: objectTypeNumber,
+		ObjectID:   targetTag.ID,
+		Title:      targetTag.SlugName,
+		Content:    string(tagInfoJson),
+		Log:        fmt.Sprintf("merge tag %s into %s", sourceTag.SlugName, targetTag.SlugName),
+		Status:     entity.RevisionReviewPassStatus,
+	}
+	if err = ts.tagRepo.MergeTag(ctx, sourceTag, targetTag, followActivityType, revision); err != nil {
+		return err
+	}
+	ts.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionTagMerge,
+		ObjectType: constant.TagObjectType,
+		ObjectID:   sourceTag.ID,
+		Before:     map[string]string{"slug_name": sourceTag.SlugName},
+		After:      map[string]string{"main_tag_id": targetTag.ID, "main_tag_slug_name": targetTag.SlugName},
+	})
+
+	ts.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		ObjectID:         targetTag.ID,
+		OriginalObjectID: targetTag.ID,
+		ActivityTypeKey:  constant.ActTagEdited,
+		RevisionID:       revision.ID,
+	})
+	return nil
+}
+
+// GetTagSlugProposals propose pinyin slug names for the tags whose slug name is chinese
+func (ts *TagService) GetTagSlugProposals(ctx context.Context) (resp []*schema.TagSlugProposal, err error) {
+	resp = make([]*schema.TagSlugProposal, 0)
+	tagList, err := ts.tagRepo.GetTagList(ctx, &entity.Tag{})
+	if err != nil {
+		return nil, err
+	}
+	usedSlugNames := make(map[string]bool)
+	for _, tag := range tagList {
+		if !checker.IsChinese(tag.SlugName) {
+			continue
+		}
+		proposedSlugName, err := htmltext.UniqueSlugName(tag.DisplayName, "tag", entity.TagSlugNameMaxLength,
+			func(slugName string) (bool, error) {
+				if usedSlugNames[slugName] {
+					return true, nil
+				}
+				// the deleted tags also hold their slug names
+				_, exist, err := ts.tagRepo.MustGetTagByNameOrID(ctx, "", slugName)
+				return exist, err
+			})
+		if err != nil {
+			return nil, err
+		}
+		usedSlugNames[proposedSlugName] = true
+		resp = append(resp, &schema.TagSlugProposal{
+			TagID:            tag.ID,
+			DisplayName:      tag.DisplayName,
+			SlugName:         tag.SlugName,
+			ProposedSlugName: proposedSlugName,
+		})
+	}
+	return resp, nil
+}
+
+// ApplyTagSlugProposals update the slug names of the tags to the proposed ones
+func (ts *TagService) ApplyTagSlugProposals(ctx context.Context, req *schema.ApplyTagSlugProposalsReq) (
+	resp []*schema.TagSlugProposal, err error) {
+	proposals, err := ts.GetTagSlugProposals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs := make(map[string]bool, len(req.TagIDs))
+	for _, tagID := range req.TagIDs {
+		tagIDs[tagID] = true
+	}
+	resp = make([]*schema.TagSlugProposal, 0)
+	for _, proposal := range proposals {
+		if len(tagIDs) > 0 && !tagIDs[proposal.TagID] {
+			continue
+		}
+		if err = ts.tagRepo.UpdateTagSlugName(ctx, proposal.TagID, proposal.ProposedSlugName); err != nil {
+			return nil, err
+		}
+		log.Infof("user %s update tag %s slug name from %s to %s", req.UserID, proposal.TagID,
+			proposal.SlugName, proposal.ProposedSlugName)
+		ts.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionTagUpdateSlugName,
+			ObjectType: constant.TagObjectType,
+			ObjectID:   proposal.TagID,
+			Before:     map[string]string{"slug_name": proposal.SlugName},
+			After:      map[string]string{"slug_name": proposal.ProposedSlugName},
+		})
+		resp = append(resp, proposal)
+	}
+	return resp, nil
+}
+
 // UpdateTag update tag
 func (ts *TagService) UpdateTag(ctx context.Context, req *schema.UpdateTagReq) (err error) {
 	return ts.tagCommonService.UpdateTag(ctx, req)
@@ -191,6 +355,10 @@ func (ts *TagService) GetTagInfo(ctx context.Context, req *schema.GetTagInfoReq)
 	resp.Description = htmltext.FetchExcerpt(tagInfo.ParsedText, "...", 240)
 	resp.FollowCount = tagInfo.FollowCount
 	resp.QuestionCount = tagInfo.QuestionCount
+	resp.ArticleCount = tagInfo.ArticleCount
+	resp.QuoteCount = tagInfo.QuoteCount
+	resp.AuthorCount = tagInfo.AuthorCount
+	resp.PieceCount = tagInfo.PieceCount
 	resp.Recommend = tagInfo.Recommend
 	resp.Reserved = tagInfo.Reserved
 	resp.IsFollower = ts.checkTagIsFollow(ctx, req.UserID, tagInfo.ID)
@@ -200,6 +368,11 @@ func (ts *TagService) GetTagInfo(ctx context.Context, req *schema.GetTagInfoReq)
 	//@cws
 	resp.ParentTagId = converter.IntToString(tagInfo.ParentTagId)
 	resp.ParentTagSlugName = tagInfo.ParentTagSlugName
+	resp.RelatedTags, err = ts.getRelatedTags(ctx, []string{tagInfo.ID}, relatedTagMaxCount)
+	if err != nil {
+		log.Error(err)
+		resp.RelatedTags = make([]*schema.RelatedTag, 0)
+	}
 	return resp, nil
 }
 
@@ -312,6 +485,9 @@ func (ts *TagService) GetTagSynonyms(ctx context.Context, req *schema.GetTagSyno
 func (ts *TagService) UpdateTagSynonym(ctx context.Context, req *schema.UpdateTagSynonymReq) (err error) {
 	// format tag slug name
 	req.Format()
+	if err = ts.tagCommonService.FormatTagSlugNames(ctx, req.SynonymTagList); err != nil {
+		return err
+	}
 	addSynonymTagList := make([]string, 0)
 	removeSynonymTagList := make([]string, 0)
 	mainTagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TagID)
@@ -426,6 +602,7 @@ func (ts *TagService) GetTagWithPage(ctx context.Context, req *schema.GetTagWith
 		TagType:             req.TagType,
 		ParentTagId:         req.ParentTagId,
 		IsArticleModuleMenu: req.IsArticleModuleMenu,
+		ContentObjectType:   schema.TagContentTypeObjectTypeMapping[req.ContentType],
 	}
 	tags, total, err := ts.tagCommonService.GetTagPage(ctx, page, pageSize, tag, req.QueryCond, tagSearchCond)
 	if err != nil {
@@ -443,6 +620,10 @@ func (ts *TagService) GetTagWithPage(ctx context.Context, req *schema.GetTagWith
 			ParsedText:    tag.ParsedText,
 			FollowCount:   tag.FollowCount,
 			QuestionCount: tag.QuestionCount,
+			ArticleCount:  tag.ArticleCount,
+			QuoteCount:    tag.QuoteCount,
+			AuthorCount:   tag.AuthorCount,
+			PieceCount:    tag.PieceCount,
 			IsFollower:    ts.checkTagIsFollow(ctx, req.UserID, tag.ID),
 			CreatedAt:     tag.CreatedAt.Unix(),
 			UpdatedAt:     tag.UpdatedAt.Unix(),
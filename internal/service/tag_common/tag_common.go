@@ -33,9 +33,14 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
@@ -46,6 +51,7 @@ type TagCommonRepo interface {
 	GetTagBySlugName(ctx context.Context, slugName string) (tagInfo *entity.Tag, exist bool, err error)
 	GetTagListByName(ctx context.Context, name string, recommend, reserved bool) (tagList []*entity.Tag, err error)
 	GetTagListByNames(ctx context.Context, names []string) (tagList []*entity.Tag, err error)
+	GetTagListByDisplayNames(ctx context.Context, displayNames []string) (tagList []*entity.Tag, err error)
 	GetTagByID(ctx context.Context, tagID string, includeDeleted bool) (tag *entity.Tag, exist bool, err error)
 	GetTagPage(ctx context.Context, page, pageSize int, tag *entity.Tag, queryCond string, tagSearchCond *schema.TagSearchCond) (tagList []*entity.Tag, total int64, err error)
 	GetRecommendTagList(ctx context.Context) (tagList []*entity.Tag, err error)
@@ -54,9 +60,7 @@ type TagCommonRepo interface {
 	UpdateTagQuestionCount(ctx context.Context, tagID string, questionCount int) (err error)
 
 	GetTagListByType(ctx context.Context, tag_type int8) (tagList []*entity.Tag, err error)
-	UpdateTagArticleCount(ctx context.Context, tagID string, articleCount int) (err error)
-	UpdateTagQuoteCount(ctx context.Context, tagID string, quoteCount int) (err error)
-	UpdateTagQuoteAuthorCount(ctx context.Context, tagID string, quoteCount int) (err error)
+	UpdateTagContentCount(ctx context.Context, tagID, objectType string, count int) (err error)
 }
 
 type TagRepo interface {
@@ -70,6 +74,13 @@ type TagRepo interface {
 	GetTagList(ctx context.Context, tag *entity.Tag) (tagList []*entity.Tag, err error)
 
 	GetTagCountByParentId(ctx context.Context, parentTagId string) (count int64, err error)
+	GetTagTreeList(ctx context.Context) (tagList []*entity.Tag, err error)
+	GetTagListByParentIDs(ctx context.Context, parentTagIDs []string) (tagList []*entity.Tag, err error)
+	GetMaxTagSortByParentID(ctx context.Context, parentTagID string) (maxSort int64, err error)
+	UpdateTagParent(ctx context.Context, tagID string, parentTagID int64, parentTagSlugName string, tagSort int64) (err error)
+	UpdateTagSortList(ctx context.Context, tagIDs []string) (err error)
+	MergeTag(ctx context.Context, sourceTag, targetTag *entity.Tag, followActivityType int, revision *entity.Revision) (err error)
+	UpdateTagSlugName(ctx context.Context, tagID, slugName string) (err error)
 }
 
 type TagRelRepo interface {
@@ -84,6 +95,7 @@ type TagRelRepo interface {
 	GetObjectTagRelList(ctx context.Context, objectId string) (tagListList []*entity.TagRel, err error)
 	BatchGetObjectTagRelList(ctx context.Context, objectIds []string) (tagListList []*entity.TagRel, err error)
 	CountTagRelByTagID(ctx context.Context, tagID string) (count int64, err error)
+	CountTagRelByTagIDAndObjectType(ctx context.Context, tagID, objectType string) (count int64, err error)
 }
 
 // TagCommonService user service
@@ -94,6 +106,7 @@ type TagCommonService struct {
 	tagRepo              TagRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activity_queue.ActivityQueueService
+	contentFilterService *content_filter.ContentFilterService
 }
 
 // NewTagCommonService new tag service
@@ -104,6 +117,7 @@ func NewTagCommonService(
 	revisionService *revision_common.RevisionService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activity_queue.ActivityQueueService,
+	contentFilterService *content_filter.ContentFilterService,
 ) *TagCommonService {
 	return &TagCommonService{
 		tagCommonRepo:        tagCommonRepo,
@@ -112,6 +126,7 @@ func NewTagCommonService(
 		revisionService:      revisionService,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
+		contentFilterService: contentFilterService,
 	}
 }
 
@@ -340,6 +355,25 @@ func (ts *TagCommonService) GetObjectTag(ctx context.Context, objectId string) (
 
 // AddTag get object tag
 func (ts *TagCommonService) AddTag(ctx context.Context, req *schema.AddTagReq) (resp *schema.AddTagResp, err error) {
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.TagObjectType, "").
+		AddName(schema.ContentFilterFieldTag, &req.SlugName).
+		AddName(schema.ContentFilterFieldTag, &req.DisplayName).
+		AddContent(&req.OriginalText, &req.ParsedText, false)
+	if err = ts.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
+
+	// the slug name set by editor is used as it is, otherwise generate it from the display name
+	if len(req.SlugName) == 0 || checker.IsChinese(req.SlugName) {
+		name := req.SlugName
+		if len(name) == 0 {
+			name = req.DisplayName
+		}
+		req.SlugName, err = ts.GenerateTagSlugName(ctx, name, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
 	_, exist, err := ts.GetTagBySlugName(ctx, req.SlugName)
 	if err != nil {
 		return nil, err
@@ -402,6 +436,70 @@ func (ts *TagCommonService) AddTag(ctx context.Context, req *schema.AddTagReq) (
 	return &schema.AddTagResp{SlugName: tagInfo.SlugName}, nil
 }
 
+// GenerateTagSlugName generate a slug name which is not used by other tags from the display name,
+// the slug names in usedSlugNames are also treated as used
+func (ts *TagCommonService) GenerateTagSlugName(ctx context.Context, displayName string, usedSlugNames map[string]bool) (
+	slugName string, err error) {
+	return htmltext.UniqueSlugName(displayName, "tag", entity.TagSlugNameMaxLength, func(slugName string) (bool, error) {
+		if usedSlugNames[slugName] {
+			return true, nil
+		}
+		_, exist, err := ts.GetTagBySlugName(ctx, slugName)
+		return exist, err
+	})
+}
+
+// FormatTagSlugNames fill the slug name of the tags which are named in chinese or without slug name.
+// If there is an existing tag with the same display name, use its slug name, otherwise generate a new one.
+func (ts *TagCommonService) FormatTagSlugNames(ctx context.Context, tags []*schema.TagItem) (err error) {
+	displayNames := make([]string, 0)
+	for _, tag := range tags {
+		if len(tag.SlugName) > 0 && !checker.IsChinese(tag.SlugName) {
+			continue
+		}
+		if len(tag.DisplayName) == 0 {
+			tag.DisplayName = tag.SlugName
+		}
+		displayNames = append(displayNames, tag.DisplayName)
+	}
+	if len(displayNames) == 0 {
+		return nil
+	}
+
+	tagList, err := ts.tagCommonRepo.GetTagListByDisplayNames(ctx, displayNames)
+	if err != nil {
+		return err
+	}
+	existTagMapping := make(map[string]*entity.Tag, len(tagList))
+	for _, tag := range tagList {
+		// reserved tags can only be used by their slug name, so that the permission check is not bypassed
+		if tag.Reserved {
+			continue
+		}
+		if _, ok := existTagMapping[tag.DisplayName]; !ok {
+			existTagMapping[tag.DisplayName] = tag
+		}
+	}
+
+	usedSlugNames := make(map[string]bool)
+	for _, tag := range tags {
+		if len(tag.SlugName) > 0 && !checker.IsChinese(tag.SlugName) {
+			usedSlugNames[tag.SlugName] = true
+			continue
+		}
+		if existTag, ok := existTagMapping[tag.DisplayName]; ok {
+			tag.SlugName = existTag.SlugName
+			continue
+		}
+		tag.SlugName, err = ts.GenerateTagSlugName(ctx, tag.DisplayName, usedSlugNames)
+		if err != nil {
+			return err
+		}
+		usedSlugNames[tag.SlugName] = true
+	}
+	return nil
+}
+
 // AddTagList get object tag
 func (ts *TagCommonService) AddTagList(ctx context.Context, tagList []*entity.Tag) (err error) {
 	return ts.tagCommonRepo.AddTagList(ctx, tagList)
@@ -683,6 +781,9 @@ func (ts *TagCommonService) ObjectChangeTag(ctx context.Context, objectTagData *
 	if len(objectTagData.Tags) == 0 {
 		return nil
 	}
+	if err = ts.FormatTagSlugNames(ctx, objectTagData.Tags); err != nil {
+		return err
+	}
 
 	thisObjTagNameList := make([]string, 0)
 	thisObjTagIDList := make([]string, 0)
@@ -758,96 +859,53 @@ func (ts *TagCommonService) CountTagRelByTagID(ctx context.Context, tagID string
 	return ts.tagRelRepo.CountTagRelByTagID(ctx, tagID)
 }
 
-// RefreshTagQuestionCount refresh tag question count
-func (ts *TagCommonService) RefreshTagQuestionCount(ctx context.Context, tagIDs []string) (err error) {
+// RefreshTagContentCount refresh the tag count of the content type
+func (ts *TagCommonService) RefreshTagContentCount(ctx context.Context, objectType string, tagIDs []string) (err error) {
 	for _, tagID := range tagIDs {
-		count, err := ts.tagRelRepo.CountTagRelByTagID(ctx, tagID)
+		count, err := ts.tagRelRepo.CountTagRelByTagIDAndObjectType(ctx, tagID, objectType)
 		if err != nil {
 			return err
 		}
-		err = ts.tagCommonRepo.UpdateTagQuestionCount(ctx, tagID, int(count))
+		err = ts.tagCommonRepo.UpdateTagContentCount(ctx, tagID, objectType, int(count))
 		if err != nil {
 			return err
 		}
-		log.Debugf("tag count updated %s %d", tagID, count)
 	}
 	return nil
 }
+
+// RefreshTagQuestionCount refresh tag question count
+func (ts *TagCommonService) RefreshTagQuestionCount(ctx context.Context, tagIDs []string) (err error) {
+	return ts.RefreshTagContentCount(ctx, constant.QuestionObjectType, tagIDs)
+}
+
+// RefreshTagArticleCount refresh tag article count
 func (ts *TagCommonService) RefreshTagArticleCount(ctx context.Context, tagIDs []string) (err error) {
-	for _, tagID := range tagIDs {
-		count, err := ts.tagRelRepo.CountTagRelByTagID(ctx, tagID)
-		if err != nil {
-			return err
-		}
-		err = ts.tagCommonRepo.UpdateTagArticleCount(ctx, tagID, int(count))
-		if err != nil {
-			return err
-		}
-		log.Debugf("tag count updated %s %d", tagID, count)
-	}
-	return nil
+	return ts.RefreshTagContentCount(ctx, constant.ArticleObjectType, tagIDs)
 }
+
+// RefreshTagQuoteCount refresh tag quote count
 func (ts *TagCommonService) RefreshTagQuoteCount(ctx context.Context, tagIDs []string) (err error) {
-	for _, tagID := range tagIDs {
-		count, err := ts.tagRelRepo.CountTagRelByTagID(ctx, tagID)
-		if err != nil {
-			return err
-		}
-		err = ts.tagCommonRepo.UpdateTagQuoteAuthorCount(ctx, tagID, int(count))
-		if err != nil {
-			return err
-		}
-		log.Debugf("tag count updated %s %d", tagID, count)
-	}
-	return nil
+	return ts.RefreshTagContentCount(ctx, constant.QuoteObjectType, tagIDs)
 }
+
+// RefreshTagQuoteAuthorCount refresh tag quote author count
 func (ts *TagCommonService) RefreshTagQuoteAuthorCount(ctx context.Context, tagIDs []string) (err error) {
-	for _, tagID := range tagIDs {
-		count, err := ts.tagRelRepo.CountTagRelByTagID(ctx, tagID)
-		if err != nil {
-			return err
-		}
-		err = ts.tagCommonRepo.UpdateTagQuoteAuthorCount(ctx, tagID, int(count))
-		if err != nil {
-			return err
-		}
-		log.Debugf("tag count updated %s %d", tagID, count)
-	}
-	return nil
+	return ts.RefreshTagContentCount(ctx, constant.QuoteAuthorObjectType, tagIDs)
 }
-func (ts *TagCommonService) RefreshTagCountByQuestionID(ctx context.Context, questionID string) (err error) {
-	tagListList, err := ts.tagRelRepo.GetObjectTagRelList(ctx, questionID)
-	if err != nil {
-		return err
-	}
-	tagIDs := make([]string, 0)
-	for _, item := range tagListList {
-		tagIDs = append(tagIDs, item.TagID)
-	}
-	err = ts.RefreshTagQuestionCount(ctx, tagIDs)
-	if err != nil {
-		return err
-	}
-	return nil
+
+// RefreshTagQuotePieceCount refresh tag quote piece count
+func (ts *TagCommonService) RefreshTagQuotePieceCount(ctx context.Context, tagIDs []string) (err error) {
+	return ts.RefreshTagContentCount(ctx, constant.QuotePieceObjectType, tagIDs)
 }
 
-func (ts *TagCommonService) RefreshTagCountByArticleID(ctx context.Context, questionID string) (err error) {
-	tagListList, err := ts.tagRelRepo.GetObjectTagRelList(ctx, questionID)
+// RefreshTagCountByObjectID refresh the count of the content type of the object for all tags of the object
+func (ts *TagCommonService) RefreshTagCountByObjectID(ctx context.Context, objectID string) (err error) {
+	objectType, err := obj.GetObjectTypeStrByObjectID(uid.DeShortID(objectID))
 	if err != nil {
 		return err
 	}
-	tagIDs := make([]string, 0)
-	for _, item := range tagListList {
-		tagIDs = append(tagIDs, item.TagID)
-	}
-	err = ts.RefreshTagQuestionCount(ctx, tagIDs) //用这个也没关系
-	if err != nil {
-		return err
-	}
-	return nil
-}
-func (ts *TagCommonService) RefreshTagCountByQuoteID(ctx context.Context, questionID string) (err error) {
-	tagListList, err := ts.tagRelRepo.GetObjectTagRelList(ctx, questionID)
+	tagListList, err := ts.tagRelRepo.GetObjectTagRelList(ctx, objectID)
 	if err != nil {
 		return err
 	}
@@ -855,11 +913,19 @@ func (ts *TagCommonService) RefreshTagCountByQuoteID(ctx context.Context, questi
 	for _, item := range tagListList {
 		tagIDs = append(tagIDs, item.TagID)
 	}
-	err = ts.RefreshTagQuoteCount(ctx, tagIDs) //用这个也没关系
-	if err != nil {
-		return err
-	}
-	return nil
+	return ts.RefreshTagContentCount(ctx, objectType, tagIDs)
+}
+
+func (ts *TagCommonService) RefreshTagCountByQuestionID(ctx context.Context, questionID string) (err error) {
+	return ts.RefreshTagCountByObjectID(ctx, questionID)
+}
+
+func (ts *TagCommonService) RefreshTagCountByArticleID(ctx context.Context, articleID string) (err error) {
+	return ts.RefreshTagCountByObjectID(ctx, articleID)
+}
+
+func (ts *TagCommonService) RefreshTagCountByQuoteID(ctx context.Context, quoteID string) (err error) {
+	return ts.RefreshTagCountByObjectID(ctx, quoteID)
 }
 
 // RemoveTagRelListByObjectID remove tag relation by object id
@@ -937,7 +1003,12 @@ func (ts *TagCommonService) CreateOrUpdateTagRelList(ctx context.Context, object
 		}
 	}
 
-	err = ts.RefreshTagQuestionCount(ctx, needRefreshTagIDs)
+	objectType, err := obj.GetObjectTypeStrByObjectID(uid.DeShortID(objectId))
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	err = ts.RefreshTagContentCount(ctx, objectType, needRefreshTagIDs)
 	if err != nil {
 		log.Error(err)
 	}
@@ -946,6 +1017,14 @@ func (ts *TagCommonService) CreateOrUpdateTagRelList(ctx context.Context, object
 
 func (ts *TagCommonService) UpdateTag(ctx context.Context, req *schema.UpdateTagReq) (err error) {
 	var canUpdate bool
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.TagObjectType, req.TagID).
+		AddName(schema.ContentFilterFieldTag, &req.SlugName).
+		AddName(schema.ContentFilterFieldTag, &req.DisplayName).
+		AddContent(&req.OriginalText, &req.ParsedText, false)
+	if err = ts.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return err
+	}
+
 	_, existUnreviewed, err := ts.revisionService.ExistUnreviewedByObjectID(ctx, req.TagID)
 	if err != nil {
 		return err
@@ -980,6 +1059,11 @@ func (ts *TagCommonService) UpdateTag(ctx context.Context, req *schema.UpdateTag
 			err = errors.BadRequest(reason.TagNotFound)
 			return err
 		}
+		if tagInfo.ParentTagId != parentTagIDInt {
+			if err = ts.CheckTagParent(ctx, tagInfo.ID, parentTagInfo.ID); err != nil {
+				return err
+			}
+		}
 		parentTagInfo_slugname = parentTagInfo.SlugName
 		//parentTagIDInt, err := strconv.ParseInt(parentTagInfo.ID, 10, 64)
 		//if err != nil {
@@ -991,6 +1075,26 @@ func (ts *TagCommonService) UpdateTag(ctx context.Context, req *schema.UpdateTag
 	//Adding equivalent slug formatting for tag update
 	slugName := strings.ReplaceAll(req.SlugName, " ", "-")
 	slugName = strings.ToLower(slugName)
+	if len(slugName) == 0 {
+		slugName = tagInfo.SlugName
+	} else if checker.IsChinese(slugName) {
+		slugName, err = htmltext.UniqueSlugName(slugName, "tag", entity.TagSlugNameMaxLength, func(name string) (bool, error) {
+			tag, exist, err := ts.GetTagBySlugName(ctx, name)
+			return exist && tag.ID != tagInfo.ID, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if slugName != tagInfo.SlugName {
+		_, exist, err := ts.GetTagBySlugName(ctx, slugName)
+		if err != nil {
+			return err
+		}
+		if exist {
+			return errors.BadRequest(reason.TagAlreadyExist)
+		}
+	}
 
 	//If the content is the same, ignore it
 	if tagInfo.OriginalText == req.OriginalText &&
@@ -1067,3 +1171,189 @@ func (ts *TagCommonService) GetTagListByType(ctx context.Context, tag_type int8)
 	//ts.TagsFormatRecommendAndReserved(ctx, tagList)
 	return
 }
+
+// GetTagTree get the tag tree, if the root tag id is set, only return the subtree of the root tag
+func (ts *TagCommonService) GetTagTree(ctx context.Context, req *schema.GetTagTreeReq) (
+	resp []*schema.TagTreeNode, err error) {
+	tagList, err := ts.tagRepo.GetTagTreeList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roots := buildTagTree(tagList)
+	if len(req.TagID) == 0 {
+		return roots, nil
+	}
+	node := findTagTreeNode(roots, req.TagID)
+	if node == nil {
+		return nil, errors.BadRequest(reason.TagNotFound)
+	}
+	return []*schema.TagTreeNode{node}, nil
+}
+
+// buildTagTree build the tag tree from the tag list, the order of siblings keeps the order of the tag list.
+// Tags whose parent tag is not in the list are treated as root tags.
+func buildTagTree(tagList []*entity.Tag) (roots []*schema.TagTreeNode) {
+	roots = make([]*schema.TagTreeNode, 0)
+	nodeMapping := make(map[string]*schema.TagTreeNode, len(tagList))
+	for _, tag := range tagList {
+		nodeMapping[tag.ID] = &schema.TagTreeNode{
+			TagID:         tag.ID,
+			SlugName:      tag.SlugName,
+			DisplayName:   tag.DisplayName,
+			ParentTagID:   strconv.FormatInt(tag.ParentTagId, 10),
+			TagSort:       tag.TagSort,
+			FollowCount:   tag.FollowCount,
+			QuestionCount: tag.QuestionCount,
+			Children:      make([]*schema.TagTreeNode, 0),
+		}
+	}
+	for _, tag := range tagList {
+		node := nodeMapping[tag.ID]
+		parent, ok := nodeMapping[node.ParentTagID]
+		if !ok || parent == node {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	for _, root := range roots {
+		sumTagTreeQuestionCount(root)
+	}
+	return roots
+}
+
+// sumTagTreeQuestionCount sum the question count of the node and all descendant nodes
+func sumTagTreeQuestionCount(node *schema.TagTreeNode) int {
+	node.TotalQuestionCount = node.QuestionCount
+	for _, child := range node.Children {
+		node.TotalQuestionCount += sumTagTreeQuestionCount(child)
+	}
+	return node.TotalQuestionCount
+}
+
+func findTagTreeNode(nodes []*schema.TagTreeNode, tagID string) *schema.TagTreeNode {
+	for _, node := range nodes {
+		if node.TagID == tagID {
+			return node
+		}
+		if found := findTagTreeNode(node.Children, tagID); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// GetDescendantTagIDs get the ids of all descendant tags of the tag, not including the tag itself
+func (ts *TagCommonService) GetDescendantTagIDs(ctx context.Context, tagID string) (tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	visited := map[string]bool{tagID: true}
+	parentIDs := []string{tagID}
+	for len(parentIDs) > 0 {
+		children, err := ts.tagRepo.GetTagListByParentIDs(ctx, parentIDs)
+		if err != nil {
+			return nil, err
+		}
+		parentIDs = make([]string, 0)
+		for _, child := range children {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			tagIDs = append(tagIDs, child.ID)
+			parentIDs = append(parentIDs, child.ID)
+		}
+	}
+	return tagIDs, nil
+}
+
+// GetDescendantTagIDsWithSynonyms get the ids of all descendant tags of the tag and their synonyms
+func (ts *TagCommonService) GetDescendantTagIDsWithSynonyms(ctx context.Context, tagID string) (tagIDs []string, err error) {
+	descendantTagIDs, err := ts.GetDescendantTagIDs(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs = make([]string, 0, len(descendantTagIDs))
+	for _, id := range descendantTagIDs {
+		synTagIDs, err := ts.GetTagIDsByMainTagID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		tagIDs = append(tagIDs, id)
+		tagIDs = append(tagIDs, synTagIDs...)
+	}
+	return tagIDs, nil
+}
+
+// CheckTagParent check the parent tag is neither the tag itself nor one of its descendants
+func (ts *TagCommonService) CheckTagParent(ctx context.Context, tagID, parentTagID string) (err error) {
+	if parentTagID == "0" || len(parentTagID) == 0 {
+		return nil
+	}
+	if tagID == parentTagID {
+		return errors.BadRequest(reason.TagParentCannotBeDescendant)
+	}
+	descendantTagIDs, err := ts.GetDescendantTagIDs(ctx, tagID)
+	if err != nil {
+		return err
+	}
+	for _, id := range descendantTagIDs {
+		if id == parentTagID {
+			return errors.BadRequest(reason.TagParentCannotBeDescendant)
+		}
+	}
+	return nil
+}
+
+// MoveTag move the tag and its subtree under the parent tag, the tag is placed at the end of its new siblings
+func (ts *TagCommonService) MoveTag(ctx context.Context, req *schema.MoveTagReq) (err error) {
+	tagInfo, exist, err := ts.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist || tagInfo.MainTagID != 0 {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+
+	parentTagSlugName := ""
+	if req.ParentTagID != "0" {
+		parentTagInfo, exist, err := ts.GetTagByID(ctx, req.ParentTagID)
+		if err != nil {
+			return err
+		}
+		if !exist || parentTagInfo.MainTagID != 0 {
+			return errors.BadRequest(reason.TagNotFound)
+		}
+		if err = ts.CheckTagParent(ctx, tagInfo.ID, parentTagInfo.ID); err != nil {
+			return err
+		}
+		parentTagSlugName = parentTagInfo.SlugName
+	}
+	parentTagID := converter.StringToInt64(req.ParentTagID)
+	if tagInfo.ParentTagId == parentTagID {
+		return nil
+	}
+
+	maxSort, err := ts.tagRepo.GetMaxTagSortByParentID(ctx, req.ParentTagID)
+	if err != nil {
+		return err
+	}
+	return ts.tagRepo.UpdateTagParent(ctx, tagInfo.ID, parentTagID, parentTagSlugName, maxSort+1)
+}
+
+// ReorderTags reorder the sibling tags by the order of request tag ids
+func (ts *TagCommonService) ReorderTags(ctx context.Context, req *schema.ReorderTagsReq) (err error) {
+	tagList, err := ts.GetTagListByIDs(ctx, req.TagIDs)
+	if err != nil {
+		return err
+	}
+	if len(tagList) != len(req.TagIDs) {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	parentTagID := converter.StringToInt64(req.ParentTagID)
+	for _, tag := range tagList {
+		if tag.ParentTagId != parentTagID {
+			return errors.BadRequest(reason.TagNotSibling)
+		}
+	}
+	return ts.tagRepo.UpdateTagSortList(ctx, req.TagIDs)
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/auth"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
@@ -76,6 +77,7 @@ type UserAdminService struct {
 	questionCommonRepo    questioncommon.QuestionRepo
 	answerCommonRepo      answercommon.AnswerRepo
 	commentCommonRepo     comment_common.CommentCommonRepo
+	auditLogService       *audit_log.AuditLogService
 }
 
 // NewUserAdminService new user admin service
@@ -90,6 +92,7 @@ func NewUserAdminService(
 	questionCommonRepo questioncommon.QuestionRepo,
 	answerCommonRepo answercommon.AnswerRepo,
 	commentCommonRepo comment_common.CommentCommonRepo,
+	auditLogService *audit_log.AuditLogService,
 ) *UserAdminService {
 	return &UserAdminService{
 		userRepo:              userRepo,
@@ -102,6 +105,7 @@ func NewUserAdminService(
 		questionCommonRepo:    questionCommonRepo,
 		answerCommonRepo:      answerCommonRepo,
 		commentCommonRepo:     commentCommonRepo,
+		auditLogService:       auditLogService,
 	}
 }
 
@@ -122,6 +126,7 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	if userInfo.Status == entity.UserStatusDeleted {
 		return nil
 	}
+	before := map[string]interface{}{"status": userInfo.Status, "mail_status": userInfo.MailStatus}
 
 	if req.IsInactive() {
 		userInfo.MailStatus = entity.EmailStatusToBeVerified
@@ -142,6 +147,18 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	if err != nil {
 		return err
 	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.LoginUserID,
+		Action:     entity.AuditLogActionUserUpdateStatus,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   userInfo.ID,
+		Before:     before,
+		After: map[string]interface{}{
+			"status":             userInfo.Status,
+			"mail_status":        userInfo.MailStatus,
+			"remove_all_content": req.RemoveAllContent,
+		},
+	})
 
 	// remove all content that user created, such as question, answer, comment, etc.
 	if req.RemoveAllContent {
@@ -174,11 +191,23 @@ func (us *UserAdminService) UpdateUserRole(ctx context.Context, req *schema.Upda
 	if req.UserID == req.LoginUserID {
 		return errors.BadRequest(reason.UserCannotUpdateYourRole)
 	}
+	oldRoleID, err := us.userRoleRelService.GetUserRole(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
 
 	err = us.userRoleRelService.SaveUserRole(ctx, req.UserID, req.RoleID)
 	if err != nil {
 		return err
 	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.LoginUserID,
+		Action:     entity.AuditLogActionUserUpdateRole,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+		Before:     map[string]int{"role_id": oldRoleID},
+		After:      map[string]int{"role_id": req.RoleID},
+	})
 
 	us.authService.RemoveUserAllTokens(ctx, req.UserID)
 	return
@@ -216,6 +245,12 @@ func (us *UserAdminService) AddUser(ctx context.Context, req *schema.AddUserReq)
 	if err != nil {
 		return err
 	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionUserAdd,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   userInfo.ID,
+		After:      userAuditValue(userInfo),
+	})
 	return
 }
 
@@ -237,7 +272,28 @@ func (us *UserAdminService) AddUsers(ctx context.Context, req *schema.AddUsersRe
 	if errData != nil {
 		return errData.GetErrField(ctx), errors.BadRequest(reason.RequestFormatError)
 	}
-	return nil, us.userRepo.AddUsers(ctx, users)
+	if err = us.userRepo.AddUsers(ctx, users); err != nil {
+		return nil, err
+	}
+	after := make([]map[string]string, 0, len(users))
+	for _, user := range users {
+		after = append(after, userAuditValue(user))
+	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionUserBulkAdd,
+		ObjectType: constant.UserObjectType,
+		After:      after,
+	})
+	return nil, nil
+}
+
+// userAuditValue the user profile recorded in the audit log
+func userAuditValue(user *entity.User) map[string]string {
+	return map[string]string{
+		"username":     user.Username,
+		"display_name": user.DisplayName,
+		"e_mail":       user.EMail,
+	}
 }
 
 func (us *UserAdminService) checkUserDuplicateInner(ctx context.Context, users []*schema.AddUserReq) (
@@ -341,6 +397,13 @@ func (us *UserAdminService) UpdateUserPassword(ctx context.Context, req *schema.
 	if err != nil {
 		return err
 	}
+	// the password is never recorded
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.LoginUserID,
+		Action:     entity.AuditLogActionUserUpdatePassword,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   userInfo.ID,
+	})
 	// logout this user
 	us.authService.RemoveUserAllTokens(ctx, req.UserID)
 	return
@@ -359,6 +422,7 @@ func (us *UserAdminService) EditUserProfile(ctx context.Context, req *schema.Edi
 	if !exist {
 		return nil, errors.BadRequest(reason.UserNotFound)
 	}
+	before := userAuditValue(userInfo)
 
 	if checker.IsInvalidUsername(req.Username) || checker.IsUsersIgnorePath(req.Username) {
 		return append(errFields, &validator.FormErrorField{
@@ -399,6 +463,14 @@ func (us *UserAdminService) EditUserProfile(ctx context.Context, req *schema.Edi
 	if err != nil {
 		return nil, err
 	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.LoginUserID,
+		Action:     entity.AuditLogActionUserEditProfile,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+		Before:     before,
+		After:      userAuditValue(user),
+	})
 	return
 }
 
@@ -566,5 +638,38 @@ func (us *UserAdminService) SendUserActivation(ctx context.Context, req *schema.
 		return err
 	}
 	go us.emailService.SendAndSaveCode(ctx, userInfo.ID, userInfo.EMail, title, body, code, data.ToJSONString())
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionUserSendActivation,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   userInfo.ID,
+		After:      map[string]string{"email": userInfo.EMail},
+	})
+	return nil
+}
+
+// GetUserSessionList get the login sessions of the user
+func (us *UserAdminService) GetUserSessionList(ctx context.Context, req *schema.AdminGetUserSessionListReq) (
+	resp []*schema.UserSessionResp, err error) {
+	_, exist, err := us.userRepo.GetUserInfo(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	return us.authService.GetUserSessionList(ctx, req.UserID, "")
+}
+
+// RevokeUserSession log out the login session of the user
+func (us *UserAdminService) RevokeUserSession(ctx context.Context, req *schema.AdminRevokeUserSessionReq) (err error) {
+	if err = us.authService.RevokeUserSession(ctx, req.UserID, req.SessionID); err != nil {
+		return err
+	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionUserRevokeSession,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+		Before:     map[string]string{"session_id": req.SessionID},
+	})
 	return nil
 }
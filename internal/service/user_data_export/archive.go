@@ -0,0 +1,359 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data_export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/pkg/dir"
+	"github.com/segmentfault/pacman/log"
+)
+
+var (
+	// uploadedFileRegexp matches the local uploaded files referenced in the contents
+	uploadedFileRegexp = regexp.MustCompile(`/uploads/((?:avatar|post)/[0-9A-Za-z_-]+\.[0-9A-Za-z]+)`)
+
+	voteActivityKeyList = []string{
+		activity_type.QuestionVoteUp,
+		activity_type.QuestionVoteDown,
+		activity_type.AnswerVoteUp,
+		activity_type.AnswerVoteDown,
+		activity_type.CommentVoteUp,
+	}
+	followActivityKeyList = []string{
+		"question.follow",
+		"tag.follow",
+		"user.follow",
+	}
+)
+
+// archiveActivity the vote or follow of the user in the export archive
+type archiveActivity struct {
+	ObjectID  string `json:"object_id"`
+	Action    string `json:"action"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// archiveWriter writes the user data into the zip archive and collects the texts referencing uploaded files
+type archiveWriter struct {
+	zw    *zip.Writer
+	texts []string
+}
+
+func (aw *archiveWriter) writeJSON(name string, data any) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	w, err := aw.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
+
+func (aw *archiveWriter) writeMarkdown(name, title, text string) error {
+	aw.texts = append(aw.texts, text)
+	w, err := aw.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "# %s\n\n%s\n", title, text)
+	return err
+}
+
+func (aw *archiveWriter) writeFile(name, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w, err := aw.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// buildArchive collect all personal data of the user into a zip archive of json and markdown files
+func (us *UserDataExportService) buildArchive(ctx context.Context, dataExport *entity.UserDataExport,
+	userInfo *entity.User) (err error) {
+	exportDir := us.exportDir()
+	if err = dir.CreateDirIfNotExist(exportDir); err != nil {
+		return err
+	}
+	tempFilePath := filepath.Join(exportDir, archiveTempFileName(dataExport.ID))
+	f, err := os.Create(tempFilePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	aw := &archiveWriter{zw: zip.NewWriter(f)}
+	steps := []func(ctx context.Context, aw *archiveWriter, userInfo *entity.User) error{
+		us.exportProfile,
+		us.exportQuestions,
+		us.exportAnswers,
+		us.exportArticles,
+		us.exportQuotes,
+		us.exportComments,
+		us.exportVotes,
+		us.exportCollections,
+		us.exportFollows,
+		us.exportNotifications,
+		us.exportUploadedFiles,
+	}
+	for i, step := range steps {
+		if err = step(ctx, aw, userInfo); err != nil {
+			return err
+		}
+		// the progress reaches 100 only after the archive is saved
+		dataExport.Progress = (i + 1) * 99 / len(steps)
+		if err = us.userDataExportRepo.UpdateUserDataExport(ctx, dataExport, []string{"progress"}); err != nil {
+			return err
+		}
+	}
+	if err = aw.zw.Close(); err != nil {
+		return err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	dataExport.FileName = archiveFileName(dataExport.ID)
+	dataExport.FileSize = stat.Size()
+	return os.Rename(tempFilePath, filepath.Join(exportDir, dataExport.FileName))
+}
+
+func (us *UserDataExportService) exportProfile(_ context.Context, aw *archiveWriter, userInfo *entity.User) error {
+	profile := &schema.UserDataExportProfile{
+		ID:          userInfo.ID,
+		Username:    userInfo.Username,
+		DisplayName: userInfo.DisplayName,
+		EMail:       userInfo.EMail,
+		MailStatus:  userInfo.MailStatus,
+		Avatar:      userInfo.Avatar,
+		Bio:         userInfo.Bio,
+		Website:     userInfo.Website,
+		Location:    userInfo.Location,
+		Language:    userInfo.Language,
+		ColorScheme: userInfo.ColorScheme,
+		Rank:        userInfo.Rank,
+		Status:      constant.ConvertUserStatus(userInfo.Status, userInfo.MailStatus),
+		IPInfo:      userInfo.IPInfo,
+		CreatedAt:   userInfo.CreatedAt.Unix(),
+	}
+	if !userInfo.LastLoginDate.IsZero() {
+		profile.LastLoginDate = userInfo.LastLoginDate.Unix()
+	}
+	aw.texts = append(aw.texts, userInfo.Avatar, userInfo.Bio)
+	return aw.writeJSON("profile.json", profile)
+}
+
+func (us *UserDataExportService) exportQuestions(ctx context.Context, aw *archiveWriter, userInfo *entity.User) error {
+	questions := make([]*entity.Question, 0)
+	if err := us.userDataExportRepo.GetUserRecords(ctx, userInfo.ID, &questions); err != nil {
+		return err
+	}
+	for _, question := range questions {
+		err := aw.writeMarkdown(path.Join("questions", question.ID+".md"), question.Title, question.OriginalText)
+		if err != nil {
+			return err
+		}
+	}
+	return aw.writeJSON("questions.json", questions)
+}
+
+func (us *UserDataExportService) exportAnswers(ctx context.Context, aw *archiveWriter, userInfo *entity.User) error {
+	answers := make([]*entity.Answer, 0)
+	if err := us.userDataExportRepo.GetUserRecords(ctx, userInfo.ID, &answers); err != nil {
+		return err
+	}
+	for _, answer := range answers {
+		title := fmt.Sprintf("Answer to question %s", answer.QuestionID)
+		if err := aw.writeMarkdown(path.Join("answers", answer.ID+".md"), title, answer.OriginalText); err != nil {
+			return err
+		}
+	}
+	return aw.writeJSON("answers.json", answers)
+}
+
+func (us *UserDataExportService) exportArticles(ctx context.Context, aw *archiveWriter, userInfo *entity.User) error {
+	articles := make([]*entity.Article, 0)
+	if err := us.userDataExportRepo.GetUserRecords(ctx, userInfo.ID, &articles); err != nil {
+		return err
+	}
+	for _, article := range articles {
+		// the password of the protected article is not personal data of the author
+		article.Password = ""
+		err := aw.writeMarkdown(path.Join("articles", article.ID+".md"), article.Title, article.OriginalText)
+		if err != nil {
+			return err
+		}
+	}
+	return aw.writeJSON("articles.json", articles)
+}
+
+func (us *UserDataExportService) exportQuotes(ctx context.Context, aw *archiveWriter, userInfo *entity.User) error {
+	quotes := make([]*entity.Quote, 0)
+	if err := us.userDataExportRepo.GetUserRecords(ctx, userInfo.ID, &quotes); err != nil {
+		return err
+	}
+	for _, quote := range quotes {
+		if err := aw.writeMarkdown(path.Join("quotes", quote.ID+".md"), quote.Title, quote.OriginalText); err != nil {
+			return err
+		}
+	}
+	if err := aw.writeJSON("quotes.json", quotes); err != nil {
+		return err
+	}
+
+	pieces := make([]*entity.QuotePiece, 0)
+	if err := us.userDataExportRepo.GetUserRecords(ctx, userInfo.ID, &pieces); err != nil {
+		return err
+	}
+	for _, piece := range pieces {
+		err := aw.writeMarkdown(path.Join("quote_pieces", piece.ID+".md"), piece.Title, piece.OriginalText)
+		if err != nil {
+			return err
+		}
+	}
+	return aw.writeJSON("quote_pieces.json", pieces)
+}
+
+func (us *UserDataExportService) exportComments(ctx context.Context, aw *archiveWriter, userInfo *entity.User) error {
+	comments := make([]*entity.Comment, 0)
+	if err := us.userDataExportRepo.GetUserRecords(ctx, userInfo.ID, &comments); err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		aw.texts = append(aw.texts, comment.OriginalText)
+	}
+	return aw.writeJSON("comments.json", comments)
+}
+
+func (us *UserDataExportService) exportVotes(ctx context.Context, aw *archiveWriter, userInfo *entity.User) error {
+	votes, err := us.getUserActivities(ctx, userInfo.ID, voteActivityKeyList)
+	if err != nil {
+		return err
+	}
+	return aw.writeJSON("votes.json", votes)
+}
+
+func (us *UserDataExportService) exportCollections(ctx context.Context, aw *archiveWriter, userInfo *entity.User) error {
+	collections := make([]*entity.Collection, 0)
+	if err := us.userDataExportRepo.GetUserRecords(ctx, userInfo.ID, &collections); err != nil {
+		return err
+	}
+	return aw.writeJSON("collections.json", collections)
+}
+
+func (us *UserDataExportService) exportFollows(ctx context.Context, aw *archiveWriter, userInfo *entity.User) error {
+	follows, err := us.getUserActivities(ctx, userInfo.ID, followActivityKeyList)
+	if err != nil {
+		return err
+	}
+	return aw.writeJSON("follows.json", follows)
+}
+
+func (us *UserDataExportService) exportNotifications(ctx context.Context, aw *archiveWriter, userInfo *entity.User) error {
+	notifications := make([]*entity.Notification, 0)
+	if err := us.userDataExportRepo.GetUserRecords(ctx, userInfo.ID, &notifications); err != nil {
+		return err
+	}
+	return aw.writeJSON("notifications.json", notifications)
+}
+
+// exportUploadedFiles the uploaded files are not recorded by user, so the local files referenced
+// in the avatar and contents of the user are exported
+func (us *UserDataExportService) exportUploadedFiles(ctx context.Context, aw *archiveWriter, _ *entity.User) error {
+	siteInfo, err := us.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return err
+	}
+	files := make([]*schema.UserDataExportFile, 0)
+	seen := make(map[string]bool)
+	for _, text := range aw.texts {
+		for _, match := range uploadedFileRegexp.FindAllStringSubmatch(text, -1) {
+			subPath := match[1]
+			if seen[subPath] {
+				continue
+			}
+			seen[subPath] = true
+			file := &schema.UserDataExportFile{URL: fmt.Sprintf("%s/uploads/%s", siteInfo.SiteUrl, subPath)}
+			filePath := filepath.Join(us.serviceConfig.UploadPath, filepath.FromSlash(subPath))
+			if dir.CheckFileExist(filePath) {
+				file.Path = path.Join("files", subPath)
+				if err = aw.writeFile(file.Path, filePath); err != nil {
+					return err
+				}
+			}
+			files = append(files, file)
+		}
+	}
+	return aw.writeJSON("files.json", files)
+}
+
+// getUserActivities get the activities of the user by config keys, the key missing in config is skipped
+func (us *UserDataExportService) getUserActivities(ctx context.Context, userID string, keys []string) (
+	activities []*archiveActivity, err error) {
+	activityTypes := make([]int, 0, len(keys))
+	keyMapping := make(map[int]string, len(keys))
+	for _, key := range keys {
+		activityType, err := us.configService.GetIDByKey(ctx, key)
+		if err != nil {
+			log.Warnf("get activity type of %s failed: %v", key, err)
+			continue
+		}
+		activityTypes = append(activityTypes, activityType)
+		keyMapping[activityType] = key
+	}
+	list, err := us.userDataExportRepo.GetUserActivities(ctx, userID, activityTypes)
+	if err != nil {
+		return nil, err
+	}
+	activities = make([]*archiveActivity, 0, len(list))
+	for _, act := range list {
+		activities = append(activities, &archiveActivity{
+			ObjectID:  act.ObjectID,
+			Action:    keyMapping[act.ActivityType],
+			CreatedAt: act.CreatedAt.Unix(),
+		})
+	}
+	return activities, nil
+}
@@ -0,0 +1,320 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data_export
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/dir"
+	"github.com/apache/incubator-answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// userDataExportInterval a user can request at most one export in the interval
+	userDataExportInterval = 24 * time.Hour
+	// userDataExportRetention the archive and its download link are available in the retention
+	userDataExportRetention = 7 * 24 * time.Hour
+	// userDataExportStaleTimeout the unfinished export is regarded as failed if not updated in the timeout,
+	// e.g. the server is restarted during the export
+	userDataExportStaleTimeout = time.Hour
+	// userDataExportDirName the directory of the archives, it is beside the upload directory and not public
+	userDataExportDirName = "data_export"
+	// userDataExportDownloadPath the api path to download the archive
+	userDataExportDownloadPath = "/answer/api/v1/user/data-export/download"
+	// userDataExportSignKeyBytes the random bytes of the key to sign the download link
+	userDataExportSignKeyBytes = 32
+	// userDataExportErrMsgMaxLen the max length of the error message saved
+	userDataExportErrMsgMaxLen = 255
+)
+
+// UserDataExportRepo user data export repository
+type UserDataExportRepo interface {
+	AddUserDataExport(ctx context.Context, export *entity.UserDataExport) (err error)
+	UpdateUserDataExport(ctx context.Context, export *entity.UserDataExport, cols []string) (err error)
+	GetUserDataExport(ctx context.Context, id string) (export *entity.UserDataExport, exist bool, err error)
+	GetLatestUserDataExport(ctx context.Context, userID string) (export *entity.UserDataExport, exist bool, err error)
+	AddUserDataExportIfNoneSince(ctx context.Context, export *entity.UserDataExport, since time.Time) (added bool, err error)
+	GetExpiredUserDataExportList(ctx context.Context, before time.Time) (exportList []*entity.UserDataExport, err error)
+	GetStaleUserDataExportList(ctx context.Context, before time.Time) (exportList []*entity.UserDataExport, err error)
+	GetUserRecords(ctx context.Context, userID string, records any) (err error)
+	GetUserActivities(ctx context.Context, userID string, activityTypes []int) (activities []*entity.Activity, err error)
+}
+
+// UserDataExportService user personal data export service
+type UserDataExportService struct {
+	userDataExportRepo UserDataExportRepo
+	userRepo           usercommon.UserRepo
+	configService      *config.ConfigService
+	emailService       *export.EmailService
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	serviceConfig      *service_config.ServiceConfig
+}
+
+// NewUserDataExportService new user personal data export service
+func NewUserDataExportService(
+	userDataExportRepo UserDataExportRepo,
+	userRepo usercommon.UserRepo,
+	configService *config.ConfigService,
+	emailService *export.EmailService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	serviceConfig *service_config.ServiceConfig,
+) *UserDataExportService {
+	return &UserDataExportService{
+		userDataExportRepo: userDataExportRepo,
+		userRepo:           userRepo,
+		configService:      configService,
+		emailService:       emailService,
+		siteInfoService:    siteInfoService,
+		serviceConfig:      serviceConfig,
+	}
+}
+
+// GetUserDataExport get the latest data export of the user, resp is nil if the user has never requested one
+func (us *UserDataExportService) GetUserDataExport(ctx context.Context, userID string) (
+	resp *schema.UserDataExportResp, err error) {
+	dataExport, exist, err := us.userDataExportRepo.GetLatestUserDataExport(ctx, userID)
+	if err != nil || !exist {
+		return nil, err
+	}
+	return us.formatUserDataExportResp(ctx, dataExport)
+}
+
+// RequestUserDataExport create a data export job of the user, the archive is generated asynchronously
+func (us *UserDataExportService) RequestUserDataExport(ctx context.Context, userID string) (
+	resp *schema.UserDataExportResp, err error) {
+	signKey, err := token.GenerateSecureToken(userDataExportSignKeyBytes)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	dataExport := &entity.UserDataExport{
+		UserID:  userID,
+		Status:  entity.UserDataExportStatusPending,
+		SignKey: signKey,
+	}
+	// the failed exports do not count, so the user can retry at once
+	added, err := us.userDataExportRepo.AddUserDataExportIfNoneSince(ctx, dataExport,
+		time.Now().Add(-userDataExportInterval))
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, errors.BadRequest(reason.UserDataExportTooFrequent)
+	}
+
+	go us.processUserDataExport(context.Background(), dataExport)
+	return us.formatUserDataExportResp(ctx, dataExport)
+}
+
+// DownloadUserDataExport verify the signed download link and return the archive path
+func (us *UserDataExportService) DownloadUserDataExport(ctx context.Context, req *schema.DownloadUserDataExportReq) (
+	filePath, fileName string, err error) {
+	now := time.Now()
+	if req.Expires < now.Unix() {
+		return "", "", errors.BadRequest(reason.UserDataExportLinkInvalid)
+	}
+	dataExport, exist, err := us.userDataExportRepo.GetUserDataExport(ctx, req.ID)
+	if err != nil {
+		return "", "", err
+	}
+	if !exist || dataExport.Status != entity.UserDataExportStatusCompleted || dataExport.ExpiredAt.Before(now) {
+		return "", "", errors.BadRequest(reason.UserDataExportLinkInvalid)
+	}
+	expected := signDownloadLink(dataExport.SignKey, dataExport.ID, req.Expires)
+	if !hmac.Equal([]byte(expected), []byte(req.Sign)) {
+		return "", "", errors.BadRequest(reason.UserDataExportLinkInvalid)
+	}
+
+	filePath = filepath.Join(us.exportDir(), dataExport.FileName)
+	if !dir.CheckFileExist(filePath) {
+		return "", "", errors.BadRequest(reason.UserDataExportLinkInvalid)
+	}
+	fileName = fmt.Sprintf("data-export-%s.zip", dataExport.CreatedAt.Format("20060102"))
+	return filePath, fileName, nil
+}
+
+// CleanUserDataExportCron remove the expired archives and fail the stale export jobs
+func (us *UserDataExportService) CleanUserDataExportCron(ctx context.Context) {
+	now := time.Now()
+	expiredList, err := us.userDataExportRepo.GetExpiredUserDataExportList(ctx, now)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, dataExport := range expiredList {
+		us.removeArchive(dataExport.FileName)
+		dataExport.Status = entity.UserDataExportStatusExpired
+		dataExport.FileName = ""
+		if err = us.userDataExportRepo.UpdateUserDataExport(ctx, dataExport, []string{"status", "file_name"}); err != nil {
+			log.Error(err)
+		}
+	}
+
+	staleList, err := us.userDataExportRepo.GetStaleUserDataExportList(ctx, now.Add(-userDataExportStaleTimeout))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, dataExport := range staleList {
+		us.removeArchive(archiveTempFileName(dataExport.ID))
+		us.failUserDataExport(ctx, dataExport, fmt.Errorf("export timeout"))
+	}
+}
+
+func (us *UserDataExportService) processUserDataExport(ctx context.Context, dataExport *entity.UserDataExport) {
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, dataExport.UserID)
+	if err != nil || !exist {
+		us.failUserDataExport(ctx, dataExport, fmt.Errorf("get user %s failed: %v", dataExport.UserID, err))
+		return
+	}
+	// If user has set language, use it to generate the archive and send email.
+	if len(userInfo.Language) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(userInfo.Language))
+	}
+
+	dataExport.Status = entity.UserDataExportStatusProcessing
+	if err = us.userDataExportRepo.UpdateUserDataExport(ctx, dataExport, []string{"status"}); err != nil {
+		log.Error(err)
+		return
+	}
+
+	if err = us.buildArchive(ctx, dataExport, userInfo); err != nil {
+		log.Errorf("user %s data export %s failed: %v", dataExport.UserID, dataExport.ID, err)
+		us.removeArchive(archiveTempFileName(dataExport.ID))
+		us.failUserDataExport(ctx, dataExport, err)
+		return
+	}
+
+	dataExport.Status = entity.UserDataExportStatusCompleted
+	dataExport.Progress = 100
+	dataExport.ExpiredAt = time.Now().Add(userDataExportRetention)
+	err = us.userDataExportRepo.UpdateUserDataExport(ctx, dataExport,
+		[]string{"status", "progress", "file_name", "file_size", "expired_at"})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	us.sendReadyEmail(ctx, dataExport, userInfo)
+}
+
+func (us *UserDataExportService) failUserDataExport(ctx context.Context, dataExport *entity.UserDataExport, cause error) {
+	dataExport.Status = entity.UserDataExportStatusFailed
+	dataExport.ErrMsg = cause.Error()
+	if len(dataExport.ErrMsg) > userDataExportErrMsgMaxLen {
+		dataExport.ErrMsg = dataExport.ErrMsg[:userDataExportErrMsgMaxLen]
+	}
+	if err := us.userDataExportRepo.UpdateUserDataExport(ctx, dataExport, []string{"status", "err_msg"}); err != nil {
+		log.Error(err)
+	}
+}
+
+func (us *UserDataExportService) sendReadyEmail(ctx context.Context, dataExport *entity.UserDataExport,
+	userInfo *entity.User) {
+	if len(userInfo.EMail) == 0 || userInfo.MailStatus != entity.EmailStatusAvailable {
+		return
+	}
+	downloadURL, err := us.downloadURL(ctx, dataExport)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	title, body, err := us.emailService.DataExportReadyTemplate(ctx, downloadURL, dataExport.ExpiredAt)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	us.emailService.Send(ctx, userInfo.EMail, title, body)
+}
+
+func (us *UserDataExportService) formatUserDataExportResp(ctx context.Context, dataExport *entity.UserDataExport) (
+	resp *schema.UserDataExportResp, err error) {
+	resp = schema.NewUserDataExportResp(dataExport)
+	if dataExport.Status != entity.UserDataExportStatusFailed {
+		resp.NextRequestAt = dataExport.CreatedAt.Add(userDataExportInterval).Unix()
+	}
+	if dataExport.Status == entity.UserDataExportStatusCompleted {
+		resp.DownloadURL, err = us.downloadURL(ctx, dataExport)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// downloadURL the signed download link expires at the same time as the archive
+func (us *UserDataExportService) downloadURL(ctx context.Context, dataExport *entity.UserDataExport) (string, error) {
+	siteInfo, err := us.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return "", err
+	}
+	expires := dataExport.ExpiredAt.Unix()
+	query := url.Values{}
+	query.Set("id", dataExport.ID)
+	query.Set("expires", fmt.Sprintf("%d", expires))
+	query.Set("sign", signDownloadLink(dataExport.SignKey, dataExport.ID, expires))
+	return fmt.Sprintf("%s%s?%s", siteInfo.SiteUrl, userDataExportDownloadPath, query.Encode()), nil
+}
+
+func (us *UserDataExportService) exportDir() string {
+	return filepath.Join(filepath.Dir(filepath.Clean(us.serviceConfig.UploadPath)), userDataExportDirName)
+}
+
+func (us *UserDataExportService) removeArchive(fileName string) {
+	if len(fileName) == 0 {
+		return
+	}
+	err := os.Remove(filepath.Join(us.exportDir(), fileName))
+	if err != nil && !os.IsNotExist(err) {
+		log.Error(err)
+	}
+}
+
+func signDownloadLink(signKey, id string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(signKey))
+	mac.Write([]byte(fmt.Sprintf("%s:%d", id, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func archiveFileName(id string) string {
+	return fmt.Sprintf("%s.zip", id)
+}
+
+func archiveTempFileName(id string) string {
+	return fmt.Sprintf("%s.zip.tmp", id)
+}
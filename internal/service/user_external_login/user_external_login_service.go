@@ -36,6 +36,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/internal/service/user_two_factor"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/random"
 	"github.com/apache/incubator-answer/pkg/token"
@@ -65,6 +66,7 @@ type UserExternalLoginService struct {
 	siteInfoCommonService         siteinfo_common.SiteInfoCommonService
 	userActivity                  activity.UserActiveActivityRepo
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	userTwoFactorService          *user_two_factor.UserTwoFactorService
 }
 
 // NewUserExternalLoginService new user external login service
@@ -76,6 +78,7 @@ func NewUserExternalLoginService(
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	userActivity activity.UserActiveActivityRepo,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	userTwoFactorService *user_two_factor.UserTwoFactorService,
 ) *UserExternalLoginService {
 	return &UserExternalLoginService{
 		userRepo:                      userRepo,
@@ -85,6 +88,7 @@ func NewUserExternalLoginService(
 		siteInfoCommonService:         siteInfoCommonService,
 		userActivity:                  userActivity,
 		userNotificationConfigService: userNotificationConfigService,
+		userTwoFactorService:          userTwoFactorService,
 	}
 }
 
@@ -118,9 +122,8 @@ func (us *UserExternalLoginService) ExternalLogin(
 			if err != nil {
 				log.Error(err)
 			}
-			accessToken, _, err := us.userCommonService.CacheLoginUserInfo(
-				ctx, oldUserInfo.ID, newMailStatus, oldUserInfo.Status, oldExternalLoginUserInfo.ExternalID)
-			return &schema.UserExternalLoginResp{AccessToken: accessToken}, err
+			return cacheLoginUserInfo(ctx, us.userCommonService, us.userTwoFactorService, false,
+				oldUserInfo.ID, newMailStatus, oldUserInfo.Status, oldExternalLoginUserInfo.ExternalID)
 		}
 	}
 
@@ -175,8 +178,30 @@ func (us *UserExternalLoginService) ExternalLogin(
 		log.Errorf("set default user notification config failed, err: %v", err)
 	}
 
-	accessToken, _, err := us.userCommonService.CacheLoginUserInfo(
-		ctx, oldUserInfo.ID, newMailStatus, oldUserInfo.Status, oldExternalLoginUserInfo.ExternalID)
+	return cacheLoginUserInfo(ctx, us.userCommonService, us.userTwoFactorService, false,
+		oldUserInfo.ID, newMailStatus, oldUserInfo.Status, oldExternalLoginUserInfo.ExternalID)
+}
+
+// cacheLoginUserInfo login the user, or returns a two-factor token if the user must pass the second factor
+func cacheLoginUserInfo(ctx context.Context, userCommonService *usercommon.UserCommon,
+	userTwoFactorService *user_two_factor.UserTwoFactorService, userCenterLogin bool,
+	userID string, userStatus, emailStatus int, externalID string) (resp *schema.UserExternalLoginResp, err error) {
+	pendingLogin := &schema.TwoFactorPendingLogin{
+		UserID:          userID,
+		ExternalID:      externalID,
+		UserCenterLogin: userCenterLogin,
+	}
+	twoFactorToken, err := userTwoFactorService.NewPendingLoginIfRequired(ctx, pendingLogin)
+	if err != nil {
+		return nil, err
+	}
+	if len(twoFactorToken) > 0 {
+		return &schema.UserExternalLoginResp{
+			TwoFactorToken:          twoFactorToken,
+			TwoFactorEnrollRequired: pendingLogin.EnrollRequired,
+		}, nil
+	}
+	accessToken, _, err := userCommonService.CacheLoginUserInfo(ctx, userID, userStatus, emailStatus, externalID)
 	return &schema.UserExternalLoginResp{AccessToken: accessToken}, err
 }
 
@@ -0,0 +1,358 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_login_security
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/apache/incubator-answer/internal/service/export"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// maxBackoffDelay the upper limit of the delay between two failed attempts
+	maxBackoffDelay = time.Hour
+	// maxBackoffDoubling the backoff delay stops doubling after this number of failed attempts
+	maxBackoffDoubling = 12
+	// maxLockoutDoubling the lockout duration stops doubling after this number of repeated lockouts
+	maxLockoutDoubling = 6
+)
+
+// UserLoginSecurityRepo user login security repository
+type UserLoginSecurityRepo interface {
+	GetUserLoginLockout(ctx context.Context, userID string) (lockout *entity.UserLoginLockout, exist bool, err error)
+	SaveUserLoginLockout(ctx context.Context, lockout *entity.UserLoginLockout) (err error)
+	RemoveUserLoginLockout(ctx context.Context, userID string) (err error)
+	GetEmailLoginLockout(ctx context.Context, email string) (lockout *entity.UserLoginLockout, exist bool, err error)
+	SaveEmailLoginLockout(ctx context.Context, email string, lockout *entity.UserLoginLockout) (err error)
+	GetLockedUserLoginLockoutPage(ctx context.Context, page, pageSize int, now time.Time) (
+		lockoutList []*entity.UserLoginLockout, total int64, err error)
+	AddUserLoginLog(ctx context.Context, loginLog *entity.UserLoginLog) (err error)
+	GetUserLoginLogPage(ctx context.Context, page, pageSize int, cond *entity.UserLoginLog) (
+		loginLogList []*entity.UserLoginLog, total int64, err error)
+	IncreaseIPFailedCount(ctx context.Context, ip string, window time.Duration) (count int64, err error)
+	GetIPLockedUntil(ctx context.Context, ip string) (lockedUntil int64, err error)
+	LockIP(ctx context.Context, ip string, lockedUntil time.Time) (err error)
+}
+
+// UserLoginSecurityService protect the password login from brute-force attacks
+type UserLoginSecurityService struct {
+	userLoginSecurityRepo UserLoginSecurityRepo
+	userRepo              usercommon.UserRepo
+	emailService          *export.EmailService
+	auditLogService       *audit_log.AuditLogService
+}
+
+// NewUserLoginSecurityService new user login security service
+func NewUserLoginSecurityService(
+	userLoginSecurityRepo UserLoginSecurityRepo,
+	userRepo usercommon.UserRepo,
+	emailService *export.EmailService,
+	auditLogService *audit_log.AuditLogService,
+) *UserLoginSecurityService {
+	return &UserLoginSecurityService{
+		userLoginSecurityRepo: userLoginSecurityRepo,
+		userRepo:              userRepo,
+		emailService:          emailService,
+		auditLogService:       auditLogService,
+	}
+}
+
+// CheckLoginAllowed check whether the ip or the account is locked or in backoff before verifying the password
+func (us *UserLoginSecurityService) CheckLoginAllowed(ctx context.Context, siteLogin *schema.SiteLoginResp,
+	attempt *schema.UserLoginAttempt) (err error) {
+	now := time.Now()
+	ipLockedUntil, err := us.userLoginSecurityRepo.GetIPLockedUntil(ctx, attempt.IP)
+	if err != nil {
+		return err
+	}
+	if ipLockedUntil > now.Unix() {
+		us.addLoginLog(ctx, attempt, entity.UserLoginLogStatusFailed, entity.UserLoginLogReasonIPLocked)
+		return us.lockedError(ctx, time.Unix(ipLockedUntil, 0).Sub(now))
+	}
+
+	lockout, exist, err := us.getLoginLockout(ctx, attempt)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return nil
+	}
+	if lockout.IsLocked(now) {
+		us.addLoginLog(ctx, attempt, entity.UserLoginLogStatusFailed, entity.UserLoginLogReasonAccountLocked)
+		return us.lockedError(ctx, lockout.LockedUntil.Sub(now))
+	}
+	delay := backoffDelay(siteLogin, lockout.FailedCount)
+	if nextAllowedAt := lockout.LastFailedAt.Add(delay); delay > 0 && nextAllowedAt.After(now) {
+		us.addLoginLog(ctx, attempt, entity.UserLoginLogStatusFailed, entity.UserLoginLogReasonTooFrequent)
+		msg := translator.TrWithData(handler.GetLangByCtx(ctx), reason.LoginTooFrequent,
+			&schema.LoginLockoutTrTplData{Seconds: ceilSeconds(nextAllowedAt.Sub(now))})
+		return errors.BadRequest(reason.LoginTooFrequent).WithMsg(msg)
+	}
+	return nil
+}
+
+// LoginFailed record the failed attempt, lock the ip or the account if the threshold is reached.
+// The userInfo is nil if no user is found by the email, the email is locked in the same way as an account,
+// so that whether the email is registered can not be told by the lockout.
+func (us *UserLoginSecurityService) LoginFailed(ctx context.Context, siteLogin *schema.SiteLoginResp,
+	attempt *schema.UserLoginAttempt, userInfo *entity.User) {
+	failedReason := entity.UserLoginLogReasonWrongPassword
+	if userInfo == nil {
+		failedReason = entity.UserLoginLogReasonUserNotFound
+	}
+	us.addLoginLog(ctx, attempt, entity.UserLoginLogStatusFailed, failedReason)
+
+	now := time.Now()
+	if siteLogin.LoginIPLockoutThreshold > 0 && len(attempt.IP) > 0 {
+		window := lockoutDuration(siteLogin, 1)
+		count, err := us.userLoginSecurityRepo.IncreaseIPFailedCount(ctx, attempt.IP, window)
+		if err != nil {
+			log.Error(err)
+		} else if count >= int64(siteLogin.LoginIPLockoutThreshold) {
+			log.Warnf("ip %s is locked after %d failed login attempts", attempt.IP, count)
+			if err = us.userLoginSecurityRepo.LockIP(ctx, attempt.IP, now.Add(window)); err != nil {
+				log.Error(err)
+			}
+		}
+	}
+	if len(attempt.UserID) == 0 && len(attempt.Email) == 0 {
+		return
+	}
+
+	lockout, exist, err := us.getLoginLockout(ctx, attempt)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist {
+		lockout = &entity.UserLoginLockout{UserID: attempt.UserID}
+	}
+	lockout.FailedCount++
+	lockout.LastFailedAt = now
+	lockout.LastFailedIP = attempt.IP
+	locked := false
+	if siteLogin.LoginLockoutThreshold > 0 && lockout.FailedCount >= siteLogin.LoginLockoutThreshold {
+		lockout.LockoutCount++
+		lockout.LockedUntil = now.Add(lockoutDuration(siteLogin, lockout.LockoutCount))
+		lockout.FailedCount = 0
+		locked = true
+	}
+	if len(attempt.UserID) > 0 {
+		err = us.userLoginSecurityRepo.SaveUserLoginLockout(ctx, lockout)
+	} else {
+		err = us.userLoginSecurityRepo.SaveEmailLoginLockout(ctx, attempt.Email, lockout)
+	}
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if locked && userInfo != nil {
+		log.Warnf("user %s is locked until %s after too many failed login attempts",
+			userInfo.ID, lockout.LockedUntil.Format(time.RFC3339))
+		us.sendLockoutEmail(ctx, userInfo, attempt.IP, lockout.LockedUntil)
+	}
+}
+
+// getLoginLockout get the failed login state of the account, or of the email if no user is found by it
+func (us *UserLoginSecurityService) getLoginLockout(ctx context.Context, attempt *schema.UserLoginAttempt) (
+	lockout *entity.UserLoginLockout, exist bool, err error) {
+	if len(attempt.UserID) > 0 {
+		return us.userLoginSecurityRepo.GetUserLoginLockout(ctx, attempt.UserID)
+	}
+	return us.userLoginSecurityRepo.GetEmailLoginLockout(ctx, attempt.Email)
+}
+
+// LoginSucceeded record the successful attempt and reset the failed state of the account.
+// It must be called after the second factor is passed if it is required.
+func (us *UserLoginSecurityService) LoginSucceeded(ctx context.Context, attempt *schema.UserLoginAttempt) {
+	us.addLoginLog(ctx, attempt, entity.UserLoginLogStatusSuccess, "")
+	if err := us.userLoginSecurityRepo.RemoveUserLoginLockout(ctx, attempt.UserID); err != nil {
+		log.Error(err)
+	}
+}
+
+// GetLockedUserPage get the accounts which are locked now
+func (us *UserLoginSecurityService) GetLockedUserPage(ctx context.Context, req *schema.GetLockedUserPageReq) (
+	pageModel *pager.PageModel, err error) {
+	lockoutList, total, err := us.userLoginSecurityRepo.GetLockedUserLoginLockoutPage(ctx,
+		req.Page, req.PageSize, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(lockoutList))
+	for _, lockout := range lockoutList {
+		userIDs = append(userIDs, lockout.UserID)
+	}
+	userList, err := us.userRepo.BatchGetByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userMapping := make(map[string]*entity.User, len(userList))
+	for _, user := range userList {
+		userMapping[user.ID] = user
+	}
+
+	resp := make([]*schema.LockedUserResp, 0, len(lockoutList))
+	for _, lockout := range lockoutList {
+		item := &schema.LockedUserResp{
+			UserID:       lockout.UserID,
+			LockoutCount: lockout.LockoutCount,
+			LastFailedAt: lockout.LastFailedAt.Unix(),
+			LastFailedIP: lockout.LastFailedIP,
+			LockedUntil:  lockout.LockedUntil.Unix(),
+		}
+		if user, ok := userMapping[lockout.UserID]; ok {
+			item.Username = user.Username
+			item.DisplayName = user.DisplayName
+			item.EMail = user.EMail
+		}
+		resp = append(resp, item)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// UnlockUser unlock the account and reset its failed state
+func (us *UserLoginSecurityService) UnlockUser(ctx context.Context, req *schema.UnlockUserReq) (err error) {
+	_, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	if err = us.userLoginSecurityRepo.RemoveUserLoginLockout(ctx, req.UserID); err != nil {
+		return err
+	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionUserUnlock,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+	})
+	return nil
+}
+
+// GetUserLoginLogPage get the login audit log
+func (us *UserLoginSecurityService) GetUserLoginLogPage(ctx context.Context, req *schema.GetUserLoginLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	cond := &entity.UserLoginLog{
+		UserID: req.UserID,
+		IP:     req.IP,
+		Status: schema.UserLoginLogStatusMapping[req.Status],
+	}
+	loginLogList, total, err := us.userLoginSecurityRepo.GetUserLoginLogPage(ctx, req.Page, req.PageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*schema.UserLoginLogResp, 0, len(loginLogList))
+	for _, loginLog := range loginLogList {
+		resp = append(resp, schema.NewUserLoginLogResp(loginLog))
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+func (us *UserLoginSecurityService) addLoginLog(ctx context.Context, attempt *schema.UserLoginAttempt,
+	status int, failedReason string) {
+	loginLog := &entity.UserLoginLog{
+		UserID:    attempt.UserID,
+		Email:     attempt.Email,
+		IP:        attempt.IP,
+		UserAgent: attempt.UserAgent,
+		Status:    status,
+		Reason:    failedReason,
+	}
+	if len(loginLog.UserID) == 0 {
+		loginLog.UserID = "0"
+	}
+	if len(loginLog.UserAgent) > 255 {
+		loginLog.UserAgent = loginLog.UserAgent[:255]
+	}
+	if err := us.userLoginSecurityRepo.AddUserLoginLog(ctx, loginLog); err != nil {
+		log.Error(err)
+	}
+}
+
+func (us *UserLoginSecurityService) lockedError(ctx context.Context, remaining time.Duration) error {
+	msg := translator.TrWithData(handler.GetLangByCtx(ctx), reason.LoginLocked,
+		&schema.LoginLockoutTrTplData{Seconds: ceilSeconds(remaining)})
+	return errors.Forbidden(reason.LoginLocked).WithMsg(msg)
+}
+
+func (us *UserLoginSecurityService) sendLockoutEmail(ctx context.Context, userInfo *entity.User,
+	ip string, lockedUntil time.Time) {
+	if len(userInfo.EMail) == 0 || userInfo.MailStatus != entity.EmailStatusAvailable {
+		return
+	}
+	title, body, err := us.emailService.LoginLockoutTemplate(ctx, ip, lockedUntil)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	go us.emailService.Send(ctx, userInfo.EMail, title, body)
+}
+
+// backoffDelay the delay required after the last failed attempt of the account
+func backoffDelay(siteLogin *schema.SiteLoginResp, failedCount int) time.Duration {
+	if siteLogin.LoginBackoffThreshold <= 0 || siteLogin.LoginBackoffBaseSeconds <= 0 ||
+		failedCount < siteLogin.LoginBackoffThreshold {
+		return 0
+	}
+	doubling := failedCount - siteLogin.LoginBackoffThreshold
+	if doubling > maxBackoffDoubling {
+		doubling = maxBackoffDoubling
+	}
+	delay := time.Duration(siteLogin.LoginBackoffBaseSeconds) * time.Second << doubling
+	if delay > maxBackoffDelay {
+		return maxBackoffDelay
+	}
+	return delay
+}
+
+// lockoutDuration the duration of the nth lockout of the account
+func lockoutDuration(siteLogin *schema.SiteLoginResp, lockoutCount int) time.Duration {
+	minutes := siteLogin.LoginLockoutMinutes
+	if minutes <= 0 {
+		minutes = schema.DefaultLoginLockoutMinutes
+	}
+	doubling := lockoutCount - 1
+	if doubling > maxLockoutDoubling {
+		doubling = maxLockoutDoubling
+	}
+	if doubling < 0 {
+		doubling = 0
+	}
+	return time.Duration(minutes) * time.Minute << doubling
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
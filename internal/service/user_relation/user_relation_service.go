@@ -0,0 +1,166 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_relation
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// UserRelationRepo user relation repository
+type UserRelationRepo interface {
+	AddUserRelation(ctx context.Context, relation *entity.UserRelation) (err error)
+	RemoveUserRelation(ctx context.Context, userID, targetUserID string, relationType int) (err error)
+	ExistUserRelation(ctx context.Context, userID, targetUserID string, relationType int) (exist bool, err error)
+	GetUserRelationPage(ctx context.Context, userID string, relationType, page, pageSize int) (
+		relationList []*entity.UserRelation, total int64, err error)
+	GetTargetUserIDs(ctx context.Context, userID string, relationType int) (targetUserIDs []string, err error)
+}
+
+// UserRelationService user block and mute service
+type UserRelationService struct {
+	userRelationRepo UserRelationRepo
+	userCommon       *usercommon.UserCommon
+}
+
+// NewUserRelationService new user block and mute service
+func NewUserRelationService(
+	userRelationRepo UserRelationRepo,
+	userCommon *usercommon.UserCommon,
+) *UserRelationService {
+	return &UserRelationService{
+		userRelationRepo: userRelationRepo,
+		userCommon:       userCommon,
+	}
+}
+
+// GetUserRelationPage get the blocked or muted users of the user
+func (us *UserRelationService) GetUserRelationPage(ctx context.Context, req *schema.GetUserRelationPageReq) (
+	pageModel *pager.PageModel, err error) {
+	relationList, total, err := us.userRelationRepo.GetUserRelationPage(ctx, req.UserID,
+		entity.UserRelationTypeMapping[req.Type], req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(relationList))
+	for _, relation := range relationList {
+		userIDs = append(userIDs, relation.TargetUserID)
+	}
+	userInfoMapping, err := us.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*schema.UserRelationResp, 0, len(relationList))
+	for _, relation := range relationList {
+		userInfo, ok := userInfoMapping[relation.TargetUserID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.UserRelationResp{
+			UserInfo:  userInfo,
+			Type:      req.Type,
+			CreatedAt: relation.CreatedAt.Unix(),
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// AddUserRelation block or mute a user
+func (us *UserRelationService) AddUserRelation(ctx context.Context, req *schema.AddUserRelationReq) (err error) {
+	targetUser, err := us.getTargetUser(ctx, req.UserID, req.Username)
+	if err != nil {
+		return err
+	}
+	return us.userRelationRepo.AddUserRelation(ctx, &entity.UserRelation{
+		UserID:       req.UserID,
+		TargetUserID: targetUser.ID,
+		RelationType: entity.UserRelationTypeMapping[req.Type],
+	})
+}
+
+// RemoveUserRelation unblock or unmute a user
+func (us *UserRelationService) RemoveUserRelation(ctx context.Context, req *schema.RemoveUserRelationReq) (err error) {
+	targetUser, err := us.getTargetUser(ctx, req.UserID, req.Username)
+	if err != nil {
+		return err
+	}
+	return us.userRelationRepo.RemoveUserRelation(ctx, req.UserID, targetUser.ID,
+		entity.UserRelationTypeMapping[req.Type])
+}
+
+// IsBlocked check whether the user is blocked by the blocker
+func (us *UserRelationService) IsBlocked(ctx context.Context, blockerUserID, userID string) (blocked bool, err error) {
+	if len(blockerUserID) == 0 || len(userID) == 0 || blockerUserID == userID {
+		return false, nil
+	}
+	return us.userRelationRepo.ExistUserRelation(ctx, blockerUserID, userID, entity.UserRelationTypeBlock)
+}
+
+// CheckBlocked return a forbidden error if the user is blocked by any of the blockers
+func (us *UserRelationService) CheckBlocked(ctx context.Context, userID string, blockerUserIDs ...string) (err error) {
+	for _, blockerUserID := range blockerUserIDs {
+		blocked, err := us.IsBlocked(ctx, blockerUserID, userID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return errors.Forbidden(reason.UserBlocked)
+		}
+	}
+	return nil
+}
+
+// GetMutedUserIDs get the ids of users muted by the user
+func (us *UserRelationService) GetMutedUserIDs(ctx context.Context, userID string) (
+	mutedUserIDs map[string]bool, err error) {
+	mutedUserIDs = make(map[string]bool)
+	if len(userID) == 0 {
+		return mutedUserIDs, nil
+	}
+	targetUserIDs, err := us.userRelationRepo.GetTargetUserIDs(ctx, userID, entity.UserRelationTypeMute)
+	if err != nil {
+		return nil, err
+	}
+	for _, targetUserID := range targetUserIDs {
+		mutedUserIDs[targetUserID] = true
+	}
+	return mutedUserIDs, nil
+}
+
+func (us *UserRelationService) getTargetUser(ctx context.Context, userID, username string) (
+	targetUser *schema.UserBasicInfo, err error) {
+	targetUser, exist, err := us.userCommon.GetUserBasicInfoByUserName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	if targetUser.ID == userID {
+		return nil, errors.BadRequest(reason.UserRelationCannotSelf)
+	}
+	return targetUser, nil
+}
@@ -0,0 +1,427 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_two_factor
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/encryption"
+	"github.com/apache/incubator-answer/pkg/token"
+	"github.com/apache/incubator-answer/pkg/totp"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// recoveryCodeCount the number of recovery codes generated each time
+	recoveryCodeCount = 10
+	// recoveryCodeBytes the random bytes of each recovery code, formatted as xxxxx-xxxxx
+	recoveryCodeBytes = 5
+	// codeSkew the number of time steps before and after the current one are accepted
+	codeSkew = 1
+	// pendingLoginTokenBytes the random bytes of the two-factor token
+	pendingLoginTokenBytes = 20
+	// pendingLoginMaxAttempts the login must be restarted after too many wrong codes
+	pendingLoginMaxAttempts = 5
+)
+
+// UserTwoFactorRepo user two-factor authentication repository
+type UserTwoFactorRepo interface {
+	GetUserTwoFactor(ctx context.Context, userID string) (twoFactor *entity.UserTwoFactor, exist bool, err error)
+	SaveUserTwoFactor(ctx context.Context, twoFactor *entity.UserTwoFactor) (err error)
+	UpdateUserTwoFactorUsage(ctx context.Context, twoFactor *entity.UserTwoFactor,
+		oldRecoveryCodes string, oldLastUsedStep int64) (updated bool, err error)
+	RemoveUserTwoFactor(ctx context.Context, userID string) (err error)
+	SetPendingLogin(ctx context.Context, token string, pendingLogin *schema.TwoFactorPendingLogin) (err error)
+	GetPendingLogin(ctx context.Context, token string) (pendingLogin *schema.TwoFactorPendingLogin, err error)
+	RemovePendingLogin(ctx context.Context, token string) (err error)
+	IncreasePendingLoginAttempts(ctx context.Context, token string) (attempts int64, err error)
+}
+
+// UserTwoFactorService user two-factor authentication service
+type UserTwoFactorService struct {
+	userTwoFactorRepo     UserTwoFactorRepo
+	userRepo              usercommon.UserRepo
+	userRoleRelService    *role.UserRoleRelService
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	auditLogService       *audit_log.AuditLogService
+}
+
+// NewUserTwoFactorService new user two-factor authentication service
+func NewUserTwoFactorService(
+	userTwoFactorRepo UserTwoFactorRepo,
+	userRepo usercommon.UserRepo,
+	userRoleRelService *role.UserRoleRelService,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	auditLogService *audit_log.AuditLogService,
+) *UserTwoFactorService {
+	return &UserTwoFactorService{
+		userTwoFactorRepo:     userTwoFactorRepo,
+		userRepo:              userRepo,
+		userRoleRelService:    userRoleRelService,
+		siteInfoCommonService: siteInfoCommonService,
+		auditLogService:       auditLogService,
+	}
+}
+
+// GetUserTwoFactor get the two-factor authentication status of user
+func (us *UserTwoFactorService) GetUserTwoFactor(ctx context.Context, userID string) (
+	resp *schema.GetUserTwoFactorResp, err error) {
+	resp = &schema.GetUserTwoFactorResp{}
+	resp.Required, err = us.isTwoFactorRequired(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	twoFactor, exist, err := us.userTwoFactorRepo.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if exist && twoFactor.Status == entity.UserTwoFactorStatusEnabled {
+		resp.Enabled = true
+		resp.RecoveryCodesRemaining = len(splitRecoveryCodes(twoFactor.RecoveryCodes))
+	}
+	return resp, nil
+}
+
+// EnrollUserTwoFactor generate a new secret for user, it takes effect after confirmed by EnableUserTwoFactor
+func (us *UserTwoFactorService) EnrollUserTwoFactor(ctx context.Context, userID string) (
+	resp *schema.UserTwoFactorEnrollResp, err error) {
+	twoFactor, exist, err := us.userTwoFactorRepo.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if exist && twoFactor.Status == entity.UserTwoFactorStatusEnabled {
+		return nil, errors.BadRequest(reason.TwoFactorAlreadyEnabled)
+	}
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	err = us.userTwoFactorRepo.SaveUserTwoFactor(ctx, &entity.UserTwoFactor{
+		UserID: userID,
+		Secret: secret,
+		Status: entity.UserTwoFactorStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := ""
+	if siteGeneral, err := us.siteInfoCommonService.GetSiteGeneral(ctx); err != nil {
+		log.Error(err)
+	} else {
+		issuer = siteGeneral.Name
+	}
+	return &schema.UserTwoFactorEnrollResp{
+		Secret: secret,
+		KeyURI: totp.KeyURI(issuer, userInfo.EMail, secret),
+	}, nil
+}
+
+// EnableUserTwoFactor confirm the enrolled secret by a code, returns the recovery codes
+func (us *UserTwoFactorService) EnableUserTwoFactor(ctx context.Context, req *schema.UserTwoFactorCodeReq) (
+	resp *schema.UserTwoFactorRecoveryCodesResp, err error) {
+	twoFactor, exist, err := us.userTwoFactorRepo.GetUserTwoFactor(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.TwoFactorNotEnabled)
+	}
+	if twoFactor.Status == entity.UserTwoFactorStatusEnabled {
+		return nil, errors.BadRequest(reason.TwoFactorAlreadyEnabled)
+	}
+	step, ok := totp.Validate(twoFactor.Secret, req.Code, time.Now(), codeSkew)
+	if !ok {
+		return nil, errors.BadRequest(reason.TwoFactorCodeInvalid)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	twoFactor.Status = entity.UserTwoFactorStatusEnabled
+	twoFactor.RecoveryCodes = hashes
+	twoFactor.LastUsedStep = step
+	if err = us.userTwoFactorRepo.SaveUserTwoFactor(ctx, twoFactor); err != nil {
+		return nil, err
+	}
+	return &schema.UserTwoFactorRecoveryCodesResp{RecoveryCodes: codes}, nil
+}
+
+// DisableUserTwoFactor disable two-factor authentication, confirmed by a code
+func (us *UserTwoFactorService) DisableUserTwoFactor(ctx context.Context, req *schema.UserTwoFactorCodeReq) (err error) {
+	required, err := us.isTwoFactorRequired(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return errors.BadRequest(reason.TwoFactorRequired)
+	}
+	if err = us.verifyUserCode(ctx, req.UserID, req.Code); err != nil {
+		return err
+	}
+	return us.userTwoFactorRepo.RemoveUserTwoFactor(ctx, req.UserID)
+}
+
+// RegenerateRecoveryCodes replace all recovery codes, confirmed by a code
+func (us *UserTwoFactorService) RegenerateRecoveryCodes(ctx context.Context, req *schema.UserTwoFactorCodeReq) (
+	resp *schema.UserTwoFactorRecoveryCodesResp, err error) {
+	if err = us.verifyUserCode(ctx, req.UserID, req.Code); err != nil {
+		return nil, err
+	}
+	twoFactor, exist, err := us.userTwoFactorRepo.GetUserTwoFactor(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.TwoFactorNotEnabled)
+	}
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	twoFactor.RecoveryCodes = hashes
+	if err = us.userTwoFactorRepo.SaveUserTwoFactor(ctx, twoFactor); err != nil {
+		return nil, err
+	}
+	return &schema.UserTwoFactorRecoveryCodesResp{RecoveryCodes: codes}, nil
+}
+
+// AdminResetUserTwoFactor remove the two-factor authentication of the user who lost the device and recovery codes.
+// If the user is staff and the site requires two-factor, the user will enroll again when login.
+func (us *UserTwoFactorService) AdminResetUserTwoFactor(ctx context.Context, req *schema.AdminResetUserTwoFactorReq) (err error) {
+	_, exist, err := us.userTwoFactorRepo.GetUserTwoFactor(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TwoFactorNotEnabled)
+	}
+	log.Infof("admin %s reset two-factor authentication of user %s", req.OperatorID, req.UserID)
+	if err = us.userTwoFactorRepo.RemoveUserTwoFactor(ctx, req.UserID); err != nil {
+		return err
+	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.OperatorID,
+		Action:     entity.AuditLogActionUserResetTwoFactor,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+	})
+	return nil
+}
+
+// NewPendingLoginIfRequired returns a two-factor token if the user must pass the second factor to login,
+// the token is empty if the login can be completed directly. The EnrollRequired of pending login is set.
+func (us *UserTwoFactorService) NewPendingLoginIfRequired(ctx context.Context,
+	pendingLogin *schema.TwoFactorPendingLogin) (twoFactorToken string, err error) {
+	twoFactor, exist, err := us.userTwoFactorRepo.GetUserTwoFactor(ctx, pendingLogin.UserID)
+	if err != nil {
+		return "", err
+	}
+	if !exist || twoFactor.Status != entity.UserTwoFactorStatusEnabled {
+		pendingLogin.EnrollRequired, err = us.isTwoFactorRequired(ctx, pendingLogin.UserID)
+		if err != nil {
+			return "", err
+		}
+		if !pendingLogin.EnrollRequired {
+			return "", nil
+		}
+	}
+
+	twoFactorToken, err = token.GenerateSecureToken(pendingLoginTokenBytes)
+	if err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = us.userTwoFactorRepo.SetPendingLogin(ctx, twoFactorToken, pendingLogin); err != nil {
+		return "", err
+	}
+	return twoFactorToken, nil
+}
+
+// EnrollPendingLogin enroll two-factor authentication for the login which requires it
+func (us *UserTwoFactorService) EnrollPendingLogin(ctx context.Context, req *schema.UserTwoFactorLoginEnrollReq) (
+	resp *schema.UserTwoFactorEnrollResp, err error) {
+	pendingLogin, err := us.userTwoFactorRepo.GetPendingLogin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if pendingLogin == nil || !pendingLogin.EnrollRequired {
+		return nil, errors.BadRequest(reason.TwoFactorTokenInvalid)
+	}
+	return us.EnrollUserTwoFactor(ctx, pendingLogin.UserID)
+}
+
+// VerifyPendingLogin verify the second factor of the login. If the two-factor authentication is enrolled during
+// the login, it will be enabled and the recovery codes are returned.
+func (us *UserTwoFactorService) VerifyPendingLogin(ctx context.Context, req *schema.UserTwoFactorLoginReq) (
+	pendingLogin *schema.TwoFactorPendingLogin, recoveryCodes []string, err error) {
+	pendingLogin, err = us.userTwoFactorRepo.GetPendingLogin(ctx, req.Token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pendingLogin == nil {
+		return nil, nil, errors.BadRequest(reason.TwoFactorTokenInvalid)
+	}
+	// the attempt is counted before the code is checked, so the parallel guesses can not exceed the limit
+	attempts, err := us.userTwoFactorRepo.IncreasePendingLoginAttempts(ctx, req.Token)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, errors.BadRequest(reason.TwoFactorTokenInvalid)
+	}
+	if attempts > pendingLoginMaxAttempts {
+		if removeErr := us.userTwoFactorRepo.RemovePendingLogin(ctx, req.Token); removeErr != nil {
+			log.Error(removeErr)
+		}
+		return nil, nil, errors.BadRequest(reason.TwoFactorTokenInvalid)
+	}
+
+	if pendingLogin.EnrollRequired {
+		var resp *schema.UserTwoFactorRecoveryCodesResp
+		resp, err = us.EnableUserTwoFactor(ctx, &schema.UserTwoFactorCodeReq{Code: req.Code, UserID: pendingLogin.UserID})
+		if resp != nil {
+			recoveryCodes = resp.RecoveryCodes
+		}
+	} else {
+		err = us.verifyUserCode(ctx, pendingLogin.UserID, req.Code)
+	}
+	if err != nil {
+		// the last wrong code ends the login
+		if e, ok := err.(*errors.Error); ok && errors.IsBadRequest(e) && attempts >= pendingLoginMaxAttempts {
+			if removeErr := us.userTwoFactorRepo.RemovePendingLogin(ctx, req.Token); removeErr != nil {
+				log.Error(removeErr)
+			}
+			return nil, nil, errors.BadRequest(reason.TwoFactorTokenInvalid)
+		}
+		return nil, nil, err
+	}
+
+	if err = us.userTwoFactorRepo.RemovePendingLogin(ctx, req.Token); err != nil {
+		return nil, nil, err
+	}
+	return pendingLogin, recoveryCodes, nil
+}
+
+// verifyUserCode verify the totp code or recovery code of the user, each code can only be used once
+func (us *UserTwoFactorService) verifyUserCode(ctx context.Context, userID, code string) (err error) {
+	twoFactor, exist, err := us.userTwoFactorRepo.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exist || twoFactor.Status != entity.UserTwoFactorStatusEnabled {
+		return errors.BadRequest(reason.TwoFactorNotEnabled)
+	}
+	oldRecoveryCodes, oldLastUsedStep := twoFactor.RecoveryCodes, twoFactor.LastUsedStep
+
+	if step, ok := totp.Validate(twoFactor.Secret, code, time.Now(), codeSkew); ok {
+		if step <= twoFactor.LastUsedStep {
+			return errors.BadRequest(reason.TwoFactorCodeInvalid)
+		}
+		twoFactor.LastUsedStep = step
+	} else {
+		codeHash := encryption.SHA256(normalizeRecoveryCode(code))
+		hashes := splitRecoveryCodes(twoFactor.RecoveryCodes)
+		remaining := make([]string, 0, len(hashes))
+		for _, hash := range hashes {
+			if subtle.ConstantTimeCompare([]byte(hash), []byte(codeHash)) == 1 {
+				continue
+			}
+			remaining = append(remaining, hash)
+		}
+		if len(remaining) == len(hashes) {
+			return errors.BadRequest(reason.TwoFactorCodeInvalid)
+		}
+		twoFactor.RecoveryCodes = strings.Join(remaining, ",")
+	}
+
+	updated, err := us.userTwoFactorRepo.UpdateUserTwoFactorUsage(ctx, twoFactor, oldRecoveryCodes, oldLastUsedStep)
+	if err != nil {
+		return err
+	}
+	// the same code is used by another request at the same time
+	if !updated {
+		return errors.BadRequest(reason.TwoFactorCodeInvalid)
+	}
+	return nil
+}
+
+// isTwoFactorRequired whether the site requires the user to enable two-factor authentication
+func (us *UserTwoFactorService) isTwoFactorRequired(ctx context.Context, userID string) (required bool, err error) {
+	siteLogin, err := us.siteInfoCommonService.GetSiteLogin(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !siteLogin.RequireTwoFactorForStaff {
+		return false, nil
+	}
+	roleID, err := us.userRoleRelService.GetUserRole(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return roleID == role.RoleAdminID || roleID == role.RoleModeratorID, nil
+}
+
+// generateRecoveryCodes generate recovery codes, returns the plain codes and the hashes joined by ','
+func generateRecoveryCodes() (codes []string, hashes string, err error) {
+	codes = make([]string, 0, recoveryCodeCount)
+	hashList := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		randomCode, err := token.GenerateSecureToken(recoveryCodeBytes)
+		if err != nil {
+			return nil, "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		codes = append(codes, fmt.Sprintf("%s-%s", randomCode[:recoveryCodeBytes], randomCode[recoveryCodeBytes:]))
+		hashList = append(hashList, encryption.SHA256(randomCode))
+	}
+	return codes, strings.Join(hashList, ","), nil
+}
+
+// normalizeRecoveryCode the recovery code is case-insensitive and the separator is optional
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
+
+func splitRecoveryCodes(recoveryCodes string) []string {
+	if len(recoveryCodes) == 0 {
+		return nil
+	}
+	return strings.Split(recoveryCodes, ",")
+}
@@ -39,9 +39,12 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
 	articlecommon "github.com/apache/incubator-answer/internal/service/article_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/mention_common"
 	metacommon "github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
@@ -89,6 +92,9 @@ type ArticleService struct {
 	reviewService                    *review.ReviewService
 	configService                    *config.ConfigService
 	eventQueueService                event_queue.EventQueueService
+	mentionCommon                    *mention_common.MentionCommon
+	contentFilterService             *content_filter.ContentFilterService
+	auditLogService                  *audit_log.AuditLogService
 }
 
 func NewArticleService(
@@ -114,6 +120,9 @@ func NewArticleService(
 	reviewService *review.ReviewService,
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
+	mentionCommon *mention_common.MentionCommon,
+	contentFilterService *content_filter.ContentFilterService,
+	auditLogService *audit_log.AuditLogService,
 ) *ArticleService {
 	return &ArticleService{
 		activityRepo:                     activityRepo,
@@ -138,6 +147,9 @@ func NewArticleService(
 		reviewService:                    reviewService,
 		configService:                    configService,
 		eventQueueService:                eventQueueService,
+		mentionCommon:                    mentionCommon,
+		contentFilterService:             contentFilterService,
+		auditLogService:                  auditLogService,
 	}
 }
 
@@ -158,6 +170,7 @@ func (qs *ArticleService) CloseArticle(ctx context.Context, req *schema.CloseArt
 		return errors.BadRequest(reason.InvalidURLError)
 	}
 
+	oldStatus := articleInfo.Status
 	articleInfo.Status = entity.ArticleStatusClosed
 	err = qs.articleRepo.UpdateArticleStatus(ctx, articleInfo.ID, articleInfo.Status)
 	if err != nil {
@@ -172,6 +185,17 @@ func (qs *ArticleService) CloseArticle(ctx context.Context, req *schema.CloseArt
 	if err != nil {
 		return err
 	}
+	// the author closing the own article is not a moderator action
+	if articleInfo.UserID != req.UserID {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionContentClose,
+			ObjectType: constant.ArticleObjectType,
+			ObjectID:   articleInfo.ID,
+			Before:     map[string]int{"status": oldStatus},
+			After:      json.RawMessage(closeMeta),
+		})
+	}
 
 	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
@@ -274,6 +298,13 @@ func (qs *ArticleService) HasNewTag(ctx context.Context, tags []*schema.TagItem)
 
 // AddArticle add article
 func (qs *ArticleService) AddArticle(ctx context.Context, req *schema.ArticleAdd) (articleInfo any, err error) {
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.ArticleObjectType, "").
+		AddText(schema.ContentFilterFieldTitle, &req.Title).
+		AddContent(&req.Content, &req.HTML, req.ContentFormat == schema.ArticleContentFormat_HTML).
+		AddTags(req.Tags)
+	if err = qs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
 	if len(req.Tags) == 0 {
 		errorlist := make([]*validator.FormErrorField, 0)
 		errorlist = append(errorlist, &validator.FormErrorField{
@@ -327,7 +358,7 @@ func (qs *ArticleService) AddArticle(ctx context.Context, req *schema.ArticleAdd
 	article.Title = req.Title
 	article.OriginalText = req.Content
 	log.Infof("addArticle content:%s", req.Content)
-	article.ParsedText = req.HTML
+	article.ParsedText = qs.mentionCommon.LinkMentions(ctx, req.HTML)
 	//article.AcceptedAnswerID = "0"
 	//article.LastAnswerID = "0"
 	//article.LastEditUserID = "0"
@@ -401,6 +432,14 @@ func (qs *ArticleService) AddArticle(ctx context.Context, req *schema.ArticleAdd
 	if article.Status == entity.ArticleStatusAvailable {
 		qs.externalNotificationQueueService.Send(ctx,
 			schema.CreateNewArticleNotificationMsg(article.ID, article.Title, article.UserID, tags))
+		qs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+			ObjectID:      article.ID,
+			ObjectType:    constant.ArticleObjectType,
+			Title:         article.Title,
+			QuestionID:    article.ID,
+			TriggerUserID: article.UserID,
+			ParsedText:    article.ParsedText,
+		})
 	}
 	qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventArticleCreate, req.UserID).TID(article.ID).
 		QID(article.ID, article.UserID))
@@ -426,6 +465,7 @@ func (qs *ArticleService) OperationArticle(ctx context.Context, req *schema.Oper
 	if articleInfo.Pin == entity.ArticlePin && req.Operation == schema.ArticleOperationHide {
 		return nil
 	}
+	before := map[string]int{"show": articleInfo.Show, "pin": articleInfo.Pin}
 
 	switch req.Operation {
 	case schema.ArticleOperationHide:
@@ -458,6 +498,14 @@ func (qs *ArticleService) OperationArticle(ctx context.Context, req *schema.Oper
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentOperate,
+		ObjectType: constant.ArticleObjectType,
+		ObjectID:   articleInfo.ID,
+		Before:     before,
+		After:      map[string]int{"show": articleInfo.Show, "pin": articleInfo.Pin},
+	})
 
 	actMap := make(map[string]constant.ActivityTypeKey)
 	actMap[schema.ArticleOperationPin] = constant.ActArticlePin
@@ -517,11 +565,23 @@ func (qs *ArticleService) RemoveArticle(ctx context.Context, req *schema.RemoveA
 		//}
 	}
 
+	oldStatus := articleInfo.Status
 	articleInfo.Status = entity.ArticleStatusDeleted
 	err = qs.articleRepo.UpdateArticleStatusWithOutUpdateTime(ctx, articleInfo)
 	if err != nil {
 		return err
 	}
+	// the author removing the own article is not a moderator action
+	if articleInfo.UserID != req.UserID {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionContentRemove,
+			ObjectType: constant.ArticleObjectType,
+			ObjectID:   articleInfo.ID,
+			Before:     map[string]int{"status": oldStatus},
+			After:      map[string]int{"status": articleInfo.Status},
+		})
+	}
 
 	userArticleCount, err := qs.articlecommon.GetUserArticleCount(ctx, articleInfo.UserID)
 	if err != nil {
@@ -678,7 +738,7 @@ func (qs *ArticleService) RecoverArticle(ctx context.Context, req *schema.Articl
 		tagIDs = append(tagIDs, v.ID)
 	}
 	if len(tagIDs) > 0 {
-		if err = qs.tagCommon.RefreshTagQuestionCount(ctx, tagIDs); err != nil {
+		if err = qs.tagCommon.RefreshTagArticleCount(ctx, tagIDs); err != nil {
 			log.Errorf("update tag's article count failed, %v", err)
 		}
 	}
@@ -828,13 +888,20 @@ func (qs *ArticleService) UpdateArticle(ctx context.Context, req *schema.Article
 		err = errors.BadRequest(reason.ArticleCannotUpdate)
 		return nil, err
 	}
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.ArticleObjectType, req.ID).
+		AddText(schema.ContentFilterFieldTitle, &req.Title).
+		AddContent(&req.Content, &req.HTML, req.ContentFormat != schema.ArticleContentFormat_MARKDOWN).
+		AddTags(req.Tags)
+	if err = qs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
 	log.Infof("UpdateArticle b:%s", req.Content)
 	log.Infof("UpdateArticle b html:%s", req.HTML)
 	now := time.Now()
 	article := &entity.Article{}
 	article.Title = req.Title
 	article.OriginalText = req.Content
-	article.ParsedText = req.HTML
+	article.ParsedText = qs.mentionCommon.LinkMentions(ctx, req.HTML)
 	article.ID = uid.DeShortID(req.ID)
 	article.UpdatedAt = now
 	article.PostUpdateTime = now
@@ -955,6 +1022,9 @@ func (qs *ArticleService) UpdateArticle(ctx context.Context, req *schema.Article
 	if err != nil {
 		return
 	}
+	if !canUpdate {
+		qs.reviewService.AddEditReview(ctx, req.UserID, article.ID, revisionID, article.Title, article.ParsedText)
+	}
 	if canUpdate {
 		qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           req.UserID,
@@ -965,6 +1035,17 @@ func (qs *ArticleService) UpdateArticle(ctx context.Context, req *schema.Article
 		})
 		qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventArticleUpdate, req.UserID).TID(article.ID).
 			QID(article.ID, article.UserID))
+		if dbinfo.Status != entity.ArticleStatusPending {
+			qs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+				ObjectID:      article.ID,
+				ObjectType:    constant.ArticleObjectType,
+				Title:         article.Title,
+				QuestionID:    article.ID,
+				TriggerUserID: req.UserID,
+				ParsedText:    article.ParsedText,
+				OldParsedText: dbinfo.ParsedText,
+			})
+		}
 	}
 
 	articleInfo, err = qs.GetArticle(ctx, article.ID, article.UserID, req.ArticlePermission)
@@ -1358,6 +1439,15 @@ func (qs *ArticleService) GetArticlePage(ctx context.Context, req *schema.Articl
 		}
 	}
 
+	// include the content of all descendant tags, the last one of tag ids is the main tag
+	if req.IncludeDescendants && len(tagIDs) > 0 {
+		descendantTagIDs, err := qs.tagCommon.GetDescendantTagIDsWithSynonyms(ctx, tagIDs[len(tagIDs)-1])
+		if err != nil {
+			return nil, 0, err
+		}
+		tagIDs = append(tagIDs, descendantTagIDs...)
+	}
+
 	// query by user condition
 	if req.Username != "" {
 		userinfo, exist, err := qs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
@@ -1443,6 +1533,14 @@ func (qs *ArticleService) AdminSetArticleStatus(ctx context.Context, req *schema
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentUpdateStatus,
+		ObjectType: constant.ArticleObjectType,
+		ObjectID:   articleInfo.ID,
+		Before:     map[string]int{"status": articleInfo.Status},
+		After:      map[string]int{"status": setStatus},
+	})
 
 	msg := &schema.NotificationMsg{}
 	if setStatus == entity.ArticleStatusDeleted {
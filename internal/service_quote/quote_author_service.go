@@ -38,8 +38,10 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/export"
 	metacommon "github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
@@ -89,6 +91,8 @@ type QuoteAuthorService struct {
 	reviewService                     *review.ReviewService
 	configService                     *config.ConfigService
 	eventQueueService                 event_queue.EventQueueService
+	contentFilterService              *content_filter.ContentFilterService
+	auditLogService                   *audit_log.AuditLogService
 }
 
 func NewQuoteAuthorService(
@@ -114,6 +118,8 @@ func NewQuoteAuthorService(
 	reviewService *review.ReviewService,
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
+	contentFilterService *content_filter.ContentFilterService,
+	auditLogService *audit_log.AuditLogService,
 ) *QuoteAuthorService {
 	return &QuoteAuthorService{
 		activityRepo:                      activityRepo,
@@ -138,6 +144,8 @@ func NewQuoteAuthorService(
 		reviewService:                     reviewService,
 		configService:                     configService,
 		eventQueueService:                 eventQueueService,
+		contentFilterService:              contentFilterService,
+		auditLogService:                   auditLogService,
 	}
 }
 
@@ -158,6 +166,7 @@ func (qs *QuoteAuthorService) CloseQuoteAuthor(ctx context.Context, req *schema.
 		return errors.BadRequest(reason.InvalidURLError)
 	}
 
+	oldStatus := quoteInfo.Status
 	quoteInfo.Status = entity.QuoteAuthorStatusClosed
 	err = qs.quoteAuthorRepo.UpdateQuoteAuthorStatus(ctx, quoteInfo.ID, quoteInfo.Status)
 	if err != nil {
@@ -172,6 +181,17 @@ func (qs *QuoteAuthorService) CloseQuoteAuthor(ctx context.Context, req *schema.
 	if err != nil {
 		return err
 	}
+	// the poster closing the own quote author is not a moderator action
+	if quoteInfo.UserID != req.UserID {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionContentClose,
+			ObjectType: constant.QuoteAuthorObjectType,
+			ObjectID:   quoteInfo.ID,
+			Before:     map[string]int{"status": oldStatus},
+			After:      json.RawMessage(closeMeta),
+		})
+	}
 
 	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
@@ -274,6 +294,14 @@ func (qs *QuoteAuthorService) HasNewTag(ctx context.Context, tags []*schema.TagI
 
 // AddQuoteAuthor add quote
 func (qs *QuoteAuthorService) AddQuoteAuthor(ctx context.Context, req *schema.QuoteAuthorAdd) (quoteInfo any, err error) {
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.QuoteAuthorObjectType, "").
+		AddText(schema.ContentFilterFieldTitle, &req.AuthorName).
+		AddField(&schema.ContentFilterField{Name: schema.ContentFilterFieldBio, Text: &req.Content, HTML: &req.HTML,
+			IsHTML: req.ContentFormat == schema.QuoteAuthorContentFormat_HTML}).
+		AddTags(req.Tags)
+	if err = qs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
 
 	//if len(req.Tags) == 0 {
 	//	errorlist := make([]*validator.FormErrorField, 0)
@@ -331,7 +359,7 @@ func (qs *QuoteAuthorService) AddQuoteAuthor(ctx context.Context, req *schema.Qu
 	quote.UserID = req.UserID
 	quote.AuthorName = req.AuthorName
 	//quote.OriginalText = req.Content
-	quote.Bio = req.Content
+	quote.Bio = req.BioText()
 	log.Infof("addQuoteAuthor content:%s", req.Content)
 	//quote.ParsedText = req.HTML
 	//quote.AcceptedAnswerID = "0"
@@ -432,6 +460,7 @@ func (qs *QuoteAuthorService) OperationQuoteAuthor(ctx context.Context, req *sch
 	if quoteInfo.Pin == entity.QuoteAuthorPin && req.Operation == schema.QuoteAuthorOperationHide {
 		return nil
 	}
+	before := map[string]int{"show": quoteInfo.Show, "pin": quoteInfo.Pin}
 
 	switch req.Operation {
 	case schema.QuoteAuthorOperationHide:
@@ -440,7 +469,7 @@ func (qs *QuoteAuthorService) OperationQuoteAuthor(ctx context.Context, req *sch
 		if err != nil {
 			return err
 		}
-		err = qs.tagCommon.RefreshTagCountByArticleID(ctx, req.ID)
+		err = qs.tagCommon.RefreshTagCountByObjectID(ctx, req.ID)
 		if err != nil {
 			return err
 		}
@@ -450,7 +479,7 @@ func (qs *QuoteAuthorService) OperationQuoteAuthor(ctx context.Context, req *sch
 		if err != nil {
 			return err
 		}
-		err = qs.tagCommon.RefreshTagCountByQuoteID(ctx, req.ID)
+		err = qs.tagCommon.RefreshTagCountByObjectID(ctx, req.ID)
 		if err != nil {
 			return err
 		}
@@ -464,6 +493,14 @@ func (qs *QuoteAuthorService) OperationQuoteAuthor(ctx context.Context, req *sch
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentOperate,
+		ObjectType: constant.QuoteAuthorObjectType,
+		ObjectID:   quoteInfo.ID,
+		Before:     before,
+		After:      map[string]int{"show": quoteInfo.Show, "pin": quoteInfo.Pin},
+	})
 
 	actMap := make(map[string]constant.ActivityTypeKey)
 	actMap[schema.QuoteAuthorOperationPin] = constant.ActQuoteAuthorPin
@@ -523,11 +560,23 @@ func (qs *QuoteAuthorService) RemoveQuoteAuthor(ctx context.Context, req *schema
 		//}
 	}
 
+	oldStatus := quoteInfo.Status
 	quoteInfo.Status = entity.QuoteAuthorStatusDeleted
 	err = qs.quoteAuthorRepo.UpdateQuoteAuthorStatusWithOutUpdateTime(ctx, quoteInfo)
 	if err != nil {
 		return err
 	}
+	// the poster removing the own quote author is not a moderator action
+	if quoteInfo.UserID != req.UserID {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionContentRemove,
+			ObjectType: constant.QuoteAuthorObjectType,
+			ObjectID:   quoteInfo.ID,
+			Before:     map[string]int{"status": oldStatus},
+			After:      map[string]int{"status": quoteInfo.Status},
+		})
+	}
 
 	//userQuoteAuthorCount, err := qs.quoteAuthorCommon.GetUserQuoteAuthorCount(ctx, quoteInfo.UserID)
 	//if err != nil {
@@ -553,7 +602,7 @@ func (qs *QuoteAuthorService) RemoveQuoteAuthor(ctx context.Context, req *schema
 	if err != nil {
 		log.Error("RemoveTagRelListByObjectID error", err.Error())
 	}
-	err = qs.tagCommon.RefreshTagQuoteCount(ctx, tagIDs)
+	err = qs.tagCommon.RefreshTagQuoteAuthorCount(ctx, tagIDs)
 	if err != nil {
 		log.Error("efreshTagQuoteAuthorCount error", err.Error())
 	}
@@ -603,7 +652,7 @@ func (qs *QuoteAuthorService) UpdateQuoteAuthorCheckTags(ctx context.Context, re
 	isChange := qs.tagCommon.CheckTagsIsChange(ctx, tagNameList, oldtagNameList)
 
 	//If the content is the same, ignore it
-	if dbinfo.AuthorName == req.AuthorName && dbinfo.Bio == req.Content && !isChange {
+	if dbinfo.AuthorName == req.AuthorName && dbinfo.Bio == req.BioText() && !isChange {
 		return
 	}
 
@@ -684,7 +733,7 @@ func (qs *QuoteAuthorService) RecoverQuoteAuthor(ctx context.Context, req *schem
 		tagIDs = append(tagIDs, v.ID)
 	}
 	if len(tagIDs) > 0 {
-		if err = qs.tagCommon.RefreshTagQuestionCount(ctx, tagIDs); err != nil {
+		if err = qs.tagCommon.RefreshTagQuoteAuthorCount(ctx, tagIDs); err != nil {
 			log.Errorf("update tag's quote count failed, %v", err)
 		}
 	}
@@ -834,13 +883,21 @@ func (qs *QuoteAuthorService) UpdateQuoteAuthor(ctx context.Context, req *schema
 		err = errors.BadRequest(reason.QuoteAuthorCannotUpdate)
 		return nil, err
 	}
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.QuoteAuthorObjectType, req.ID).
+		AddText(schema.ContentFilterFieldTitle, &req.AuthorName).
+		AddField(&schema.ContentFilterField{Name: schema.ContentFilterFieldBio, Text: &req.Content, HTML: &req.HTML,
+			IsHTML: req.ContentFormat != schema.QuoteAuthorContentFormat_MARKDOWN}).
+		AddTags(req.Tags)
+	if err = qs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
 	log.Infof("UpdateQuoteAuthor b:%s", req.Content)
 	log.Infof("UpdateQuoteAuthor b html:%s", req.HTML)
 	now := time.Now()
 	quote := &entity.QuoteAuthor{}
 	quote.AuthorName = req.AuthorName
 	//quote.OriginalText = req.Content
-	quote.Bio = req.Content
+	quote.Bio = req.BioText()
 	//quote.ParsedText = req.HTML
 	quote.ID = uid.DeShortID(req.ID)
 	quote.UpdatedAt = now
@@ -866,7 +923,7 @@ func (qs *QuoteAuthorService) UpdateQuoteAuthor(ctx context.Context, req *schema
 	isChange := qs.tagCommon.CheckTagsIsChange(ctx, tagNameList, oldtagNameList)
 
 	//If the content is the same, ignore it
-	if dbinfo.AuthorName == req.AuthorName && dbinfo.Bio == req.Content && !isChange {
+	if dbinfo.AuthorName == req.AuthorName && dbinfo.Bio == req.BioText() && !isChange {
 		return
 	}
 
@@ -1450,6 +1507,14 @@ func (qs *QuoteAuthorService) AdminSetQuoteAuthorStatus(ctx context.Context, req
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentUpdateStatus,
+		ObjectType: constant.QuoteAuthorObjectType,
+		ObjectID:   quoteInfo.ID,
+		Before:     map[string]int{"status": quoteInfo.Status},
+		After:      map[string]int{"status": setStatus},
+	})
 
 	msg := &schema.NotificationMsg{}
 	if setStatus == entity.QuoteAuthorStatusDeleted {
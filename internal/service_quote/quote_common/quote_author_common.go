@@ -39,6 +39,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/revision"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -95,6 +96,7 @@ type QuoteAuthorCommon struct {
 	activityQueueService activity_queue.ActivityQueueService
 	revisionRepo         revision.RevisionRepo
 	data                 *data.Data
+	userRelationService  *user_relation.UserRelationService
 }
 
 func NewQuoteAuthorCommon(quoteAuthorRepo QuoteAuthorRepo,
@@ -110,6 +112,7 @@ func NewQuoteAuthorCommon(quoteAuthorRepo QuoteAuthorRepo,
 	activityQueueService activity_queue.ActivityQueueService,
 	revisionRepo revision.RevisionRepo,
 	data *data.Data,
+	userRelationService *user_relation.UserRelationService,
 ) *QuoteAuthorCommon {
 	return &QuoteAuthorCommon{
 		quoteAuthorRepo:      quoteAuthorRepo,
@@ -125,6 +128,7 @@ func NewQuoteAuthorCommon(quoteAuthorRepo QuoteAuthorRepo,
 		activityQueueService: activityQueueService,
 		revisionRepo:         revisionRepo,
 		data:                 data,
+		userRelationService:  userRelationService,
 	}
 }
 
@@ -357,6 +361,11 @@ func (qs *QuoteAuthorCommon) FormatQuoteAuthorsPage(
 	ctx context.Context, quoteAuthorList []*entity.QuoteAuthor, loginUserID string, orderCond string) (
 	formattedQuoteAuthors []*schema.QuoteAuthorPageResp, err error) {
 	formattedQuoteAuthors = make([]*schema.QuoteAuthorPageResp, 0)
+	// the content of muted users is collapsed for the login user
+	mutedUserIDs, err := qs.userRelationService.GetMutedUserIDs(ctx, loginUserID)
+	if err != nil {
+		return formattedQuoteAuthors, err
+	}
 	quoteAuthorIDs := make([]string, 0)
 	userIDs := make([]string, 0)
 
@@ -385,6 +394,8 @@ func (qs *QuoteAuthorCommon) FormatQuoteAuthorsPage(
 			//Thumbnails: thumbnails,
 		}
 
+		t.Muted = mutedUserIDs[quoteAuthorInfo.UserID]
+
 		quoteAuthorIDs = append(quoteAuthorIDs, quoteAuthorInfo.ID)
 		userIDs = append(userIDs, quoteAuthorInfo.UserID)
 		haveEdited, haveAnswered := false, false
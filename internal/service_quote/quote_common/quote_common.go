@@ -50,6 +50,7 @@ import (
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -98,8 +99,9 @@ type QuoteCommon struct {
 	revisionRepo         revision.RevisionRepo
 	data                 *data.Data
 
-	quoteAuthorRepo QuoteAuthorRepo
-	quotePieceRepo  QuotePieceRepo
+	quoteAuthorRepo     QuoteAuthorRepo
+	quotePieceRepo      QuotePieceRepo
+	userRelationService *user_relation.UserRelationService
 }
 
 func NewQuoteCommon(quoteRepo QuoteRepo,
@@ -118,6 +120,7 @@ func NewQuoteCommon(quoteRepo QuoteRepo,
 
 	quoteAuthorRepo QuoteAuthorRepo,
 	quotePieceRepo QuotePieceRepo,
+	userRelationService *user_relation.UserRelationService,
 ) *QuoteCommon {
 	return &QuoteCommon{
 		quoteRepo:            quoteRepo,
@@ -134,8 +137,9 @@ func NewQuoteCommon(quoteRepo QuoteRepo,
 		revisionRepo:         revisionRepo,
 		data:                 data,
 
-		quoteAuthorRepo: quoteAuthorRepo,
-		quotePieceRepo:  quotePieceRepo,
+		quoteAuthorRepo:     quoteAuthorRepo,
+		quotePieceRepo:      quotePieceRepo,
+		userRelationService: userRelationService,
 	}
 }
 
@@ -436,6 +440,11 @@ func (qs *QuoteCommon) FormatQuotesPage(
 	ctx context.Context, quoteList []*entity.Quote, loginUserID string, orderCond string) (
 	formattedQuotes []*schema.QuotePageResp, err error) {
 	formattedQuotes = make([]*schema.QuotePageResp, 0)
+	// the content of muted users is collapsed for the login user
+	mutedUserIDs, err := qs.userRelationService.GetMutedUserIDs(ctx, loginUserID)
+	if err != nil {
+		return formattedQuotes, err
+	}
 	quoteIDs := make([]string, 0)
 	userIDs := make([]string, 0)
 
@@ -465,6 +474,8 @@ func (qs *QuoteCommon) FormatQuotesPage(
 			QuoteAuthorId: quoteInfo.QuoteAuthorId,
 			QuotePieceId:  quoteInfo.QuotePieceId,
 		}
+
+		t.Muted = mutedUserIDs[quoteInfo.UserID]
 		if t.Title == "" {
 			t.Title = t.Description //@cws 如果title为空，则使用描述代替
 		}
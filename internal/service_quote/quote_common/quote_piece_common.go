@@ -39,6 +39,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/revision"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_relation"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -95,6 +96,7 @@ type QuotePieceCommon struct {
 	activityQueueService activity_queue.ActivityQueueService
 	revisionRepo         revision.RevisionRepo
 	data                 *data.Data
+	userRelationService  *user_relation.UserRelationService
 }
 
 func NewQuotePieceCommon(quotePieceRepo QuotePieceRepo,
@@ -110,6 +112,7 @@ func NewQuotePieceCommon(quotePieceRepo QuotePieceRepo,
 	activityQueueService activity_queue.ActivityQueueService,
 	revisionRepo revision.RevisionRepo,
 	data *data.Data,
+	userRelationService *user_relation.UserRelationService,
 ) *QuotePieceCommon {
 	return &QuotePieceCommon{
 		quotePieceRepo:       quotePieceRepo,
@@ -125,6 +128,7 @@ func NewQuotePieceCommon(quotePieceRepo QuotePieceRepo,
 		activityQueueService: activityQueueService,
 		revisionRepo:         revisionRepo,
 		data:                 data,
+		userRelationService:  userRelationService,
 	}
 }
 
@@ -357,6 +361,11 @@ func (qs *QuotePieceCommon) FormatQuotePiecesPage(
 	ctx context.Context, QuotePieceList []*entity.QuotePiece, loginUserID string, orderCond string) (
 	formattedQuotePieces []*schema.QuotePiecePageResp, err error) {
 	formattedQuotePieces = make([]*schema.QuotePiecePageResp, 0)
+	// the content of muted users is collapsed for the login user
+	mutedUserIDs, err := qs.userRelationService.GetMutedUserIDs(ctx, loginUserID)
+	if err != nil {
+		return formattedQuotePieces, err
+	}
 	QuotePieceIDs := make([]string, 0)
 	userIDs := make([]string, 0)
 
@@ -385,6 +394,8 @@ func (qs *QuotePieceCommon) FormatQuotePiecesPage(
 			//Thumbnails: thumbnails,
 		}
 
+		t.Muted = mutedUserIDs[QuotePieceInfo.UserID]
+
 		QuotePieceIDs = append(QuotePieceIDs, QuotePieceInfo.ID)
 		userIDs = append(userIDs, QuotePieceInfo.UserID)
 		haveEdited, haveAnswered := false, false
@@ -38,8 +38,10 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/export"
 	metacommon "github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
@@ -89,6 +91,8 @@ type QuotePieceService struct {
 	reviewService                    *review.ReviewService
 	configService                    *config.ConfigService
 	eventQueueService                event_queue.EventQueueService
+	contentFilterService             *content_filter.ContentFilterService
+	auditLogService                  *audit_log.AuditLogService
 }
 
 func NewQuotePieceService(
@@ -114,6 +118,8 @@ func NewQuotePieceService(
 	reviewService *review.ReviewService,
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
+	contentFilterService *content_filter.ContentFilterService,
+	auditLogService *audit_log.AuditLogService,
 ) *QuotePieceService {
 	return &QuotePieceService{
 		activityRepo:                     activityRepo,
@@ -138,6 +144,8 @@ func NewQuotePieceService(
 		reviewService:                    reviewService,
 		configService:                    configService,
 		eventQueueService:                eventQueueService,
+		contentFilterService:             contentFilterService,
+		auditLogService:                  auditLogService,
 	}
 }
 
@@ -158,6 +166,7 @@ func (qs *QuotePieceService) CloseQuotePiece(ctx context.Context, req *schema.Cl
 		return errors.BadRequest(reason.InvalidURLError)
 	}
 
+	oldStatus := quoteInfo.Status
 	quoteInfo.Status = entity.QuotePieceStatusClosed
 	err = qs.QuotePieceRepo.UpdateQuotePieceStatus(ctx, quoteInfo.ID, quoteInfo.Status)
 	if err != nil {
@@ -172,6 +181,17 @@ func (qs *QuotePieceService) CloseQuotePiece(ctx context.Context, req *schema.Cl
 	if err != nil {
 		return err
 	}
+	// the author closing the own quote piece is not a moderator action
+	if quoteInfo.UserID != req.UserID {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionContentClose,
+			ObjectType: constant.QuotePieceObjectType,
+			ObjectID:   quoteInfo.ID,
+			Before:     map[string]int{"status": oldStatus},
+			After:      json.RawMessage(closeMeta),
+		})
+	}
 
 	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
@@ -274,6 +294,13 @@ func (qs *QuotePieceService) HasNewTag(ctx context.Context, tags []*schema.TagIt
 
 // AddQuotePiece add quote
 func (qs *QuotePieceService) AddQuotePiece(ctx context.Context, req *schema.QuotePieceAdd) (quoteInfo any, err error) {
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.QuotePieceObjectType, "").
+		AddText(schema.ContentFilterFieldTitle, &req.Title).
+		AddContent(&req.Content, &req.HTML, req.ContentFormat == schema.QuotePieceContentFormat_HTML).
+		AddTags(req.Tags)
+	if err = qs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
 
 	//if len(req.Tags) == 0 {
 	//	errorlist := make([]*validator.FormErrorField, 0)
@@ -436,6 +463,7 @@ func (qs *QuotePieceService) OperationQuotePiece(ctx context.Context, req *schem
 	if quoteInfo.Pin == entity.QuotePiecePin && req.Operation == schema.QuotePieceOperationHide {
 		return nil
 	}
+	before := map[string]int{"show": quoteInfo.Show, "pin": quoteInfo.Pin}
 
 	switch req.Operation {
 	case schema.QuotePieceOperationHide:
@@ -444,7 +472,7 @@ func (qs *QuotePieceService) OperationQuotePiece(ctx context.Context, req *schem
 		if err != nil {
 			return err
 		}
-		err = qs.tagCommon.RefreshTagCountByArticleID(ctx, req.ID)
+		err = qs.tagCommon.RefreshTagCountByObjectID(ctx, req.ID)
 		if err != nil {
 			return err
 		}
@@ -454,7 +482,7 @@ func (qs *QuotePieceService) OperationQuotePiece(ctx context.Context, req *schem
 		if err != nil {
 			return err
 		}
-		err = qs.tagCommon.RefreshTagCountByQuoteID(ctx, req.ID)
+		err = qs.tagCommon.RefreshTagCountByObjectID(ctx, req.ID)
 		if err != nil {
 			return err
 		}
@@ -468,6 +496,14 @@ func (qs *QuotePieceService) OperationQuotePiece(ctx context.Context, req *schem
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentOperate,
+		ObjectType: constant.QuotePieceObjectType,
+		ObjectID:   quoteInfo.ID,
+		Before:     before,
+		After:      map[string]int{"show": quoteInfo.Show, "pin": quoteInfo.Pin},
+	})
 
 	actMap := make(map[string]constant.ActivityTypeKey)
 	actMap[schema.QuotePieceOperationPin] = constant.ActQuotePiecePin
@@ -527,11 +563,23 @@ func (qs *QuotePieceService) RemoveQuotePiece(ctx context.Context, req *schema.R
 		//}
 	}
 
+	oldStatus := quoteInfo.Status
 	quoteInfo.Status = entity.QuotePieceStatusDeleted
 	err = qs.QuotePieceRepo.UpdateQuotePieceStatusWithOutUpdateTime(ctx, quoteInfo)
 	if err != nil {
 		return err
 	}
+	// the author removing the own quote piece is not a moderator action
+	if quoteInfo.UserID != req.UserID {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionContentRemove,
+			ObjectType: constant.QuotePieceObjectType,
+			ObjectID:   quoteInfo.ID,
+			Before:     map[string]int{"status": oldStatus},
+			After:      map[string]int{"status": quoteInfo.Status},
+		})
+	}
 
 	//userQuotePieceCount, err := qs.QuotePieceCommon.GetUserQuotePieceCount(ctx, quoteInfo.UserID)
 	//if err != nil {
@@ -557,7 +605,7 @@ func (qs *QuotePieceService) RemoveQuotePiece(ctx context.Context, req *schema.R
 	if err != nil {
 		log.Error("RemoveTagRelListByObjectID error", err.Error())
 	}
-	err = qs.tagCommon.RefreshTagQuoteCount(ctx, tagIDs)
+	err = qs.tagCommon.RefreshTagQuotePieceCount(ctx, tagIDs)
 	if err != nil {
 		log.Error("efreshTagQuotePieceCount error", err.Error())
 	}
@@ -688,7 +736,7 @@ func (qs *QuotePieceService) RecoverQuotePiece(ctx context.Context, req *schema.
 		tagIDs = append(tagIDs, v.ID)
 	}
 	if len(tagIDs) > 0 {
-		if err = qs.tagCommon.RefreshTagQuestionCount(ctx, tagIDs); err != nil {
+		if err = qs.tagCommon.RefreshTagQuotePieceCount(ctx, tagIDs); err != nil {
 			log.Errorf("update tag's quote count failed, %v", err)
 		}
 	}
@@ -838,6 +886,13 @@ func (qs *QuotePieceService) UpdateQuotePiece(ctx context.Context, req *schema.Q
 		err = errors.BadRequest(reason.QuotePieceCannotUpdate)
 		return nil, err
 	}
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.QuotePieceObjectType, req.ID).
+		AddText(schema.ContentFilterFieldTitle, &req.Title).
+		AddContent(&req.Content, &req.HTML, req.ContentFormat != schema.QuotePieceContentFormat_MARKDOWN).
+		AddTags(req.Tags)
+	if err = qs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
 	log.Infof("UpdateQuotePiece b:%s", req.Content)
 	log.Infof("UpdateQuotePiece b html:%s", req.HTML)
 	now := time.Now()
@@ -1454,6 +1509,14 @@ func (qs *QuotePieceService) AdminSetQuotePieceStatus(ctx context.Context, req *
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentUpdateStatus,
+		ObjectType: constant.QuotePieceObjectType,
+		ObjectID:   quoteInfo.ID,
+		Before:     map[string]int{"status": quoteInfo.Status},
+		After:      map[string]int{"status": setStatus},
+	})
 
 	msg := &schema.NotificationMsg{}
 	if setStatus == entity.QuotePieceStatusDeleted {
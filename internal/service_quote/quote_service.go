@@ -38,9 +38,12 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/mention_common"
 	metacommon "github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
@@ -90,12 +93,15 @@ type QuoteService struct {
 	configService                    *config.ConfigService
 	eventQueueService                event_queue.EventQueueService
 
-	quoteAuthorService *QuoteAuthorService
-	quotePieceService  *QuotePieceService
-	quoteAuthorRepo    quotecommon.QuoteAuthorRepo
-	quotePieceRepo     quotecommon.QuotePieceRepo
-	quoteAuthorCommon  *quotecommon.QuoteAuthorCommon
-	quotePieceCommon   *quotecommon.QuotePieceCommon
+	quoteAuthorService   *QuoteAuthorService
+	quotePieceService    *QuotePieceService
+	quoteAuthorRepo      quotecommon.QuoteAuthorRepo
+	quotePieceRepo       quotecommon.QuotePieceRepo
+	quoteAuthorCommon    *quotecommon.QuoteAuthorCommon
+	quotePieceCommon     *quotecommon.QuotePieceCommon
+	mentionCommon        *mention_common.MentionCommon
+	contentFilterService *content_filter.ContentFilterService
+	auditLogService      *audit_log.AuditLogService
 }
 
 func NewQuoteService(
@@ -129,6 +135,9 @@ func NewQuoteService(
 
 	quoteAuthorCommon *quotecommon.QuoteAuthorCommon,
 	quotePieceCommon *quotecommon.QuotePieceCommon,
+	mentionCommon *mention_common.MentionCommon,
+	contentFilterService *content_filter.ContentFilterService,
+	auditLogService *audit_log.AuditLogService,
 ) *QuoteService {
 	return &QuoteService{
 		activityRepo:                     activityRepo,
@@ -160,8 +169,11 @@ func NewQuoteService(
 		quoteAuthorRepo: quoteAuthorRepo,
 		quotePieceRepo:  quotePieceRepo,
 
-		quoteAuthorCommon: quoteAuthorCommon,
-		quotePieceCommon:  quotePieceCommon,
+		quoteAuthorCommon:    quoteAuthorCommon,
+		quotePieceCommon:     quotePieceCommon,
+		mentionCommon:        mentionCommon,
+		contentFilterService: contentFilterService,
+		auditLogService:      auditLogService,
 	}
 }
 
@@ -182,6 +194,7 @@ func (qs *QuoteService) CloseQuote(ctx context.Context, req *schema.CloseQuoteRe
 		return errors.BadRequest(reason.InvalidURLError)
 	}
 
+	oldStatus := quoteInfo.Status
 	quoteInfo.Status = entity.QuoteStatusClosed
 	err = qs.quoteRepo.UpdateQuoteStatus(ctx, quoteInfo.ID, quoteInfo.Status)
 	if err != nil {
@@ -196,6 +209,17 @@ func (qs *QuoteService) CloseQuote(ctx context.Context, req *schema.CloseQuoteRe
 	if err != nil {
 		return err
 	}
+	// the author closing the own quote is not a moderator action
+	if quoteInfo.UserID != req.UserID {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionContentClose,
+			ObjectType: constant.QuoteObjectType,
+			ObjectID:   quoteInfo.ID,
+			Before:     map[string]int{"status": oldStatus},
+			After:      json.RawMessage(closeMeta),
+		})
+	}
 
 	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
@@ -522,6 +546,16 @@ func (qs *QuoteService) AddQuotePiece(ctx context.Context, req *schema.QuoteAdd)
 
 // AddQuote add quote
 func (qs *QuoteService) AddQuote(ctx context.Context, req *schema.QuoteAdd) (quoteInfo any, err error) {
+	// the author and piece are created by name if they don't exist
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.QuoteObjectType, "").
+		AddText(schema.ContentFilterFieldTitle, &req.Title).
+		AddText(schema.ContentFilterFieldTitle, &req.Author).
+		AddText(schema.ContentFilterFieldTitle, &req.PieceName).
+		AddContent(&req.Content, &req.HTML, req.ContentFormat == schema.QuoteContentFormat_HTML).
+		AddTags(req.Tags)
+	if err = qs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
 	if len(req.Tags) == 0 {
 		errorlist := make([]*validator.FormErrorField, 0)
 		errorlist = append(errorlist, &validator.FormErrorField{
@@ -575,7 +609,7 @@ func (qs *QuoteService) AddQuote(ctx context.Context, req *schema.QuoteAdd) (quo
 	quote.Title = req.Title
 	quote.OriginalText = req.Content
 	log.Infof("addQuote content:%s", req.Content)
-	quote.ParsedText = req.HTML
+	quote.ParsedText = qs.mentionCommon.LinkMentions(ctx, req.HTML)
 	//quote.AcceptedAnswerID = "0"
 	//quote.LastAnswerID = "0"
 	//quote.LastEditUserID = "0"
@@ -736,6 +770,14 @@ func (qs *QuoteService) AddQuote(ctx context.Context, req *schema.QuoteAdd) (quo
 	if quote.Status == entity.QuoteStatusAvailable {
 		qs.externalNotificationQueueService.Send(ctx,
 			schema.CreateNewQuoteNotificationMsg(quote.ID, quote.Title, quote.UserID, tags))
+		qs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+			ObjectID:      quote.ID,
+			ObjectType:    constant.QuoteObjectType,
+			Title:         quote.Title,
+			QuestionID:    quote.ID,
+			TriggerUserID: quote.UserID,
+			ParsedText:    quote.ParsedText,
+		})
 	}
 	qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuoteCreate, req.UserID).TID(quote.ID).
 		QID(quote.ID, quote.UserID))
@@ -761,6 +803,7 @@ func (qs *QuoteService) OperationQuote(ctx context.Context, req *schema.Operatio
 	if quoteInfo.Pin == entity.QuotePin && req.Operation == schema.QuoteOperationHide {
 		return nil
 	}
+	before := map[string]int{"show": quoteInfo.Show, "pin": quoteInfo.Pin}
 
 	switch req.Operation {
 	case schema.QuoteOperationHide:
@@ -769,7 +812,7 @@ func (qs *QuoteService) OperationQuote(ctx context.Context, req *schema.Operatio
 		if err != nil {
 			return err
 		}
-		err = qs.tagCommon.RefreshTagCountByArticleID(ctx, req.ID)
+		err = qs.tagCommon.RefreshTagCountByQuoteID(ctx, req.ID)
 		if err != nil {
 			return err
 		}
@@ -793,6 +836,14 @@ func (qs *QuoteService) OperationQuote(ctx context.Context, req *schema.Operatio
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentOperate,
+		ObjectType: constant.QuoteObjectType,
+		ObjectID:   quoteInfo.ID,
+		Before:     before,
+		After:      map[string]int{"show": quoteInfo.Show, "pin": quoteInfo.Pin},
+	})
 
 	actMap := make(map[string]constant.ActivityTypeKey)
 	actMap[schema.QuoteOperationPin] = constant.ActQuotePin
@@ -852,11 +903,23 @@ func (qs *QuoteService) RemoveQuote(ctx context.Context, req *schema.RemoveQuote
 		//}
 	}
 
+	oldStatus := quoteInfo.Status
 	quoteInfo.Status = entity.QuoteStatusDeleted
 	err = qs.quoteRepo.UpdateQuoteStatusWithOutUpdateTime(ctx, quoteInfo)
 	if err != nil {
 		return err
 	}
+	// the author removing the own quote is not a moderator action
+	if quoteInfo.UserID != req.UserID {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+			UserID:     req.UserID,
+			Action:     entity.AuditLogActionContentRemove,
+			ObjectType: constant.QuoteObjectType,
+			ObjectID:   quoteInfo.ID,
+			Before:     map[string]int{"status": oldStatus},
+			After:      map[string]int{"status": quoteInfo.Status},
+		})
+	}
 
 	userQuoteCount, err := qs.quotecommon.GetUserQuoteCount(ctx, quoteInfo.UserID)
 	if err != nil {
@@ -1013,7 +1076,7 @@ func (qs *QuoteService) RecoverQuote(ctx context.Context, req *schema.QuoteRecov
 		tagIDs = append(tagIDs, v.ID)
 	}
 	if len(tagIDs) > 0 {
-		if err = qs.tagCommon.RefreshTagQuestionCount(ctx, tagIDs); err != nil {
+		if err = qs.tagCommon.RefreshTagQuoteCount(ctx, tagIDs); err != nil {
 			log.Errorf("update tag's quote count failed, %v", err)
 		}
 	}
@@ -1163,13 +1226,20 @@ func (qs *QuoteService) UpdateQuote(ctx context.Context, req *schema.QuoteUpdate
 		err = errors.BadRequest(reason.QuoteCannotUpdate)
 		return nil, err
 	}
+	filterReq := schema.NewContentFilterReq(req.UserID, constant.QuoteObjectType, req.ID).
+		AddText(schema.ContentFilterFieldTitle, &req.Title).
+		AddContent(&req.Content, &req.HTML, req.ContentFormat != schema.QuoteContentFormat_MARKDOWN).
+		AddTags(req.Tags)
+	if err = qs.contentFilterService.FilterContent(ctx, filterReq); err != nil {
+		return nil, err
+	}
 	log.Infof("UpdateQuote b:%s", req.Content)
 	log.Infof("UpdateQuote b html:%s", req.HTML)
 	now := time.Now()
 	quote := &entity.Quote{}
 	quote.Title = req.Title
 	quote.OriginalText = req.Content
-	quote.ParsedText = req.HTML
+	quote.ParsedText = qs.mentionCommon.LinkMentions(ctx, req.HTML)
 	quote.ID = uid.DeShortID(req.ID)
 	quote.UpdatedAt = now
 	quote.PostUpdateTime = now
@@ -1290,6 +1360,9 @@ func (qs *QuoteService) UpdateQuote(ctx context.Context, req *schema.QuoteUpdate
 	if err != nil {
 		return
 	}
+	if !canUpdate {
+		qs.reviewService.AddEditReview(ctx, req.UserID, quote.ID, revisionID, quote.Title, quote.ParsedText)
+	}
 	if canUpdate {
 		qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           req.UserID,
@@ -1300,6 +1373,17 @@ func (qs *QuoteService) UpdateQuote(ctx context.Context, req *schema.QuoteUpdate
 		})
 		qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuoteUpdate, req.UserID).TID(quote.ID).
 			QID(quote.ID, quote.UserID))
+		if dbinfo.Status != entity.QuoteStatusPending {
+			qs.mentionCommon.NotifyMentions(ctx, &schema.MentionNotificationReq{
+				ObjectID:      quote.ID,
+				ObjectType:    constant.QuoteObjectType,
+				Title:         quote.Title,
+				QuestionID:    quote.ID,
+				TriggerUserID: req.UserID,
+				ParsedText:    quote.ParsedText,
+				OldParsedText: dbinfo.ParsedText,
+			})
+		}
 	}
 
 	quoteInfo, err = qs.GetQuote(ctx, quote.ID, quote.UserID, req.QuotePermission)
@@ -1734,6 +1818,15 @@ func (qs *QuoteService) GetQuotePage(ctx context.Context, req *schema.QuotePageR
 		}
 	}
 
+	// include the content of all descendant tags, the last one of tag ids is the main tag
+	if req.IncludeDescendants && len(tagIDs) > 0 {
+		descendantTagIDs, err := qs.tagCommon.GetDescendantTagIDsWithSynonyms(ctx, tagIDs[len(tagIDs)-1])
+		if err != nil {
+			return nil, 0, err
+		}
+		tagIDs = append(tagIDs, descendantTagIDs...)
+	}
+
 	// query by user condition
 	if req.Username != "" {
 		userinfo, exist, err := qs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
@@ -1819,6 +1912,14 @@ func (qs *QuoteService) AdminSetQuoteStatus(ctx context.Context, req *schema.Adm
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogRecord{
+		UserID:     req.UserID,
+		Action:     entity.AuditLogActionContentUpdateStatus,
+		ObjectType: constant.QuoteObjectType,
+		ObjectID:   quoteInfo.ID,
+		Before:     map[string]int{"status": quoteInfo.Status},
+		After:      map[string]int{"status": setStatus},
+	})
 
 	msg := &schema.NotificationMsg{}
 	if setStatus == entity.QuoteStatusDeleted {
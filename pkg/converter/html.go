@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package converter
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// HTMLAllowlist the markup allowed in html-format content, on top of the basic user-generated content policy
+type HTMLAllowlist struct {
+	// Elements extra elements, such as "kbd"
+	Elements []string
+	// Attributes extra attributes allowed on all elements
+	Attributes []string
+	// URLSchemes the schemes allowed in href and src, "data" only permits inline images
+	URLSchemes []string
+	// IframeHosts the hosts allowed to be embedded by https iframe, no iframe is allowed if empty
+	IframeHosts []string
+}
+
+// DefaultHTMLAllowlist the default allowlist of html-format content
+func DefaultHTMLAllowlist() *HTMLAllowlist {
+	return &HTMLAllowlist{
+		Elements:    []string{"kbd"},
+		Attributes:  []string{},
+		URLSchemes:  []string{"http", "https", "mailto"},
+		IframeHosts: []string{"www.youtube.com", "player.vimeo.com", "player.bilibili.com"},
+	}
+}
+
+var (
+	htmlNamePattern = regexp.MustCompile(`^[a-z][a-z0-9\-]*$`)
+	htmlHostPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9\-\.]*[a-z0-9])?(:[0-9]+)?$`)
+	// the elements can not be allowed by the allowlist, iframe is allowed by IframeHosts only
+	deniedHTMLElements = map[string]bool{
+		"script": true, "style": true, "iframe": true, "frame": true, "frameset": true, "object": true,
+		"embed": true, "applet": true, "base": true, "link": true, "meta": true, "form": true, "input": true,
+		"button": true, "select": true, "textarea": true, "svg": true, "math": true, "template": true,
+		"noscript": true,
+	}
+	// the attributes can not be allowed by the allowlist, the url attributes are controlled by URLSchemes
+	deniedHTMLAttributes = map[string]bool{
+		"style": true, "href": true, "src": true, "srcset": true, "srcdoc": true, "action": true,
+		"formaction": true, "xmlns": true,
+	}
+	// the schemes allowed by bluemonday.UGCPolicy
+	ugcURLSchemes = []string{"http", "https", "mailto"}
+)
+
+var defaultHTMLSanitizer atomic.Value
+
+func init() {
+	SetHTMLAllowlist(DefaultHTMLAllowlist())
+}
+
+// SetHTMLAllowlist replace the allowlist used by SanitizeHTML
+func SetHTMLAllowlist(allowlist *HTMLAllowlist) {
+	defaultHTMLSanitizer.Store(NewHTMLSanitizer(allowlist))
+}
+
+// SanitizeHTML sanitize the html-format content by the current allowlist
+func SanitizeHTML(source string) string {
+	return defaultHTMLSanitizer.Load().(*bluemonday.Policy).Sanitize(source)
+}
+
+// NewHTMLSanitizer new the sanitizer of html-format content.
+// The link policy is the same as Markdown2HTML, rel="nofollow" is not required.
+// The unsafe entries in the allowlist are ignored.
+func NewHTMLSanitizer(allowlist *HTMLAllowlist) *bluemonday.Policy {
+	filter := bluemonday.UGCPolicy()
+	filter.AllowStyling()
+	filter.RequireNoFollowOnLinks(false)
+	filter.RequireNoFollowOnFullyQualifiedLinks(false)
+	filter.AllowAttrs("title").Matching(regexp.MustCompile(`^[\p{L}\p{N}\s\-_',\[\]!\./\\\(\)]*$|^@embed?$`)).Globally()
+
+	for _, element := range allowlist.Elements {
+		element = strings.ToLower(strings.TrimSpace(element))
+		if htmlNamePattern.MatchString(element) && !deniedHTMLElements[element] {
+			filter.AllowElements(element)
+		}
+	}
+	for _, attr := range allowlist.Attributes {
+		attr = strings.ToLower(strings.TrimSpace(attr))
+		if htmlNamePattern.MatchString(attr) && !strings.HasPrefix(attr, "on") && !deniedHTMLAttributes[attr] {
+			filter.AllowAttrs(attr).Globally()
+		}
+	}
+
+	schemes := make(map[string]bool, len(allowlist.URLSchemes))
+	for _, scheme := range allowlist.URLSchemes {
+		scheme = strings.ToLower(strings.TrimSpace(scheme))
+		if scheme == "data" {
+			filter.AllowDataURIImages()
+			continue
+		}
+		if scheme == "javascript" || scheme == "vbscript" || !htmlNamePattern.MatchString(scheme) {
+			continue
+		}
+		schemes[scheme] = true
+		filter.AllowURLSchemes(scheme)
+	}
+	// the schemes allowed by UGCPolicy can not be removed, so they are rejected by a custom policy
+	for _, scheme := range ugcURLSchemes {
+		if !schemes[scheme] {
+			filter.AllowURLSchemeWithCustomPolicy(scheme, func(*url.URL) bool { return false })
+		}
+	}
+
+	hosts := make([]string, 0, len(allowlist.IframeHosts))
+	for _, host := range allowlist.IframeHosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if htmlHostPattern.MatchString(host) {
+			hosts = append(hosts, regexp.QuoteMeta(host))
+		}
+	}
+	if len(hosts) > 0 {
+		filter.AllowElements("iframe")
+		filter.AllowAttrs("src").
+			Matching(regexp.MustCompile(`^https://(` + strings.Join(hosts, "|") + `)([/?#]|$)`)).
+			OnElements("iframe")
+		filter.AllowAttrs("width", "height").Matching(bluemonday.NumberOrPercent).OnElements("iframe")
+		filter.AllowAttrs("allowfullscreen", "frameborder").OnElements("iframe")
+	}
+	return filter
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHTMLSanitizer(t *testing.T) {
+	sanitizer := NewHTMLSanitizer(DefaultHTMLAllowlist())
+
+	// script and event handlers are removed
+	assert.Equal(t, "<p>hello</p>", sanitizer.Sanitize(`<p onclick="alert(1)">hello<script>alert(1)</script></p>`))
+	assert.Equal(t, `link`, sanitizer.Sanitize(`<a href="javascript:alert(1)">link</a>`))
+
+	// the link policy is the same as markdown, no nofollow is added
+	assert.Equal(t, `<a href="https://example.com/">link</a>`, sanitizer.Sanitize(`<a href="https://example.com/">link</a>`))
+	assert.Equal(t, `<a href="/questions">link</a>`, sanitizer.Sanitize(`<a href="/questions">link</a>`))
+	assert.Equal(t, `<kbd>Ctrl</kbd><mark>hi</mark>`, sanitizer.Sanitize(`<kbd>Ctrl</kbd><mark>hi</mark>`))
+
+	// only the iframes from the allowed hosts are kept
+	assert.Equal(t, `<iframe src="https://www.youtube.com/embed/abc" width="560"></iframe>`,
+		sanitizer.Sanitize(`<iframe src="https://www.youtube.com/embed/abc" width="560" onload="alert(1)"></iframe>`))
+	assert.Equal(t, ``, sanitizer.Sanitize(`<iframe src="https://evil.example.com/"></iframe>`))
+	assert.Equal(t, ``, sanitizer.Sanitize(`<iframe src="https://www.youtube.com.evil.com/"></iframe>`))
+}
+
+func TestNewHTMLSanitizer_Allowlist(t *testing.T) {
+	sanitizer := NewHTMLSanitizer(&HTMLAllowlist{
+		Elements:   []string{"script", "kbd"},
+		Attributes: []string{"onclick", "style", "data-id"},
+		URLSchemes: []string{"https", "javascript"},
+	})
+
+	// the unsafe entries are ignored
+	assert.Equal(t, `<kbd data-id="1">hi</kbd>`,
+		sanitizer.Sanitize(`<kbd data-id="1" onclick="alert(1)" style="color:red">hi<script>alert(1)</script></kbd>`))
+	assert.Equal(t, `link`, sanitizer.Sanitize(`<a href="javascript:alert(1)">link</a>`))
+
+	// the schemes not in the allowlist are removed, even if allowed by default
+	assert.Equal(t, `mail`, sanitizer.Sanitize(`<a href="mailto:a@example.com">mail</a>`))
+	assert.Equal(t, `<a href="https://example.com/">link</a>`, sanitizer.Sanitize(`<a href="https://example.com/">link</a>`))
+
+	// no iframe is allowed without hosts
+	assert.Equal(t, ``, sanitizer.Sanitize(`<iframe src="https://www.youtube.com/embed/abc"></iframe>`))
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	defer SetHTMLAllowlist(DefaultHTMLAllowlist())
+
+	SetHTMLAllowlist(&HTMLAllowlist{URLSchemes: []string{"https"}})
+	assert.Equal(t, "Ctrl", SanitizeHTML(`<kbd>Ctrl</kbd>`))
+	SetHTMLAllowlist(DefaultHTMLAllowlist())
+	assert.Equal(t, "<kbd>Ctrl</kbd>", SanitizeHTML(`<kbd>Ctrl</kbd>`))
+}
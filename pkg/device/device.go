@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package device parses the user agent into a readable device description, such as "Chrome on Windows".
+// Only the common browsers and operating systems are recognized, it is used for display only.
+package device
+
+import (
+	"strings"
+)
+
+const (
+	TypeDesktop = "desktop"
+	TypeMobile  = "mobile"
+	TypeTablet  = "tablet"
+	TypeBot     = "bot"
+	TypeUnknown = "unknown"
+
+	unknown = "Unknown"
+)
+
+// Device the device parsed from user agent
+type Device struct {
+	Browser string `json:"browser"`
+	OS      string `json:"os"`
+	Type    string `json:"type"`
+}
+
+// String the readable description of the device
+func (d *Device) String() string {
+	return d.Browser + " on " + d.OS
+}
+
+// keyword the first matched keyword decides the name, so the more specific keywords must be in front
+type keyword struct {
+	match string
+	name  string
+}
+
+var browserKeywords = []keyword{
+	{"edg/", "Edge"},
+	{"edga/", "Edge"},
+	{"edgios/", "Edge"},
+	{"opr/", "Opera"},
+	{"opera", "Opera"},
+	{"samsungbrowser/", "Samsung Internet"},
+	{"ucbrowser/", "UC Browser"},
+	{"micromessenger/", "WeChat"},
+	{"firefox/", "Firefox"},
+	{"fxios/", "Firefox"},
+	{"crios/", "Chrome"},
+	{"chrome/", "Chrome"},
+	{"chromium/", "Chromium"},
+	{"safari/", "Safari"},
+	{"msie ", "Internet Explorer"},
+	{"trident/", "Internet Explorer"},
+	{"curl/", "curl"},
+	{"postman", "Postman"},
+}
+
+var osKeywords = []keyword{
+	{"windows phone", "Windows Phone"},
+	{"windows", "Windows"},
+	{"iphone", "iOS"},
+	{"ipad", "iPadOS"},
+	{"ipod", "iOS"},
+	{"android", "Android"},
+	{"cros", "ChromeOS"},
+	{"mac os x", "macOS"},
+	{"macintosh", "macOS"},
+	{"linux", "Linux"},
+}
+
+var botKeywords = []string{"bot", "crawler", "spider", "slurp"}
+
+// Parse parse the user agent
+func Parse(userAgent string) *Device {
+	ua := strings.ToLower(userAgent)
+	d := &Device{
+		Browser: matchKeyword(ua, browserKeywords),
+		OS:      matchKeyword(ua, osKeywords),
+	}
+	switch {
+	case len(ua) == 0:
+		d.Type = TypeUnknown
+	case containsAny(ua, botKeywords):
+		d.Type = TypeBot
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet") ||
+		(strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		d.Type = TypeTablet
+	case strings.Contains(ua, "mobile") || strings.Contains(ua, "iphone") || strings.Contains(ua, "windows phone"):
+		d.Type = TypeMobile
+	case d.OS != unknown:
+		d.Type = TypeDesktop
+	default:
+		d.Type = TypeUnknown
+	}
+	return d
+}
+
+func matchKeyword(ua string, keywords []keyword) string {
+	for _, k := range keywords {
+		if strings.Contains(ua, k.match) {
+			return k.name
+		}
+	}
+	return unknown
+}
+
+func containsAny(ua string, keywords []string) bool {
+	for _, k := range keywords {
+		if strings.Contains(ua, k) {
+			return true
+		}
+	}
+	return false
+}
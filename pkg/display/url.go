@@ -59,6 +59,16 @@ func CommentURL(permalink int, siteUrl, questionID, title, answerID, commentID s
 	return QuestionURL(permalink, siteUrl, questionID, title) + "?commentId=" + commentID
 }
 
+// ArticleURL get article url
+func ArticleURL(siteUrl, articleID string) string {
+	return siteUrl + "/articles/" + uid.DeShortID(articleID)
+}
+
+// QuoteURL get quote url
+func QuoteURL(siteUrl, quoteID string) string {
+	return siteUrl + "/quotes/" + uid.DeShortID(quoteID)
+}
+
 // UserURL get user url
 func UserURL(siteUrl, username string) string {
 	return siteUrl + "/users/" + username
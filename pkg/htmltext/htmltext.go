@@ -20,6 +20,7 @@
 package htmltext
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -85,9 +86,7 @@ func ClearText(html string) (text string) {
 }
 
 func UrlTitle(title string) (text string) {
-	title = convertChinese(title)
-	title = clearEmoji(title)
-	title = slugify.Slugify(title)
+	title = slugText(title)
 	title = url.QueryEscape(title)
 	title = cutLongTitle(title)
 	if len(title) == 0 {
@@ -96,6 +95,48 @@ func UrlTitle(title string) (text string) {
 	return title
 }
 
+// SlugName generate a readable slug from the display name, chinese characters are converted to pinyin.
+// The slug is cut to maxLength and may be empty if nothing in the name can be kept.
+func SlugName(name string, maxLength int) string {
+	slug := slugText(name)
+	if maxLength > 0 && len(slug) > maxLength {
+		slug = slug[0:maxLength]
+	}
+	return strings.Trim(slug, "-")
+}
+
+// UniqueSlugName generate the slug name from the name by SlugName, defaultSlug is used if nothing can be kept.
+// If the slug name is used, a numeric suffix is appended to it, such as `shu-ju-ku-2`
+func UniqueSlugName(name, defaultSlug string, maxLength int, isUsed func(slug string) (bool, error)) (string, error) {
+	base := SlugName(name, maxLength)
+	if len(base) == 0 {
+		base = defaultSlug
+	}
+	slug := base
+	for i := 2; ; i++ {
+		used, err := isUsed(slug)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return slug, nil
+		}
+		suffix := fmt.Sprintf("-%d", i)
+		prefix := base
+		if maxLength > 0 && len(prefix)+len(suffix) > maxLength {
+			prefix = strings.TrimRight(prefix[0:maxLength-len(suffix)], "-")
+		}
+		slug = prefix + suffix
+	}
+}
+
+// slugText convert chinese to pinyin, clear emoji and slugify the text
+func slugText(text string) string {
+	text = convertChinese(text)
+	text = clearEmoji(text)
+	return slugify.Slugify(text)
+}
+
 func clearEmoji(s string) string {
 	ret := ""
 	rs := []rune(s)
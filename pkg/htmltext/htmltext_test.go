@@ -86,6 +86,31 @@ func TestUrlTitle(t *testing.T) {
 	}
 }
 
+func TestSlugName(t *testing.T) {
+	assert.Equal(t, "go-yu-yan", SlugName("Go语言", 35))
+	assert.Equal(t, "shu-ju-ku-she-ji", SlugName("数据库 设计", 35))
+	assert.Equal(t, "hello-world", SlugName("Hello World😂", 35))
+	assert.Equal(t, "shu-ju", SlugName("数据库", 7))
+	assert.Equal(t, "", SlugName("😂", 35))
+}
+
+func TestUniqueSlugName(t *testing.T) {
+	used := map[string]bool{"shu-ju-ku": true, "shu-ju-ku-2": true, "tag": true}
+	isUsed := func(slug string) (bool, error) { return used[slug], nil }
+
+	slug, err := UniqueSlugName("数据库", "tag", 35, isUsed)
+	assert.NoError(t, err)
+	assert.Equal(t, "shu-ju-ku-3", slug)
+
+	slug, err = UniqueSlugName("😂", "tag", 35, isUsed)
+	assert.NoError(t, err)
+	assert.Equal(t, "tag-2", slug)
+
+	slug, err = UniqueSlugName("数据库", "tag", 10, isUsed)
+	assert.NoError(t, err)
+	assert.Equal(t, "shu-ju-k-2", slug)
+}
+
 func TestFindFirstMatchedWord(t *testing.T) {
 	var (
 		expectedWord,
@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mention
+
+import (
+	"html"
+	"io"
+	"regexp"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// mentionReg matches the @username mention, the username follows the rule of the user register.
+// The character before the @ is captured to avoid matching the email address.
+var mentionReg = regexp.MustCompile(`(^|[^\w@./-])@([a-z0-9._-]{4,30})`)
+
+// skipTags the mentions inside these elements are not parsed
+var skipTags = map[string]bool{
+	"a":    true,
+	"code": true,
+	"pre":  true,
+}
+
+// profilePath the path of the user profile page, the linked mentions point to it
+const profilePath = "/users/"
+
+// Parse returns the usernames mentioned in the html in the order of appearance without duplicates.
+// The mentions that are already linked to the user profile are included.
+func Parse(htmlText string) (usernames []string) {
+	exist := make(map[string]bool)
+	add := func(username string) {
+		if !exist[username] {
+			exist[username] = true
+			usernames = append(usernames, username)
+		}
+	}
+	walk(htmlText, func(text string) string {
+		return replace(text, func(username string) string {
+			add(username)
+			return "@" + username
+		})
+	}, func(href, text string) {
+		username := strings.TrimPrefix(text, "@")
+		if len(username) != len(text) && mentionReg.MatchString(text) &&
+			strings.HasSuffix(href, profilePath+username) {
+			add(username)
+		}
+	})
+	return usernames
+}
+
+// Link wraps the plain text mentions in the html with the link returned by hrefFunc.
+// If hrefFunc returns false, the mention is kept as plain text.
+func Link(htmlText string, hrefFunc func(username string) (href string, ok bool)) string {
+	return walk(htmlText, func(text string) string {
+		return replace(text, func(username string) string {
+			href, ok := hrefFunc(username)
+			if !ok {
+				return "@" + username
+			}
+			return `<a href="` + html.EscapeString(href) + `">@` + username + `</a>`
+		})
+	}, nil)
+}
+
+// walk tokenizes the html and rewrites the text outside the skipped elements by textFunc,
+// the other tokens are kept as they are. anchorFunc is called with the href and the text of every link.
+func walk(htmlText string, textFunc func(text string) string, anchorFunc func(href, text string)) string {
+	var (
+		buf        strings.Builder
+		skipDepth  int
+		inAnchor   bool
+		anchorHref string
+		anchorText strings.Builder
+	)
+	z := xhtml.NewTokenizer(strings.NewReader(htmlText))
+	for {
+		tt := z.Next()
+		if tt == xhtml.ErrorToken {
+			if z.Err() != io.EOF {
+				return htmlText
+			}
+			break
+		}
+		raw := string(z.Raw())
+		switch tt {
+		case xhtml.StartTagToken:
+			name, hasAttr := z.TagName()
+			tag := string(name)
+			if skipTags[tag] {
+				skipDepth++
+			}
+			if tag == "a" {
+				inAnchor, anchorHref = true, ""
+				anchorText.Reset()
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = z.TagAttr()
+					if string(key) == "href" {
+						anchorHref = string(val)
+					}
+				}
+			}
+		case xhtml.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if skipTags[tag] && skipDepth > 0 {
+				skipDepth--
+			}
+			if tag == "a" && inAnchor {
+				inAnchor = false
+				if anchorFunc != nil {
+					anchorFunc(anchorHref, strings.TrimSpace(anchorText.String()))
+				}
+			}
+		case xhtml.TextToken:
+			if inAnchor {
+				anchorText.WriteString(html.UnescapeString(raw))
+			}
+			if skipDepth == 0 {
+				raw = textFunc(raw)
+			}
+		}
+		buf.WriteString(raw)
+	}
+	return buf.String()
+}
+
+// replace replaces every mention in the text with the result of fn
+func replace(text string, fn func(username string) string) string {
+	matches := mentionReg.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+	var buf strings.Builder
+	last := 0
+	for _, m := range matches {
+		// m[4]:m[5] is the username, the @ is right before it
+		start, end := m[4], m[5]
+		// the dot at the end is treated as the punctuation of the sentence
+		username := strings.TrimRight(text[start:end], ".")
+		if len(username) < 4 {
+			continue
+		}
+		buf.WriteString(text[last : start-1])
+		buf.WriteString(fn(username))
+		last = start + len(username)
+	}
+	buf.WriteString(text[last:])
+	return buf.String()
+}
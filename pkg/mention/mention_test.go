@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mention
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string][]string{
+		"<p>hi @alice and @bob_1, @alice again</p>":                   {"alice", "bob_1"},
+		"<p>mail me at foo@example.com</p>":                           nil,
+		"<p>@abc is too short</p>":                                    nil,
+		"<p>thanks @alice.</p>":                                       {"alice"},
+		"<pre><code>@alice</code></pre><p><code>@bob_1</code></p>":    nil,
+		`<p><a href="https://example.com/users/carol">@carol</a></p>`: {"carol"},
+		`<p><a href="https://example.com">@carol</a></p>`:             nil,
+		"<p>@Alice is not a valid username</p>":                       nil,
+	}
+	for html, expected := range cases {
+		assert.Equal(t, expected, Parse(html), html)
+	}
+}
+
+func TestLink(t *testing.T) {
+	hrefFunc := func(username string) (string, bool) {
+		if username == "ghost" {
+			return "", false
+		}
+		return "/users/" + username, true
+	}
+
+	html := `<p>hi @alice, <a href="/x">@bob_1</a> <code>@carol</code> @ghost.</p>`
+	expected := `<p>hi <a href="/users/alice">@alice</a>, <a href="/x">@bob_1</a> <code>@carol</code> @ghost.</p>`
+	assert.Equal(t, expected, Link(html, hrefFunc))
+
+	// the linked mentions are parsed again
+	assert.Equal(t, []string{"alice"}, Parse(Link("<p>@alice</p>", hrefFunc)))
+	// the html without mentions is kept as it is
+	html = "<p class=\"x\">a &amp; b<br/>c</p>"
+	assert.Equal(t, html, Link(html, hrefFunc))
+}
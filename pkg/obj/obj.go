@@ -20,6 +20,8 @@
 package obj
 
 import (
+	"fmt"
+
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
@@ -55,6 +57,17 @@ func GetObjectTypeNumberByObjectID(objectID string) (objectTypeNumber int, err e
 	return converter.StringToInt(objectID[1:4]), nil
 }
 
+// GetObjectIDRangeByObjectType get the range of object id of the object type, object id is 1 + 00x(objectType) + 000000000000x(id)
+func GetObjectIDRangeByObjectType(objectTypeStr string) (minID, maxID int64, err error) {
+	objectTypeNumber, ok := constant.ObjectTypeStrMapping[objectTypeStr]
+	if !ok {
+		return 0, 0, errors.BadRequest(reason.ObjectNotFound)
+	}
+	minID = converter.StringToInt64(fmt.Sprintf("1%03d%013d", objectTypeNumber, 0))
+	maxID = converter.StringToInt64(fmt.Sprintf("1%03d%013d", objectTypeNumber, int64(9999999999999)))
+	return minID, maxID, nil
+}
+
 func checkObjectID(objectID string) (err error) {
 	if len(objectID) < 5 {
 		return errors.BadRequest(reason.ObjectNotFound)
@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package pow issues the proof-of-work challenges and verifies the solutions.
+// The client has to find a nonce that makes sha256(payload + ":" + nonce) start with
+// at least Difficulty zero bits. The challenge is not signed, so it must be kept on the server
+// and only the nonce is accepted from the client.
+package pow
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxDifficulty the challenge with more zero bits can hardly be solved in the browser
+const MaxDifficulty = 32
+
+// Challenge proof-of-work challenge
+type Challenge struct {
+	Salt       string
+	Difficulty int
+	ExpiresAt  int64
+}
+
+// NewChallenge generate a challenge with a random salt
+func NewChallenge(difficulty int, ttl time.Duration) *Challenge {
+	if difficulty < 1 {
+		difficulty = 1
+	}
+	if difficulty > MaxDifficulty {
+		difficulty = MaxDifficulty
+	}
+	salt := make([]byte, 16)
+	_, _ = rand.Read(salt)
+	return &Challenge{
+		Salt:       hex.EncodeToString(salt),
+		Difficulty: difficulty,
+		ExpiresAt:  time.Now().Add(ttl).Unix(),
+	}
+}
+
+// Payload the part of the challenge that is hashed with the nonce
+func (c *Challenge) Payload() string {
+	return fmt.Sprintf("%s.%d.%d", c.Salt, c.Difficulty, c.ExpiresAt)
+}
+
+// Encode encode the challenge to string
+func (c *Challenge) Encode() string {
+	return c.Payload()
+}
+
+// Decode decode the challenge from string
+func Decode(s string) (c *Challenge, err error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid challenge")
+	}
+	c = &Challenge{Salt: parts[0]}
+	if c.Difficulty, err = strconv.Atoi(parts[1]); err != nil {
+		return nil, fmt.Errorf("invalid challenge difficulty: %w", err)
+	}
+	if c.ExpiresAt, err = strconv.ParseInt(parts[2], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid challenge expiration: %w", err)
+	}
+	return c, nil
+}
+
+// Verify check the challenge is not expired and the nonce solves it
+func (c *Challenge) Verify(nonce string, now time.Time) bool {
+	if now.Unix() > c.ExpiresAt {
+		return false
+	}
+	if c.Difficulty < 1 || c.Difficulty > MaxDifficulty || len(nonce) == 0 {
+		return false
+	}
+	return LeadingZeroBits(c.hash(nonce)) >= c.Difficulty
+}
+
+// Solve find the nonce of the challenge, it is the same as what the client does
+func (c *Challenge) Solve() (nonce string) {
+	for i := 0; ; i++ {
+		nonce = strconv.Itoa(i)
+		if LeadingZeroBits(c.hash(nonce)) >= c.Difficulty {
+			return nonce
+		}
+	}
+}
+
+// LeadingZeroBits count the leading zero bits of the hash
+func LeadingZeroBits(hash []byte) (n int) {
+	for _, b := range hash {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
+
+func (c *Challenge) hash(nonce string) []byte {
+	sum := sha256.Sum256([]byte(c.Payload() + ":" + nonce))
+	return sum[:]
+}
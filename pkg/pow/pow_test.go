@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package pow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChallenge_Verify(t *testing.T) {
+	c := NewChallenge(8, time.Minute)
+	nonce := c.Solve()
+	assert.True(t, c.Verify(nonce, time.Now()))
+
+	decoded, err := Decode(c.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, c, decoded)
+	assert.True(t, decoded.Verify(nonce, time.Now()))
+
+	// expired
+	assert.False(t, c.Verify(nonce, time.Now().Add(2*time.Minute)))
+	// empty nonce
+	assert.False(t, c.Verify("", time.Now()))
+}
+
+func TestDecode(t *testing.T) {
+	_, err := Decode("invalid")
+	assert.Error(t, err)
+	_, err = Decode("salt.x.1")
+	assert.Error(t, err)
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+	assert.Equal(t, 0, LeadingZeroBits([]byte{0x80}))
+	assert.Equal(t, 3, LeadingZeroBits([]byte{0x10, 0x00}))
+	assert.Equal(t, 12, LeadingZeroBits([]byte{0x00, 0x08}))
+	assert.Equal(t, 16, LeadingZeroBits([]byte{0x00, 0x00}))
+}
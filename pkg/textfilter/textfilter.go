@@ -0,0 +1,264 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package textfilter finds the banned words in text by Aho-Corasick automaton.
+// The text and the words are normalized before matching, so that the common evasions,
+// such as full-width characters, separators between letters and leetspeak, are still matched.
+package textfilter
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Range the byte range [Start, End) in the original text
+type Range struct {
+	Start int
+	End   int
+}
+
+// Match the pattern matched in the text
+type Match struct {
+	// Pattern the index of the matched pattern
+	Pattern int
+	Range
+}
+
+// leetMapping the characters commonly used to replace letters
+var leetMapping = map[rune]rune{
+	'0': 'o',
+	'1': 'i',
+	'3': 'e',
+	'4': 'a',
+	'5': 's',
+	'7': 't',
+	'@': 'a',
+	'$': 's',
+}
+
+// normalizeRune returns the normalized rune, false if the rune should be ignored
+func normalizeRune(r rune) (rune, bool) {
+	switch {
+	case r == '　':
+		r = ' '
+	case r >= '！' && r <= '～':
+		// full-width ascii characters
+		r -= 0xFEE0
+	}
+	if l, ok := leetMapping[r]; ok {
+		return l, true
+	}
+	if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsMark(r) ||
+		unicode.Is(unicode.Cf, r) || unicode.IsControl(r) {
+		return 0, false
+	}
+	return unicode.ToLower(r), true
+}
+
+// normalizedText the normalized text with the byte range of each rune in the original text
+type normalizedText struct {
+	runes  []rune
+	ranges []Range
+}
+
+func normalize(text string) *normalizedText {
+	n := &normalizedText{
+		runes:  make([]rune, 0, len(text)),
+		ranges: make([]Range, 0, len(text)),
+	}
+	for offset := 0; offset < len(text); {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		if normalized, ok := normalizeRune(r); ok {
+			n.runes = append(n.runes, normalized)
+			n.ranges = append(n.ranges, Range{Start: offset, End: offset + size})
+		}
+		offset += size
+	}
+	return n
+}
+
+// Normalize returns the normalized text used for matching
+func Normalize(text string) string {
+	return string(normalize(text).runes)
+}
+
+type acNode struct {
+	next map[rune]int
+	fail int
+	// outputs the patterns end at this node, including the ones of the fail nodes
+	outputs []int
+}
+
+// Matcher the Aho-Corasick automaton of the patterns
+type Matcher struct {
+	nodes []*acNode
+	// lengths the normalized length of each pattern
+	lengths []int
+	// wordBoundary the pattern only consists of ascii letters and digits,
+	// it should be matched as a whole word to avoid matching a part of the normal word
+	wordBoundary []bool
+}
+
+// NewMatcher build the matcher of the patterns, the empty patterns after normalized are never matched
+func NewMatcher(patterns []string) *Matcher {
+	m := &Matcher{
+		nodes:        []*acNode{{next: make(map[rune]int)}},
+		lengths:      make([]int, len(patterns)),
+		wordBoundary: make([]bool, len(patterns)),
+	}
+	for i, pattern := range patterns {
+		runes := normalize(pattern).runes
+		m.lengths[i] = len(runes)
+		m.wordBoundary[i] = isASCIIWord(pattern)
+		if len(runes) == 0 {
+			continue
+		}
+		cur := 0
+		for _, r := range runes {
+			next, ok := m.nodes[cur].next[r]
+			if !ok {
+				next = len(m.nodes)
+				m.nodes = append(m.nodes, &acNode{next: make(map[rune]int)})
+				m.nodes[cur].next[r] = next
+			}
+			cur = next
+		}
+		m.nodes[cur].outputs = append(m.nodes[cur].outputs, i)
+	}
+	m.buildFailLinks()
+	return m
+}
+
+// buildFailLinks build the fail links by breadth-first search
+func (m *Matcher) buildFailLinks() {
+	queue := make([]int, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[cur].next {
+			fail := m.nodes[cur].fail
+			for fail > 0 && m.nodes[fail].next[r] == 0 {
+				fail = m.nodes[fail].fail
+			}
+			if next, ok := m.nodes[fail].next[r]; ok && next != child {
+				m.nodes[child].fail = next
+			}
+			m.nodes[child].outputs = append(m.nodes[child].outputs, m.nodes[m.nodes[child].fail].outputs...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// FindAll find all the patterns in the text, the ranges are in the original text
+func (m *Matcher) FindAll(text string) (matches []Match) {
+	n := normalize(text)
+	cur := 0
+	for i, r := range n.runes {
+		for cur > 0 && m.nodes[cur].next[r] == 0 {
+			cur = m.nodes[cur].fail
+		}
+		cur = m.nodes[cur].next[r]
+		for _, pattern := range m.nodes[cur].outputs {
+			matched := Range{Start: n.ranges[i-m.lengths[pattern]+1].Start, End: n.ranges[i].End}
+			if m.wordBoundary[pattern] && !isWordBoundary(text, matched) {
+				continue
+			}
+			matches = append(matches, Match{Pattern: pattern, Range: matched})
+		}
+	}
+	return matches
+}
+
+func isASCIIWord(pattern string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if len(pattern) == 0 {
+		return false
+	}
+	for _, r := range pattern {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ') {
+			return false
+		}
+	}
+	return true
+}
+
+// isWordBoundary whether the matched range is not a part of a longer word
+func isWordBoundary(text string, matched Range) bool {
+	if before, _ := utf8.DecodeLastRuneInString(text[:matched.Start]); matched.Start > 0 && isWordRune(before) {
+		return false
+	}
+	if after, _ := utf8.DecodeRuneInString(text[matched.End:]); matched.End < len(text) && isWordRune(after) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Mask replace the characters in the ranges with "*", the spaces are kept
+func Mask(text string, ranges []Range) string {
+	if len(ranges) == 0 {
+		return text
+	}
+	sorted := make([]Range, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var b strings.Builder
+	b.Grow(len(text))
+	idx := 0
+	for offset, r := range text {
+		for idx < len(sorted) && sorted[idx].End <= offset {
+			idx++
+		}
+		if idx < len(sorted) && sorted[idx].Start <= offset && !unicode.IsSpace(r) {
+			b.WriteByte('*')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+var hostPattern = regexp.MustCompile(`(?i)(?:[a-z0-9](?:[a-z0-9\-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}\b`)
+
+// FindDomains find the hosts in the text which are the domains or their subdomains
+func FindDomains(text string, domains []string) (matches []Match) {
+	for _, loc := range hostPattern.FindAllStringIndex(text, -1) {
+		host := strings.ToLower(text[loc[0]:loc[1]])
+		for i, domain := range domains {
+			domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "."))
+			if len(domain) == 0 {
+				continue
+			}
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				matches = append(matches, Match{Pattern: i, Range: Range{Start: loc[0], End: loc[1]}})
+			}
+		}
+	}
+	return matches
+}
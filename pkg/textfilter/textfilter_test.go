@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package textfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func matchedTexts(text string, matches []Match) (texts []string) {
+	for _, m := range matches {
+		texts = append(texts, text[m.Start:m.End])
+	}
+	return texts
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "spam", Normalize("ＳＰＡＭ"))
+	assert.Equal(t, "spam", Normalize("s.p-a m"))
+	assert.Equal(t, "spam", Normalize("$p4m"))
+	assert.Equal(t, "spam", Normalize("sp\u200bam"))
+	assert.Equal(t, "广告", Normalize("广 告"))
+}
+
+func TestMatcher_FindAll(t *testing.T) {
+	m := NewMatcher([]string{"spam", "he", "she", "hers", "广告"})
+
+	text := "This is S.P.A.M and ＳＰＡＭ"
+	assert.Equal(t, []string{"S.P.A.M", "ＳＰＡＭ"}, matchedTexts(text, m.FindAll(text)))
+
+	// the ascii words are matched as a whole word
+	text = "spammer she"
+	assert.Equal(t, []string{"she"}, matchedTexts(text, m.FindAll(text)))
+
+	// the non-ascii words are matched anywhere
+	text = "免费广 告链接"
+	assert.Equal(t, []string{"广 告"}, matchedTexts(text, m.FindAll(text)))
+
+	// the overlapping patterns are all matched
+	m = NewMatcher([]string{"他", "他们", "们的"})
+	text = "他们的"
+	matches := m.FindAll(text)
+	assert.Len(t, matches, 3)
+	assert.Equal(t, []string{"他", "他们", "们的"}, matchedTexts(text, matches))
+
+	// the empty pattern is never matched
+	m = NewMatcher([]string{"", "..."})
+	assert.Empty(t, m.FindAll("hello ..."))
+}
+
+func TestMask(t *testing.T) {
+	text := "buy s p a m now"
+	m := NewMatcher([]string{"spam"})
+	var ranges []Range
+	for _, match := range m.FindAll(text) {
+		ranges = append(ranges, match.Range)
+	}
+	assert.Equal(t, "buy * * * * now", Mask(text, ranges))
+	assert.Equal(t, "免费**链接", Mask("免费广告链接", []Range{{Start: 6, End: 12}}))
+	assert.Equal(t, "hello", Mask("hello", nil))
+}
+
+func TestFindDomains(t *testing.T) {
+	text := "visit https://www.Spam.com/a and spam.com.cn or notspam.com"
+	matches := FindDomains(text, []string{"spam.com"})
+	assert.Equal(t, []string{"www.Spam.com"}, matchedTexts(text, matches))
+}
@@ -19,10 +19,24 @@
 
 package token
 
-import "github.com/google/uuid"
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/google/uuid"
+)
 
 // GenerateToken generate token
 func GenerateToken() string {
 	uid, _ := uuid.NewUUID()
 	return uid.String()
 }
+
+// GenerateSecureToken generate a random token of byteLength bytes from crypto/rand, encoded by hex
+func GenerateSecureToken(byteLength int) (string, error) {
+	b := make([]byte, byteLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
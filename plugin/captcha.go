@@ -33,6 +33,14 @@ type Captcha interface {
 	Verify(captchaCode, userInput string) (pass bool)
 }
 
+// CaptchaLevel optional. If the captcha plugin implements this interface,
+// the captcha is created with the level of the anti-abuse signal instead of the Create method.
+// The level 0 is the normal one, the higher level means the action is more suspicious,
+// so the plugin can make the captcha harder.
+type CaptchaLevel interface {
+	CreateWithLevel(level int) (captcha, code string)
+}
+
 var (
 	// CallCaptcha is a function that calls all registered parsers
 	callCaptcha,
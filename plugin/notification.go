@@ -43,6 +43,7 @@ const (
 	NotificationInvitedYouToAnswer     NotificationType = "notification.action.invited_you_to_answer"
 	NotificationNewQuestion            NotificationType = "notification.action.new_question"
 	NotificationNewQuestionFollowedTag NotificationType = "notification.action.new_question_followed_tag"
+	NotificationSavedSearchNewResult   NotificationType = "notification.action.saved_search_new_result"
 )
 
 type Notification interface {
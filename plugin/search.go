@@ -21,6 +21,7 @@ package plugin
 
 import (
 	"context"
+	"time"
 )
 
 type SearchResult struct {
@@ -76,6 +77,25 @@ type SearchBasicCond struct {
 	ViewAmount int
 	// greater than or equal to the number of answers. Only support search question.
 	AnswerAmount int
+
+	// The keywords that the content should not contain.
+	ExcludedWords []string
+	// The tag IDs that the content should not be related. Same structure as TagIDs.
+	ExcludedTagIDs [][]string
+	// The time range of content created.
+	Created SearchTimeRangeCond
+	// The time range of content updated.
+	Updated SearchTimeRangeCond
+	// The time range of content last active.
+	Active SearchTimeRangeCond
+}
+
+// SearchTimeRangeCond time range condition, the zero time means unlimited.
+type SearchTimeRangeCond struct {
+	// Greater than or equal to the start time.
+	Start time.Time
+	// Less than the end time.
+	End time.Time
 }
 
 type SearchAcceptedCond int
@@ -111,6 +131,24 @@ type Search interface {
 	DeleteContent(ctx context.Context, objectID string) (err error)
 }
 
+// SearchFacetFinder is an optional interface of the search plugin.
+// If the search plugin implements it, the facets of the search result will be counted by the plugin.
+type SearchFacetFinder interface {
+	SearchFacets(ctx context.Context, cond *SearchBasicCond) (facets *SearchFacets, err error)
+}
+
+// SearchFacets the count of the search result, key is the facet value and value is the count.
+type SearchFacets struct {
+	// Object type, example: "question", "answer", "article", "tq_quote"
+	ObjectTypes map[string]int64
+	// Tag ID
+	TagIDs map[string]int64
+	// The object's owner user ID
+	UserIDs map[string]int64
+	// Created time bucket: "day", "week", "month", "year", "older"
+	TimeBuckets map[string]int64
+}
+
 type SearchDesc struct {
 	// A svg icon it wil be display in search result page. optional
 	Icon string `json:"icon"`